@@ -0,0 +1,53 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+// decodeScriptFile decodes in place the base64 encoded script written to
+// path by the place-scripts init container. If checksum isn't empty, the
+// hex encoded SHA-256 checksum of the decoded script must match it.
+func decodeScriptFile(path, checksum string) error {
+	encoded, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading script %q: %w", path, err)
+	}
+	script, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(encoded)))
+	if err != nil {
+		return fmt.Errorf("error decoding script %q: %w", path, err)
+	}
+	if checksum != "" {
+		if got := fmt.Sprintf("%x", sha256.Sum256(script)); got != checksum {
+			return fmt.Errorf("checksum of script %q is %s, expected %s", path, got, checksum)
+		}
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("error reading script %q: %w", path, err)
+	}
+	if err := ioutil.WriteFile(path, script, info.Mode()); err != nil {
+		return fmt.Errorf("error writing script %q: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDecodeScriptFile(t *testing.T) {
+	script := "#!/bin/sh\ncat << EOF\n_EOF_\nEOF\n\x00\x01"
+	checksum := fmt.Sprintf("%x", sha256.Sum256([]byte(script)))
+	for _, tc := range []struct {
+		desc     string
+		encoded  string
+		checksum string
+		wantErr  bool
+	}{{
+		desc:     "valid checksum",
+		encoded:  base64.StdEncoding.EncodeToString([]byte(script)) + "\n",
+		checksum: checksum,
+	}, {
+		desc:    "no checksum",
+		encoded: base64.StdEncoding.EncodeToString([]byte(script)),
+	}, {
+		desc:     "invalid checksum",
+		encoded:  base64.StdEncoding.EncodeToString([]byte(script)),
+		checksum: fmt.Sprintf("%x", sha256.Sum256([]byte("another script"))),
+		wantErr:  true,
+	}, {
+		desc:    "invalid encoding",
+		encoded: script,
+		wantErr: true,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "scripts")
+			if err != nil {
+				t.Fatalf("Couldn't create the scripts directory: %v", err)
+			}
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "script-0")
+			if err := ioutil.WriteFile(path, []byte(tc.encoded), 0755); err != nil {
+				t.Fatalf("Couldn't write the script: %v", err)
+			}
+
+			err = decodeScriptFile(path, tc.checksum)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("Expected an error decoding the script but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error decoding the script: %v", err)
+			}
+			got, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Couldn't read the decoded script: %v", err)
+			}
+			if string(got) != script {
+				t.Errorf("Expected the decoded script %q, got %q", script, got)
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatalf("Couldn't stat the decoded script: %v", err)
+			}
+			if info.Mode().Perm() != 0755 {
+				t.Errorf("Expected the decoded script to keep its mode 0755, got %v", info.Mode().Perm())
+			}
+		})
+	}
+}
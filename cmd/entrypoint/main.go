@@ -17,7 +17,9 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"log"
 	"os"
 	"os/exec"
@@ -25,6 +27,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/credentials"
 	"github.com/tektoncd/pipeline/pkg/credentials/dockercreds"
 	"github.com/tektoncd/pipeline/pkg/credentials/gitcreds"
@@ -39,6 +42,16 @@ var (
 	postFile            = flag.String("post_file", "", "If specified, file to write upon completion")
 	terminationPath     = flag.String("termination_path", "/tekton/termination", "If specified, file to write upon termination")
 	results             = flag.String("results", "", "If specified, list of file names that might contain task results")
+	timeout             = flag.Duration("timeout", time.Duration(0), "If specified, sets timeout for step")
+	onError             = flag.String("on_error", "", "Set to \"continue\" to ignore an error and continue when a container terminates with a non-zero exit code."+
+		" Set to \"stopAndFail\" to declare a failure with a step error and stop executing the rest of the steps.")
+	breakpointOnFailure = flag.Bool("breakpoint_on_failure", false, "If specified, pause after the step fails until it is resumed or the debug timeout elapses")
+	debugTimeout        = flag.Duration("debug_timeout", time.Duration(0), "If specified, sets how long a step paused on a breakpoint waits to be resumed")
+	breakpointCheck     = flag.String("breakpoint_check", "", "If specified, exit successfully only if the file written by a step paused on a breakpoint exists")
+	breakpointContinue  = flag.String("breakpoint_continue", "", "If specified, resume a step paused on a breakpoint by writing the file, and exit")
+	decodeScript        = flag.String("decode_script", "", "If specified, decode the base64 encoded script in the file in place, and exit")
+	scriptSHA256        = flag.String("script_sha256", "", "If specified, the hex encoded SHA-256 checksum the script decoded with decode_script must match")
+	hermetic            = flag.Bool("hermetic", false, "If specified, run the step in a new network namespace, without network access")
 	waitPollingInterval = time.Second
 )
 
@@ -49,6 +62,30 @@ func main() {
 
 	flag.Parse()
 
+	// The entrypoint binary is also run inside the step containers to check and resume the
+	// steps paused on breakpoints.
+	if *breakpointCheck != "" {
+		if _, err := os.Stat(*breakpointCheck); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if *breakpointContinue != "" {
+		(&realPostWriter{}).Write(*breakpointContinue)
+		os.Exit(0)
+	}
+	// It is also run by the place-scripts init container to decode the scripts of the steps.
+	if *decodeScript != "" {
+		if err := decodeScriptFile(*decodeScript, *scriptSHA256); err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(0)
+	}
+
+	if err := checkForOnError(*onError); err != nil {
+		log.Fatal(err)
+	}
+
 	// Copy creds-init credentials from secret volume mounts to /tekton/creds
 	// This is done to support the expansion of a variable, $(credentials.path), that
 	// resolves to a single place with all the stored credentials.
@@ -60,16 +97,21 @@ func main() {
 	}
 
 	e := entrypoint.Entrypointer{
-		Entrypoint:      *ep,
-		WaitFiles:       strings.Split(*waitFiles, ","),
-		WaitFileContent: *waitFileContent,
-		PostFile:        *postFile,
-		TerminationPath: *terminationPath,
-		Args:            flag.Args(),
-		Waiter:          &realWaiter{},
-		Runner:          &realRunner{},
-		PostWriter:      &realPostWriter{},
-		Results:         strings.Split(*results, ","),
+		Entrypoint:          *ep,
+		WaitFiles:           strings.Split(*waitFiles, ","),
+		WaitFileContent:     *waitFileContent,
+		PostFile:            *postFile,
+		TerminationPath:     *terminationPath,
+		Args:                flag.Args(),
+		Waiter:              &realWaiter{},
+		Runner:              &realRunner{hermetic: *hermetic},
+		PostWriter:          &realPostWriter{},
+		Results:             strings.Split(*results, ","),
+		Timeout:             timeout,
+		OnError:             *onError,
+		BreakpointOnFailure: *breakpointOnFailure,
+		DebugTimeout:        debugTimeout,
+		Hermetic:            *hermetic,
 	}
 
 	// Copy any creds injected by the controller into the $HOME directory of the current
@@ -79,6 +121,10 @@ func main() {
 	}
 
 	if err := e.Go(); err != nil {
+		if err == context.DeadlineExceeded {
+			log.Print("Step exceeded its timeout")
+			os.Exit(1)
+		}
 		switch t := err.(type) {
 		case skipError:
 			log.Print("Skipping step because a previous step failed")
@@ -102,3 +148,11 @@ func main() {
 		}
 	}
 }
+
+func checkForOnError(onError string) error {
+	switch v1beta1.OnErrorType(onError) {
+	case "", v1beta1.Continue, v1beta1.StopAndFail:
+		return nil
+	}
+	return fmt.Errorf("invalid value %q for -on_error, must be either %q or %q", onError, v1beta1.Continue, v1beta1.StopAndFail)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// dropNetworking makes cmd run in new user and network namespaces, where only
+// a loopback interface exists. The user namespace maps the IDs to themselves, so
+// that the command runs as the same user and the files keep their owners. When the
+// caller is root, every ID of its own user namespace is mapped. Otherwise, only the
+// user and group of the caller are, since an unprivileged process can't map any
+// other ID: the files owned by other users then belong to the overflow user, nobody,
+// and can't be written unless their mode allows any user to.
+func dropNetworking(cmd *exec.Cmd) error {
+	cmd.SysProcAttr.Cloneflags |= syscall.CLONE_NEWUSER | syscall.CLONE_NEWNET
+	uids := []syscall.SysProcIDMap{{ContainerID: os.Getuid(), HostID: os.Getuid(), Size: 1}}
+	gids := []syscall.SysProcIDMap{{ContainerID: os.Getgid(), HostID: os.Getgid(), Size: 1}}
+	if os.Geteuid() == 0 {
+		var err error
+		if uids, err = readIDMap("/proc/self/uid_map"); err != nil {
+			return err
+		}
+		if gids, err = readIDMap("/proc/self/gid_map"); err != nil {
+			return err
+		}
+	}
+	cmd.SysProcAttr.UidMappings = uids
+	cmd.SysProcAttr.GidMappings = gids
+	return nil
+}
+
+// readIDMap returns the mappings of the IDs of the user namespace of the process to
+// themselves, from its uid_map or gid_map.
+func readIDMap(path string) ([]syscall.SysProcIDMap, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	mappings, err := parseIDMap(f)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	return mappings, nil
+}
+
+// parseIDMap parses the ranges of a uid_map or gid_map, where each line holds the
+// first ID of a range in the namespace, the first ID it maps to outside, and the
+// length of the range.
+func parseIDMap(r io.Reader) ([]syscall.SysProcIDMap, error) {
+	var mappings []syscall.SysProcIDMap
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid mapping %q", scanner.Text())
+		}
+		id, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid mapping %q: %w", scanner.Text(), err)
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid mapping %q: %w", scanner.Text(), err)
+		}
+		mappings = append(mappings, syscall.SysProcIDMap{ContainerID: id, HostID: id, Size: size})
+	}
+	return mappings, scanner.Err()
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestParseIDMap(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		in   string
+		want []syscall.SysProcIDMap
+	}{{
+		desc: "initial namespace",
+		in:   "         0          0 4294967295\n",
+		want: []syscall.SysProcIDMap{{ContainerID: 0, HostID: 0, Size: 4294967295}},
+	}, {
+		desc: "container namespace",
+		in:   "         0     100000      65536\n     65536     300000          1\n",
+		want: []syscall.SysProcIDMap{
+			{ContainerID: 0, HostID: 0, Size: 65536},
+			{ContainerID: 65536, HostID: 65536, Size: 1},
+		},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := parseIDMap(strings.NewReader(tc.in))
+			if err != nil {
+				t.Fatalf("parseIDMap() = %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Unexpected mappings %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestParseIDMap_Invalid(t *testing.T) {
+	for _, in := range []string{"0 0\n", "0 0 many\n", "root 0 1\n"} {
+		if _, err := parseIDMap(strings.NewReader(in)); err == nil {
+			t.Errorf("Expected an error parsing %q", in)
+		}
+	}
+}
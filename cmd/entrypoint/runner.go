@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -15,11 +16,13 @@ import (
 // realRunner actually runs commands.
 type realRunner struct {
 	signals chan os.Signal
+	// hermetic runs the command without network access
+	hermetic bool
 }
 
 var _ entrypoint.Runner = (*realRunner)(nil)
 
-func (rr *realRunner) Run(args ...string) error {
+func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	if len(args) == 0 {
 		return nil
 	}
@@ -39,6 +42,11 @@ func (rr *realRunner) Run(args ...string) error {
 	// dedicated PID group used to forward signals to
 	// main process and all children
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if rr.hermetic {
+		if err := dropNetworking(cmd); err != nil {
+			return err
+		}
+	}
 
 	// Start defined command
 	if err := cmd.Start(); err != nil {
@@ -55,8 +63,23 @@ func (rr *realRunner) Run(args ...string) error {
 		}
 	}()
 
+	// Goroutine for killing the main process and all children
+	// when the step times out
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		case <-done:
+		}
+	}()
+
 	// Wait for command to exit
 	if err := cmd.Wait(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return context.DeadlineExceeded
+		}
 		return err
 	}
 
@@ -1,9 +1,18 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"syscall"
 	"testing"
+	"time"
 )
 
 // TestRealRunnerSignalForwarding will artificially put an interrupt signal (SIGINT) in the rr.signals chan.
@@ -14,9 +23,124 @@ func TestRealRunnerSignalForwarding(t *testing.T) {
 	rr := realRunner{}
 	rr.signals = make(chan os.Signal, 1)
 	rr.signals <- syscall.SIGINT
-	if err := rr.Run("sleep", "3600"); err.Error() == "signal: interrupt" {
+	if err := rr.Run(context.Background(), "sleep", "3600"); err.Error() == "signal: interrupt" {
 		t.Logf("SIGINT forwarded to Entrypoint")
 	} else {
 		t.Fatalf("Unexpected error received: %v", err)
 	}
 }
+
+// TestRealRunnerTimeout checks that the process group of the command is killed
+// once its context times out.
+func TestRealRunnerTimeout(t *testing.T) {
+	rr := realRunner{}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := rr.Run(ctx, "sh", "-c", "sleep 3600 & sleep 3600"); err != context.DeadlineExceeded {
+		t.Fatalf("Expected %v, got: %v", context.DeadlineExceeded, err)
+	}
+}
+
+// TestRealRunnerHermetic checks that a hermetic command only sees the loopback
+// network interface. It is skipped where namespaces can't be created.
+func TestRealRunnerHermetic(t *testing.T) {
+	rr := realRunner{hermetic: true}
+	err := rr.Run(context.Background(), "sh", "-c", `! grep -v "lo:" /proc/net/dev | grep -q ":"`)
+	var ee *exec.ExitError
+	if err != nil && !errors.As(err, &ee) {
+		t.Skipf("Couldn't run the command in new namespaces: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("Expected the hermetic command to only see the loopback interface, got: %v", err)
+	}
+}
+
+// nonRootEnv is set when TestRealRunnerHermeticNonRoot runs again as a non-root user.
+const nonRootEnv = "TEST_HERMETIC_NON_ROOT"
+
+// TestRealRunnerHermeticNonRoot checks that a non-root user can run a hermetic command.
+// When the tests run as root, a copy of the test binary runs this test again as the
+// nobody user. It is skipped where user namespaces can't be created.
+func TestRealRunnerHermeticNonRoot(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("Hermetic execution is only supported on Linux")
+	}
+	if max, err := ioutil.ReadFile("/proc/sys/user/max_user_namespaces"); err != nil || strings.TrimSpace(string(max)) == "0" {
+		t.Skip("User namespaces are not available")
+	}
+	if os.Getuid() != 0 {
+		rr := realRunner{hermetic: true}
+		if err := rr.Run(context.Background(), "sh", "-c", fmt.Sprintf(`test "$(id -u)" = %d`, os.Getuid())); err != nil {
+			t.Fatalf("Expected the hermetic command to run as uid %d, got: %v", os.Getuid(), err)
+		}
+		return
+	}
+	if os.Getenv(nonRootEnv) != "" {
+		t.Fatal("Expected the test to run as a non-root user")
+	}
+
+	// The test binary is copied where the nobody user can execute it
+	dir, err := ioutil.TempDir("", "hermetic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := os.Chmod(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	binary, err := ioutil.ReadFile(os.Args[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	testBinary := filepath.Join(dir, "entrypoint.test")
+	if err := ioutil.WriteFile(testBinary, binary, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(testBinary, "-test.run=^TestRealRunnerHermeticNonRoot$", "-test.v")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), nonRootEnv+"=true")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Credential: &syscall.Credential{Uid: 65534, Gid: 65534}}
+	out, err := cmd.CombinedOutput()
+	var ee *exec.ExitError
+	if err != nil && !errors.As(err, &ee) {
+		t.Skipf("Couldn't run the test as a non-root user: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("Expected a non-root user to run a hermetic command, got: %v\n%s", err, out)
+	}
+	if strings.Contains(string(out), "--- SKIP") {
+		t.Skipf("The test was skipped as a non-root user:\n%s", out)
+	}
+}
+
+// TestRealRunnerHermeticFileOwnedByOtherUser checks that a hermetic command running as root
+// can write a workspace file owned by another user, which keeps its owner. It is skipped
+// where namespaces can't be created.
+func TestRealRunnerHermeticFileOwnedByOtherUser(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skip("Only root can own a file by another user")
+	}
+	dir, err := ioutil.TempDir("", "workspace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "file")
+	if err := ioutil.WriteFile(path, []byte("written by another step\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chown(path, 1234, 1234); err != nil {
+		t.Fatal(err)
+	}
+
+	rr := realRunner{hermetic: true}
+	err = rr.Run(context.Background(), "sh", "-c", `echo "written hermetically" >> "$0" && test "$(stat -c %u:%g "$0")" = 1234:1234`, path)
+	var ee *exec.ExitError
+	if err != nil && !errors.As(err, &ee) {
+		t.Skipf("Couldn't run the command in new namespaces: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("Expected the hermetic command to write the file owned by uid 1234, got: %v", err)
+	}
+}
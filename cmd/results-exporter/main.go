@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/tektoncd/pipeline/pkg/reconciler/resultsexporter"
+	"github.com/tektoncd/pipeline/pkg/results"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/injection/sharedmain"
+	"knative.dev/pkg/signals"
+)
+
+const (
+	// ResultsExporterLogKey is the name of the logger for the results-exporter cmd
+	ResultsExporterLogKey = "results-exporter"
+)
+
+var (
+	database   = flag.String("database", "/var/tekton/results/results.db", "Path of the SQLite database storing the records of the runs.")
+	apiAddress = flag.String("api-address", ":8080", "Address serving the API to read the records of the runs.")
+	namespace  = flag.String("namespace", corev1.NamespaceAll, "Namespace to restrict informer to. Optional, defaults to all namespaces.")
+)
+
+func main() {
+	flag.Parse()
+	cfg := sharedmain.ParseAndGetConfigOrDie()
+	kubeClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	store, err := results.NewSQLiteStore(*database)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		log.Fatal(err)
+	}
+	server := &http.Server{
+		Addr:    *apiAddress,
+		Handler: results.NewHandler(store, results.NewKubeAuthorizer(kubeClient), logger.Sugar().Named("api")),
+	}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal(err)
+		}
+	}()
+
+	// MainWithConfig returns once the results-exporter is asked to stop, stop serving the
+	// API before closing the store.
+	sharedmain.MainWithConfig(injection.WithNamespaceScope(signals.NewContext(), *namespace), ResultsExporterLogKey, cfg,
+		resultsexporter.NewPipelineRunController(store),
+		resultsexporter.NewTaskRunController(store),
+	)
+	if err := server.Shutdown(context.Background()); err != nil {
+		logger.Sugar().Errorf("Failed to stop the API server: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		logger.Sugar().Errorf("Failed to close the store: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/sidecarlogresults"
+)
+
+var (
+	resultsDir          = flag.String("results_dir", "/tekton/results", "Path to the directory where the Steps write the Task results")
+	resultNames         = flag.String("result_names", "", "Comma-separated list of the names of the Task results")
+	waitFile            = flag.String("wait_file", "", "Post file of the last Step, written once all the Steps have finished")
+	waitPollingInterval = time.Second
+)
+
+func main() {
+	flag.Parse()
+
+	if *waitFile != "" {
+		waitForFile(*waitFile)
+	}
+	if err := sidecarlogresults.LookForResults(os.Stdout, *resultsDir, strings.Split(*resultNames, ",")); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// waitForFile blocks until either the file or the file with the ".err" extension exists, which
+// the entrypoint of the last Step writes whether the Steps succeeded or not.
+func waitForFile(file string) {
+	for ; ; time.Sleep(waitPollingInterval) {
+		for _, f := range []string{file, file + ".err"} {
+			if _, err := os.Stat(f); err == nil {
+				return
+			} else if !os.IsNotExist(err) {
+				log.Fatalf("Waiting for %q: %v", f, err)
+			}
+		}
+	}
+}
@@ -78,6 +78,16 @@ func PipelineNamespace(namespace string) PipelineOp {
 	}
 }
 
+// PipelineType sets the TypeMeta on the Pipeline which is useful for making it serializable/deserializable.
+func PipelineType() PipelineOp {
+	return func(p *v1beta1.Pipeline) {
+		p.TypeMeta = metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1beta1",
+			Kind:       "Pipeline",
+		}
+	}
+}
+
 // PipelineSpec sets the PipelineSpec to the Pipeline.
 // Any number of PipelineSpec modifier can be passed to transform it.
 func PipelineSpec(ops ...PipelineSpecOp) PipelineOp {
@@ -99,6 +109,16 @@ func PipelineCreationTimestamp(t time.Time) PipelineOp {
 	}
 }
 
+// PipelineAnnotation adds an annotation to the Pipeline.
+func PipelineAnnotation(key, value string) PipelineOp {
+	return func(p *v1beta1.Pipeline) {
+		if p.ObjectMeta.Annotations == nil {
+			p.ObjectMeta.Annotations = map[string]string{}
+		}
+		p.ObjectMeta.Annotations[key] = value
+	}
+}
+
 // PipelineDescription sets the description of the pipeline
 func PipelineDescription(desc string) PipelineSpecOp {
 	return func(ps *v1beta1.PipelineSpec) {
@@ -111,6 +131,21 @@ func PipelineRunCancelled(spec *v1beta1.PipelineRunSpec) {
 	spec.Status = v1beta1.PipelineRunSpecStatusCancelled
 }
 
+// PipelineRunPending sets the status to pending to the PipelineRunSpec.
+func PipelineRunPending(spec *v1beta1.PipelineRunSpec) {
+	spec.Status = v1beta1.PipelineRunSpecStatusPending
+}
+
+// PipelineRunPaused sets the status to paused to the PipelineRunSpec.
+func PipelineRunPaused(spec *v1beta1.PipelineRunSpec) {
+	spec.Status = v1beta1.PipelineRunSpecStatusPaused
+}
+
+// PipelineRunCancelledRunFinally sets the status to CancelledRunFinally to the PipelineRunSpec.
+func PipelineRunCancelledRunFinally(spec *v1beta1.PipelineRunSpec) {
+	spec.Status = v1beta1.PipelineRunSpecStatusCancelledRunFinally
+}
+
 // PipelineDeclaredResource adds a resource declaration to the Pipeline Spec,
 // with the specified name and type.
 func PipelineDeclaredResource(name string, t v1beta1.PipelineResourceType) PipelineSpecOp {
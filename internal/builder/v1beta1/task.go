@@ -616,6 +616,13 @@ func TaskRefAPIVersion(version string) TaskRefOp {
 	}
 }
 
+// TaskRefBundle sets the specified bundle to the TaskRef.
+func TaskRefBundle(bundle string) TaskRefOp {
+	return func(ref *v1beta1.TaskRef) {
+		ref.Bundle = bundle
+	}
+}
+
 // TaskRunTaskSpec sets the specified TaskRunSpec reference to the TaskRunSpec.
 // Any number of TaskRunSpec modifier can be passed to transform it.
 func TaskRunTaskSpec(ops ...TaskSpecOp) TaskRunSpecOp {
@@ -39,17 +39,36 @@ const (
 	defaultCloudEventsSinkKey      = "default-cloud-events-sink"
 	DefaultCloudEventSinkValue     = ""
 	defaultTaskRunWorkspaceBinding = "default-task-run-workspace-binding"
+	// DefaultMaxMatrixCombinationsCount is the default maximum number of TaskRuns a PipelineTask
+	// with a Matrix fans out to.
+	DefaultMaxMatrixCombinationsCount    = 256
+	defaultMaxMatrixCombinationsCountKey = "default-max-matrix-combinations-count"
+	// DefaultMaxConcurrentPipelineRuns is the default maximum number of PipelineRuns running at
+	// the same time in a namespace, 0 means there is no limit.
+	DefaultMaxConcurrentPipelineRuns    = 0
+	defaultMaxConcurrentPipelineRunsKey = "default-max-concurrent-pipelineruns"
+	// DefaultMaxPipelineNestingDepth is the default maximum number of PipelineRuns nested in a
+	// PipelineRun through PipelineTasks running a Pipeline.
+	DefaultMaxPipelineNestingDepth    = 10
+	defaultMaxPipelineNestingDepthKey = "default-max-pipeline-nesting-depth"
+	// defaultCloudEventsDeadLetterSinkKey is the sink of the cloud events which could not be
+	// delivered to the sink of their run.
+	defaultCloudEventsDeadLetterSinkKey = "default-cloud-events-dead-letter-sink"
 )
 
 // Defaults holds the default configurations
 // +k8s:deepcopy-gen=true
 type Defaults struct {
-	DefaultTimeoutMinutes          int
-	DefaultServiceAccount          string
-	DefaultManagedByLabelValue     string
-	DefaultPodTemplate             *pod.Template
-	DefaultCloudEventsSink         string
-	DefaultTaskRunWorkspaceBinding string
+	DefaultTimeoutMinutes             int
+	DefaultServiceAccount             string
+	DefaultManagedByLabelValue        string
+	DefaultPodTemplate                *pod.Template
+	DefaultCloudEventsSink            string
+	DefaultCloudEventsDeadLetterSink  string
+	DefaultTaskRunWorkspaceBinding    string
+	DefaultMaxMatrixCombinationsCount int
+	DefaultMaxConcurrentPipelineRuns  int
+	DefaultMaxPipelineNestingDepth    int
 }
 
 // GetDefaultsConfigName returns the name of the configmap containing all
@@ -76,16 +95,23 @@ func (cfg *Defaults) Equals(other *Defaults) bool {
 		other.DefaultManagedByLabelValue == cfg.DefaultManagedByLabelValue &&
 		other.DefaultPodTemplate.Equals(cfg.DefaultPodTemplate) &&
 		other.DefaultCloudEventsSink == cfg.DefaultCloudEventsSink &&
-		other.DefaultTaskRunWorkspaceBinding == cfg.DefaultTaskRunWorkspaceBinding
+		other.DefaultCloudEventsDeadLetterSink == cfg.DefaultCloudEventsDeadLetterSink &&
+		other.DefaultTaskRunWorkspaceBinding == cfg.DefaultTaskRunWorkspaceBinding &&
+		other.DefaultMaxMatrixCombinationsCount == cfg.DefaultMaxMatrixCombinationsCount &&
+		other.DefaultMaxConcurrentPipelineRuns == cfg.DefaultMaxConcurrentPipelineRuns &&
+		other.DefaultMaxPipelineNestingDepth == cfg.DefaultMaxPipelineNestingDepth
 }
 
 // NewDefaultsFromMap returns a Config given a map corresponding to a ConfigMap
 func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 	tc := Defaults{
-		DefaultTimeoutMinutes:      DefaultTimeoutMinutes,
-		DefaultServiceAccount:      DefaultServiceAccountValue,
-		DefaultManagedByLabelValue: DefaultManagedByLabelValue,
-		DefaultCloudEventsSink:     DefaultCloudEventSinkValue,
+		DefaultTimeoutMinutes:             DefaultTimeoutMinutes,
+		DefaultServiceAccount:             DefaultServiceAccountValue,
+		DefaultManagedByLabelValue:        DefaultManagedByLabelValue,
+		DefaultCloudEventsSink:            DefaultCloudEventSinkValue,
+		DefaultMaxMatrixCombinationsCount: DefaultMaxMatrixCombinationsCount,
+		DefaultMaxConcurrentPipelineRuns:  DefaultMaxConcurrentPipelineRuns,
+		DefaultMaxPipelineNestingDepth:    DefaultMaxPipelineNestingDepth,
 	}
 
 	if defaultTimeoutMin, ok := cfgMap[defaultTimeoutMinutesKey]; ok {
@@ -116,9 +142,37 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		tc.DefaultCloudEventsSink = defaultCloudEventsSink
 	}
 
+	if deadLetterSink, ok := cfgMap[defaultCloudEventsDeadLetterSinkKey]; ok {
+		tc.DefaultCloudEventsDeadLetterSink = deadLetterSink
+	}
+
 	if bindingYAML, ok := cfgMap[defaultTaskRunWorkspaceBinding]; ok {
 		tc.DefaultTaskRunWorkspaceBinding = bindingYAML
 	}
+
+	if defaultMaxMatrixCombinationsCount, ok := cfgMap[defaultMaxMatrixCombinationsCountKey]; ok {
+		count, err := strconv.ParseInt(defaultMaxMatrixCombinationsCount, 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing defaults config %q", defaultMaxMatrixCombinationsCountKey)
+		}
+		tc.DefaultMaxMatrixCombinationsCount = int(count)
+	}
+
+	if defaultMaxConcurrentPipelineRuns, ok := cfgMap[defaultMaxConcurrentPipelineRunsKey]; ok {
+		count, err := strconv.ParseInt(defaultMaxConcurrentPipelineRuns, 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing defaults config %q", defaultMaxConcurrentPipelineRunsKey)
+		}
+		tc.DefaultMaxConcurrentPipelineRuns = int(count)
+	}
+
+	if defaultMaxPipelineNestingDepth, ok := cfgMap[defaultMaxPipelineNestingDepthKey]; ok {
+		depth, err := strconv.ParseInt(defaultMaxPipelineNestingDepth, 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing defaults config %q", defaultMaxPipelineNestingDepthKey)
+		}
+		tc.DefaultMaxPipelineNestingDepth = int(depth)
+	}
 	return &tc, nil
 }
 
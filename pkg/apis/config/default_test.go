@@ -36,17 +36,23 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 	testCases := []testCase{
 		{
 			expectedConfig: &config.Defaults{
-				DefaultTimeoutMinutes:      50,
-				DefaultServiceAccount:      "tekton",
-				DefaultManagedByLabelValue: "something-else",
+				DefaultTimeoutMinutes:             50,
+				DefaultServiceAccount:             "tekton",
+				DefaultManagedByLabelValue:        "something-else",
+				DefaultMaxMatrixCombinationsCount: 16,
+				DefaultMaxConcurrentPipelineRuns:  10,
+				DefaultMaxPipelineNestingDepth:    3,
+				DefaultCloudEventsDeadLetterSink:  "http://dead-letters.example.com",
 			},
 			fileName: config.GetDefaultsConfigName(),
 		},
 		{
 			expectedConfig: &config.Defaults{
-				DefaultTimeoutMinutes:      50,
-				DefaultServiceAccount:      "tekton",
-				DefaultManagedByLabelValue: config.DefaultManagedByLabelValue,
+				DefaultTimeoutMinutes:             50,
+				DefaultServiceAccount:             "tekton",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount: config.DefaultMaxMatrixCombinationsCount,
+				DefaultMaxPipelineNestingDepth:    config.DefaultMaxPipelineNestingDepth,
 				DefaultPodTemplate: &pod.Template{
 					NodeSelector: map[string]string{
 						"label": "value",
@@ -79,9 +85,11 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 func TestNewDefaultsFromEmptyConfigMap(t *testing.T) {
 	DefaultsConfigEmptyName := "config-defaults-empty"
 	expectedConfig := &config.Defaults{
-		DefaultTimeoutMinutes:      60,
-		DefaultManagedByLabelValue: "tekton-pipelines",
-		DefaultServiceAccount:      "default",
+		DefaultTimeoutMinutes:             60,
+		DefaultManagedByLabelValue:        "tekton-pipelines",
+		DefaultServiceAccount:             "default",
+		DefaultMaxMatrixCombinationsCount: config.DefaultMaxMatrixCombinationsCount,
+		DefaultMaxPipelineNestingDepth:    config.DefaultMaxPipelineNestingDepth,
 	}
 	verifyConfigFileWithExpectedConfig(t, DefaultsConfigEmptyName, expectedConfig)
 }
@@ -193,6 +201,36 @@ func TestEquals(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "different max matrix combinations count",
+			left: &config.Defaults{
+				DefaultMaxMatrixCombinationsCount: 256,
+			},
+			right: &config.Defaults{
+				DefaultMaxMatrixCombinationsCount: 16,
+			},
+			expected: false,
+		},
+		{
+			name: "different max concurrent pipelineruns",
+			left: &config.Defaults{
+				DefaultMaxConcurrentPipelineRuns: 0,
+			},
+			right: &config.Defaults{
+				DefaultMaxConcurrentPipelineRuns: 5,
+			},
+			expected: false,
+		},
+		{
+			name: "different max pipeline nesting depth",
+			left: &config.Defaults{
+				DefaultMaxPipelineNestingDepth: 10,
+			},
+			right: &config.Defaults{
+				DefaultMaxPipelineNestingDepth: 3,
+			},
+			expected: false,
+		},
 	}
 
 	for _, tc := range testCases {
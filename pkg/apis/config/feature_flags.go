@@ -29,10 +29,31 @@ const (
 	disableWorkingDirOverwriteKey           = "disable-working-directory-overwrite"
 	disableAffinityAssistantKey             = "disable-affinity-assistant"
 	runningInEnvWithInjectedSidecarsKey     = "running-in-environment-with-injected-sidecars"
+	enableTektonOCIBundlesKey               = "enable-tekton-oci-bundles"
+	enableCustomTasksKey                    = "enable-custom-tasks"
+	enablePipelinesInPipelinesKey           = "enable-pipelines-in-pipelines"
+	resultExtractionMethodKey               = "results-from"
+	maxResultSizeKey                        = "max-result-size"
 	DefaultDisableHomeEnvOverwrite          = false
 	DefaultDisableWorkingDirOverwrite       = false
 	DefaultDisableAffinityAssistant         = false
 	DefaultRunningInEnvWithInjectedSidecars = true
+	DefaultEnableTektonOCIBundles           = false
+	DefaultEnableCustomTasks                = false
+	DefaultEnablePipelinesInPipelines       = false
+	DefaultResultExtractionMethod           = ResultExtractionMethodTerminationMessage
+	DefaultMaxResultSize                    = 4096
+
+	// ResultExtractionMethodTerminationMessage is the value of the results-from feature flag
+	// to read Task results from the termination message of the steps containers
+	ResultExtractionMethodTerminationMessage = "termination-message"
+	// ResultExtractionMethodSidecarLogs is the value of the results-from feature flag to
+	// read Task results from the logs of a dedicated sidecar container
+	ResultExtractionMethodSidecarLogs = "sidecar-logs"
+
+	// MaxAllowedResultSize is the upper bound of the max-result-size feature flag, set below
+	// the size limit of the objects stored by the API server so that TaskRuns can be updated
+	MaxAllowedResultSize = 1024 * 1024
 )
 
 // FeatureFlags holds the features configurations
@@ -42,6 +63,11 @@ type FeatureFlags struct {
 	DisableWorkingDirOverwrite       bool
 	DisableAffinityAssistant         bool
 	RunningInEnvWithInjectedSidecars bool
+	EnableTektonOCIBundles           bool
+	EnableCustomTasks                bool
+	EnablePipelinesInPipelines       bool
+	ResultExtractionMethod           string
+	MaxResultSize                    int
 }
 
 // GetFeatureFlagsConfigName returns the name of the configmap containing all
@@ -81,6 +107,37 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 	if err := setFeature(runningInEnvWithInjectedSidecarsKey, DefaultRunningInEnvWithInjectedSidecars, &tc.RunningInEnvWithInjectedSidecars); err != nil {
 		return nil, err
 	}
+	if err := setFeature(enableTektonOCIBundlesKey, DefaultEnableTektonOCIBundles, &tc.EnableTektonOCIBundles); err != nil {
+		return nil, err
+	}
+	if err := setFeature(enableCustomTasksKey, DefaultEnableCustomTasks, &tc.EnableCustomTasks); err != nil {
+		return nil, err
+	}
+	if err := setFeature(enablePipelinesInPipelinesKey, DefaultEnablePipelinesInPipelines, &tc.EnablePipelinesInPipelines); err != nil {
+		return nil, err
+	}
+
+	tc.ResultExtractionMethod = DefaultResultExtractionMethod
+	if method, ok := cfgMap[resultExtractionMethodKey]; ok {
+		switch method {
+		case ResultExtractionMethodTerminationMessage, ResultExtractionMethodSidecarLogs:
+			tc.ResultExtractionMethod = method
+		default:
+			return nil, fmt.Errorf("invalid value for feature flag %q: %q, must be %q or %q", resultExtractionMethodKey, method,
+				ResultExtractionMethodTerminationMessage, ResultExtractionMethodSidecarLogs)
+		}
+	}
+	tc.MaxResultSize = DefaultMaxResultSize
+	if size, ok := cfgMap[maxResultSizeKey]; ok {
+		value, err := strconv.Atoi(size)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing feature flags config %q: %v", size, err)
+		}
+		if value <= 0 || value > MaxAllowedResultSize {
+			return nil, fmt.Errorf("invalid value for feature flag %q: %d, must be between 1 and %d", maxResultSizeKey, value, MaxAllowedResultSize)
+		}
+		tc.MaxResultSize = value
+	}
 	return &tc, nil
 }
 
@@ -36,6 +36,8 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 		{
 			expectedConfig: &config.FeatureFlags{
 				RunningInEnvWithInjectedSidecars: config.DefaultRunningInEnvWithInjectedSidecars,
+				ResultExtractionMethod:           config.DefaultResultExtractionMethod,
+				MaxResultSize:                    config.DefaultMaxResultSize,
 			},
 			fileName: config.GetFeatureFlagsConfigName(),
 		},
@@ -45,6 +47,11 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				DisableWorkingDirOverwrite:       true,
 				DisableAffinityAssistant:         true,
 				RunningInEnvWithInjectedSidecars: false,
+				EnableTektonOCIBundles:           true,
+				EnableCustomTasks:                true,
+				EnablePipelinesInPipelines:       true,
+				ResultExtractionMethod:           config.ResultExtractionMethodSidecarLogs,
+				MaxResultSize:                    8192,
 			},
 			fileName: "feature-flags-all-flags-set",
 		},
@@ -59,10 +66,37 @@ func TestNewFeatureFlagsFromEmptyConfigMap(t *testing.T) {
 	FeatureFlagsConfigEmptyName := "feature-flags-empty"
 	expectedConfig := &config.FeatureFlags{
 		RunningInEnvWithInjectedSidecars: true,
+		ResultExtractionMethod:           config.DefaultResultExtractionMethod,
+		MaxResultSize:                    config.DefaultMaxResultSize,
 	}
 	verifyConfigFileWithExpectedFeatureFlagsConfig(t, FeatureFlagsConfigEmptyName, expectedConfig)
 }
 
+func TestNewFeatureFlagsFromMapInvalidResultsConfig(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		data        map[string]string
+	}{{
+		description: "unknown results-from",
+		data:        map[string]string{"results-from": "annotations"},
+	}, {
+		description: "max-result-size not a number",
+		data:        map[string]string{"max-result-size": "4k"},
+	}, {
+		description: "max-result-size not positive",
+		data:        map[string]string{"max-result-size": "0"},
+	}, {
+		description: "max-result-size above the allowed maximum",
+		data:        map[string]string{"max-result-size": "2097152"},
+	}} {
+		t.Run(tc.description, func(t *testing.T) {
+			if _, err := config.NewFeatureFlagsFromMap(tc.data); err == nil {
+				t.Error("expected an error parsing the feature flags but got none")
+			}
+		})
+	}
+}
+
 func TestGetFeatureFlagsConfigName(t *testing.T) {
 	for _, tc := range []struct {
 		description         string
@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// LogArchiveSinkKey is the name of the configmap entry that specifies where the logs of
+	// the steps of TaskRuns are archived
+	LogArchiveSinkKey = "sink"
+
+	// LogArchivePVCClaimNameKey is the name of the configmap entry that specifies the name of
+	// the PersistentVolumeClaim mounted in the controller the logs are archived to
+	LogArchivePVCClaimNameKey = "pvc.claim.name"
+
+	// LogArchivePVCMountPathKey is the name of the configmap entry that specifies where the
+	// PersistentVolumeClaim is mounted in the controller
+	LogArchivePVCMountPathKey = "pvc.mount.path"
+
+	// LogArchiveS3EndpointKey is the name of the configmap entry that specifies the URL of the
+	// S3-compatible endpoint the logs are archived to
+	LogArchiveS3EndpointKey = "s3.endpoint"
+
+	// LogArchiveS3RegionKey is the name of the configmap entry that specifies the region of
+	// the S3-compatible endpoint
+	LogArchiveS3RegionKey = "s3.region"
+
+	// LogArchiveS3BucketKey is the name of the configmap entry that specifies the S3 bucket
+	// the logs are archived to
+	LogArchiveS3BucketKey = "s3.bucket"
+
+	// LogArchiveS3SecretNameKey is the name of the configmap entry that specifies the Secret,
+	// in the namespace of the controller, holding the credentials of the S3-compatible endpoint
+	LogArchiveS3SecretNameKey = "s3.secret.name"
+
+	// LogArchiveGCSBucketKey is the name of the configmap entry that specifies the GCS bucket
+	// the logs are archived to
+	LogArchiveGCSBucketKey = "gcs.bucket"
+
+	// LogArchiveGCSSecretNameKey is the name of the configmap entry that specifies the Secret,
+	// in the namespace of the controller, holding the service account key used to write to
+	// the GCS bucket
+	LogArchiveGCSSecretNameKey = "gcs.secret.name"
+
+	// LogArchiveGCSSecretKeyKey is the name of the configmap entry that specifies the key of
+	// the Secret holding the service account key
+	LogArchiveGCSSecretKeyKey = "gcs.secret.key"
+
+	// LogArchiveSinkPVC archives the logs to a PersistentVolumeClaim mounted in the controller
+	LogArchiveSinkPVC = "pvc"
+
+	// LogArchiveSinkS3 archives the logs to a bucket of an S3-compatible endpoint
+	LogArchiveSinkS3 = "s3"
+
+	// LogArchiveSinkGCS archives the logs to a GCS bucket
+	LogArchiveSinkGCS = "gcs"
+
+	// DefaultLogArchivePVCMountPath is the default path the PersistentVolumeClaim is mounted at
+	DefaultLogArchivePVCMountPath = "/var/tekton/logs"
+
+	// DefaultLogArchiveS3Endpoint is the default S3-compatible endpoint
+	DefaultLogArchiveS3Endpoint = "https://s3.amazonaws.com"
+
+	// DefaultLogArchiveS3Region is the default region of the S3-compatible endpoint
+	DefaultLogArchiveS3Region = "us-east-1"
+
+	// DefaultLogArchiveGCSSecretKey is the default key of the Secret holding the service
+	// account key
+	DefaultLogArchiveGCSSecretKey = "service_account.json"
+)
+
+// LogArchive holds the configurations for the archiving of the logs of the steps of TaskRuns
+// +k8s:deepcopy-gen=true
+type LogArchive struct {
+	Sink string
+
+	PVCClaimName string
+	PVCMountPath string
+
+	S3Endpoint   string
+	S3Region     string
+	S3Bucket     string
+	S3SecretName string
+
+	GCSBucket     string
+	GCSSecretName string
+	GCSSecretKey  string
+}
+
+// GetLogArchiveConfigName returns the name of the configmap containing all
+// customizations for the archiving of logs.
+func GetLogArchiveConfigName() string {
+	if e := os.Getenv("CONFIG_LOG_ARCHIVE_NAME"); e != "" {
+		return e
+	}
+	return "config-log-archive"
+}
+
+// Enabled returns true if the logs of the steps of TaskRuns are archived
+func (cfg *LogArchive) Enabled() bool {
+	return cfg != nil && cfg.Sink != ""
+}
+
+// NewLogArchiveFromMap returns a Config given a map corresponding to a ConfigMap
+func NewLogArchiveFromMap(cfgMap map[string]string) (*LogArchive, error) {
+	lc := LogArchive{
+		PVCMountPath: DefaultLogArchivePVCMountPath,
+		S3Endpoint:   DefaultLogArchiveS3Endpoint,
+		S3Region:     DefaultLogArchiveS3Region,
+		GCSSecretKey: DefaultLogArchiveGCSSecretKey,
+	}
+
+	for key, field := range map[string]*string{
+		LogArchiveSinkKey:          &lc.Sink,
+		LogArchivePVCClaimNameKey:  &lc.PVCClaimName,
+		LogArchivePVCMountPathKey:  &lc.PVCMountPath,
+		LogArchiveS3EndpointKey:    &lc.S3Endpoint,
+		LogArchiveS3RegionKey:      &lc.S3Region,
+		LogArchiveS3BucketKey:      &lc.S3Bucket,
+		LogArchiveS3SecretNameKey:  &lc.S3SecretName,
+		LogArchiveGCSBucketKey:     &lc.GCSBucket,
+		LogArchiveGCSSecretNameKey: &lc.GCSSecretName,
+		LogArchiveGCSSecretKeyKey:  &lc.GCSSecretKey,
+	} {
+		if value, ok := cfgMap[key]; ok && value != "" {
+			*field = value
+		}
+	}
+
+	var required map[string]string
+	switch lc.Sink {
+	case "":
+	case LogArchiveSinkPVC:
+		required = map[string]string{LogArchivePVCClaimNameKey: lc.PVCClaimName}
+	case LogArchiveSinkS3:
+		required = map[string]string{LogArchiveS3BucketKey: lc.S3Bucket, LogArchiveS3SecretNameKey: lc.S3SecretName}
+	case LogArchiveSinkGCS:
+		required = map[string]string{LogArchiveGCSBucketKey: lc.GCSBucket, LogArchiveGCSSecretNameKey: lc.GCSSecretName}
+	default:
+		return nil, fmt.Errorf("invalid value for %q: %q, must be %q, %q or %q", LogArchiveSinkKey, lc.Sink,
+			LogArchiveSinkPVC, LogArchiveSinkS3, LogArchiveSinkGCS)
+	}
+	for key, value := range required {
+		if value == "" {
+			return nil, fmt.Errorf("%q must be set when %q is %q", key, LogArchiveSinkKey, lc.Sink)
+		}
+	}
+
+	return &lc, nil
+}
+
+// NewLogArchiveFromConfigMap returns a Config for the given configmap
+func NewLogArchiveFromConfigMap(config *corev1.ConfigMap) (*LogArchive, error) {
+	return NewLogArchiveFromMap(config.Data)
+}
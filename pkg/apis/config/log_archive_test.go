@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestNewLogArchiveFromConfigMap(t *testing.T) {
+	defaults := config.LogArchive{
+		PVCMountPath: config.DefaultLogArchivePVCMountPath,
+		S3Endpoint:   config.DefaultLogArchiveS3Endpoint,
+		S3Region:     config.DefaultLogArchiveS3Region,
+		GCSSecretKey: config.DefaultLogArchiveGCSSecretKey,
+	}
+	s3 := defaults
+	s3.Sink = config.LogArchiveSinkS3
+	s3.S3Endpoint = "https://minio.example.com"
+	s3.S3Region = "eu-west-1"
+	s3.S3Bucket = "tekton-logs"
+	s3.S3SecretName = "s3-credentials"
+	pvc := defaults
+	pvc.Sink = config.LogArchiveSinkPVC
+	pvc.PVCClaimName = "tekton-logs"
+	pvc.PVCMountPath = "/logs"
+	gcs := defaults
+	gcs.Sink = config.LogArchiveSinkGCS
+	gcs.GCSBucket = "tekton-logs"
+	gcs.GCSSecretName = "gcs-credentials"
+
+	for _, tc := range []struct {
+		fileName       string
+		expectedConfig config.LogArchive
+	}{{
+		fileName:       config.GetLogArchiveConfigName(),
+		expectedConfig: s3,
+	}, {
+		fileName:       "config-log-archive-pvc",
+		expectedConfig: pvc,
+	}, {
+		fileName:       "config-log-archive-gcs",
+		expectedConfig: gcs,
+	}, {
+		fileName:       "config-log-archive-empty",
+		expectedConfig: defaults,
+	}} {
+		t.Run(tc.fileName, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, tc.fileName)
+			got, err := config.NewLogArchiveFromConfigMap(cm)
+			if err != nil {
+				t.Fatalf("NewLogArchiveFromConfigMap(actual) = %v", err)
+			}
+			if d := cmp.Diff(&tc.expectedConfig, got); d != "" {
+				t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestNewLogArchiveFromConfigMap_Invalid(t *testing.T) {
+	for _, fileName := range []string{
+		"config-log-archive-invalid-sink",
+		"config-log-archive-no-bucket",
+	} {
+		t.Run(fileName, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, fileName)
+			if _, err := config.NewLogArchiveFromConfigMap(cm); err == nil {
+				t.Error("Expected an error parsing an invalid config but got none")
+			}
+		})
+	}
+}
+
+func TestLogArchiveEnabled(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		cfg         *config.LogArchive
+		want        bool
+	}{{
+		description: "no config",
+		want:        false,
+	}, {
+		description: "no sink",
+		cfg:         &config.LogArchive{},
+		want:        false,
+	}, {
+		description: "sink",
+		cfg:         &config.LogArchive{Sink: config.LogArchiveSinkPVC},
+		want:        true,
+	}} {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := tc.cfg.Enabled(); got != tc.want {
+				t.Errorf("Enabled() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
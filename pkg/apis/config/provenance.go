@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ProvenanceSigningSecretNameKey is the name of the configmap entry that specifies the Secret,
+	// in the namespace of the controller, holding the key which signs the provenance of TaskRuns
+	ProvenanceSigningSecretNameKey = "signing-secret-name"
+
+	// ProvenanceStorageKey is the name of the configmap entry that specifies where the signed
+	// provenance is stored
+	ProvenanceStorageKey = "storage"
+
+	// ProvenanceBuilderIDKey is the name of the configmap entry that specifies the ID of the
+	// builder recorded in the provenance
+	ProvenanceBuilderIDKey = "builder-id"
+
+	// ProvenanceStorageAnnotations stores the signed provenance in annotations of the TaskRun
+	ProvenanceStorageAnnotations = "annotations"
+
+	// ProvenanceStorageOCI pushes the signed provenance to the registry, next to the images
+	// built by the TaskRun
+	ProvenanceStorageOCI = "oci"
+
+	// DefaultProvenanceStorage is the default storage of the signed provenance
+	DefaultProvenanceStorage = ProvenanceStorageAnnotations
+
+	// DefaultProvenanceBuilderID is the default ID of the builder recorded in the provenance
+	DefaultProvenanceBuilderID = "https://tekton.dev/pipelines/controller"
+)
+
+// Provenance holds the configurations for the signed provenance of TaskRuns
+// +k8s:deepcopy-gen=true
+type Provenance struct {
+	SigningSecretName string
+	Storage           string
+	BuilderID         string
+}
+
+// GetProvenanceConfigName returns the name of the configmap containing all
+// customizations for the signed provenance of TaskRuns.
+func GetProvenanceConfigName() string {
+	if e := os.Getenv("CONFIG_PROVENANCE_NAME"); e != "" {
+		return e
+	}
+	return "config-provenance"
+}
+
+// Enabled returns true if the provenance of TaskRuns is signed
+func (cfg *Provenance) Enabled() bool {
+	return cfg != nil && cfg.SigningSecretName != ""
+}
+
+// NewProvenanceFromMap returns a Config given a map corresponding to a ConfigMap
+func NewProvenanceFromMap(cfgMap map[string]string) (*Provenance, error) {
+	tc := Provenance{
+		Storage:   DefaultProvenanceStorage,
+		BuilderID: DefaultProvenanceBuilderID,
+	}
+
+	if name, ok := cfgMap[ProvenanceSigningSecretNameKey]; ok {
+		tc.SigningSecretName = name
+	}
+
+	if storage, ok := cfgMap[ProvenanceStorageKey]; ok {
+		switch storage {
+		case ProvenanceStorageAnnotations, ProvenanceStorageOCI:
+			tc.Storage = storage
+		default:
+			return nil, fmt.Errorf("invalid value for %q: %q, must be %q or %q", ProvenanceStorageKey, storage,
+				ProvenanceStorageAnnotations, ProvenanceStorageOCI)
+		}
+	}
+
+	if builderID, ok := cfgMap[ProvenanceBuilderIDKey]; ok && builderID != "" {
+		tc.BuilderID = builderID
+	}
+
+	return &tc, nil
+}
+
+// NewProvenanceFromConfigMap returns a Config for the given configmap
+func NewProvenanceFromConfigMap(config *corev1.ConfigMap) (*Provenance, error) {
+	return NewProvenanceFromMap(config.Data)
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestNewProvenanceFromConfigMap(t *testing.T) {
+	for _, tc := range []struct {
+		expectedConfig *config.Provenance
+		fileName       string
+	}{{
+		expectedConfig: &config.Provenance{
+			SigningSecretName: "signing-secrets",
+			Storage:           config.ProvenanceStorageAnnotations,
+			BuilderID:         config.DefaultProvenanceBuilderID,
+		},
+		fileName: config.GetProvenanceConfigName(),
+	}, {
+		expectedConfig: &config.Provenance{
+			SigningSecretName: "signing-secrets",
+			Storage:           config.ProvenanceStorageOCI,
+			BuilderID:         "https://example.com/builder",
+		},
+		fileName: "config-provenance-all-set",
+	}, {
+		expectedConfig: &config.Provenance{
+			Storage:   config.DefaultProvenanceStorage,
+			BuilderID: config.DefaultProvenanceBuilderID,
+		},
+		fileName: "config-provenance-empty",
+	}} {
+		t.Run(tc.fileName, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, tc.fileName)
+			got, err := config.NewProvenanceFromConfigMap(cm)
+			if err != nil {
+				t.Fatalf("NewProvenanceFromConfigMap(actual) = %v", err)
+			}
+			if d := cmp.Diff(tc.expectedConfig, got); d != "" {
+				t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestNewProvenanceFromConfigMap_InvalidStorage(t *testing.T) {
+	cm := test.ConfigMapFromTestFile(t, "config-provenance-invalid-storage")
+	if _, err := config.NewProvenanceFromConfigMap(cm); err == nil {
+		t.Error("Expected an error parsing an invalid storage but got none")
+	}
+}
+
+func TestProvenanceEnabled(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		cfg         *config.Provenance
+		want        bool
+	}{{
+		description: "no config",
+		want:        false,
+	}, {
+		description: "no signing secret",
+		cfg:         &config.Provenance{Storage: config.ProvenanceStorageOCI},
+		want:        false,
+	}, {
+		description: "signing secret",
+		cfg:         &config.Provenance{SigningSecretName: "signing-secrets"},
+		want:        true,
+	}} {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := tc.cfg.Enabled(); got != tc.want {
+				t.Errorf("Enabled() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// PrunerKeepKey is the name of the configmap entry that specifies how many completed
+	// runs of each Pipeline and Task are kept
+	PrunerKeepKey = "keep"
+
+	// PrunerMaxAgeKey is the name of the configmap entry that specifies how long completed
+	// runs are kept after their completion
+	PrunerMaxAgeKey = "max-age"
+
+	// PrunerWaitForResultsExportKey is the name of the configmap entry that specifies whether
+	// completed runs are only pruned once the results-exporter exported their record
+	PrunerWaitForResultsExportKey = "wait-for-results-export"
+
+	// PrunerKeepAnnotation is the annotation of a Namespace which overrides the number of
+	// completed runs kept in that namespace
+	PrunerKeepAnnotation = "tekton.dev/pruner-keep"
+
+	// PrunerMaxAgeAnnotation is the annotation of a Namespace which overrides how long
+	// completed runs are kept in that namespace
+	PrunerMaxAgeAnnotation = "tekton.dev/pruner-max-age"
+)
+
+// Pruner holds the configurations for the retention of completed PipelineRuns and TaskRuns.
+// A zero value for either Keep or MaxAge means no limit.
+// +k8s:deepcopy-gen=true
+type Pruner struct {
+	Keep                 int
+	MaxAge               time.Duration
+	WaitForResultsExport bool
+}
+
+// GetPrunerConfigName returns the name of the configmap containing all
+// customizations for the pruning of completed runs.
+func GetPrunerConfigName() string {
+	if e := os.Getenv("CONFIG_PRUNER_NAME"); e != "" {
+		return e
+	}
+	return "config-pruner"
+}
+
+// Enabled returns true if completed runs are pruned
+func (cfg *Pruner) Enabled() bool {
+	return cfg != nil && (cfg.Keep > 0 || cfg.MaxAge > 0)
+}
+
+// ForNamespace returns the Pruner configuration of a namespace, taking the overrides
+// in the annotations of the Namespace into account
+func (cfg *Pruner) ForNamespace(ns *corev1.Namespace) (*Pruner, error) {
+	pc := cfg.DeepCopy()
+	if pc == nil {
+		pc = &Pruner{}
+	}
+	if ns == nil {
+		return pc, nil
+	}
+	if err := parsePruner(pc, ns.Annotations, PrunerKeepAnnotation, PrunerMaxAgeAnnotation); err != nil {
+		return nil, fmt.Errorf("namespace %s: %w", ns.Name, err)
+	}
+	return pc, nil
+}
+
+// NewPrunerFromMap returns a Config given a map corresponding to a ConfigMap
+func NewPrunerFromMap(cfgMap map[string]string) (*Pruner, error) {
+	pc := Pruner{}
+	if err := parsePruner(&pc, cfgMap, PrunerKeepKey, PrunerMaxAgeKey); err != nil {
+		return nil, err
+	}
+	if wait, ok := cfgMap[PrunerWaitForResultsExportKey]; ok {
+		b, err := strconv.ParseBool(wait)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %q: %q, must be a boolean", PrunerWaitForResultsExportKey, wait)
+		}
+		pc.WaitForResultsExport = b
+	}
+	return &pc, nil
+}
+
+// NewPrunerFromConfigMap returns a Config for the given configmap
+func NewPrunerFromConfigMap(config *corev1.ConfigMap) (*Pruner, error) {
+	return NewPrunerFromMap(config.Data)
+}
+
+func parsePruner(pc *Pruner, m map[string]string, keepKey, maxAgeKey string) error {
+	if keep, ok := m[keepKey]; ok {
+		n, err := strconv.Atoi(keep)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid value for %q: %q, must be a non-negative integer", keepKey, keep)
+		}
+		pc.Keep = n
+	}
+	if maxAge, ok := m[maxAgeKey]; ok {
+		d, err := time.ParseDuration(maxAge)
+		if err != nil || d < 0 {
+			return fmt.Errorf("invalid value for %q: %q, must be a non-negative duration", maxAgeKey, maxAge)
+		}
+		pc.MaxAge = d
+	}
+	return nil
+}
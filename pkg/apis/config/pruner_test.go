@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewPrunerFromConfigMap(t *testing.T) {
+	for _, tc := range []struct {
+		fileName       string
+		expectedConfig *config.Pruner
+	}{{
+		fileName:       config.GetPrunerConfigName(),
+		expectedConfig: &config.Pruner{Keep: 5, MaxAge: 168 * time.Hour, WaitForResultsExport: true},
+	}, {
+		fileName:       "config-pruner-empty",
+		expectedConfig: &config.Pruner{},
+	}} {
+		t.Run(tc.fileName, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, tc.fileName)
+			got, err := config.NewPrunerFromConfigMap(cm)
+			if err != nil {
+				t.Fatalf("NewPrunerFromConfigMap(actual) = %v", err)
+			}
+			if d := cmp.Diff(tc.expectedConfig, got); d != "" {
+				t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestNewPrunerFromConfigMap_Invalid(t *testing.T) {
+	for _, fileName := range []string{
+		"config-pruner-invalid-keep",
+		"config-pruner-invalid-max-age",
+		"config-pruner-invalid-wait-for-results-export",
+	} {
+		t.Run(fileName, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, fileName)
+			if _, err := config.NewPrunerFromConfigMap(cm); err == nil {
+				t.Error("Expected an error parsing an invalid config but got none")
+			}
+		})
+	}
+}
+
+func TestPrunerForNamespace(t *testing.T) {
+	cfg := &config.Pruner{Keep: 5, MaxAge: time.Hour}
+	for _, tc := range []struct {
+		description string
+		cfg         *config.Pruner
+		annotations map[string]string
+		want        *config.Pruner
+	}{{
+		description: "no overrides",
+		cfg:         cfg,
+		want:        cfg,
+	}, {
+		description: "keep overridden",
+		cfg:         cfg,
+		annotations: map[string]string{config.PrunerKeepAnnotation: "2"},
+		want:        &config.Pruner{Keep: 2, MaxAge: time.Hour},
+	}, {
+		description: "max age overridden",
+		cfg:         cfg,
+		annotations: map[string]string{config.PrunerMaxAgeAnnotation: "0"},
+		want:        &config.Pruner{Keep: 5},
+	}, {
+		description: "no config",
+		annotations: map[string]string{config.PrunerKeepAnnotation: "3"},
+		want:        &config.Pruner{Keep: 3},
+	}} {
+		t.Run(tc.description, func(t *testing.T) {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "foo", Annotations: tc.annotations}}
+			got, err := tc.cfg.ForNamespace(ns)
+			if err != nil {
+				t.Fatalf("ForNamespace() = %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPrunerForNamespace_Invalid(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "foo",
+		Annotations: map[string]string{config.PrunerKeepAnnotation: "many"},
+	}}
+	if _, err := (&config.Pruner{}).ForNamespace(ns); err == nil {
+		t.Error("Expected an error parsing an invalid annotation but got none")
+	}
+}
+
+func TestPrunerEnabled(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		cfg         *config.Pruner
+		want        bool
+	}{{
+		description: "no config",
+		want:        false,
+	}, {
+		description: "no limits",
+		cfg:         &config.Pruner{},
+		want:        false,
+	}, {
+		description: "keep",
+		cfg:         &config.Pruner{Keep: 1},
+		want:        true,
+	}, {
+		description: "max age",
+		cfg:         &config.Pruner{MaxAge: time.Minute},
+		want:        true,
+	}} {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := tc.cfg.Enabled(); got != tc.want {
+				t.Errorf("Enabled() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
@@ -27,10 +27,14 @@ type cfgKey struct{}
 // Config holds the collection of configurations that we attach to contexts.
 // +k8s:deepcopy-gen=false
 type Config struct {
-	Defaults       *Defaults
-	FeatureFlags   *FeatureFlags
-	ArtifactBucket *ArtifactBucket
-	ArtifactPVC    *ArtifactPVC
+	Defaults         *Defaults
+	FeatureFlags     *FeatureFlags
+	ArtifactBucket   *ArtifactBucket
+	ArtifactPVC      *ArtifactPVC
+	Provenance       *Provenance
+	TrustedResources *TrustedResources
+	Pruner           *Pruner
+	LogArchive       *LogArchive
 }
 
 // FromContext extracts a Config from the provided context.
@@ -52,11 +56,19 @@ func FromContextOrDefaults(ctx context.Context) *Config {
 	featureFlags, _ := NewFeatureFlagsFromMap(map[string]string{})
 	artifactBucket, _ := NewArtifactBucketFromMap(map[string]string{})
 	artifactPVC, _ := NewArtifactPVCFromMap(map[string]string{})
+	provenance, _ := NewProvenanceFromMap(map[string]string{})
+	trustedResources, _ := NewTrustedResourcesFromMap(map[string]string{})
+	pruner, _ := NewPrunerFromMap(map[string]string{})
+	logArchive, _ := NewLogArchiveFromMap(map[string]string{})
 	return &Config{
-		Defaults:       defaults,
-		FeatureFlags:   featureFlags,
-		ArtifactBucket: artifactBucket,
-		ArtifactPVC:    artifactPVC,
+		Defaults:         defaults,
+		FeatureFlags:     featureFlags,
+		ArtifactBucket:   artifactBucket,
+		ArtifactPVC:      artifactPVC,
+		Provenance:       provenance,
+		TrustedResources: trustedResources,
+		Pruner:           pruner,
+		LogArchive:       logArchive,
 	}
 }
 
@@ -79,10 +91,14 @@ func NewStore(logger configmap.Logger, onAfterStore ...func(name string, value i
 			"defaults/features/artifacts",
 			logger,
 			configmap.Constructors{
-				GetDefaultsConfigName():       NewDefaultsFromConfigMap,
-				GetFeatureFlagsConfigName():   NewFeatureFlagsFromConfigMap,
-				GetArtifactBucketConfigName(): NewArtifactBucketFromConfigMap,
-				GetArtifactPVCConfigName():    NewArtifactPVCFromConfigMap,
+				GetDefaultsConfigName():         NewDefaultsFromConfigMap,
+				GetFeatureFlagsConfigName():     NewFeatureFlagsFromConfigMap,
+				GetArtifactBucketConfigName():   NewArtifactBucketFromConfigMap,
+				GetArtifactPVCConfigName():      NewArtifactPVCFromConfigMap,
+				GetProvenanceConfigName():       NewProvenanceFromConfigMap,
+				GetTrustedResourcesConfigName(): NewTrustedResourcesFromConfigMap,
+				GetPrunerConfigName():           NewPrunerFromConfigMap,
+				GetLogArchiveConfigName():       NewLogArchiveFromConfigMap,
 			},
 			onAfterStore...,
 		),
@@ -114,11 +130,31 @@ func (s *Store) Load() *Config {
 	if artifactPVC == nil {
 		artifactPVC, _ = NewArtifactPVCFromMap(map[string]string{})
 	}
+	provenance := s.UntypedLoad(GetProvenanceConfigName())
+	if provenance == nil {
+		provenance, _ = NewProvenanceFromMap(map[string]string{})
+	}
+	trustedResources := s.UntypedLoad(GetTrustedResourcesConfigName())
+	if trustedResources == nil {
+		trustedResources, _ = NewTrustedResourcesFromMap(map[string]string{})
+	}
+	pruner := s.UntypedLoad(GetPrunerConfigName())
+	if pruner == nil {
+		pruner, _ = NewPrunerFromMap(map[string]string{})
+	}
+	logArchive := s.UntypedLoad(GetLogArchiveConfigName())
+	if logArchive == nil {
+		logArchive, _ = NewLogArchiveFromMap(map[string]string{})
+	}
 
 	return &Config{
-		Defaults:       defaults.(*Defaults).DeepCopy(),
-		FeatureFlags:   featureFlags.(*FeatureFlags).DeepCopy(),
-		ArtifactBucket: artifactBucket.(*ArtifactBucket).DeepCopy(),
-		ArtifactPVC:    artifactPVC.(*ArtifactPVC).DeepCopy(),
+		Defaults:         defaults.(*Defaults).DeepCopy(),
+		FeatureFlags:     featureFlags.(*FeatureFlags).DeepCopy(),
+		ArtifactBucket:   artifactBucket.(*ArtifactBucket).DeepCopy(),
+		ArtifactPVC:      artifactPVC.(*ArtifactPVC).DeepCopy(),
+		Provenance:       provenance.(*Provenance).DeepCopy(),
+		TrustedResources: trustedResources.(*TrustedResources).DeepCopy(),
+		Pruner:           pruner.(*Pruner).DeepCopy(),
+		LogArchive:       logArchive.(*LogArchive).DeepCopy(),
 	}
 }
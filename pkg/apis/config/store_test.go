@@ -32,17 +32,29 @@ func TestStoreLoadWithContext(t *testing.T) {
 	featuresConfig := test.ConfigMapFromTestFile(t, "feature-flags-all-flags-set")
 	artifactBucketConfig := test.ConfigMapFromTestFile(t, "config-artifact-bucket")
 	artifactPVCConfig := test.ConfigMapFromTestFile(t, "config-artifact-pvc")
+	provenanceConfig := test.ConfigMapFromTestFile(t, "config-provenance")
+	trustedResourcesConfig := test.ConfigMapFromTestFile(t, "config-trusted-resources")
+	prunerConfig := test.ConfigMapFromTestFile(t, "config-pruner")
+	logArchiveConfig := test.ConfigMapFromTestFile(t, "config-log-archive")
 
 	expectedDefaults, _ := config.NewDefaultsFromConfigMap(defaultConfig)
 	expectedFeatures, _ := config.NewFeatureFlagsFromConfigMap(featuresConfig)
 	expectedArtifactBucket, _ := config.NewArtifactBucketFromConfigMap(artifactBucketConfig)
 	expectedArtifactPVC, _ := config.NewArtifactPVCFromConfigMap(artifactPVCConfig)
+	expectedProvenance, _ := config.NewProvenanceFromConfigMap(provenanceConfig)
+	expectedTrustedResources, _ := config.NewTrustedResourcesFromConfigMap(trustedResourcesConfig)
+	expectedPruner, _ := config.NewPrunerFromConfigMap(prunerConfig)
+	expectedLogArchive, _ := config.NewLogArchiveFromConfigMap(logArchiveConfig)
 
 	expected := &config.Config{
-		Defaults:       expectedDefaults,
-		FeatureFlags:   expectedFeatures,
-		ArtifactBucket: expectedArtifactBucket,
-		ArtifactPVC:    expectedArtifactPVC,
+		Defaults:         expectedDefaults,
+		FeatureFlags:     expectedFeatures,
+		ArtifactBucket:   expectedArtifactBucket,
+		ArtifactPVC:      expectedArtifactPVC,
+		Provenance:       expectedProvenance,
+		TrustedResources: expectedTrustedResources,
+		Pruner:           expectedPruner,
+		LogArchive:       expectedLogArchive,
 	}
 
 	store := config.NewStore(logtesting.TestLogger(t))
@@ -50,6 +62,10 @@ func TestStoreLoadWithContext(t *testing.T) {
 	store.OnConfigChanged(featuresConfig)
 	store.OnConfigChanged(artifactBucketConfig)
 	store.OnConfigChanged(artifactPVCConfig)
+	store.OnConfigChanged(provenanceConfig)
+	store.OnConfigChanged(trustedResourcesConfig)
+	store.OnConfigChanged(prunerConfig)
+	store.OnConfigChanged(logArchiveConfig)
 
 	cfg := config.FromContext(store.ToContext(context.Background()))
 
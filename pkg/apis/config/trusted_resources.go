@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/tektoncd/pipeline/pkg/signature"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// VerificationModeKey is the name of the configmap entry that specifies how the signatures
+	// of Tasks and Pipelines are verified
+	VerificationModeKey = "verification-mode"
+
+	// PublicKeysKey is the name of the configmap entry that specifies the PEM encoded public keys
+	// Tasks and Pipelines can be signed with
+	PublicKeysKey = "public-keys"
+
+	// VerificationModeSkip doesn't verify the signatures of Tasks and Pipelines
+	VerificationModeSkip = "skip"
+
+	// VerificationModeWarn logs a warning when a Task or Pipeline fails verification
+	VerificationModeWarn = "warn"
+
+	// VerificationModeEnforce fails the runs of Tasks and Pipelines which fail verification
+	VerificationModeEnforce = "enforce"
+
+	// DefaultVerificationMode is the default verification mode
+	DefaultVerificationMode = VerificationModeSkip
+)
+
+// TrustedResources holds the configurations for the verification of the signatures of Tasks
+// and Pipelines
+// +k8s:deepcopy-gen=true
+type TrustedResources struct {
+	VerificationMode string
+	PublicKeys       string
+}
+
+// GetTrustedResourcesConfigName returns the name of the configmap containing all
+// customizations for the verification of Tasks and Pipelines.
+func GetTrustedResourcesConfigName() string {
+	if e := os.Getenv("CONFIG_TRUSTED_RESOURCES_NAME"); e != "" {
+		return e
+	}
+	return "config-trusted-resources"
+}
+
+// Enabled returns true if the signatures of Tasks and Pipelines are verified
+func (cfg *TrustedResources) Enabled() bool {
+	return cfg != nil && cfg.VerificationMode != "" && cfg.VerificationMode != VerificationModeSkip
+}
+
+// NewTrustedResourcesFromMap returns a Config given a map corresponding to a ConfigMap
+func NewTrustedResourcesFromMap(cfgMap map[string]string) (*TrustedResources, error) {
+	tc := TrustedResources{
+		VerificationMode: DefaultVerificationMode,
+	}
+
+	if mode, ok := cfgMap[VerificationModeKey]; ok {
+		switch mode {
+		case VerificationModeSkip, VerificationModeWarn, VerificationModeEnforce:
+			tc.VerificationMode = mode
+		default:
+			return nil, fmt.Errorf("invalid value for %q: %q, must be %q, %q or %q", VerificationModeKey, mode,
+				VerificationModeSkip, VerificationModeWarn, VerificationModeEnforce)
+		}
+	}
+
+	if keys, ok := cfgMap[PublicKeysKey]; ok && keys != "" {
+		if _, err := signature.ParsePublicKeys([]byte(keys)); err != nil {
+			return nil, fmt.Errorf("invalid value for %q: %w", PublicKeysKey, err)
+		}
+		tc.PublicKeys = keys
+	}
+
+	if tc.VerificationMode != VerificationModeSkip && tc.PublicKeys == "" {
+		return nil, fmt.Errorf("%q must be set when %q is %q", PublicKeysKey, VerificationModeKey, tc.VerificationMode)
+	}
+
+	return &tc, nil
+}
+
+// NewTrustedResourcesFromConfigMap returns a Config for the given configmap
+func NewTrustedResourcesFromConfigMap(config *corev1.ConfigMap) (*TrustedResources, error) {
+	return NewTrustedResourcesFromMap(config.Data)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestNewTrustedResourcesFromConfigMap(t *testing.T) {
+	for _, tc := range []struct {
+		fileName string
+		mode     string
+		withKeys bool
+	}{{
+		fileName: config.GetTrustedResourcesConfigName(),
+		mode:     config.VerificationModeEnforce,
+		withKeys: true,
+	}, {
+		fileName: "config-trusted-resources-multiple-keys",
+		mode:     config.VerificationModeWarn,
+		withKeys: true,
+	}, {
+		fileName: "config-trusted-resources-empty",
+		mode:     config.DefaultVerificationMode,
+	}} {
+		t.Run(tc.fileName, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, tc.fileName)
+			expectedConfig := &config.TrustedResources{VerificationMode: tc.mode}
+			if tc.withKeys {
+				expectedConfig.PublicKeys = cm.Data[config.PublicKeysKey]
+			}
+			got, err := config.NewTrustedResourcesFromConfigMap(cm)
+			if err != nil {
+				t.Fatalf("NewTrustedResourcesFromConfigMap(actual) = %v", err)
+			}
+			if d := cmp.Diff(expectedConfig, got); d != "" {
+				t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestNewTrustedResourcesFromConfigMap_Invalid(t *testing.T) {
+	for _, fileName := range []string{
+		"config-trusted-resources-invalid-mode",
+		"config-trusted-resources-invalid-keys",
+		"config-trusted-resources-no-keys",
+	} {
+		t.Run(fileName, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, fileName)
+			if _, err := config.NewTrustedResourcesFromConfigMap(cm); err == nil {
+				t.Error("Expected an error parsing an invalid config but got none")
+			}
+		})
+	}
+}
+
+func TestTrustedResourcesEnabled(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		cfg         *config.TrustedResources
+		want        bool
+	}{{
+		description: "no config",
+		want:        false,
+	}, {
+		description: "skip",
+		cfg:         &config.TrustedResources{VerificationMode: config.VerificationModeSkip},
+		want:        false,
+	}, {
+		description: "warn",
+		cfg:         &config.TrustedResources{VerificationMode: config.VerificationModeWarn},
+		want:        true,
+	}, {
+		description: "enforce",
+		cfg:         &config.TrustedResources{VerificationMode: config.VerificationModeEnforce},
+		want:        true,
+	}} {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := tc.cfg.Enabled(); got != tc.want {
+				t.Errorf("Enabled() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
@@ -26,4 +26,15 @@ const (
 
 	// TaskRunControllerName holds the name of the PipelineRun controller
 	RunControllerName = "Run"
+
+	// PrunerControllerName holds the name of the controller pruning completed runs
+	PrunerControllerName = "Pruner"
+
+	// PipelineRunResultsExporterControllerName holds the name of the controller exporting
+	// the records of completed PipelineRuns
+	PipelineRunResultsExporterControllerName = "PipelineRunResultsExporter"
+
+	// TaskRunResultsExporterControllerName holds the name of the controller exporting
+	// the records of completed TaskRuns
+	TaskRunResultsExporterControllerName = "TaskRunResultsExporter"
 )
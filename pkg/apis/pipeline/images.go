@@ -44,6 +44,9 @@ type Images struct {
 	PRImage string
 	// ImageDigestExporterImage is the container image containing our image digest exporter binary.
 	ImageDigestExporterImage string
+	// SidecarLogResultsImage is the container image containing the binary which outputs Task results
+	// to the logs of the results sidecar.
+	SidecarLogResultsImage string
 
 	// NOTE: Make sure to add any new images to Validate below!
 }
@@ -64,6 +67,7 @@ func (i Images) Validate() error {
 		{i.BuildGCSFetcherImage, "build-gcs-fetcher"},
 		{i.PRImage, "pr"},
 		{i.ImageDigestExporterImage, "imagedigest-exporter"},
+		{i.SidecarLogResultsImage, "sidecarlogresults"},
 	} {
 		if f.v == "" {
 			unset = append(unset, f.name)
@@ -18,6 +18,7 @@ func TestValidate(t *testing.T) {
 		BuildGCSFetcherImage:     "set",
 		PRImage:                  "set",
 		ImageDigestExporterImage: "set",
+		SidecarLogResultsImage:   "set",
 	}
 	if err := valid.Validate(); err != nil {
 		t.Errorf("valid Images returned error: %v", err)
@@ -34,8 +35,9 @@ func TestValidate(t *testing.T) {
 		BuildGCSFetcherImage:     "", // unset!
 		PRImage:                  "", // unset!
 		ImageDigestExporterImage: "set",
+		SidecarLogResultsImage:   "", // unset!
 	}
-	wantErr := "found unset image flags: [build-gcs-fetcher git pr shell]"
+	wantErr := "found unset image flags: [build-gcs-fetcher git pr shell sidecarlogresults]"
 	if err := invalid.Validate(); err == nil {
 		t.Error("invalid Images expected error, got nil")
 	} else if err.Error() != wantErr {
@@ -48,6 +48,18 @@ const (
 
 	// RunKey is used as the label identifier for a Run
 	RunKey = "/run"
+
+	// CloudEventsSinkAnnotationKey is used as the annotation identifier for the sink
+	// of the CloudEvents of a PipelineRun or TaskRun, overriding the default sink
+	CloudEventsSinkAnnotationKey = "/cloudevents-sink"
+
+	// ProgressEventsAnnotationKey is used as the annotation identifier for the record
+	// of the CloudEvents about the progress of a PipelineRun which were sent
+	ProgressEventsAnnotationKey = "/progress-events"
+
+	// ResultsExportedAnnotationKey is used as the annotation identifier for the completed
+	// PipelineRuns and TaskRuns whose record is exported by the results-exporter
+	ResultsExportedAnnotationKey = "/results-exported"
 )
 
 var (
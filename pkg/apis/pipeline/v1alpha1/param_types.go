@@ -36,6 +36,7 @@ type ParamType = v1beta1.ParamType
 const (
 	ParamTypeString ParamType = v1beta1.ParamTypeString
 	ParamTypeArray  ParamType = v1beta1.ParamTypeArray
+	ParamTypeObject ParamType = v1beta1.ParamTypeObject
 )
 
 // AllParamTypes can be used for ParamType validation.
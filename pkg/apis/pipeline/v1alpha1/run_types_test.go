@@ -153,7 +153,7 @@ status:
 			},
 			RunStatusFields: v1alpha1.RunStatusFields{
 				// Results are parsed correctly.
-				Results: []v1beta1.TaskRunResult{{
+				Results: []v1alpha1.RunResult{{
 					Name:  "foo",
 					Value: "bar",
 				}},
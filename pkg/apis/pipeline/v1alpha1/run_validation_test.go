@@ -19,6 +19,7 @@ package v1alpha1_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
@@ -93,6 +94,30 @@ func TestRun_Invalid(t *testing.T) {
 			},
 		},
 		want: apis.ErrMultipleOneOf("spec.params"),
+	}, {
+		name: "invalid status",
+		run: &v1alpha1.Run{
+			Spec: v1alpha1.RunSpec{
+				Ref: &v1alpha1.TaskRef{
+					APIVersion: "blah",
+					Kind:       "blah",
+				},
+				Status: "RunStopped",
+			},
+		},
+		want: apis.ErrInvalidValue("RunStopped should be RunCancelled", "spec.status"),
+	}, {
+		name: "negative timeout",
+		run: &v1alpha1.Run{
+			Spec: v1alpha1.RunSpec{
+				Ref: &v1alpha1.TaskRef{
+					APIVersion: "blah",
+					Kind:       "blah",
+				},
+				Timeout: &metav1.Duration{Duration: -48 * time.Hour},
+			},
+		},
+		want: apis.ErrInvalidValue("-48h0m0s should be >= 0", "spec.timeout"),
 	}} {
 		t.Run(c.name, func(t *testing.T) {
 			err := c.run.Validate(context.Background())
@@ -145,6 +170,18 @@ func TestRun_Valid(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		name: "cancelled with timeout",
+		run: &v1alpha1.Run{
+			Spec: v1alpha1.RunSpec{
+				Ref: &v1alpha1.TaskRef{
+					APIVersion: "blah",
+					Kind:       "blah",
+				},
+				Status:  v1alpha1.RunSpecStatusCancelled,
+				Timeout: &metav1.Duration{Duration: time.Hour},
+			},
+		},
 	}} {
 		t.Run(c.name, func(t *testing.T) {
 			if err := c.run.Validate(context.Background()); err != nil {
@@ -686,6 +686,11 @@ func (in *RunSpec) DeepCopyInto(out *RunSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -699,54 +704,6 @@ func (in *RunSpec) DeepCopy() *RunSpec {
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RunStatus) DeepCopyInto(out *RunStatus) {
-	*out = *in
-	in.Status.DeepCopyInto(&out.Status)
-	in.RunStatusFields.DeepCopyInto(&out.RunStatusFields)
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunStatus.
-func (in *RunStatus) DeepCopy() *RunStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(RunStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RunStatusFields) DeepCopyInto(out *RunStatusFields) {
-	*out = *in
-	if in.StartTime != nil {
-		in, out := &in.StartTime, &out.StartTime
-		*out = (*in).DeepCopy()
-	}
-	if in.CompletionTime != nil {
-		in, out := &in.CompletionTime, &out.CompletionTime
-		*out = (*in).DeepCopy()
-	}
-	if in.Results != nil {
-		in, out := &in.Results, &out.Results
-		*out = make([]v1beta1.TaskRunResult, len(*in))
-		copy(*out, *in)
-	}
-	in.ExtraFields.DeepCopyInto(&out.ExtraFields)
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunStatusFields.
-func (in *RunStatusFields) DeepCopy() *RunStatusFields {
-	if in == nil {
-		return nil
-	}
-	out := new(RunStatusFields)
-	in.DeepCopyInto(out)
-	return out
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Task) DeepCopyInto(out *Task) {
 	*out = *in
@@ -0,0 +1,57 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"knative.dev/pkg/apis"
+)
+
+// Validate ensures that a TaskRef pointing at a Tekton Bundle is well formed.
+func (ref *TaskRef) Validate(ctx context.Context) *apis.FieldError {
+	if ref == nil || ref.Bundle == "" {
+		return nil
+	}
+	return validateBundle(ctx, ref.Name, ref.Bundle)
+}
+
+// Validate ensures that a PipelineRef pointing at a Tekton Bundle is well formed.
+func (ref *PipelineRef) Validate(ctx context.Context) *apis.FieldError {
+	if ref == nil || ref.Bundle == "" {
+		return nil
+	}
+	return validateBundle(ctx, ref.Name, ref.Bundle)
+}
+
+// validateBundle checks that bundles are enabled, that the referenced object
+// is named and that the bundle is a valid image reference.
+func validateBundle(ctx context.Context, objectName, bundle string) (errs *apis.FieldError) {
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableTektonOCIBundles {
+		return apis.ErrDisallowedFields("bundle")
+	}
+	if objectName == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	}
+	if _, err := name.ParseReference(bundle); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("invalid bundle reference %q: %v", bundle, err), "bundle"))
+	}
+	return errs
+}
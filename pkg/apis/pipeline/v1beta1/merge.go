@@ -84,8 +84,8 @@ func MergeStepsWithStepTemplate(template *v1.Container, steps []Step) ([]Step, e
 			merged.Args = []string{}
 		}
 
-		// Pass through original step Script, for later conversion.
-		steps[i] = Step{Container: *merged, Script: s.Script}
+		// Pass through original step Script and ScriptInterpreter, for later conversion.
+		steps[i] = Step{Container: *merged, Script: s.Script, Timeout: s.Timeout, OnError: s.OnError, ScriptInterpreter: s.ScriptInterpreter, ExecutionMode: s.ExecutionMode}
 	}
 	return steps, nil
 }
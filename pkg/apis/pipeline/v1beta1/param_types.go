@@ -20,6 +20,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
 
 	resource "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/substitution"
@@ -34,7 +36,7 @@ type ParamSpec struct {
 	// Name declares the name by which a parameter is referenced.
 	Name string `json:"name"`
 	// Type is the user-specified type of the parameter. The possible types
-	// are currently "string" and "array", and "string" is the default.
+	// are currently "string", "array" and "object", and "string" is the default.
 	// +optional
 	Type ParamType `json:"type,omitempty"`
 	// Description is a user-facing description of the parameter that may be
@@ -46,6 +48,26 @@ type ParamSpec struct {
 	// parameter.
 	// +optional
 	Default *ArrayOrString `json:"default,omitempty"`
+	// Properties is the JSON Schema properties to support key-value pairs parameter.
+	// It is required for, and only allowed with, parameters of type "object".
+	// +optional
+	Properties map[string]PropertySpec `json:"properties,omitempty"`
+	// Enum declares the values the parameter is allowed to take. For array
+	// parameters, each element must be one of these values.
+	// +optional
+	Enum []string `json:"enum,omitempty"`
+	// Pattern is a regular expression, in the RE2 syntax, which the value of
+	// the parameter must match. As in JSON Schema, the expression is not
+	// implicitly anchored. For array parameters, each element must match.
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// PropertySpec defines the struct for object keys
+type PropertySpec struct {
+	// Type is the type of the value of the key. Only "string" is supported.
+	// +optional
+	Type ParamType `json:"type,omitempty"`
 }
 
 // SetDefaults set the default type
@@ -59,6 +81,83 @@ func (pp *ParamSpec) SetDefaults(ctx context.Context) {
 			pp.Type = ParamTypeString
 		}
 	}
+	// The values of the keys of an object are strings
+	for key, property := range pp.Properties {
+		if property.Type == "" {
+			property.Type = ParamTypeString
+			pp.Properties[key] = property
+		}
+	}
+}
+
+// MissingObjectKeys returns the keys declared by an object ParamSpec which are provided neither
+// by value nor by the default value of the ParamSpec, sorted by name.
+func (pp *ParamSpec) MissingObjectKeys(value ArrayOrString) []string {
+	var missingKeys []string
+	for key := range pp.Properties {
+		if _, ok := value.ObjectVal[key]; ok {
+			continue
+		}
+		if pp.Default != nil {
+			if _, ok := pp.Default.ObjectVal[key]; ok {
+				continue
+			}
+		}
+		missingKeys = append(missingKeys, key)
+	}
+	sort.Strings(missingKeys)
+	return missingKeys
+}
+
+// ValidateValue returns an error if value, or any of its elements for array parameters, isn't
+// one of the values allowed by the ParamSpec or doesn't match its pattern.
+func (pp *ParamSpec) ValidateValue(value ArrayOrString) error {
+	if len(pp.Enum) == 0 && pp.Pattern == "" {
+		return nil
+	}
+	var values []string
+	switch value.Type {
+	case ParamTypeArray:
+		values = value.ArrayVal
+	case ParamTypeString:
+		values = []string{value.StringVal}
+	default:
+		return nil
+	}
+	var pattern *regexp.Regexp
+	if pp.Pattern != "" {
+		var err error
+		if pattern, err = regexp.Compile(pp.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern %q of param %q: %w", pp.Pattern, pp.Name, err)
+		}
+	}
+	for _, v := range values {
+		if len(pp.Enum) != 0 && !sets.NewString(pp.Enum...).Has(v) {
+			return fmt.Errorf("value %q of param %q is not one of the allowed values %v", v, pp.Name, pp.Enum)
+		}
+		if pattern != nil && !pattern.MatchString(v) {
+			return fmt.Errorf("value %q of param %q does not match the pattern %q", v, pp.Name, pp.Pattern)
+		}
+	}
+	return nil
+}
+
+// ValidateParamValues returns an error for each of the params whose value isn't allowed by the
+// enum or the pattern of the ParamSpec of the same name. It is used by the webhook, and by the
+// reconcilers once the values from the results of other Tasks are known.
+func ValidateParamValues(paramSpecs []ParamSpec, params []Param) (errs *apis.FieldError) {
+	specs := make(map[string]ParamSpec, len(paramSpecs))
+	for _, ps := range paramSpecs {
+		specs[ps.Name] = ps
+	}
+	for _, p := range params {
+		if ps, ok := specs[p.Name]; ok {
+			if err := ps.ValidateValue(p.Value); err != nil {
+				errs = errs.Also(apis.ErrInvalidValue(err.Error(), "value").ViaKey(p.Name))
+			}
+		}
+	}
+	return errs
 }
 
 // ResourceParam declares a string value to use for the parameter called Name, and is used in
@@ -72,34 +171,40 @@ type Param struct {
 }
 
 // ParamType indicates the type of an input parameter;
-// Used to distinguish between a single string and an array of strings.
+// Used to distinguish between a single string, an array of strings and an object.
 type ParamType string
 
 // Valid ParamTypes:
 const (
 	ParamTypeString ParamType = "string"
 	ParamTypeArray  ParamType = "array"
+	ParamTypeObject ParamType = "object"
 )
 
 // AllParamTypes can be used for ParamType validation.
-var AllParamTypes = []ParamType{ParamTypeString, ParamTypeArray}
+var AllParamTypes = []ParamType{ParamTypeString, ParamTypeArray, ParamTypeObject}
 
 // ArrayOrString is modeled after IntOrString in kubernetes/apimachinery:
 
-// ArrayOrString is a type that can hold a single string or string array.
-// Used in JSON unmarshalling so that a single JSON field can accept
-// either an individual string or an array of strings.
+// ArrayOrString is a type that can hold a single string, a string array or
+// a string map. Used in JSON unmarshalling so that a single JSON field can
+// accept either an individual string, an array of strings or an object.
 type ArrayOrString struct {
-	Type      ParamType `json:"type"` // Represents the stored type of ArrayOrString.
-	StringVal string    `json:"stringVal"`
-	ArrayVal  []string  `json:"arrayVal"`
+	Type      ParamType         `json:"type"` // Represents the stored type of ArrayOrString.
+	StringVal string            `json:"stringVal"`
+	ArrayVal  []string          `json:"arrayVal"`
+	ObjectVal map[string]string `json:"objectVal"`
 }
 
 // UnmarshalJSON implements the json.Unmarshaller interface.
 func (arrayOrString *ArrayOrString) UnmarshalJSON(value []byte) error {
-	if value[0] == '"' {
+	switch value[0] {
+	case '"':
 		arrayOrString.Type = ParamTypeString
 		return json.Unmarshal(value, &arrayOrString.StringVal)
+	case '{':
+		arrayOrString.Type = ParamTypeObject
+		return json.Unmarshal(value, &arrayOrString.ObjectVal)
 	}
 	arrayOrString.Type = ParamTypeArray
 	return json.Unmarshal(value, &arrayOrString.ArrayVal)
@@ -112,16 +217,30 @@ func (arrayOrString ArrayOrString) MarshalJSON() ([]byte, error) {
 		return json.Marshal(arrayOrString.StringVal)
 	case ParamTypeArray:
 		return json.Marshal(arrayOrString.ArrayVal)
+	case ParamTypeObject:
+		return json.Marshal(arrayOrString.ObjectVal)
 	default:
 		return []byte{}, fmt.Errorf("impossible ArrayOrString.Type: %q", arrayOrString.Type)
 	}
 }
 
-// ApplyReplacements applyes replacements for ArrayOrString type
-func (arrayOrString *ArrayOrString) ApplyReplacements(stringReplacements map[string]string, arrayReplacements map[string][]string) {
-	if arrayOrString.Type == ParamTypeString {
+// ApplyReplacements applyes replacements for ArrayOrString type. A string which is entirely
+// a reference to one of the objectReplacements, e.g. "$(params.foo[*])", becomes that object.
+func (arrayOrString *ArrayOrString) ApplyReplacements(stringReplacements map[string]string, arrayReplacements map[string][]string, objectReplacements map[string]map[string]string) {
+	switch arrayOrString.Type {
+	case ParamTypeString:
+		if object, ok := ApplyObjectReplacements(arrayOrString.StringVal, objectReplacements); ok {
+			*arrayOrString = *NewObject(object)
+			return
+		}
 		arrayOrString.StringVal = ApplyReplacements(arrayOrString.StringVal, stringReplacements)
-	} else {
+	case ParamTypeObject:
+		newObjectVal := make(map[string]string, len(arrayOrString.ObjectVal))
+		for k, v := range arrayOrString.ObjectVal {
+			newObjectVal[k] = ApplyReplacements(v, stringReplacements)
+		}
+		arrayOrString.ObjectVal = newObjectVal
+	default:
 		var newArrayVal []string
 		for _, v := range arrayOrString.ArrayVal {
 			newArrayVal = append(newArrayVal, ApplyArrayReplacements(v, stringReplacements, arrayReplacements)...)
@@ -145,11 +264,28 @@ func NewArrayOrString(value string, values ...string) *ArrayOrString {
 	}
 }
 
+// NewObject creates an ArrayOrString of type ParamTypeObject holding the given keys and values.
+func NewObject(value map[string]string) *ArrayOrString {
+	objectVal := make(map[string]string, len(value))
+	for k, v := range value {
+		objectVal[k] = v
+	}
+	return &ArrayOrString{
+		Type:      ParamTypeObject,
+		ObjectVal: objectVal,
+	}
+}
+
 func validatePipelineParametersVariablesInTaskParameters(params []Param, prefix string, paramNames sets.String, arrayParamNames sets.String) (errs *apis.FieldError) {
 	for _, param := range params {
-		if param.Value.Type == ParamTypeString {
+		switch param.Value.Type {
+		case ParamTypeString:
 			errs = errs.Also(validateStringVariableInTaskParameters(param.Value.StringVal, prefix, paramNames, arrayParamNames).ViaFieldKey("params", param.Name))
-		} else {
+		case ParamTypeObject:
+			for key, value := range param.Value.ObjectVal {
+				errs = errs.Also(validateStringVariableInTaskParameters(value, prefix, paramNames, arrayParamNames).ViaFieldKey("value", key).ViaFieldKey("params", param.Name))
+			}
+		default:
 			for idx, arrayElement := range param.Value.ArrayVal {
 				errs = errs.Also(validateArrayVariableInTaskParameters(arrayElement, prefix, paramNames, arrayParamNames).ViaFieldIndex("value", idx).ViaFieldKey("params", param.Name))
 			}
@@ -158,6 +294,52 @@ func validatePipelineParametersVariablesInTaskParameters(params []Param, prefix
 	return errs
 }
 
+// validatePipelineObjectParametersVariablesInTaskParameters ensures that the object params are referenced
+// through their keys in the params of a PipelineTask, e.g. "$(params.foo.key)". An entire object can
+// only be passed as the whole value of a param, e.g. "$(params.foo[*])".
+func validatePipelineObjectParametersVariablesInTaskParameters(params []Param, prefix string, objectParamKeys map[string]sets.String) (errs *apis.FieldError) {
+	for _, param := range params {
+		switch param.Value.Type {
+		case ParamTypeString:
+			if !isEntireObjectReference(param.Value.StringVal, prefix, objectParamKeys) {
+				errs = errs.Also(substitution.ValidateObjectKeysP(param.Value.StringVal, prefix, objectParamKeys).ViaFieldKey("params", param.Name))
+			}
+		case ParamTypeObject:
+			for key, value := range param.Value.ObjectVal {
+				errs = errs.Also(substitution.ValidateObjectKeysP(value, prefix, objectParamKeys).ViaFieldKey("value", key).ViaFieldKey("params", param.Name))
+			}
+		default:
+			for idx, arrayElement := range param.Value.ArrayVal {
+				errs = errs.Also(substitution.ValidateObjectKeysP(arrayElement, prefix, objectParamKeys).ViaFieldIndex("value", idx).ViaFieldKey("params", param.Name))
+			}
+		}
+	}
+	return errs
+}
+
+// isEntireObjectReference returns true if value is entirely a reference to one of the objects of
+// objectParamKeys, e.g. "$(params.foo[*])".
+func isEntireObjectReference(value, prefix string, objectParamKeys map[string]sets.String) bool {
+	for name := range objectParamKeys {
+		if value == fmt.Sprintf("$(%s.%s)", prefix, name) || value == fmt.Sprintf("$(%s.%s[*])", prefix, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// validatePipelineParametersVariablesInMatrixParameters validates the parameter variables used in the
+// values of a Matrix: array parameters can be used too, when isolated, to expand into several values.
+func validatePipelineParametersVariablesInMatrixParameters(matrix []Param, prefix string, paramNames sets.String, arrayParamNames sets.String) (errs *apis.FieldError) {
+	for _, param := range matrix {
+		for idx, arrayElement := range param.Value.ArrayVal {
+			errs = errs.Also(substitution.ValidateVariableP(arrayElement, prefix, paramNames).ViaFieldIndex("value", idx).ViaFieldKey("matrix", param.Name))
+			errs = errs.Also(substitution.ValidateVariableIsolated(param.Name, arrayElement, prefix, "matrix parameter", "matrix", arrayParamNames))
+		}
+	}
+	return errs
+}
+
 func validateStringVariableInTaskParameters(value, prefix string, stringVars sets.String, arrayVars sets.String) *apis.FieldError {
 	errs := substitution.ValidateVariableP(value, prefix, stringVars)
 	return errs.Also(substitution.ValidateVariableProhibitedP(value, prefix, arrayVars))
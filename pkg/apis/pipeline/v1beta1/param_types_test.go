@@ -25,6 +25,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
+	"knative.dev/pkg/apis"
 )
 
 func TestParamSpec_SetDefaults(t *testing.T) {
@@ -66,6 +67,19 @@ func TestParamSpec_SetDefaults(t *testing.T) {
 			Description: "a description",
 			Default:     v1beta1.NewArrayOrString("an", "array"),
 		},
+	}, {
+		name: "inferred object type and property types",
+		before: &v1beta1.ParamSpec{
+			Name:       "parametername",
+			Properties: map[string]v1beta1.PropertySpec{"url": {}, "commit": {Type: v1beta1.ParamTypeString}},
+			Default:    v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline", "commit": "main"}),
+		},
+		defaultsApplied: &v1beta1.ParamSpec{
+			Name:       "parametername",
+			Type:       v1beta1.ParamTypeObject,
+			Properties: map[string]v1beta1.PropertySpec{"url": {Type: v1beta1.ParamTypeString}, "commit": {Type: v1beta1.ParamTypeString}},
+			Default:    v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline", "commit": "main"}),
+		},
 	}}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -78,11 +92,127 @@ func TestParamSpec_SetDefaults(t *testing.T) {
 	}
 }
 
+func TestParamSpec_ValidateValue(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		paramSpec v1beta1.ParamSpec
+		value     *v1beta1.ArrayOrString
+		wantErr   string
+	}{{
+		name:      "no constraints",
+		paramSpec: v1beta1.ParamSpec{Name: "env", Type: v1beta1.ParamTypeString},
+		value:     v1beta1.NewArrayOrString("anything"),
+	}, {
+		name:      "value in enum",
+		paramSpec: v1beta1.ParamSpec{Name: "env", Type: v1beta1.ParamTypeString, Enum: []string{"dev", "prod"}},
+		value:     v1beta1.NewArrayOrString("prod"),
+	}, {
+		name:      "value not in enum",
+		paramSpec: v1beta1.ParamSpec{Name: "env", Type: v1beta1.ParamTypeString, Enum: []string{"dev", "prod"}},
+		value:     v1beta1.NewArrayOrString("prdo"),
+		wantErr:   `value "prdo" of param "env" is not one of the allowed values [dev prod]`,
+	}, {
+		name:      "value matching pattern",
+		paramSpec: v1beta1.ParamSpec{Name: "version", Type: v1beta1.ParamTypeString, Pattern: "^v[0-9]+$"},
+		value:     v1beta1.NewArrayOrString("v12"),
+	}, {
+		name:      "pattern is not anchored",
+		paramSpec: v1beta1.ParamSpec{Name: "version", Type: v1beta1.ParamTypeString, Pattern: "v[0-9]+"},
+		value:     v1beta1.NewArrayOrString("release-v12"),
+	}, {
+		name:      "value not matching pattern",
+		paramSpec: v1beta1.ParamSpec{Name: "version", Type: v1beta1.ParamTypeString, Pattern: "^v[0-9]+$"},
+		value:     v1beta1.NewArrayOrString("latest"),
+		wantErr:   `value "latest" of param "version" does not match the pattern "^v[0-9]+$"`,
+	}, {
+		name:      "array elements satisfying enum and pattern",
+		paramSpec: v1beta1.ParamSpec{Name: "envs", Type: v1beta1.ParamTypeArray, Enum: []string{"dev", "prod"}, Pattern: "^[a-z]+$"},
+		value:     v1beta1.NewArrayOrString("dev", "prod"),
+	}, {
+		name:      "array element not in enum",
+		paramSpec: v1beta1.ParamSpec{Name: "envs", Type: v1beta1.ParamTypeArray, Enum: []string{"dev", "prod"}},
+		value:     v1beta1.NewArrayOrString("dev", "qa"),
+		wantErr:   `value "qa" of param "envs" is not one of the allowed values [dev prod]`,
+	}, {
+		name:      "invalid pattern",
+		paramSpec: v1beta1.ParamSpec{Name: "version", Type: v1beta1.ParamTypeString, Pattern: "(v"},
+		value:     v1beta1.NewArrayOrString("v1"),
+		wantErr:   "invalid pattern \"(v\" of param \"version\": error parsing regexp: missing closing ): `(v`",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.paramSpec.ValidateValue(*tc.value)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected error %q but got none", tc.wantErr)
+			}
+			if d := cmp.Diff(tc.wantErr, err.Error()); d != "" {
+				t.Errorf("Unexpected error %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidateParamValues(t *testing.T) {
+	paramSpecs := []v1beta1.ParamSpec{{
+		Name: "env",
+		Type: v1beta1.ParamTypeString,
+		Enum: []string{"dev", "prod"},
+	}, {
+		Name:    "tags",
+		Type:    v1beta1.ParamTypeArray,
+		Pattern: "^v[0-9]+$",
+	}}
+	for _, tc := range []struct {
+		name    string
+		params  []v1beta1.Param
+		wantErr *apis.FieldError
+	}{{
+		name: "allowed values",
+		params: []v1beta1.Param{{
+			Name:  "env",
+			Value: *v1beta1.NewArrayOrString("prod"),
+		}, {
+			Name:  "tags",
+			Value: *v1beta1.NewArrayOrString("v1", "v2"),
+		}, {
+			Name:  "undeclared",
+			Value: *v1beta1.NewArrayOrString("anything"),
+		}},
+	}, {
+		name: "value not in enum",
+		params: []v1beta1.Param{{
+			Name:  "env",
+			Value: *v1beta1.NewArrayOrString("prdo"),
+		}},
+		wantErr: apis.ErrInvalidValue(`value "prdo" of param "env" is not one of the allowed values [dev prod]`, "[env].value"),
+	}, {
+		name: "array element not matching pattern",
+		params: []v1beta1.Param{{
+			Name:  "tags",
+			Value: *v1beta1.NewArrayOrString("v1", "latest"),
+		}},
+		wantErr: apis.ErrInvalidValue(`value "latest" of param "tags" does not match the pattern "^v[0-9]+$"`, "[tags].value"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := v1beta1.ValidateParamValues(paramSpecs, tc.params)
+			if d := cmp.Diff(tc.wantErr.Error(), err.Error()); d != "" {
+				t.Errorf("ValidateParamValues() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestArrayOrString_ApplyReplacements(t *testing.T) {
 	type args struct {
 		input              *v1beta1.ArrayOrString
 		stringReplacements map[string]string
 		arrayReplacements  map[string][]string
+		objectReplacements map[string]map[string]string
 	}
 	tests := []struct {
 		name           string
@@ -128,10 +258,33 @@ func TestArrayOrString_ApplyReplacements(t *testing.T) {
 			arrayReplacements:  map[string][]string{"arraykey": {}},
 		},
 		expectedOutput: v1beta1.NewArrayOrString("firstvalue", "lastvalue"),
+	}, {
+		name: "string replacements on object",
+		args: args{
+			input:              v1beta1.NewObject(map[string]string{"url": "$(params.repo.url)", "path": "/src/$(some)"}),
+			stringReplacements: map[string]string{"some": "value", "params.repo.url": "https://github.com/tektoncd/pipeline"},
+		},
+		expectedOutput: v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline", "path": "/src/value"}),
+	}, {
+		name: "entire object replacement",
+		args: args{
+			input:              v1beta1.NewArrayOrString("$(params.repo[*])"),
+			stringReplacements: map[string]string{"params.repo.url": "https://github.com/tektoncd/pipeline"},
+			objectReplacements: map[string]map[string]string{"params.repo": {"url": "https://github.com/tektoncd/pipeline"}},
+		},
+		expectedOutput: v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline"}),
+	}, {
+		name: "object key replacement in string",
+		args: args{
+			input:              v1beta1.NewArrayOrString("$(params.repo.url)#main"),
+			stringReplacements: map[string]string{"params.repo.url": "https://github.com/tektoncd/pipeline"},
+			objectReplacements: map[string]map[string]string{"params.repo": {"url": "https://github.com/tektoncd/pipeline"}},
+		},
+		expectedOutput: v1beta1.NewArrayOrString("https://github.com/tektoncd/pipeline#main"),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.args.input.ApplyReplacements(tt.args.stringReplacements, tt.args.arrayReplacements)
+			tt.args.input.ApplyReplacements(tt.args.stringReplacements, tt.args.arrayReplacements, tt.args.objectReplacements)
 			if d := cmp.Diff(tt.expectedOutput, tt.args.input); d != "" {
 				t.Errorf("ApplyReplacements() output did not match expected value %s", diff.PrintWantGot(d))
 			}
@@ -153,6 +306,8 @@ func TestArrayOrString_UnmarshalJSON(t *testing.T) {
 		{"{\"val\":[]}", v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{}}},
 		{"{\"val\":[\"oneelement\"]}", v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{"oneelement"}}},
 		{"{\"val\":[\"multiple\", \"elements\"]}", *v1beta1.NewArrayOrString("multiple", "elements")},
+		{"{\"val\":{}}", v1beta1.ArrayOrString{Type: v1beta1.ParamTypeObject, ObjectVal: map[string]string{}}},
+		{"{\"val\":{\"url\": \"https://github.com\", \"commit\": \"main\"}}", *v1beta1.NewObject(map[string]string{"url": "https://github.com", "commit": "main"})},
 	}
 
 	for _, c := range cases {
@@ -174,6 +329,7 @@ func TestArrayOrString_MarshalJSON(t *testing.T) {
 		{*v1beta1.NewArrayOrString("123"), "{\"val\":\"123\"}"},
 		{*v1beta1.NewArrayOrString("123", "1234"), "{\"val\":[\"123\",\"1234\"]}"},
 		{*v1beta1.NewArrayOrString("a", "a", "a"), "{\"val\":[\"a\",\"a\",\"a\"]}"},
+		{*v1beta1.NewObject(map[string]string{"url": "https://github.com", "commit": "main"}), "{\"val\":{\"commit\":\"main\",\"url\":\"https://github.com\"}}"},
 	}
 
 	for _, c := range cases {
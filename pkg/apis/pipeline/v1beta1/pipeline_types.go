@@ -17,8 +17,10 @@ limitations under the License.
 package v1beta1
 
 import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // +genclient
@@ -75,6 +77,31 @@ type PipelineSpec struct {
 	// i.e. either after all Tasks are finished executing successfully
 	// or after a failure which would result in ending the Pipeline
 	Finally []PipelineTask `json:"finally,omitempty"`
+	// Concurrency limits the number of PipelineRuns of the Pipeline running at the same time
+	// +optional
+	Concurrency *PipelineConcurrency `json:"concurrency,omitempty"`
+}
+
+const (
+	// ConcurrencyStrategyQueue queues new PipelineRuns until older ones complete
+	ConcurrencyStrategyQueue = "queue"
+	// ConcurrencyStrategyCancelOlder cancels the older PipelineRuns of the same concurrency
+	// group when a new PipelineRun is created
+	ConcurrencyStrategyCancelOlder = "cancel-older"
+)
+
+// PipelineConcurrency limits the number of PipelineRuns of a concurrency group running at
+// the same time in a namespace. The tekton.dev/max-concurrent-runs and
+// tekton.dev/concurrency-strategy annotations override it.
+type PipelineConcurrency struct {
+	// MaxRuns is the maximum number of PipelineRuns of the concurrency group running at
+	// the same time, 0 means no limit
+	// +optional
+	MaxRuns int `json:"maxRuns,omitempty"`
+	// Strategy selects what happens to the older PipelineRuns of the concurrency group:
+	// "queue" (default) or "cancel-older"
+	// +optional
+	Strategy string `json:"strategy,omitempty"`
 }
 
 // PipelineResult used to describe the results of a pipeline
@@ -123,6 +150,16 @@ type PipelineTask struct {
 	// +optional
 	TaskSpec *EmbeddedTask `json:"taskSpec,inline,omitempty"`
 
+	// PipelineRef is a reference to a pipeline definition which is executed
+	// by a child PipelineRun.
+	// +optional
+	PipelineRef *PipelineRef `json:"pipelineRef,omitempty"`
+
+	// PipelineSpec is a specification of a pipeline which is executed by a
+	// child PipelineRun.
+	// +optional
+	PipelineSpec *PipelineSpec `json:"pipelineSpec,omitempty"`
+
 	// Conditions is a list of conditions that need to be true for the task to run
 	// Conditions are deprecated, use WhenExpressions instead
 	// +optional
@@ -149,6 +186,12 @@ type PipelineTask struct {
 	// +optional
 	Params []Param `json:"params,omitempty"`
 
+	// Matrix declares array parameters used to fan out this task: a TaskRun is
+	// created for each combination of their values, with one value of each of
+	// them passed to the TaskRun as a string parameter.
+	// +optional
+	Matrix []Param `json:"matrix,omitempty"`
+
 	// Workspaces maps workspaces from the pipeline spec to the workspaces
 	// declared in the Task.
 	// +optional
@@ -165,6 +208,66 @@ func (pt *PipelineTask) TaskSpecMetadata() PipelineTaskMetadata {
 	return pt.TaskSpec.Metadata
 }
 
+// IsCustomTask returns true if the PipelineTask references a Custom Task, that
+// is a task type which is not provided by Tekton and is executed through a Run.
+func (pt PipelineTask) IsCustomTask() bool {
+	if pt.TaskRef == nil || pt.TaskRef.APIVersion == "" {
+		return false
+	}
+	gv, err := schema.ParseGroupVersion(pt.TaskRef.APIVersion)
+	if err != nil {
+		// An unparseable apiVersion can't refer to a Tekton Task either; let
+		// validation report it as a custom task reference.
+		return true
+	}
+	return gv.Group != pipeline.GroupName
+}
+
+// IsChildPipeline returns true if the PipelineTask runs another Pipeline, through
+// a child PipelineRun, instead of a Task.
+func (pt PipelineTask) IsChildPipeline() bool {
+	return pt.PipelineRef != nil || pt.PipelineSpec != nil
+}
+
+// IsMatrixed returns true if the PipelineTask fans out over a Matrix of parameters.
+func (pt PipelineTask) IsMatrixed() bool {
+	return len(pt.Matrix) > 0
+}
+
+// MatrixCombinationsCount returns the number of TaskRuns the Matrix of the PipelineTask fans out to.
+func (pt PipelineTask) MatrixCombinationsCount() int {
+	if !pt.IsMatrixed() {
+		return 0
+	}
+	count := 1
+	for _, param := range pt.Matrix {
+		count *= len(param.Value.ArrayVal)
+	}
+	return count
+}
+
+// MatrixCombinations returns the parameters of each TaskRun the Matrix of the PipelineTask fans
+// out to. The combinations are ordered by the values of the first parameter of the Matrix, then
+// by the values of the second one, and so on.
+func (pt PipelineTask) MatrixCombinations() [][]Param {
+	if !pt.IsMatrixed() {
+		return nil
+	}
+	combinations := [][]Param{{}}
+	for _, param := range pt.Matrix {
+		var next [][]Param
+		for _, combination := range combinations {
+			for _, value := range param.Value.ArrayVal {
+				c := make([]Param, 0, len(combination)+1)
+				c = append(c, combination...)
+				next = append(next, append(c, Param{Name: param.Name, Value: *NewArrayOrString(value)}))
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
 func (pt PipelineTask) HashKey() string {
 	return pt.Name
 }
@@ -202,6 +305,16 @@ func (pt PipelineTask) Deps() []string {
 			}
 		}
 	}
+	// Add any dependents from task results in the matrix
+	for _, param := range pt.Matrix {
+		expressions, ok := GetVarSubstitutionExpressionsForParam(param)
+		if ok {
+			resultRefs := NewResultRefs(expressions)
+			for _, resultRef := range resultRefs {
+				deps = append(deps, resultRef.PipelineTask)
+			}
+		}
+	}
 	// Add any dependents from when expressions
 	for _, whenExpression := range pt.WhenExpressions {
 		expressions, ok := whenExpression.GetVarSubstitutionExpressions()
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestPipelineTask_MatrixCombinations(t *testing.T) {
+	tests := []struct {
+		name  string
+		pt    v1beta1.PipelineTask
+		count int
+		want  [][]v1beta1.Param
+	}{{
+		name: "no matrix",
+		pt:   v1beta1.PipelineTask{Name: "build"},
+	}, {
+		name: "single parameter",
+		pt: v1beta1.PipelineTask{
+			Name:   "build",
+			Matrix: []v1beta1.Param{{Name: "goos", Value: *v1beta1.NewArrayOrString("linux", "darwin")}},
+		},
+		count: 2,
+		want: [][]v1beta1.Param{
+			{{Name: "goos", Value: *v1beta1.NewArrayOrString("linux")}},
+			{{Name: "goos", Value: *v1beta1.NewArrayOrString("darwin")}},
+		},
+	}, {
+		name: "several parameters",
+		pt: v1beta1.PipelineTask{
+			Name: "build",
+			Matrix: []v1beta1.Param{
+				{Name: "goos", Value: *v1beta1.NewArrayOrString("linux", "darwin")},
+				{Name: "goversion", Value: *v1beta1.NewArrayOrString("1.14", "1.15", "1.16")},
+			},
+		},
+		count: 6,
+		want: [][]v1beta1.Param{
+			{{Name: "goos", Value: *v1beta1.NewArrayOrString("linux")}, {Name: "goversion", Value: *v1beta1.NewArrayOrString("1.14")}},
+			{{Name: "goos", Value: *v1beta1.NewArrayOrString("linux")}, {Name: "goversion", Value: *v1beta1.NewArrayOrString("1.15")}},
+			{{Name: "goos", Value: *v1beta1.NewArrayOrString("linux")}, {Name: "goversion", Value: *v1beta1.NewArrayOrString("1.16")}},
+			{{Name: "goos", Value: *v1beta1.NewArrayOrString("darwin")}, {Name: "goversion", Value: *v1beta1.NewArrayOrString("1.14")}},
+			{{Name: "goos", Value: *v1beta1.NewArrayOrString("darwin")}, {Name: "goversion", Value: *v1beta1.NewArrayOrString("1.15")}},
+			{{Name: "goos", Value: *v1beta1.NewArrayOrString("darwin")}, {Name: "goversion", Value: *v1beta1.NewArrayOrString("1.16")}},
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if count := tc.pt.MatrixCombinationsCount(); count != tc.count {
+				t.Errorf("MatrixCombinationsCount() = %d, want %d", count, tc.count)
+			}
+			if d := cmp.Diff(tc.want, tc.pt.MatrixCombinations()); d != "" {
+				t.Errorf("MatrixCombinations() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineTask_Deps_Matrix(t *testing.T) {
+	pt := v1beta1.PipelineTask{
+		Name:     "test",
+		RunAfter: []string{"lint"},
+		Matrix: []v1beta1.Param{{
+			Name:  "version",
+			Value: *v1beta1.NewArrayOrString("$(tasks.versions.results.latest)", "1.14"),
+		}},
+	}
+	if d := cmp.Diff([]string{"lint", "versions"}, pt.Deps()); d != "" {
+		t.Errorf("Deps() %s", diff.PrintWantGot(d))
+	}
+}
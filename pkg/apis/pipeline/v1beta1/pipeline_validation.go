@@ -21,11 +21,13 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"github.com/tektoncd/pipeline/pkg/list"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
@@ -64,9 +66,29 @@ func (ps *PipelineSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(validatePipelineWorkspaces(ps.Workspaces, ps.Tasks, ps.Finally))
 	// Validate the pipeline's results
 	errs = errs.Also(validatePipelineResults(ps.Results))
+	errs = errs.Also(validatePipelineResultsFromMatrix(ps.Results, ps.Tasks))
 	errs = errs.Also(validateTasksAndFinallySection(ps))
-	errs = errs.Also(validateFinalTasks(ps.Finally))
+	errs = errs.Also(validateFinalTasks(ps.Tasks, ps.Finally))
 	errs = errs.Also(validateWhenExpressions(ps.Tasks))
+	errs = errs.Also(validateExecutionStatusVariablesNotUsed(ps.Tasks))
+	errs = errs.Also(validatePipelineConcurrency(ps.Concurrency).ViaField("concurrency"))
+	return errs
+}
+
+// validatePipelineConcurrency ensures that the concurrency limit is not negative and that
+// the concurrency strategy is known
+func validatePipelineConcurrency(c *PipelineConcurrency) (errs *apis.FieldError) {
+	if c == nil {
+		return nil
+	}
+	if c.MaxRuns < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", c.MaxRuns), "maxRuns"))
+	}
+	switch c.Strategy {
+	case "", ConcurrencyStrategyQueue, ConcurrencyStrategyCancelOlder:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be %s or %s", c.Strategy, ConcurrencyStrategyQueue, ConcurrencyStrategyCancelOlder), "strategy"))
+	}
 	return errs
 }
 
@@ -99,6 +121,12 @@ func validatePipelineTaskName(name string) *apis.FieldError {
 
 func validatePipelineTask(ctx context.Context, t PipelineTask, taskNames sets.String) *apis.FieldError {
 	errs := validatePipelineTaskName(t.Name)
+	if t.IsCustomTask() {
+		return errs.Also(validateCustomTask(ctx, t, taskNames))
+	}
+	if t.IsChildPipeline() {
+		return errs.Also(validateChildPipeline(ctx, t, taskNames))
+	}
 	// can't have both taskRef and taskSpec at the same time
 	if (t.TaskRef != nil && t.TaskRef.Name != "") && t.TaskSpec != nil {
 		errs = errs.Also(apis.ErrMultipleOneOf("taskRef", "taskSpec"))
@@ -111,6 +139,10 @@ func validatePipelineTask(ctx context.Context, t PipelineTask, taskNames sets.St
 	if t.TaskSpec != nil {
 		errs = errs.Also(t.TaskSpec.Validate(ctx).ViaField("taskSpec"))
 	}
+	// Validate TaskRef if it's present
+	if t.TaskRef != nil {
+		errs = errs.Also(t.TaskRef.Validate(ctx).ViaField("taskRef"))
+	}
 	if t.TaskRef != nil && t.TaskRef.Name != "" {
 		// TaskRef name must be a valid k8s name
 		if errSlice := validation.IsQualifiedName(t.TaskRef.Name); len(errSlice) != 0 {
@@ -121,6 +153,135 @@ func validatePipelineTask(ctx context.Context, t PipelineTask, taskNames sets.St
 		}
 		taskNames[t.Name] = struct{}{}
 	}
+	return errs.Also(validateMatrix(ctx, t))
+}
+
+// validateMatrix validates the Matrix of a PipelineTask: its parameters must be non empty arrays
+// which don't collide with the other parameters of the PipelineTask, and it must not fan out to
+// more TaskRuns than allowed by the "default-max-matrix-combinations-count" default.
+func validateMatrix(ctx context.Context, t PipelineTask) (errs *apis.FieldError) {
+	if !t.IsMatrixed() {
+		return nil
+	}
+	paramNames := sets.NewString()
+	for _, p := range t.Params {
+		paramNames.Insert(p.Name)
+	}
+	matrixParamNames := sets.NewString()
+	for _, p := range t.Matrix {
+		if p.Value.Type != ParamTypeArray {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("matrix parameter %q must be an array", p.Name), "").ViaFieldKey("matrix", p.Name))
+		} else if len(p.Value.ArrayVal) == 0 {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("matrix parameter %q must have at least one value", p.Name), "").ViaFieldKey("matrix", p.Name))
+		}
+		if paramNames.Has(p.Name) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("parameter %q appears in both params and matrix", p.Name), "").ViaFieldKey("matrix", p.Name))
+		}
+		if matrixParamNames.Has(p.Name) {
+			errs = errs.Also(apis.ErrGeneric("parameter appears more than once", "").ViaFieldKey("matrix", p.Name))
+		}
+		matrixParamNames.Insert(p.Name)
+	}
+	if len(t.Conditions) > 0 {
+		errs = errs.Also(apis.ErrMultipleOneOf("matrix", "conditions"))
+	}
+	max := config.DefaultMaxMatrixCombinationsCount
+	if defaults := config.FromContextOrDefaults(ctx).Defaults; defaults != nil {
+		max = defaults.DefaultMaxMatrixCombinationsCount
+	}
+	if count := t.MatrixCombinationsCount(); count > max {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("matrix fans out to %d combinations, more than the maximum of %d", count, max), "matrix"))
+	}
+	return errs
+}
+
+// validateCustomTask validates a PipelineTask referencing a Custom Task. Custom Tasks are
+// executed through a Run, so the fields which only make sense for a TaskRun are not allowed.
+func validateCustomTask(ctx context.Context, t PipelineTask, taskNames sets.String) (errs *apis.FieldError) {
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableCustomTasks {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("custom task ref %q is not allowed", t.TaskRef.APIVersion),
+			Paths:   []string{"taskRef.apiVersion"},
+			Details: "Custom Tasks require the \"enable-custom-tasks\" feature flag to be \"true\"",
+		}
+	}
+	if _, err := schema.ParseGroupVersion(t.TaskRef.APIVersion); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(err.Error(), "taskRef.apiVersion"))
+	}
+	if t.TaskRef.Kind == "" {
+		errs = errs.Also(apis.ErrMissingField("taskRef.kind"))
+	}
+	if t.TaskRef.Bundle != "" {
+		errs = errs.Also(apis.ErrDisallowedFields("taskRef.bundle"))
+	}
+	if t.TaskSpec != nil {
+		errs = errs.Also(apis.ErrMultipleOneOf("taskRef", "taskSpec"))
+	}
+	if len(t.Conditions) > 0 {
+		errs = errs.Also(apis.ErrDisallowedFields("conditions"))
+	}
+	if t.Resources != nil {
+		errs = errs.Also(apis.ErrDisallowedFields("resources"))
+	}
+	if len(t.Workspaces) > 0 {
+		errs = errs.Also(apis.ErrDisallowedFields("workspaces"))
+	}
+	if t.Retries != 0 {
+		errs = errs.Also(apis.ErrDisallowedFields("retries"))
+	}
+	if t.IsMatrixed() {
+		errs = errs.Also(apis.ErrDisallowedFields("matrix"))
+	}
+	if taskNames.Has(t.Name) {
+		errs = errs.Also(apis.ErrMultipleOneOf("name"))
+	}
+	taskNames.Insert(t.Name)
+	return errs
+}
+
+// validateChildPipeline validates a PipelineTask running another Pipeline. The Pipeline is
+// executed through a child PipelineRun, so the fields which only make sense for a TaskRun
+// are not allowed.
+func validateChildPipeline(ctx context.Context, t PipelineTask, taskNames sets.String) (errs *apis.FieldError) {
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.EnablePipelinesInPipelines {
+		return &apis.FieldError{
+			Message: "pipelineRef and pipelineSpec are not allowed in a pipeline task",
+			Paths:   []string{"pipelineRef", "pipelineSpec"},
+			Details: "Pipelines in Pipelines require the \"enable-pipelines-in-pipelines\" feature flag to be \"true\"",
+		}
+	}
+	if t.PipelineRef != nil && t.PipelineSpec != nil {
+		errs = errs.Also(apis.ErrMultipleOneOf("pipelineRef", "pipelineSpec"))
+	}
+	if t.TaskRef != nil || t.TaskSpec != nil {
+		errs = errs.Also(apis.ErrMultipleOneOf("taskRef", "taskSpec", "pipelineRef", "pipelineSpec"))
+	}
+	if t.PipelineRef != nil {
+		if t.PipelineRef.Bundle != "" {
+			errs = errs.Also(t.PipelineRef.Validate(ctx).ViaField("pipelineRef"))
+		} else if t.PipelineRef.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("pipelineRef.name"))
+		}
+	}
+	if t.PipelineSpec != nil {
+		errs = errs.Also(t.PipelineSpec.Validate(ctx).ViaField("pipelineSpec"))
+	}
+	if len(t.Conditions) > 0 {
+		errs = errs.Also(apis.ErrDisallowedFields("conditions"))
+	}
+	if t.Resources != nil {
+		errs = errs.Also(apis.ErrDisallowedFields("resources"))
+	}
+	if t.Retries != 0 {
+		errs = errs.Also(apis.ErrDisallowedFields("retries"))
+	}
+	if t.IsMatrixed() {
+		errs = errs.Also(apis.ErrDisallowedFields("matrix"))
+	}
+	if taskNames.Has(t.Name) {
+		errs = errs.Also(apis.ErrMultipleOneOf("name"))
+	}
+	taskNames.Insert(t.Name)
 	return errs
 }
 
@@ -163,15 +324,26 @@ func validatePipelineWorkspaces(wss []PipelineWorkspaceDeclaration, pts []Pipeli
 			}
 		}
 	}
+
+	// $(workspaces.<name>.bound) in when expressions should refer to a workspace declared in the Pipeline.
+	for i, pt := range pts {
+		for j, we := range pt.WhenExpressions {
+			errs = errs.Also(substitution.ValidateVariableP(we.Input, "workspaces", wsTable).ViaField("input").ViaFieldIndex("when", j).ViaFieldIndex("tasks", i))
+			for _, val := range we.Values {
+				errs = errs.Also(substitution.ValidateVariableP(val, "workspaces", wsTable).ViaField("values").ViaFieldIndex("when", j).ViaFieldIndex("tasks", i))
+			}
+		}
+	}
 	return errs
 }
 
 // validatePipelineParameterVariables validates parameters with those specified by each pipeline task,
-// (1) it validates the type of parameter is either string or array (2) parameter default value matches
+// (1) it validates the type of parameter is either string, array or object (2) parameter default value matches
 // with the type of that param (3) ensures that the referenced param variable is defined is part of the param declarations
 func validatePipelineParameterVariables(tasks []PipelineTask, params []ParamSpec) (errs *apis.FieldError) {
 	parameterNames := sets.NewString()
 	arrayParameterNames := sets.NewString()
+	objectParameterKeys := map[string]sets.String{}
 
 	for _, p := range params {
 		// Verify that p is a valid type.
@@ -194,20 +366,35 @@ func validatePipelineParameterVariables(tasks []PipelineTask, params []ParamSpec
 		if parameterNames.Has(p.Name) {
 			errs = errs.Also(apis.ErrGeneric("parameter appears more than once", "").ViaFieldKey("params", p.Name))
 		}
-		// Add parameter name to parameterNames, and to arrayParameterNames if type is array.
+		errs = errs.Also(p.validateProperties().Also(p.validateConstraints()).ViaFieldKey("params", p.Name))
+
+		// Add parameter name to parameterNames, and to arrayParameterNames if type is array,
+		// or to objectParameterKeys with its keys if type is object.
 		parameterNames.Insert(p.Name)
-		if p.Type == ParamTypeArray {
+		switch p.Type {
+		case ParamTypeArray:
 			arrayParameterNames.Insert(p.Name)
+		case ParamTypeObject:
+			objectParameterKeys[p.Name] = sets.NewString()
+			for key := range p.Properties {
+				objectParameterKeys[p.Name].Insert(key)
+			}
 		}
 	}
 
-	return errs.Also(validatePipelineParametersVariables(tasks, "params", parameterNames, arrayParameterNames))
+	return errs.Also(validatePipelineParametersVariables(tasks, "params", parameterNames, arrayParameterNames, objectParameterKeys))
 }
 
-func validatePipelineParametersVariables(tasks []PipelineTask, prefix string, paramNames sets.String, arrayParamNames sets.String) (errs *apis.FieldError) {
+func validatePipelineParametersVariables(tasks []PipelineTask, prefix string, paramNames sets.String, arrayParamNames sets.String, objectParamKeys map[string]sets.String) (errs *apis.FieldError) {
 	for idx, task := range tasks {
 		errs = errs.Also(validatePipelineParametersVariablesInTaskParameters(task.Params, prefix, paramNames, arrayParamNames).ViaIndex(idx))
+		errs = errs.Also(validatePipelineParametersVariablesInMatrixParameters(task.Matrix, prefix, paramNames, arrayParamNames).ViaIndex(idx))
 		errs = errs.Also(task.WhenExpressions.validatePipelineParametersVariables(prefix, paramNames, arrayParamNames).ViaIndex(idx))
+		if len(objectParamKeys) != 0 {
+			errs = errs.Also(validatePipelineObjectParametersVariablesInTaskParameters(task.Params, prefix, objectParamKeys).ViaIndex(idx))
+			errs = errs.Also(validatePipelineObjectParametersVariablesInTaskParameters(task.Matrix, prefix, objectParamKeys).ViaIndex(idx))
+			errs = errs.Also(task.WhenExpressions.validatePipelineObjectParametersVariables(prefix, objectParamKeys).ViaIndex(idx))
+		}
 	}
 	return errs
 }
@@ -226,6 +413,12 @@ func validatePipelineContextVariables(tasks []PipelineTask) *apis.FieldError {
 		for _, param := range task.Params {
 			paramValues = append(paramValues, param.Value.StringVal)
 			paramValues = append(paramValues, param.Value.ArrayVal...)
+			for _, value := range param.Value.ObjectVal {
+				paramValues = append(paramValues, value)
+			}
+		}
+		for _, param := range task.Matrix {
+			paramValues = append(paramValues, param.Value.ArrayVal...)
 		}
 	}
 	errs := validatePipelineContextVariablesInParamValues(paramValues, "context\\.pipelineRun", pipelineRunContextNames)
@@ -242,16 +435,22 @@ func validatePipelineContextVariablesInParamValues(paramValues []string, prefix
 // validateParamResults ensures that task result variables are properly configured
 func validateParamResults(tasks []PipelineTask) (errs *apis.FieldError) {
 	for idx, task := range tasks {
-		for _, param := range task.Params {
-			expressions, ok := GetVarSubstitutionExpressionsForParam(param)
-			if ok {
-				if LooksLikeContainsResultRefs(expressions) {
-					expressions = filter(expressions, looksLikeResultRef)
-					resultRefs := NewResultRefs(expressions)
-					if len(expressions) != len(resultRefs) {
-						errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("expected all of the expressions %v to be result expressions but only %v were", expressions, resultRefs),
-							"value").ViaFieldKey("params", param.Name).ViaFieldIndex("tasks", idx))
-					}
+		errs = errs.Also(validateParamResultsIn(task.Params, "params").ViaFieldIndex("tasks", idx))
+		errs = errs.Also(validateParamResultsIn(task.Matrix, "matrix").ViaFieldIndex("tasks", idx))
+	}
+	return errs
+}
+
+func validateParamResultsIn(params []Param, field string) (errs *apis.FieldError) {
+	for _, param := range params {
+		expressions, ok := GetVarSubstitutionExpressionsForParam(param)
+		if ok {
+			if LooksLikeContainsResultRefs(expressions) {
+				expressions = filter(expressions, looksLikeResultRef)
+				resultRefs := NewResultRefs(expressions)
+				if len(expressions) != len(resultRefs) {
+					errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("expected all of the expressions %v to be result expressions but only %v were", expressions, resultRefs),
+						"value").ViaFieldKey(field, param.Name))
 				}
 			}
 		}
@@ -288,6 +487,33 @@ func validatePipelineResults(results []PipelineResult) (errs *apis.FieldError) {
 	return errs
 }
 
+// validatePipelineResultsFromMatrix ensures that pipeline results don't reference the results of a
+// PipelineTask with a Matrix, which are produced by several TaskRuns
+func validatePipelineResultsFromMatrix(results []PipelineResult, tasks []PipelineTask) (errs *apis.FieldError) {
+	matrixed := sets.NewString()
+	for _, t := range tasks {
+		if t.IsMatrixed() {
+			matrixed.Insert(t.Name)
+		}
+	}
+	if matrixed.Len() == 0 {
+		return nil
+	}
+	for idx, result := range results {
+		expressions, ok := GetVarSubstitutionExpressionsForPipelineResult(result)
+		if !ok {
+			continue
+		}
+		for _, resultRef := range NewResultRefs(expressions) {
+			if matrixed.Has(resultRef.PipelineTask) {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("pipeline result can't reference the results of task %q which has a matrix", resultRef.PipelineTask),
+					"value").ViaFieldIndex("results", idx))
+			}
+		}
+	}
+	return errs
+}
+
 func validateTasksAndFinallySection(ps *PipelineSpec) *apis.FieldError {
 	if len(ps.Finally) != 0 && len(ps.Tasks) == 0 {
 		return apis.ErrInvalidValue(fmt.Sprintf("spec.tasks is empty but spec.finally has %d tasks", len(ps.Finally)), "finally")
@@ -295,7 +521,7 @@ func validateTasksAndFinallySection(ps *PipelineSpec) *apis.FieldError {
 	return nil
 }
 
-func validateFinalTasks(finalTasks []PipelineTask) *apis.FieldError {
+func validateFinalTasks(tasks []PipelineTask, finalTasks []PipelineTask) *apis.FieldError {
 	for idx, f := range finalTasks {
 		if len(f.RunAfter) != 0 {
 			return apis.ErrInvalidValue(fmt.Sprintf("no runAfter allowed under spec.finally, final task %s has runAfter specified", f.Name), "").ViaFieldIndex("finally", idx)
@@ -303,12 +529,15 @@ func validateFinalTasks(finalTasks []PipelineTask) *apis.FieldError {
 		if len(f.Conditions) != 0 {
 			return apis.ErrInvalidValue(fmt.Sprintf("no conditions allowed under spec.finally, final task %s has conditions specified", f.Name), "").ViaFieldIndex("finally", idx)
 		}
-		if len(f.WhenExpressions) != 0 {
-			return apis.ErrInvalidValue(fmt.Sprintf("no when expressions allowed under spec.finally, final task %s has when expressions specified", f.Name), "").ViaFieldIndex("finally", idx)
+		if f.IsMatrixed() {
+			return apis.ErrInvalidValue(fmt.Sprintf("no matrix allowed under spec.finally, final task %s has matrix specified", f.Name), "").ViaFieldIndex("finally", idx)
+		}
+		if err := f.WhenExpressions.validate().ViaFieldIndex("finally", idx); err != nil {
+			return err
 		}
 	}
 
-	if err := validateTaskResultReferenceNotUsed(finalTasks).ViaField("finally"); err != nil {
+	if err := validateFinalTasksReferences(tasks, finalTasks).ViaField("finally"); err != nil {
 		return err
 	}
 
@@ -319,19 +548,85 @@ func validateFinalTasks(finalTasks []PipelineTask) *apis.FieldError {
 	return nil
 }
 
-func validateTaskResultReferenceNotUsed(tasks []PipelineTask) *apis.FieldError {
+// validateFinalTasksReferences ensures that the results and the execution status consumed by the
+// final tasks, through their params and when expressions, are the ones of tasks of the DAG
+func validateFinalTasksReferences(tasks []PipelineTask, finalTasks []PipelineTask) (errs *apis.FieldError) {
+	dagTaskNames := sets.NewString()
+	for _, t := range tasks {
+		dagTaskNames.Insert(t.Name)
+	}
+	for idx, f := range finalTasks {
+		errs = errs.Also(validateParamResultsIn(f.Params, "params").ViaIndex(idx))
+		for _, p := range f.Params {
+			if expressions, ok := GetVarSubstitutionExpressionsForParam(p); ok {
+				errs = errs.Also(validateReferencesToDAGTasks(expressions, dagTaskNames).ViaField("value").ViaFieldKey("params", p.Name).ViaIndex(idx))
+			}
+		}
+		for i, we := range f.WhenExpressions {
+			if expressions, ok := we.GetVarSubstitutionExpressions(); ok {
+				errs = errs.Also(validateReferencesToDAGTasks(expressions, dagTaskNames).ViaFieldIndex("when", i).ViaIndex(idx))
+			}
+		}
+	}
+	return errs
+}
+
+func validateReferencesToDAGTasks(expressions []string, dagTaskNames sets.String) (errs *apis.FieldError) {
+	for _, resultRef := range NewResultRefs(filter(expressions, looksLikeResultRef)) {
+		if !dagTaskNames.Has(resultRef.PipelineTask) {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("invalid task result reference, final task has task result reference from a task %s which is not defined in the pipeline tasks", resultRef.PipelineTask), apis.CurrentField))
+		}
+	}
+	for _, expression := range expressions {
+		if name, ok := pipelineTaskStatusReference(expression); ok && name != "" && !dagTaskNames.Has(name) {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("invalid task status reference, final task has execution status reference from a task %s which is not defined in the pipeline tasks", name), apis.CurrentField))
+		}
+	}
+	return errs
+}
+
+// validateExecutionStatusVariablesNotUsed ensures that the tasks of the DAG don't consume the
+// execution status of tasks, only final tasks can
+func validateExecutionStatusVariablesNotUsed(tasks []PipelineTask) (errs *apis.FieldError) {
 	for idx, t := range tasks {
 		for _, p := range t.Params {
-			expressions, ok := GetVarSubstitutionExpressionsForParam(p)
-			if ok {
-				if LooksLikeContainsResultRefs(expressions) {
-					return apis.ErrInvalidValue(fmt.Sprintf("no task result allowed under params,"+
-						"final task param %s has set task result as its value", p.Name), "params").ViaIndex(idx)
-				}
+			if expressions, ok := GetVarSubstitutionExpressionsForParam(p); ok && containsPipelineTaskStatusReference(expressions) {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("pipeline tasks can not refer to execution status of any other pipeline task or aggregate status of tasks, task %s param %s does", t.Name, p.Name), "value").ViaFieldKey("params", p.Name).ViaFieldIndex("tasks", idx))
+			}
+		}
+		for i, we := range t.WhenExpressions {
+			if expressions, ok := we.GetVarSubstitutionExpressions(); ok && containsPipelineTaskStatusReference(expressions) {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("pipeline tasks can not refer to execution status of any other pipeline task or aggregate status of tasks, task %s when expression does", t.Name), apis.CurrentField).ViaFieldIndex("when", i).ViaFieldIndex("tasks", idx))
 			}
 		}
 	}
-	return nil
+	return errs
+}
+
+func containsPipelineTaskStatusReference(expressions []string) bool {
+	for _, expression := range expressions {
+		if _, ok := pipelineTaskStatusReference(expression); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// pipelineTaskStatusReference returns true if the expression references the execution status of a
+// task, $(tasks.<pipelineTaskName>.status), along with the name of the task, or the aggregate execution
+// status of the tasks, $(tasks.status), along with an empty name
+func pipelineTaskStatusReference(expression string) (string, bool) {
+	if expression == PipelineTasksAggregateStatus {
+		return "", true
+	}
+	if !strings.HasPrefix(expression, PipelineTaskStatusPrefix) || !strings.HasSuffix(expression, PipelineTaskStatusSuffix) {
+		return "", false
+	}
+	name := strings.TrimSuffix(strings.TrimPrefix(expression, PipelineTaskStatusPrefix), PipelineTaskStatusSuffix)
+	if name == "" || strings.Contains(name, ".") {
+		return "", false
+	}
+	return name, true
 }
 
 func validateTasksInputFrom(tasks []PipelineTask) (errs *apis.FieldError) {
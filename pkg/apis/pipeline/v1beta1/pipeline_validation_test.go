@@ -23,6 +23,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -529,6 +530,431 @@ func TestValidatePipelineTasks_Failure(t *testing.T) {
 	}
 }
 
+func TestValidatePipelineTasks_CustomTask(t *testing.T) {
+	enabled := config.ToContext(context.Background(), &config.Config{
+		FeatureFlags: &config.FeatureFlags{EnableCustomTasks: true},
+	})
+	customTaskRef := &TaskRef{APIVersion: "example.dev/v0", Kind: "Example"}
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		tasks         []PipelineTask
+		expectedError *apis.FieldError
+	}{{
+		name: "named custom task",
+		ctx:  enabled,
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{APIVersion: "example.dev/v0", Kind: "Example", Name: "my-example"},
+		}},
+	}, {
+		name: "unnamed custom task",
+		ctx:  enabled,
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: customTaskRef,
+			Params:  []Param{{Name: "p", Value: *NewArrayOrString("v")}},
+		}},
+	}, {
+		name: "tekton apiVersion is not a custom task",
+		ctx:  context.Background(),
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{APIVersion: "tekton.dev/v1beta1", Kind: NamespacedTaskKind, Name: "foo-task"},
+		}},
+	}, {
+		name: "custom task without feature flag",
+		ctx:  context.Background(),
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: customTaskRef,
+		}},
+		expectedError: &apis.FieldError{
+			Message: `custom task ref "example.dev/v0" is not allowed`,
+			Paths:   []string{"tasks[0].taskRef.apiVersion"},
+			Details: `Custom Tasks require the "enable-custom-tasks" feature flag to be "true"`,
+		},
+	}, {
+		name: "custom task without kind",
+		ctx:  enabled,
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{APIVersion: "example.dev/v0"},
+		}},
+		expectedError: apis.ErrMissingField("tasks[0].taskRef.kind"),
+	}, {
+		name: "custom task with taskSpec",
+		ctx:  enabled,
+		tasks: []PipelineTask{{
+			Name:     "foo",
+			TaskRef:  customTaskRef,
+			TaskSpec: &EmbeddedTask{TaskSpec: getTaskSpec()},
+		}},
+		expectedError: apis.ErrMultipleOneOf("tasks[0].taskRef", "tasks[0].taskSpec"),
+	}, {
+		name: "custom task with TaskRun only fields",
+		ctx:  enabled,
+		tasks: []PipelineTask{{
+			Name:       "foo",
+			TaskRef:    customTaskRef,
+			Retries:    2,
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: "ws", Workspace: "ws"}},
+		}},
+		expectedError: apis.ErrDisallowedFields("tasks[0].retries").Also(apis.ErrDisallowedFields("tasks[0].workspaces")),
+	}, {
+		name: "duplicate custom tasks",
+		ctx:  enabled,
+		tasks: []PipelineTask{
+			{Name: "foo", TaskRef: customTaskRef},
+			{Name: "foo", TaskRef: customTaskRef},
+		},
+		expectedError: apis.ErrMultipleOneOf("tasks[1].name"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePipelineTasks(tt.ctx, tt.tasks, []PipelineTask{})
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Fatalf("Pipeline.validatePipelineTasks() returned error for valid pipeline tasks: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Pipeline.validatePipelineTasks() did not return error for invalid pipeline tasks")
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("Pipeline.validatePipelineTasks() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidatePipelineTasks_ChildPipeline(t *testing.T) {
+	enabled := config.ToContext(context.Background(), &config.Config{
+		FeatureFlags: &config.FeatureFlags{EnablePipelinesInPipelines: true},
+	})
+	childSpec := &PipelineSpec{
+		Tasks: []PipelineTask{{Name: "bar", TaskRef: &TaskRef{Name: "bar-task"}}},
+	}
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		tasks         []PipelineTask
+		expectedError *apis.FieldError
+	}{{
+		name: "pipelineRef",
+		ctx:  enabled,
+		tasks: []PipelineTask{{
+			Name:        "foo",
+			PipelineRef: &PipelineRef{Name: "build"},
+			Params:      []Param{{Name: "p", Value: *NewArrayOrString("v")}},
+			Workspaces:  []WorkspacePipelineTaskBinding{{Name: "ws", Workspace: "ws"}},
+		}},
+	}, {
+		name: "pipelineSpec",
+		ctx:  enabled,
+		tasks: []PipelineTask{{
+			Name:         "foo",
+			PipelineSpec: childSpec,
+		}},
+	}, {
+		name: "pipelineRef without feature flag",
+		ctx:  context.Background(),
+		tasks: []PipelineTask{{
+			Name:        "foo",
+			PipelineRef: &PipelineRef{Name: "build"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: "pipelineRef and pipelineSpec are not allowed in a pipeline task",
+			Paths:   []string{"tasks[0].pipelineRef", "tasks[0].pipelineSpec"},
+			Details: `Pipelines in Pipelines require the "enable-pipelines-in-pipelines" feature flag to be "true"`,
+		},
+	}, {
+		name: "pipelineRef without name",
+		ctx:  enabled,
+		tasks: []PipelineTask{{
+			Name:        "foo",
+			PipelineRef: &PipelineRef{},
+		}},
+		expectedError: apis.ErrMissingField("tasks[0].pipelineRef.name"),
+	}, {
+		name: "pipelineRef and pipelineSpec",
+		ctx:  enabled,
+		tasks: []PipelineTask{{
+			Name:         "foo",
+			PipelineRef:  &PipelineRef{Name: "build"},
+			PipelineSpec: childSpec,
+		}},
+		expectedError: apis.ErrMultipleOneOf("tasks[0].pipelineRef", "tasks[0].pipelineSpec"),
+	}, {
+		name: "pipelineRef and taskRef",
+		ctx:  enabled,
+		tasks: []PipelineTask{{
+			Name:        "foo",
+			PipelineRef: &PipelineRef{Name: "build"},
+			TaskRef:     &TaskRef{Name: "foo-task"},
+		}},
+		expectedError: apis.ErrMultipleOneOf("tasks[0].taskRef", "tasks[0].taskSpec", "tasks[0].pipelineRef", "tasks[0].pipelineSpec"),
+	}, {
+		name: "invalid pipelineSpec",
+		ctx:  enabled,
+		tasks: []PipelineTask{{
+			Name: "foo",
+			PipelineSpec: &PipelineSpec{
+				Tasks: []PipelineTask{{Name: "bar"}},
+			},
+		}},
+		expectedError: apis.ErrMissingOneOf("tasks[0].pipelineSpec.tasks[0].taskRef", "tasks[0].pipelineSpec.tasks[0].taskSpec"),
+	}, {
+		name: "child pipeline with TaskRun only fields",
+		ctx:  enabled,
+		tasks: []PipelineTask{{
+			Name:        "foo",
+			PipelineRef: &PipelineRef{Name: "build"},
+			Retries:     2,
+			Resources:   &PipelineTaskResources{},
+		}},
+		expectedError: apis.ErrDisallowedFields("tasks[0].resources").Also(apis.ErrDisallowedFields("tasks[0].retries")),
+	}, {
+		name: "duplicate child pipelines",
+		ctx:  enabled,
+		tasks: []PipelineTask{
+			{Name: "foo", PipelineRef: &PipelineRef{Name: "build"}},
+			{Name: "foo", PipelineSpec: childSpec},
+		},
+		expectedError: apis.ErrMultipleOneOf("tasks[1].name"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePipelineTasks(tt.ctx, tt.tasks, []PipelineTask{})
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Fatalf("Pipeline.validatePipelineTasks() returned error for valid pipeline tasks: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Pipeline.validatePipelineTasks() did not return error for invalid pipeline tasks")
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("Pipeline.validatePipelineTasks() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidatePipelineTasks_Matrix(t *testing.T) {
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		tasks         []PipelineTask
+		expectedError *apis.FieldError
+	}{{
+		name: "matrix",
+		ctx:  context.Background(),
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Params:  []Param{{Name: "package", Value: *NewArrayOrString("./...")}},
+			Matrix: []Param{
+				{Name: "goos", Value: *NewArrayOrString("linux", "darwin")},
+				{Name: "goversion", Value: *NewArrayOrString("1.14", "1.15")},
+			},
+		}},
+	}, {
+		name: "matrix parameter not an array",
+		ctx:  context.Background(),
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Matrix:  []Param{{Name: "goos", Value: *NewArrayOrString("linux")}},
+		}},
+		expectedError: apis.ErrInvalidValue(`matrix parameter "goos" must be an array`, "tasks[0].matrix[goos]"),
+	}, {
+		name: "empty matrix parameter",
+		ctx:  context.Background(),
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Matrix:  []Param{{Name: "goos", Value: ArrayOrString{Type: ParamTypeArray}}},
+		}},
+		expectedError: apis.ErrInvalidValue(`matrix parameter "goos" must have at least one value`, "tasks[0].matrix[goos]"),
+	}, {
+		name: "matrix parameter in params",
+		ctx:  context.Background(),
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Params:  []Param{{Name: "goos", Value: *NewArrayOrString("linux")}},
+			Matrix:  []Param{{Name: "goos", Value: *NewArrayOrString("linux", "darwin")}},
+		}},
+		expectedError: apis.ErrGeneric(`parameter "goos" appears in both params and matrix`, "tasks[0].matrix[goos]"),
+	}, {
+		name: "duplicate matrix parameter",
+		ctx:  context.Background(),
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Matrix: []Param{
+				{Name: "goos", Value: *NewArrayOrString("linux", "darwin")},
+				{Name: "goos", Value: *NewArrayOrString("windows", "freebsd")},
+			},
+		}},
+		expectedError: apis.ErrGeneric("parameter appears more than once", "tasks[0].matrix[goos]"),
+	}, {
+		name: "matrix and conditions",
+		ctx:  context.Background(),
+		tasks: []PipelineTask{{
+			Name:       "foo",
+			TaskRef:    &TaskRef{Name: "foo-task"},
+			Conditions: []PipelineTaskCondition{{ConditionRef: "cond"}},
+			Matrix:     []Param{{Name: "goos", Value: *NewArrayOrString("linux", "darwin")}},
+		}},
+		expectedError: apis.ErrMultipleOneOf("tasks[0].matrix", "tasks[0].conditions"),
+	}, {
+		name: "too many combinations",
+		ctx: config.ToContext(context.Background(), &config.Config{
+			Defaults: &config.Defaults{DefaultMaxMatrixCombinationsCount: 3},
+		}),
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Matrix: []Param{
+				{Name: "goos", Value: *NewArrayOrString("linux", "darwin")},
+				{Name: "goversion", Value: *NewArrayOrString("1.14", "1.15")},
+			},
+		}},
+		expectedError: apis.ErrInvalidValue("matrix fans out to 4 combinations, more than the maximum of 3", "tasks[0].matrix"),
+	}, {
+		name: "matrix on custom task",
+		ctx: config.ToContext(context.Background(), &config.Config{
+			FeatureFlags: &config.FeatureFlags{EnableCustomTasks: true},
+		}),
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{APIVersion: "example.dev/v0", Kind: "Example"},
+			Matrix:  []Param{{Name: "goos", Value: *NewArrayOrString("linux", "darwin")}},
+		}},
+		expectedError: apis.ErrDisallowedFields("tasks[0].matrix"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePipelineTasks(tt.ctx, tt.tasks, []PipelineTask{})
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Fatalf("Pipeline.validatePipelineTasks() returned error for valid pipeline tasks: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Pipeline.validatePipelineTasks() did not return error for invalid pipeline tasks")
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("Pipeline.validatePipelineTasks() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineSpec_Validate_Matrix(t *testing.T) {
+	tests := []struct {
+		name          string
+		ps            *PipelineSpec
+		expectedError *apis.FieldError
+	}{{
+		name: "array parameter expanded in matrix",
+		ps: &PipelineSpec{
+			Params: []ParamSpec{{Name: "platforms", Type: ParamTypeArray}},
+			Tasks: []PipelineTask{{
+				Name:    "foo",
+				TaskRef: &TaskRef{Name: "foo-task"},
+				Matrix:  []Param{{Name: "platform", Value: *NewArrayOrString("$(params.platforms)", "windows")}},
+			}},
+		},
+	}, {
+		name: "result in matrix",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:    "foo",
+				TaskRef: &TaskRef{Name: "foo-task"},
+			}, {
+				Name:    "bar",
+				TaskRef: &TaskRef{Name: "bar-task"},
+				Matrix:  []Param{{Name: "version", Value: *NewArrayOrString("$(tasks.foo.results.version)", "latest")}},
+			}},
+		},
+	}, {
+		name: "undeclared parameter in matrix",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:    "foo",
+				TaskRef: &TaskRef{Name: "foo-task"},
+				Matrix:  []Param{{Name: "platform", Value: *NewArrayOrString("$(params.platforms)", "windows")}},
+			}},
+		},
+		expectedError: &apis.FieldError{
+			Message: `non-existent variable in "$(params.platforms)"`,
+			Paths:   []string{"tasks[0].matrix[platform].value[0]"},
+		},
+	}, {
+		name: "array parameter not isolated in matrix",
+		ps: &PipelineSpec{
+			Params: []ParamSpec{{Name: "platforms", Type: ParamTypeArray}},
+			Tasks: []PipelineTask{{
+				Name:    "foo",
+				TaskRef: &TaskRef{Name: "foo-task"},
+				Matrix:  []Param{{Name: "platform", Value: *NewArrayOrString("os-$(params.platforms)", "windows")}},
+			}},
+		},
+		expectedError: &apis.FieldError{
+			Message: `variable is not properly isolated in "os-$(params.platforms)" for matrix parameter platform`,
+			Paths:   []string{"tasks[0].matrix.platform"},
+		},
+	}, {
+		name: "pipeline result from matrix",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:    "foo",
+				TaskRef: &TaskRef{Name: "foo-task"},
+				Matrix:  []Param{{Name: "platform", Value: *NewArrayOrString("linux", "windows")}},
+			}},
+			Results: []PipelineResult{{Name: "digest", Value: "$(tasks.foo.results.digest)"}},
+		},
+		expectedError: apis.ErrInvalidValue(`pipeline result can't reference the results of task "foo" which has a matrix`, "results[0].value"),
+	}, {
+		name: "matrix in finally",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:    "foo",
+				TaskRef: &TaskRef{Name: "foo-task"},
+			}},
+			Finally: []PipelineTask{{
+				Name:    "bar",
+				TaskRef: &TaskRef{Name: "bar-task"},
+				Matrix:  []Param{{Name: "platform", Value: *NewArrayOrString("linux", "windows")}},
+			}},
+		},
+		expectedError: apis.ErrInvalidValue("no matrix allowed under spec.finally, final task bar has matrix specified", "finally[0]"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ps.Validate(context.Background())
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Fatalf("PipelineSpec.Validate() returned error for valid pipeline spec: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("PipelineSpec.Validate() did not return error for invalid pipeline spec")
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("PipelineSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestValidateFrom_Success(t *testing.T) {
 	desc := "valid pipeline task - from resource referring to valid output resource of the pipeline task"
 	tasks := []PipelineTask{{
@@ -952,6 +1378,10 @@ func TestValidatePipelineResults_Success(t *testing.T) {
 		Name:        "my-pipeline-result",
 		Description: "this is my pipeline result",
 		Value:       "$(tasks.a-task.results.output)",
+	}, {
+		Name:        "my-pipeline-object-result-key",
+		Description: "this is the key of an object result",
+		Value:       "$(tasks.a-task.results.output.key)",
 	}}
 	if err := validatePipelineResults(results); err != nil {
 		t.Errorf("Pipeline.validatePipelineResults() returned error for valid pipeline: %s: %v", desc, err)
@@ -963,10 +1393,10 @@ func TestValidatePipelineResults_Failure(t *testing.T) {
 	results := []PipelineResult{{
 		Name:        "my-pipeline-result",
 		Description: "this is my pipeline result",
-		Value:       "$(tasks.a-task.results.output.output)",
+		Value:       "$(tasks.a-task.results.output.key.key)",
 	}}
 	expectedError := apis.FieldError{
-		Message: `invalid value: expected all of the expressions [tasks.a-task.results.output.output] to be result expressions but only [] were`,
+		Message: `invalid value: expected all of the expressions [tasks.a-task.results.output.key.key] to be result expressions but only [] were`,
 		Paths:   []string{"results[0].value"},
 	}
 	err := validatePipelineResults(results)
@@ -1057,6 +1487,27 @@ func TestValidatePipelineParameterVariables_Success(t *testing.T) {
 				Name: "a-param", Value: ArrayOrString{StringVal: "$(input.workspace.$(baz))"},
 			}},
 		}},
+	}, {
+		name: "valid object parameter variables",
+		params: []ParamSpec{{
+			Name: "repo", Type: ParamTypeObject, Properties: map[string]PropertySpec{"url": {}, "commit": {}},
+		}},
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: []Param{{
+				Name: "url", Value: ArrayOrString{Type: ParamTypeString, StringVal: "$(params.repo.url)"},
+			}, {
+				Name: "entire-repo", Value: ArrayOrString{Type: ParamTypeString, StringVal: "$(params.repo[*])"},
+			}, {
+				Name: "new-repo", Value: ArrayOrString{Type: ParamTypeObject, ObjectVal: map[string]string{"url": "$(params.repo.url)", "commit": "main"}},
+			}},
+			WhenExpressions: []WhenExpression{{
+				Input:    "$(params.repo.commit)",
+				Operator: selection.NotIn,
+				Values:   []string{"main"},
+			}},
+		}},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1335,6 +1786,64 @@ func TestValidatePipelineParameterVariables_Failure(t *testing.T) {
 			Message: `parameter appears more than once`,
 			Paths:   []string{"params[baz]"},
 		},
+	}, {
+		name: "undeclared key of object parameter",
+		params: []ParamSpec{{
+			Name: "repo", Type: ParamTypeObject, Properties: map[string]PropertySpec{"url": {}},
+		}},
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Params: []Param{{
+				Name: "a-param", Value: ArrayOrString{Type: ParamTypeString, StringVal: "$(params.repo.commit)"},
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent key "commit" of object "repo" in "$(params.repo.commit)"`,
+			Paths:   []string{"[0].params[a-param]"},
+		},
+	}, {
+		name: "entire object parameter not isolated",
+		params: []ParamSpec{{
+			Name: "repo", Type: ParamTypeObject, Properties: map[string]PropertySpec{"url": {}},
+		}},
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Params: []Param{{
+				Name: "a-param", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"$(params.repo[*])"}},
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `object variable must reference one of its keys in "$(params.repo[*])"`,
+			Paths:   []string{"[0].params[a-param].value[0]"},
+		},
+	}, {
+		name: "object parameter without properties",
+		params: []ParamSpec{{
+			Name: "repo", Type: ParamTypeObject,
+		}},
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+		}},
+		expectedError: apis.FieldError{
+			Message: `missing field(s)`,
+			Paths:   []string{"params[repo].properties"},
+		},
+	}, {
+		name: "default value not in enum",
+		params: []ParamSpec{{
+			Name: "env", Type: ParamTypeString, Enum: []string{"dev", "prod"}, Default: NewArrayOrString("prdo"),
+		}},
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: value "prdo" of param "env" is not one of the allowed values [dev prod]`,
+			Paths:   []string{"params[env].default"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1365,6 +1874,30 @@ func TestValidatePipelineWorkspaces_Success(t *testing.T) {
 			t.Errorf("Pipeline.validatePipelineWorkspaces() returned error for valid pipeline workspaces: %s: %v", desc, err)
 		}
 	})
+
+	desc = "optional workspaces can be checked in when expressions"
+	workspaces = []PipelineWorkspaceDeclaration{{
+		Name:     "cache",
+		Optional: true,
+	}}
+	tasks = []PipelineTask{{
+		Name: "foo", TaskRef: &TaskRef{Name: "foo"},
+		Workspaces: []WorkspacePipelineTaskBinding{{
+			Name:      "cache",
+			Workspace: "cache",
+		}},
+		WhenExpressions: WhenExpressions{{
+			Input:    "$(workspaces.cache.bound)",
+			Operator: selection.In,
+			Values:   []string{"true"},
+		}},
+	}}
+	t.Run(desc, func(t *testing.T) {
+		err := validatePipelineWorkspaces(workspaces, tasks, []PipelineTask{})
+		if err != nil {
+			t.Errorf("Pipeline.validatePipelineWorkspaces() returned error for valid pipeline workspaces: %s: %v", desc, err)
+		}
+	})
 }
 
 func TestValidatePipelineWorkspaces_Failure(t *testing.T) {
@@ -1389,6 +1922,23 @@ func TestValidatePipelineWorkspaces_Failure(t *testing.T) {
 			Message: `invalid value: pipeline task "foo" expects workspace with name "pipelineWorkspaceName" but none exists in pipeline spec`,
 			Paths:   []string{"tasks[0].workspaces[0]"},
 		},
+	}, {
+		name: "when expressions checking a non-existent pipeline workspace cause an error",
+		workspaces: []PipelineWorkspaceDeclaration{{
+			Name: "foo",
+		}},
+		tasks: []PipelineTask{{
+			Name: "foo", TaskRef: &TaskRef{Name: "foo"},
+			WhenExpressions: WhenExpressions{{
+				Input:    "$(workspaces.bar.bound)",
+				Operator: selection.In,
+				Values:   []string{"true"},
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "$(workspaces.bar.bound)"`,
+			Paths:   []string{"tasks[0].when[0].input"},
+		},
 	}, {
 		name: "multiple workspaces sharing the same name are not allowed",
 		workspaces: []PipelineWorkspaceDeclaration{{
@@ -1429,6 +1979,34 @@ func TestValidatePipelineWorkspaces_Failure(t *testing.T) {
 	}
 }
 
+func TestValidatePipelineConcurrency(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		concurrency *PipelineConcurrency
+		wantErr     *apis.FieldError
+	}{{
+		name: "no concurrency",
+	}, {
+		name:        "valid concurrency",
+		concurrency: &PipelineConcurrency{MaxRuns: 3, Strategy: ConcurrencyStrategyCancelOlder},
+	}, {
+		name:        "negative max runs",
+		concurrency: &PipelineConcurrency{MaxRuns: -1},
+		wantErr:     apis.ErrInvalidValue("-1 should be >= 0", "maxRuns"),
+	}, {
+		name:        "unknown strategy",
+		concurrency: &PipelineConcurrency{Strategy: "cancel-newer"},
+		wantErr:     apis.ErrInvalidValue("cancel-newer should be queue or cancel-older", "strategy"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validatePipelineConcurrency(tc.concurrency)
+			if d := cmp.Diff(tc.wantErr.Error(), err.Error()); d != "" {
+				t.Errorf("validatePipelineConcurrency() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestValidatePipelineWithFinalTasks_Success(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1493,6 +2071,41 @@ func TestValidatePipelineWithFinalTasks_Success(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		name: "valid pipeline with final tasks consuming the results and the execution status of non-final tasks",
+		p: &Pipeline{
+			ObjectMeta: metav1.ObjectMeta{Name: "pipeline"},
+			Spec: PipelineSpec{
+				Tasks: []PipelineTask{{
+					Name:    "non-final-task",
+					TaskRef: &TaskRef{Name: "non-final-task"},
+				}},
+				Finally: []PipelineTask{{
+					Name:    "final-task-1",
+					TaskRef: &TaskRef{Name: "final-task"},
+					Params: []Param{{
+						Name: "commit", Value: *NewArrayOrString("$(tasks.non-final-task.results.commit)"),
+					}, {
+						Name: "status", Value: *NewArrayOrString("$(tasks.non-final-task.status)"),
+					}},
+				}, {
+					Name:    "final-task-2",
+					TaskRef: &TaskRef{Name: "final-task"},
+					Params: []Param{{
+						Name: "status", Value: *NewArrayOrString("$(tasks.status)"),
+					}},
+					WhenExpressions: []WhenExpression{{
+						Input:    "$(tasks.non-final-task.status)",
+						Operator: selection.In,
+						Values:   []string{"Failed"},
+					}, {
+						Input:    "$(tasks.non-final-task.results.commit)",
+						Operator: selection.NotIn,
+						Values:   []string{""},
+					}},
+				}},
+			},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1839,37 +2452,68 @@ func TestValidateFinalTasks_Failure(t *testing.T) {
 			Paths:   []string{"finally[0].resources.inputs[0]"},
 		},
 	}, {
-		name: "invalid pipeline with final tasks having reference to task results",
+		name: "invalid pipeline with final tasks having reference to results of a task not in the pipeline tasks",
 		finalTasks: []PipelineTask{{
 			Name:    "final-task",
 			TaskRef: &TaskRef{Name: "final-task"},
 			Params: []Param{{
-				Name: "param1", Value: ArrayOrString{Type: ParamTypeString, StringVal: "$(tasks.a-task.results.output)"},
+				Name: "param1", Value: ArrayOrString{Type: ParamTypeString, StringVal: "$(tasks.no-such-task.results.output)"},
 			}},
 		}},
 		expectedError: apis.FieldError{
-			Message: `invalid value: no task result allowed under params,final task param param1 has set task result as its value`,
-			Paths:   []string{"finally[0].params"},
+			Message: `invalid value: invalid task result reference, final task has task result reference from a task no-such-task which is not defined in the pipeline tasks`,
+			Paths:   []string{"finally[0].params[param1].value"},
 		},
 	}, {
-		name: "invalid pipeline with final task specifying when expressions",
+		name: "invalid pipeline with final tasks having reference to results of another final task",
 		finalTasks: []PipelineTask{{
-			Name:    "final-task",
+			Name:    "final-task-1",
+			TaskRef: &TaskRef{Name: "final-task"},
+		}, {
+			Name:    "final-task-2",
 			TaskRef: &TaskRef{Name: "final-task"},
 			WhenExpressions: []WhenExpression{{
-				Input:    "foo",
+				Input:    "$(tasks.final-task-1.results.output)",
 				Operator: selection.In,
-				Values:   []string{"foo", "bar"},
+				Values:   []string{"foo"},
 			}},
 		}},
 		expectedError: apis.FieldError{
-			Message: `invalid value: no when expressions allowed under spec.finally, final task final-task has when expressions specified`,
-			Paths:   []string{"finally[0]"},
+			Message: `invalid value: invalid task result reference, final task has task result reference from a task final-task-1 which is not defined in the pipeline tasks`,
+			Paths:   []string{"finally[1].when[0]"},
+		},
+	}, {
+		name: "invalid pipeline with final tasks having reference to the execution status of a task not in the pipeline tasks",
+		finalTasks: []PipelineTask{{
+			Name:    "final-task",
+			TaskRef: &TaskRef{Name: "final-task"},
+			Params: []Param{{
+				Name: "param1", Value: ArrayOrString{Type: ParamTypeString, StringVal: "$(tasks.no-such-task.status)"},
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: invalid task status reference, final task has execution status reference from a task no-such-task which is not defined in the pipeline tasks`,
+			Paths:   []string{"finally[0].params[param1].value"},
+		},
+	}, {
+		name: "invalid pipeline with final task specifying invalid when expressions",
+		finalTasks: []PipelineTask{{
+			Name:    "final-task",
+			TaskRef: &TaskRef{Name: "final-task"},
+			WhenExpressions: []WhenExpression{{
+				Input:    "$(tasks.status)",
+				Operator: selection.Exists,
+				Values:   []string{"Failed"},
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: operator "exists" is not recognized. valid operators: in,notin`,
+			Paths:   []string{"finally[0].when[0]"},
 		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateFinalTasks(tt.finalTasks)
+			err := validateFinalTasks([]PipelineTask{{Name: "a-task", TaskRef: &TaskRef{Name: "a-task"}}}, tt.finalTasks)
 			if err == nil {
 				t.Errorf("Pipeline.ValidateFinalTasks() did not return error for invalid pipeline: %s", tt.name)
 			}
@@ -1880,6 +2524,56 @@ func TestValidateFinalTasks_Failure(t *testing.T) {
 	}
 }
 
+func TestValidateExecutionStatusVariablesNotUsed(t *testing.T) {
+	tests := []struct {
+		name          string
+		tasks         []PipelineTask
+		expectedError apis.FieldError
+	}{{
+		name: "invalid pipeline task having reference to the execution status of another task",
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+		}, {
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: []Param{{
+				Name: "foo-status", Value: *NewArrayOrString("$(tasks.foo.status)"),
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: pipeline tasks can not refer to execution status of any other pipeline task or aggregate status of tasks, task bar param foo-status does`,
+			Paths:   []string{"tasks[1].params[foo-status].value"},
+		},
+	}, {
+		name: "invalid pipeline task having reference to the aggregate execution status of the tasks",
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			WhenExpressions: []WhenExpression{{
+				Input:    "$(tasks.status)",
+				Operator: selection.In,
+				Values:   []string{"Succeeded"},
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: pipeline tasks can not refer to execution status of any other pipeline task or aggregate status of tasks, task foo when expression does`,
+			Paths:   []string{"tasks[0].when[0]"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateExecutionStatusVariablesNotUsed(tt.tasks)
+			if err == nil {
+				t.Fatalf("validateExecutionStatusVariablesNotUsed() did not return error for invalid pipeline: %s", tt.name)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("validateExecutionStatusVariablesNotUsed() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestContextValid(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -55,6 +55,29 @@ func TestPipelineRunSpec_SetDefaults(t *testing.T) {
 				Timeout:            &metav1.Duration{Duration: 500 * time.Millisecond},
 			},
 		},
+		{
+			desc: "timeouts without pipeline timeout",
+			prs: &v1beta1.PipelineRunSpec{
+				Timeouts: &v1beta1.TimeoutFields{Tasks: &metav1.Duration{Duration: 10 * time.Minute}},
+			},
+			want: &v1beta1.PipelineRunSpec{
+				ServiceAccountName: config.DefaultServiceAccountValue,
+				Timeouts: &v1beta1.TimeoutFields{
+					Pipeline: &metav1.Duration{Duration: config.DefaultTimeoutMinutes * time.Minute},
+					Tasks:    &metav1.Duration{Duration: 10 * time.Minute},
+				},
+			},
+		},
+		{
+			desc: "timeouts with pipeline timeout",
+			prs: &v1beta1.PipelineRunSpec{
+				Timeouts: &v1beta1.TimeoutFields{Pipeline: &metav1.Duration{Duration: 2 * time.Hour}},
+			},
+			want: &v1beta1.PipelineRunSpec{
+				ServiceAccountName: config.DefaultServiceAccountValue,
+				Timeouts:           &v1beta1.TimeoutFields{Pipeline: &metav1.Duration{Duration: 2 * time.Hour}},
+			},
+		},
 		{
 			desc: "pod template is nil",
 			prs:  &v1beta1.PipelineRunSpec{},
@@ -17,10 +17,12 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	runv1alpha1 "github.com/tektoncd/pipeline/pkg/apis/run/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -98,6 +100,28 @@ func (pr *PipelineRun) IsCancelled() bool {
 	return pr.Spec.Status == PipelineRunSpecStatusCancelled
 }
 
+// IsPending returns true if the PipelineRun's spec status is set to Pending state
+func (pr *PipelineRun) IsPending() bool {
+	return pr.Spec.Status == PipelineRunSpecStatusPending
+}
+
+// IsQueued returns true if the PipelineRun has not started yet because it is waiting for
+// other PipelineRuns to complete, to honour its concurrency limits
+func (pr *PipelineRun) IsQueued() bool {
+	c := pr.Status.GetCondition(apis.ConditionSucceeded)
+	return !pr.HasStarted() && c.IsUnknown() && c.Reason == PipelineRunReasonQueued.String()
+}
+
+// IsPaused returns true if the PipelineRun's spec status is set to Paused state
+func (pr *PipelineRun) IsPaused() bool {
+	return pr.Spec.Status == PipelineRunSpecStatusPaused
+}
+
+// IsGracefullyCancelled returns true if the PipelineRun's spec status is set to CancelledRunFinally state
+func (pr *PipelineRun) IsGracefullyCancelled() bool {
+	return pr.Spec.Status == PipelineRunSpecStatusCancelledRunFinally
+}
+
 // GetNamespacedName returns a k8s namespaced name that identifies this PipelineRun
 func (pr *PipelineRun) GetNamespacedName() types.NamespacedName {
 	return types.NamespacedName{Namespace: pr.Namespace, Name: pr.Name}
@@ -110,7 +134,7 @@ func (pr *PipelineRun) IsTimedOut() bool {
 
 // HasTimedOut returns true if a pipelinerun has exceeded its spec.Timeout based on its status.Timeout
 func (pr *PipelineRun) HasTimedOut() bool {
-	pipelineTimeout := pr.Spec.Timeout
+	pipelineTimeout := pr.pipelineTimeout()
 	startTime := pr.Status.StartTime
 
 	if !startTime.IsZero() && pipelineTimeout != nil {
@@ -126,6 +150,63 @@ func (pr *PipelineRun) HasTimedOut() bool {
 	return false
 }
 
+// HaveTasksTimedOut returns true if the tasks of a pipelinerun have exceeded their
+// spec.timeouts.tasks, in which case no new task of its DAG is scheduled
+func (pr *PipelineRun) HaveTasksTimedOut() bool {
+	tasksTimeout := pr.TasksTimeout()
+	startTime := pr.Status.StartTime
+
+	if startTime.IsZero() || tasksTimeout == nil || tasksTimeout.Duration == config.NoTimeoutDuration {
+		return false
+	}
+	return time.Since(startTime.Time) > tasksTimeout.Duration
+}
+
+// HaveFinallyTimedOut returns true if the finally tasks of a pipelinerun have exceeded their
+// spec.timeouts.finally since they started
+func (pr *PipelineRun) HaveFinallyTimedOut() bool {
+	finallyTimeout := pr.FinallyTimeout()
+	finallyStartTime := pr.Status.FinallyStartTime
+
+	if finallyStartTime.IsZero() || finallyTimeout == nil || finallyTimeout.Duration == config.NoTimeoutDuration {
+		return false
+	}
+	return time.Since(finallyStartTime.Time) > finallyTimeout.Duration
+}
+
+// PipelineTimeout returns the timeout of the whole pipelinerun, set by spec.timeouts.pipeline
+// or by spec.timeout, or the default timeout when neither is set
+func (pr *PipelineRun) PipelineTimeout(ctx context.Context) time.Duration {
+	if timeout := pr.pipelineTimeout(); timeout != nil {
+		return timeout.Duration
+	}
+	return time.Duration(config.FromContextOrDefaults(ctx).Defaults.DefaultTimeoutMinutes) * time.Minute
+}
+
+// TasksTimeout returns the timeout of the tasks of the pipelinerun, if set by spec.timeouts.tasks
+func (pr *PipelineRun) TasksTimeout() *metav1.Duration {
+	if pr.Spec.Timeouts == nil {
+		return nil
+	}
+	return pr.Spec.Timeouts.Tasks
+}
+
+// FinallyTimeout returns the timeout of the finally tasks of the pipelinerun, if set by
+// spec.timeouts.finally
+func (pr *PipelineRun) FinallyTimeout() *metav1.Duration {
+	if pr.Spec.Timeouts == nil {
+		return nil
+	}
+	return pr.Spec.Timeouts.Finally
+}
+
+func (pr *PipelineRun) pipelineTimeout() *metav1.Duration {
+	if pr.Spec.Timeouts != nil && pr.Spec.Timeouts.Pipeline != nil {
+		return pr.Spec.Timeouts.Pipeline
+	}
+	return pr.Spec.Timeout
+}
+
 // GetServiceAccountName returns the service account name for a given
 // PipelineTask if configured, otherwise it returns the PipelineRun's serviceAccountName.
 func (pr *PipelineRun) GetServiceAccountName(pipelineTaskName string) string {
@@ -174,6 +255,10 @@ type PipelineRunSpec struct {
 	// Refer to Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Timeouts splits the timeout of the PipelineRun between its tasks and its finally tasks.
+	// It cannot be used together with Timeout.
+	// +optional
+	Timeouts *TimeoutFields `json:"timeouts,omitempty"`
 	// PodTemplate holds pod specific configuration
 	PodTemplate *PodTemplate `json:"podTemplate,omitempty"`
 	// Workspaces holds a set of workspace bindings that must match names
@@ -185,6 +270,21 @@ type PipelineRunSpec struct {
 	TaskRunSpecs []PipelineTaskRunSpec `json:"taskRunSpecs,omitempty"`
 }
 
+// TimeoutFields holds the timeouts of the sections of a PipelineRun: the tasks of its
+// Pipeline and its finally tasks must complete within their own timeout, and the whole
+// PipelineRun within the pipeline timeout.
+type TimeoutFields struct {
+	// Pipeline is the timeout of the whole PipelineRun. Defaults to the default timeout.
+	// +optional
+	Pipeline *metav1.Duration `json:"pipeline,omitempty"`
+	// Tasks is the timeout of the tasks of the Pipeline, from the start of the PipelineRun.
+	// +optional
+	Tasks *metav1.Duration `json:"tasks,omitempty"`
+	// Finally is the timeout of the finally tasks of the Pipeline, from their start.
+	// +optional
+	Finally *metav1.Duration `json:"finally,omitempty"`
+}
+
 // PipelineRunSpecStatus defines the pipelinerun spec status the user can provide
 type PipelineRunSpecStatus string
 
@@ -192,6 +292,18 @@ const (
 	// PipelineRunSpecStatusCancelled indicates that the user wants to cancel the task,
 	// if not already cancelled or terminated
 	PipelineRunSpecStatusCancelled = "PipelineRunCancelled"
+
+	// PipelineRunSpecStatusPending indicates that the user wants to postpone starting a PipelineRun
+	// until some condition is met
+	PipelineRunSpecStatusPending = "PipelineRunPending"
+
+	// PipelineRunSpecStatusPaused indicates that the user wants the controller to stop scheduling
+	// new Tasks for a running PipelineRun, while letting the running TaskRuns finish
+	PipelineRunSpecStatusPaused = "PipelineRunPaused"
+
+	// PipelineRunSpecStatusCancelledRunFinally indicates that the user wants to cancel the running
+	// Tasks of a PipelineRun and stop scheduling new ones, but still run its finally Tasks
+	PipelineRunSpecStatusCancelledRunFinally = "CancelledRunFinally"
 )
 
 // PipelineRef can be used to refer to a specific instance of a Pipeline.
@@ -202,6 +314,9 @@ type PipelineRef struct {
 	// API version of the referent
 	// +optional
 	APIVersion string `json:"apiVersion,omitempty"`
+	// Bundle url reference to a Tekton Bundle.
+	// +optional
+	Bundle string `json:"bundle,omitempty"`
 }
 
 // PipelineRunStatus defines the observed state of PipelineRun
@@ -235,12 +350,37 @@ const (
 	// PipelineRunReasonStopping indicates that no new Tasks will be scheduled by the controller, and the
 	// pipeline will stop once all running tasks complete their work
 	PipelineRunReasonStopping PipelineRunReason = "PipelineRunStopping"
+	// PipelineRunReasonPending is the reason set when the PipelineRun is in the pending state
+	PipelineRunReasonPending PipelineRunReason = "PipelineRunPending"
+	// PipelineRunReasonPaused is the reason set when the PipelineRun is paused: no new Tasks will be
+	// scheduled by the controller until the PipelineRun is resumed, running tasks are left to complete
+	PipelineRunReasonPaused PipelineRunReason = "PipelineRunPaused"
+	// PipelineRunReasonQueued is the reason set when the PipelineRun is waiting for other PipelineRuns
+	// to complete before starting, because of its concurrency limits
+	PipelineRunReasonQueued PipelineRunReason = "PipelineRunQueued"
+	// PipelineRunReasonCancelledRunningFinally is the reason set when the PipelineRun was cancelled
+	// gracefully: its running Tasks are cancelled and its finally Tasks are running
+	PipelineRunReasonCancelledRunningFinally PipelineRunReason = "CancelledRunningFinally"
 )
 
 func (t PipelineRunReason) String() string {
 	return string(t)
 }
 
+const (
+	// PipelineTasksAggregateStatus is the context variable, $(tasks.status), through which finally
+	// Tasks consume the aggregate execution status of the Tasks of the DAG
+	PipelineTasksAggregateStatus = "tasks.status"
+	// PipelineTaskStatusPrefix is the prefix of the context variable, $(tasks.<pipelineTaskName>.status),
+	// through which finally Tasks consume the execution status of a Task of the DAG
+	PipelineTaskStatusPrefix = "tasks."
+	// PipelineTaskStatusSuffix is the suffix of the context variable, $(tasks.<pipelineTaskName>.status)
+	PipelineTaskStatusSuffix = ".status"
+	// PipelineTaskStateNone is the execution status of a Task of the DAG which did not run, or the
+	// aggregate execution status of the Tasks of the DAG when none of the other states apply
+	PipelineTaskStateNone = "None"
+)
+
 var pipelineRunCondSet = apis.NewBatchConditionSet()
 
 // GetCondition returns the Condition matching the given type.
@@ -264,6 +404,10 @@ func (pr *PipelineRunStatus) InitializeConditions() {
 	// Ensure the started reason is set for the "Succeeded" condition
 	if started {
 		initialCondition := conditionManager.GetCondition(apis.ConditionSucceeded)
+		if initialCondition.Reason == PipelineRunReasonPending.String() || initialCondition.Reason == PipelineRunReasonQueued.String() {
+			// Clear the message left over from the pending or queued state
+			initialCondition.Message = ""
+		}
 		initialCondition.Reason = PipelineRunReasonStarted.String()
 		conditionManager.SetCondition(*initialCondition)
 	}
@@ -320,10 +464,22 @@ type PipelineRunStatusFields struct {
 	// +optional
 	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 
+	// FinallyStartTime is the time the finally tasks of the PipelineRun were started.
+	// +optional
+	FinallyStartTime *metav1.Time `json:"finallyStartTime,omitempty"`
+
 	// map of PipelineRunTaskRunStatus with the taskRun name as the key
 	// +optional
 	TaskRuns map[string]*PipelineRunTaskRunStatus `json:"taskRuns,omitempty"`
 
+	// map of PipelineRunRunStatus with the run name as the key
+	// +optional
+	Runs map[string]*PipelineRunRunStatus `json:"runs,omitempty"`
+
+	// map of PipelineRunChildStatus with the child PipelineRun name as the key
+	// +optional
+	ChildPipelineRuns map[string]*PipelineRunChildStatus `json:"childPipelineRuns,omitempty"`
+
 	// PipelineResults are the list of results written out by the pipeline task's containers
 	// +optional
 	PipelineResults []PipelineRunResult `json:"pipelineResults,omitempty"`
@@ -334,6 +490,11 @@ type PipelineRunStatusFields struct {
 	// list of tasks that were skipped due to when expressions evaluating to false
 	// +optional
 	SkippedTasks []SkippedTask `json:"skippedTasks,omitempty"`
+
+	// CloudEventSinks describe the delivery of the cloud events of the PipelineRun
+	// to each of their sinks.
+	// +optional
+	CloudEventSinks []CloudEventDelivery `json:"cloudEventSinks,omitempty"`
 }
 
 // SkippedTask is used to describe the Tasks that were skipped due to their When Expressions
@@ -365,6 +526,25 @@ type PipelineRunTaskRunStatus struct {
 	ConditionChecks map[string]*PipelineRunConditionCheckStatus `json:"conditionChecks,omitempty"`
 }
 
+// PipelineRunRunStatus contains the name of the PipelineTask for this Run and the Run's Status
+type PipelineRunRunStatus struct {
+	// PipelineTaskName is the name of the PipelineTask.
+	PipelineTaskName string `json:"pipelineTaskName,omitempty"`
+	// Status is the RunStatus for the corresponding Run
+	// +optional
+	Status *runv1alpha1.RunStatus `json:"status,omitempty"`
+}
+
+// PipelineRunChildStatus contains the name of the PipelineTask for this child PipelineRun
+// and the child PipelineRun's Status
+type PipelineRunChildStatus struct {
+	// PipelineTaskName is the name of the PipelineTask.
+	PipelineTaskName string `json:"pipelineTaskName,omitempty"`
+	// Status is the PipelineRunStatus for the corresponding child PipelineRun
+	// +optional
+	Status *PipelineRunStatus `json:"status,omitempty"`
+}
+
 // PipelineRunConditionCheckStatus returns the condition check status
 type PipelineRunConditionCheckStatus struct {
 	// ConditionName is the name of the Condition
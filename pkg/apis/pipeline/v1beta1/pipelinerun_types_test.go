@@ -17,10 +17,12 @@ limitations under the License.
 package v1beta1_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
@@ -146,6 +148,64 @@ func TestPipelineRunIsCancelled(t *testing.T) {
 	}
 }
 
+func TestPipelineRunIsPending(t *testing.T) {
+	pr := &v1beta1.PipelineRun{
+		Spec: v1beta1.PipelineRunSpec{
+			Status: v1beta1.PipelineRunSpecStatusPending,
+		},
+	}
+	if !pr.IsPending() {
+		t.Fatal("Expected pipelinerun status to be pending")
+	}
+	if pr.IsPaused() || pr.IsCancelled() {
+		t.Fatal("Expected pending pipelinerun not to be paused or cancelled")
+	}
+}
+
+func TestPipelineRunIsQueued(t *testing.T) {
+	pr := &v1beta1.PipelineRun{}
+	pr.Status.SetCondition(&apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown,
+		Reason: v1beta1.PipelineRunReasonQueued.String(),
+	})
+	if !pr.IsQueued() {
+		t.Fatal("Expected pipelinerun to be queued")
+	}
+	pr.Status.StartTime = &metav1.Time{Time: time.Now()}
+	if pr.IsQueued() {
+		t.Fatal("Expected a started pipelinerun not to be queued")
+	}
+}
+
+func TestPipelineRunIsPaused(t *testing.T) {
+	pr := &v1beta1.PipelineRun{
+		Spec: v1beta1.PipelineRunSpec{
+			Status: v1beta1.PipelineRunSpecStatusPaused,
+		},
+	}
+	if !pr.IsPaused() {
+		t.Fatal("Expected pipelinerun status to be paused")
+	}
+	if pr.IsPending() || pr.IsCancelled() {
+		t.Fatal("Expected paused pipelinerun not to be pending or cancelled")
+	}
+}
+
+func TestPipelineRunIsGracefullyCancelled(t *testing.T) {
+	pr := &v1beta1.PipelineRun{
+		Spec: v1beta1.PipelineRunSpec{
+			Status: v1beta1.PipelineRunSpecStatusCancelledRunFinally,
+		},
+	}
+	if !pr.IsGracefullyCancelled() {
+		t.Fatal("Expected pipelinerun status to be gracefully cancelled")
+	}
+	if pr.IsCancelled() || pr.IsPaused() {
+		t.Fatal("Expected gracefully cancelled pipelinerun not to be cancelled or paused")
+	}
+}
+
 func TestPipelineRunHasVolumeClaimTemplate(t *testing.T) {
 	pr := &v1beta1.PipelineRun{
 		Spec: v1beta1.PipelineRunSpec{
@@ -259,6 +319,73 @@ func TestPipelineRunHasTimedOut(t *testing.T) {
 	}
 }
 
+func TestPipelineRunHasTimedOut_Timeouts(t *testing.T) {
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		Spec: v1beta1.PipelineRunSpec{
+			Timeouts: &v1beta1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: 2 * time.Hour},
+				Tasks:    &metav1.Duration{Duration: time.Hour},
+				Finally:  &metav1.Duration{Duration: 20 * time.Minute},
+			},
+		},
+		Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+			StartTime: &metav1.Time{Time: time.Now().Add(-90 * time.Minute)},
+		}},
+	}
+	if pr.HasTimedOut() {
+		t.Error("Expected the pipelinerun not to have timed out before its pipeline timeout")
+	}
+	if !pr.HaveTasksTimedOut() {
+		t.Error("Expected the tasks of the pipelinerun to have timed out after their tasks timeout")
+	}
+	if pr.HaveFinallyTimedOut() {
+		t.Error("Expected the finally tasks of the pipelinerun not to have timed out before they started")
+	}
+	pr.Status.FinallyStartTime = &metav1.Time{Time: time.Now().Add(-30 * time.Minute)}
+	if !pr.HaveFinallyTimedOut() {
+		t.Error("Expected the finally tasks of the pipelinerun to have timed out after their finally timeout")
+	}
+	if got := pr.PipelineTimeout(context.Background()); got != 2*time.Hour {
+		t.Errorf("Expected the pipeline timeout to be 2h, got %s", got)
+	}
+
+	pr.Status.StartTime = &metav1.Time{Time: time.Now().Add(-3 * time.Hour)}
+	if !pr.HasTimedOut() {
+		t.Error("Expected the pipelinerun to have timed out after its pipeline timeout")
+	}
+}
+
+func TestPipelineRunPipelineTimeout(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		spec v1beta1.PipelineRunSpec
+		want time.Duration
+	}{{
+		name: "default timeout",
+		want: config.DefaultTimeoutMinutes * time.Minute,
+	}, {
+		name: "timeout",
+		spec: v1beta1.PipelineRunSpec{Timeout: &metav1.Duration{Duration: time.Hour}},
+		want: time.Hour,
+	}, {
+		name: "timeouts without pipeline timeout",
+		spec: v1beta1.PipelineRunSpec{Timeouts: &v1beta1.TimeoutFields{Tasks: &metav1.Duration{Duration: time.Minute}}},
+		want: config.DefaultTimeoutMinutes * time.Minute,
+	}, {
+		name: "timeouts",
+		spec: v1beta1.PipelineRunSpec{Timeouts: &v1beta1.TimeoutFields{Pipeline: &metav1.Duration{Duration: 2 * time.Hour}}},
+		want: 2 * time.Hour,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1beta1.PipelineRun{Spec: tc.spec}
+			if got := pr.PipelineTimeout(context.Background()); got != tc.want {
+				t.Errorf("PipelineTimeout() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestPipelineRunGetServiceAccountName(t *testing.T) {
 	for _, tt := range []struct {
 		name    string
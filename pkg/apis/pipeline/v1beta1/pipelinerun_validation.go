@@ -19,9 +19,14 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"time"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
 
@@ -32,9 +37,27 @@ func (pr *PipelineRun) Validate(ctx context.Context) *apis.FieldError {
 	if err := validate.ObjectMetadata(pr.GetObjectMeta()).ViaField("metadata"); err != nil {
 		return err
 	}
+	if err := validateCloudEventsSink(pr.GetObjectMeta()).ViaField("metadata"); err != nil {
+		return err
+	}
 	return pr.Spec.Validate(ctx)
 }
 
+// validateCloudEventsSink validates the sink overriding the default sink of the
+// CloudEvents of a PipelineRun or TaskRun, when one is set. An empty sink disables
+// the CloudEvents of the run.
+func validateCloudEventsSink(meta metav1.Object) *apis.FieldError {
+	sink := meta.GetAnnotations()[pipeline.GroupName+pipeline.CloudEventsSinkAnnotationKey]
+	if sink == "" {
+		return nil
+	}
+	if u, err := url.Parse(sink); err != nil || !u.IsAbs() || u.Host == "" {
+		return apis.ErrInvalidValue(fmt.Sprintf("%q must be an absolute URL", sink),
+			fmt.Sprintf("annotations.%s", pipeline.GroupName+pipeline.CloudEventsSinkAnnotationKey))
+	}
+	return nil
+}
+
 // Validate pipelinerun spec
 func (ps *PipelineRunSpec) Validate(ctx context.Context) *apis.FieldError {
 	if equality.Semantic.DeepEqual(ps, &PipelineRunSpec{}) {
@@ -51,11 +74,21 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) *apis.FieldError {
 		return apis.ErrMissingField("spec.pipelineref.name", "spec.pipelinespec")
 	}
 
+	// Validate PipelineRef if it's present
+	if ps.PipelineRef != nil {
+		if err := ps.PipelineRef.Validate(ctx); err != nil {
+			return err.ViaField("spec.pipelineref")
+		}
+	}
+
 	// Validate PipelineSpec if it's present
 	if ps.PipelineSpec != nil {
 		if err := ps.PipelineSpec.Validate(ctx); err != nil {
 			return err
 		}
+		if err := ValidateParamValues(ps.PipelineSpec.Params, ps.Params); err != nil {
+			return err.ViaField("spec.params")
+		}
 	}
 
 	if ps.Timeout != nil {
@@ -65,9 +98,22 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	if ps.Timeouts != nil {
+		if ps.Timeout != nil {
+			return apis.ErrMultipleOneOf("spec.timeout", "spec.timeouts")
+		}
+		if err := ps.Timeouts.validate(ctx); err != nil {
+			return err
+		}
+	}
+
 	if ps.Status != "" {
-		if ps.Status != PipelineRunSpecStatusCancelled {
-			return apis.ErrInvalidValue(fmt.Sprintf("%s should be %s", ps.Status, PipelineRunSpecStatusCancelled), "spec.status")
+		switch ps.Status {
+		case PipelineRunSpecStatusCancelled, PipelineRunSpecStatusPending, PipelineRunSpecStatusPaused, PipelineRunSpecStatusCancelledRunFinally:
+		default:
+			return apis.ErrInvalidValue(fmt.Sprintf("%s should be %s, %s, %s or %s", ps.Status,
+				PipelineRunSpecStatusCancelled, PipelineRunSpecStatusPending, PipelineRunSpecStatusPaused,
+				PipelineRunSpecStatusCancelledRunFinally), "spec.status")
 		}
 	}
 
@@ -90,3 +136,42 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) *apis.FieldError {
 
 	return nil
 }
+
+// validate checks that the timeouts are valid durations of at least 0, and that the
+// timeouts of the tasks and of the finally tasks fit within the timeout of the pipeline
+func (tf *TimeoutFields) validate(ctx context.Context) *apis.FieldError {
+	for _, t := range []struct {
+		field   string
+		timeout *metav1.Duration
+	}{{"spec.timeouts.pipeline", tf.Pipeline}, {"spec.timeouts.tasks", tf.Tasks}, {"spec.timeouts.finally", tf.Finally}} {
+		if t.timeout != nil && t.timeout.Duration < 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", t.timeout.Duration.String()), t.field)
+		}
+	}
+
+	pipelineTimeout := time.Duration(config.FromContextOrDefaults(ctx).Defaults.DefaultTimeoutMinutes) * time.Minute
+	if tf.Pipeline != nil {
+		pipelineTimeout = tf.Pipeline.Duration
+	}
+	// A pipeline without timeout accepts any timeout for its tasks and finally tasks
+	if pipelineTimeout == config.NoTimeoutDuration {
+		return nil
+	}
+	var tasksTimeout, finallyTimeout time.Duration
+	if tf.Tasks != nil {
+		tasksTimeout = tf.Tasks.Duration
+		if tasksTimeout == config.NoTimeoutDuration || tasksTimeout > pipelineTimeout {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s should be > 0 and <= the pipeline timeout %s", tasksTimeout, pipelineTimeout), "spec.timeouts.tasks")
+		}
+	}
+	if tf.Finally != nil {
+		finallyTimeout = tf.Finally.Duration
+		if finallyTimeout == config.NoTimeoutDuration || finallyTimeout > pipelineTimeout {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s should be > 0 and <= the pipeline timeout %s", finallyTimeout, pipelineTimeout), "spec.timeouts.finally")
+		}
+	}
+	if tasksTimeout+finallyTimeout > pipelineTimeout {
+		return apis.ErrInvalidValue(fmt.Sprintf("the tasks timeout %s and the finally timeout %s should add up to <= the pipeline timeout %s", tasksTimeout, finallyTimeout, pipelineTimeout), "spec.timeouts")
+	}
+	return nil
+}
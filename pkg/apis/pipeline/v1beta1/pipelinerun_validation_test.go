@@ -93,7 +93,21 @@ func TestPipelineRun_Invalidate(t *testing.T) {
 					Status: "PipelineRunCancell",
 				},
 			},
-			want: apis.ErrInvalidValue("PipelineRunCancell should be PipelineRunCancelled", "spec.status"),
+			want: apis.ErrInvalidValue("PipelineRunCancell should be PipelineRunCancelled, PipelineRunPending, PipelineRunPaused or CancelledRunFinally", "spec.status"),
+		}, {
+			name: "relative cloudevents sink",
+			pr: v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pipelinelineName",
+					Annotations: map[string]string{"tekton.dev/cloudevents-sink": "/events"},
+				},
+				Spec: v1beta1.PipelineRunSpec{
+					PipelineRef: &v1beta1.PipelineRef{
+						Name: "prname",
+					},
+				},
+			},
+			want: apis.ErrInvalidValue(`"/events" must be an absolute URL`, "metadata.annotations.tekton.dev/cloudevents-sink"),
 		},
 	}
 
@@ -137,6 +151,58 @@ func TestPipelineRun_Validate(t *testing.T) {
 					Timeout: &metav1.Duration{Duration: 0},
 				},
 			},
+		}, {
+			name: "pending pipelinerun",
+			pr: v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: v1beta1.PipelineRunSpec{
+					PipelineRef: &v1beta1.PipelineRef{
+						Name: "prname",
+					},
+					Status: v1beta1.PipelineRunSpecStatusPending,
+				},
+			},
+		}, {
+			name: "paused pipelinerun",
+			pr: v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: v1beta1.PipelineRunSpec{
+					PipelineRef: &v1beta1.PipelineRef{
+						Name: "prname",
+					},
+					Status: v1beta1.PipelineRunSpecStatusPaused,
+				},
+			},
+		}, {
+			name: "gracefully cancelled pipelinerun",
+			pr: v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: v1beta1.PipelineRunSpec{
+					PipelineRef: &v1beta1.PipelineRef{
+						Name: "prname",
+					},
+					Status: v1beta1.PipelineRunSpecStatusCancelledRunFinally,
+				},
+			},
+		}, {
+			name: "cloudevents sink",
+			pr: v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pipelinelineName",
+					Annotations: map[string]string{"tekton.dev/cloudevents-sink": "http://events.example.com/tekton"},
+				},
+				Spec: v1beta1.PipelineRunSpec{
+					PipelineRef: &v1beta1.PipelineRef{
+						Name: "prname",
+					},
+				},
+			},
 		},
 	}
 
@@ -179,6 +245,15 @@ func TestPipelineRunSpec_Invalidate(t *testing.T) {
 				}}},
 		},
 		wantErr: apis.ErrDisallowedFields("spec.pipelinespec", "spec.pipelineref"),
+	}, {
+		name: "pipelineRef with bundle without the feature flag",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{
+				Name:   "pipelinerefname",
+				Bundle: "docker.io/foo/bar:latest",
+			},
+		},
+		wantErr: apis.ErrDisallowedFields("spec.pipelineref.bundle"),
 	}, {
 		name: "workspaces may only appear once",
 		spec: v1beta1.PipelineRunSpec{
@@ -217,6 +292,71 @@ func TestPipelineRunSpec_Invalidate(t *testing.T) {
 				"spec.workspaces[0].volumeclaimtemplate",
 			},
 		},
+	}, {
+		name: "param value not matching the pattern of the pipelinespec",
+		spec: v1beta1.PipelineRunSpec{
+			Params: []v1beta1.Param{{
+				Name:  "version",
+				Value: *v1beta1.NewArrayOrString("latest"),
+			}},
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Params: []v1beta1.ParamSpec{{
+					Name:    "version",
+					Type:    v1beta1.ParamTypeString,
+					Pattern: "^v[0-9]+$",
+				}},
+				Tasks: []v1beta1.PipelineTask{{
+					Name: "mytask",
+					TaskRef: &v1beta1.TaskRef{
+						Name: "mytask",
+					},
+				}},
+			},
+		},
+		wantErr: apis.ErrInvalidValue(`value "latest" of param "version" does not match the pattern "^v[0-9]+$"`, "spec.params[version].value"),
+	}, {
+		name: "timeout and timeouts together",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "pipelinerefname"},
+			Timeout:     &metav1.Duration{Duration: time.Hour},
+			Timeouts:    &v1beta1.TimeoutFields{Tasks: &metav1.Duration{Duration: time.Minute}},
+		},
+		wantErr: apis.ErrMultipleOneOf("spec.timeout", "spec.timeouts"),
+	}, {
+		name: "negative finally timeout",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "pipelinerefname"},
+			Timeouts:    &v1beta1.TimeoutFields{Finally: &metav1.Duration{Duration: -time.Minute}},
+		},
+		wantErr: apis.ErrInvalidValue("-1m0s should be >= 0", "spec.timeouts.finally"),
+	}, {
+		name: "tasks timeout longer than the pipeline timeout",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "pipelinerefname"},
+			Timeouts: &v1beta1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: time.Hour},
+				Tasks:    &metav1.Duration{Duration: 2 * time.Hour},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("2h0m0s should be > 0 and <= the pipeline timeout 1h0m0s", "spec.timeouts.tasks"),
+	}, {
+		name: "tasks without timeout in a pipeline with a timeout",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "pipelinerefname"},
+			Timeouts:    &v1beta1.TimeoutFields{Tasks: &metav1.Duration{Duration: 0}},
+		},
+		wantErr: apis.ErrInvalidValue("0s should be > 0 and <= the pipeline timeout 1h0m0s", "spec.timeouts.tasks"),
+	}, {
+		name: "tasks and finally timeouts longer than the pipeline timeout",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "pipelinerefname"},
+			Timeouts: &v1beta1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: time.Hour},
+				Tasks:    &metav1.Duration{Duration: 45 * time.Minute},
+				Finally:  &metav1.Duration{Duration: 30 * time.Minute},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("the tasks timeout 45m0s and the finally timeout 30m0s should add up to <= the pipeline timeout 1h0m0s", "spec.timeouts"),
 	}}
 	for _, ps := range tests {
 		t.Run(ps.name, func(t *testing.T) {
@@ -244,6 +384,25 @@ func TestPipelineRunSpec_Validate(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		name: "timeouts",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "pipelinerefname"},
+			Timeouts: &v1beta1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: time.Hour},
+				Tasks:    &metav1.Duration{Duration: 45 * time.Minute},
+				Finally:  &metav1.Duration{Duration: 15 * time.Minute},
+			},
+		},
+	}, {
+		name: "timeouts of a pipeline without timeout",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "pipelinerefname"},
+			Timeouts: &v1beta1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: 0},
+				Tasks:    &metav1.Duration{Duration: 2 * time.Hour},
+			},
+		},
 	}}
 	for _, ps := range tests {
 		t.Run(ps.name, func(t *testing.T) {
@@ -26,10 +26,12 @@ import (
 type ResultRef struct {
 	PipelineTask string
 	Result       string
+	// Property is the key of an object result, if the reference is to a single key of the result
+	Property string
 }
 
 const (
-	resultExpressionFormat = "tasks.<taskName>.results.<resultName>"
+	resultExpressionFormat = "tasks.<taskName>.results.<resultName>(.<key>)"
 	// ResultTaskPart Constant used to define the "tasks" part of a pipeline result reference
 	ResultTaskPart = "tasks"
 	// ResultResultPart Constant used to define the "results" part of a pipeline result reference
@@ -49,7 +51,7 @@ var resultNameFormatRegex = regexp.MustCompile(ResultNameFormat)
 func NewResultRefs(expressions []string) []*ResultRef {
 	var resultRefs []*ResultRef
 	for _, expression := range expressions {
-		pipelineTask, result, err := parseExpression(expression)
+		pipelineTask, result, property, err := parseExpression(expression)
 		// If the expression isn't a result but is some other expression,
 		// parseExpression will return an error, in which case we just skip that expression,
 		// since although it's not a result ref, it might be some other kind of reference
@@ -57,6 +59,7 @@ func NewResultRefs(expressions []string) []*ResultRef {
 			resultRefs = append(resultRefs, &ResultRef{
 				PipelineTask: pipelineTask,
 				Result:       result,
+				Property:     property,
 			})
 		}
 	}
@@ -94,6 +97,11 @@ func GetVarSubstitutionExpressionsForParam(param Param) ([]string, bool) {
 	case ParamTypeString:
 		// string type
 		allExpressions = append(allExpressions, validateString(param.Value.StringVal)...)
+	case ParamTypeObject:
+		// object type
+		for _, value := range param.Value.ObjectVal {
+			allExpressions = append(allExpressions, validateString(value)...)
+		}
 	default:
 		return nil, false
 	}
@@ -122,10 +130,13 @@ func stripVarSubExpression(expression string) string {
 	return strings.TrimSuffix(strings.TrimPrefix(expression, "$("), ")")
 }
 
-func parseExpression(substitutionExpression string) (string, string, error) {
+func parseExpression(substitutionExpression string) (string, string, string, error) {
 	subExpressions := strings.Split(substitutionExpression, ".")
-	if len(subExpressions) != 4 || subExpressions[0] != ResultTaskPart || subExpressions[2] != ResultResultPart {
-		return "", "", fmt.Errorf("Must be of the form %q", resultExpressionFormat)
+	if len(subExpressions) < 4 || len(subExpressions) > 5 || subExpressions[0] != ResultTaskPart || subExpressions[2] != ResultResultPart {
+		return "", "", "", fmt.Errorf("Must be of the form %q", resultExpressionFormat)
+	}
+	if len(subExpressions) == 5 {
+		return subExpressions[1], subExpressions[3], subExpressions[4], nil
 	}
-	return subExpressions[1], subExpressions[3], nil
+	return subExpressions[1], subExpressions[3], "", nil
 }
@@ -108,6 +108,34 @@ func TestNewResultReference(t *testing.T) {
 			PipelineTask: "sumTask1",
 			Result:       "sumResult",
 		}},
+	}, {
+		name: "key of an object result",
+		param: v1beta1.Param{
+			Name:  "param",
+			Value: *v1beta1.NewArrayOrString("$(tasks.cloneTask.results.repo.commit)"),
+		},
+		want: []*v1beta1.ResultRef{{
+			PipelineTask: "cloneTask",
+			Result:       "repo",
+			Property:     "commit",
+		}},
+	}, {
+		name: "result in the value of an object param",
+		param: v1beta1.Param{
+			Name:  "param",
+			Value: *v1beta1.NewObject(map[string]string{"commit": "$(tasks.cloneTask.results.commit)"}),
+		},
+		want: []*v1beta1.ResultRef{{
+			PipelineTask: "cloneTask",
+			Result:       "commit",
+		}},
+	}, {
+		name: "too many separators",
+		param: v1beta1.Param{
+			Name:  "param",
+			Value: *v1beta1.NewArrayOrString("$(tasks.cloneTask.results.repo.commit.sha)"),
+		},
+		want: nil,
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			expressions, ok := v1beta1.GetVarSubstitutionExpressionsForParam(tt.param)
@@ -137,3 +137,15 @@ func ApplyArrayReplacements(in string, stringReplacements map[string]string, arr
 	// Otherwise return a size-1 array containing the input string with standard stringReplacements applied.
 	return []string{ApplyReplacements(in, stringReplacements)}
 }
+
+// ApplyObjectReplacements returns the object of objectReplacements which the input string entirely
+// references, e.g. "$(params.foo)" or "$(params.foo[*])". The second return value is false if the
+// input string is not a reference to one of the objects.
+func ApplyObjectReplacements(in string, objectReplacements map[string]map[string]string) (map[string]string, bool) {
+	for k, v := range objectReplacements {
+		if in == fmt.Sprintf("$(%s)", k) || in == fmt.Sprintf("$(%s[*])", k) {
+			return v, true
+		}
+	}
+	return nil, false
+}
@@ -104,6 +104,13 @@ type TaskSpec struct {
 
 	// Results are values that this Task can output
 	Results []TaskResult `json:"results,omitempty"`
+
+	// ScriptInterpreter is the default command, with its arguments, which runs
+	// the scripts of the Steps and Sidecars that don't start with a shebang.
+	// The script file and the args of the Step are appended to it. Defaults to
+	// running the scripts with "sh -xe".
+	// +optional
+	ScriptInterpreter []string `json:"scriptInterpreter,omitempty"`
 }
 
 // TaskResult used to describe the results of a task
@@ -111,11 +118,33 @@ type TaskResult struct {
 	// Name the given name
 	Name string `json:"name"`
 
+	// Type is the user-specified type of the result. The possible types
+	// are currently "string" and "object", and "string" is the default.
+	// +optional
+	Type ResultsType `json:"type,omitempty"`
+
+	// Properties declares the keys of a result of type "object".
+	// +optional
+	Properties map[string]PropertySpec `json:"properties,omitempty"`
+
 	// Description is a human-readable description of the result
 	// +optional
 	Description string `json:"description"`
 }
 
+// ResultsType indicates the type of a result;
+// Used to distinguish between a single string and an object.
+type ResultsType string
+
+// Valid ResultsTypes:
+const (
+	ResultsTypeString ResultsType = "string"
+	ResultsTypeObject ResultsType = "object"
+)
+
+// AllResultsTypes can be used for ResultsTypes validation.
+var AllResultsTypes = []ResultsType{ResultsTypeString, ResultsTypeObject}
+
 // Step embeds the Container type, which allows it to include fields not
 // provided by Container.
 type Step struct {
@@ -125,8 +154,49 @@ type Step struct {
 	//
 	// If Script is not empty, the Step cannot have an Command or Args.
 	Script string `json:"script,omitempty"`
+
+	// Timeout is the time after which the step times out. Defaults to never.
+	// Refer to Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// OnError defines the exiting behavior of the step on error. When set to
+	// "continue", the exit code of the step is recorded and the following steps
+	// are executed. Defaults to "stopAndFail", which fails the TaskRun and skips
+	// the following steps.
+	// +optional
+	OnError OnErrorType `json:"onError,omitempty"`
+
+	// ScriptInterpreter is the command, with its arguments, which runs the
+	// script of the Step if it doesn't start with a shebang, overriding the
+	// ScriptInterpreter of the Task. The script file and the args of the Step
+	// are appended to it, and it is looked up in the PATH like env does.
+	// +optional
+	ScriptInterpreter []string `json:"scriptInterpreter,omitempty"`
+
+	// ExecutionMode is set to "hermetic" to run the step without network
+	// access. Defaults to running the step with the network of the Pod.
+	// +optional
+	ExecutionMode ExecutionMode `json:"executionMode,omitempty"`
 }
 
+// OnErrorType defines the exiting behavior of a step on error
+type OnErrorType string
+
+const (
+	// StopAndFail fails the TaskRun and skips the following steps when the step fails
+	StopAndFail OnErrorType = "stopAndFail"
+	// Continue records the exit code of the step when it fails and executes the following steps
+	Continue OnErrorType = "continue"
+)
+
+// ExecutionMode defines how the steps of a TaskRun are isolated when they run
+type ExecutionMode string
+
+// ExecutionModeHermetic runs the steps in their own network namespace, which cuts them off from
+// the network
+const ExecutionModeHermetic ExecutionMode = "hermetic"
+
 // Sidecar embeds the Container type, which allows it to include fields not
 // provided by Container.
 type Sidecar struct {
@@ -157,6 +227,9 @@ type TaskRef struct {
 	// API version of the referent
 	// +optional
 	APIVersion string `json:"apiVersion,omitempty"`
+	// Bundle url reference to a Tekton Bundle.
+	// +optional
+	Bundle string `json:"bundle,omitempty"`
 }
 
 // Check that Pipeline may be validated and defaulted.
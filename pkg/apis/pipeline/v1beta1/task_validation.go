@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
@@ -53,6 +54,9 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	}
 
 	errs = errs.Also(validateSteps(mergedSteps).ViaField("steps"))
+	if len(ts.ScriptInterpreter) > 0 {
+		errs = errs.Also(validateScriptInterpreter(ts.ScriptInterpreter).ViaField("scriptInterpreter"))
+	}
 	errs = errs.Also(ts.Resources.Validate(ctx).ViaField("resources"))
 	errs = errs.Also(ValidateParameterTypes(ts.Params).ViaField("params"))
 	errs = errs.Also(ValidateParameterVariables(ts.Steps, ts.Params))
@@ -73,9 +77,32 @@ func (tr TaskResult) Validate(_ context.Context) *apis.FieldError {
 	if !resultNameFormatRegex.MatchString(tr.Name) {
 		return apis.ErrInvalidKeyName(tr.Name, "name", fmt.Sprintf("Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '%s')", ResultNameFormat))
 	}
+	switch tr.Type {
+	case "", ResultsTypeString:
+		if len(tr.Properties) != 0 {
+			return apis.ErrDisallowedFields("properties")
+		}
+	case ResultsTypeObject:
+		if len(tr.Properties) == 0 {
+			return apis.ErrMissingField("properties")
+		}
+		return validateProperties(tr.Properties)
+	default:
+		return apis.ErrInvalidValue(tr.Type, "type")
+	}
 	return nil
 }
 
+// validateProperties ensures that the keys of an object have a valid type.
+func validateProperties(properties map[string]PropertySpec) (errs *apis.FieldError) {
+	for key, property := range properties {
+		if property.Type != "" && property.Type != ParamTypeString {
+			errs = errs.Also(apis.ErrInvalidValue(property.Type, "type").ViaFieldKey("properties", key))
+		}
+	}
+	return errs
+}
+
 // a mount path which conflicts with any other declared workspaces, with the explicitly
 // declared volume mounts, or with the stepTemplate. The names must also be unique.
 func ValidateDeclaredWorkspaces(workspaces []WorkspaceDeclaration, steps []Step, stepTemplate *corev1.Container) (errs *apis.FieldError) {
@@ -131,6 +158,22 @@ func validateSteps(steps []Step) (errs *apis.FieldError) {
 	return errs
 }
 
+// Validate ensures that the execution mode is either the default one or "hermetic"
+func (em ExecutionMode) Validate() *apis.FieldError {
+	if em != "" && em != ExecutionModeHermetic {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be %s", em, ExecutionModeHermetic), apis.CurrentField)
+	}
+	return nil
+}
+
+// validateScriptInterpreter ensures that a script interpreter names the command to run
+func validateScriptInterpreter(interpreter []string) *apis.FieldError {
+	if strings.TrimSpace(interpreter[0]) == "" {
+		return apis.ErrInvalidValue("the command of the interpreter must not be empty", "[0]")
+	}
+	return nil
+}
+
 func validateStep(s Step, names sets.String) (errs *apis.FieldError) {
 	if s.Image == "" {
 		errs = errs.Also(apis.ErrMissingField("Image"))
@@ -144,6 +187,12 @@ func validateStep(s Step, names sets.String) (errs *apis.FieldError) {
 			})
 		}
 	}
+	if len(s.ScriptInterpreter) > 0 {
+		if s.Script == "" {
+			errs = errs.Also(apis.ErrGeneric("scriptInterpreter can only be used with script", "scriptInterpreter"))
+		}
+		errs = errs.Also(validateScriptInterpreter(s.ScriptInterpreter).ViaField("scriptInterpreter"))
+	}
 
 	if s.Name != "" {
 		if names.Has(s.Name) {
@@ -159,6 +208,20 @@ func validateStep(s Step, names sets.String) (errs *apis.FieldError) {
 		names.Insert(s.Name)
 	}
 
+	if s.Timeout != nil && s.Timeout.Duration < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", s.Timeout.Duration.String()), "timeout"))
+	}
+
+	if s.OnError != "" && s.OnError != StopAndFail && s.OnError != Continue {
+		errs = errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("invalid value: %v", s.OnError),
+			Paths:   []string{"onError"},
+			Details: "Task step onError must be either continue or stopAndFail",
+		})
+	}
+
+	errs = errs.Also(s.ExecutionMode.Validate().ViaField("executionMode"))
+
 	for j, vm := range s.VolumeMounts {
 		if strings.HasPrefix(vm.MountPath, "/tekton/") &&
 			!strings.HasPrefix(vm.MountPath, "/tekton/home") {
@@ -201,22 +264,86 @@ func (p ParamSpec) ValidateType() *apis.FieldError {
 			},
 		}
 	}
-	return nil
+	return p.validateProperties().Also(p.validateConstraints()).ViaField(p.Name)
+}
+
+// validateProperties ensures that the keys of an object param are declared, and that its
+// default value provides all of them.
+func (p ParamSpec) validateProperties() *apis.FieldError {
+	if p.Type != ParamTypeObject {
+		if len(p.Properties) != 0 {
+			return apis.ErrDisallowedFields("properties")
+		}
+		return nil
+	}
+	if len(p.Properties) == 0 {
+		return apis.ErrMissingField("properties")
+	}
+	errs := validateProperties(p.Properties)
+	if p.Default != nil {
+		if missingKeys := p.MissingObjectKeys(ArrayOrString{}); len(missingKeys) != 0 {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("default value is missing the keys %v", missingKeys), "default"))
+		}
+	}
+	return errs
+}
+
+// validateConstraints ensures that the enum and pattern of a param are well-formed, and that its
+// default value satisfies them.
+func (p ParamSpec) validateConstraints() (errs *apis.FieldError) {
+	if len(p.Enum) == 0 && p.Pattern == "" {
+		return nil
+	}
+	if p.Type == ParamTypeObject {
+		if len(p.Enum) != 0 {
+			errs = errs.Also(apis.ErrDisallowedFields("enum"))
+		}
+		if p.Pattern != "" {
+			errs = errs.Also(apis.ErrDisallowedFields("pattern"))
+		}
+		return errs
+	}
+	seen := sets.NewString()
+	for _, v := range p.Enum {
+		if seen.Has(v) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("duplicate value %q", v), "enum"))
+		}
+		seen.Insert(v)
+	}
+	if p.Pattern != "" {
+		if _, err := regexp.Compile(p.Pattern); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s: %v", p.Pattern, err), "pattern"))
+		}
+	}
+	if errs == nil && p.Default != nil {
+		if err := p.ValidateValue(*p.Default); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(err.Error(), "default"))
+		}
+	}
+	return errs
 }
 
 func ValidateParameterVariables(steps []Step, params []ParamSpec) *apis.FieldError {
 	parameterNames := sets.NewString()
 	arrayParameterNames := sets.NewString()
+	objectParameterKeys := map[string]sets.String{}
 
 	for _, p := range params {
 		parameterNames.Insert(p.Name)
-		if p.Type == ParamTypeArray {
+		switch p.Type {
+		case ParamTypeArray:
 			arrayParameterNames.Insert(p.Name)
+		case ParamTypeObject:
+			objectParameterKeys[p.Name] = sets.NewString()
+			for key := range p.Properties {
+				objectParameterKeys[p.Name].Insert(key)
+			}
 		}
 	}
 
 	errs := validateVariables(steps, "params", parameterNames)
-	return errs.Also(validateArrayUsage(steps, "params", arrayParameterNames))
+	errs = errs.Also(validateArrayUsage(steps, "params", arrayParameterNames))
+	return errs.Also(validateObjectUsage(steps, "params", objectParameterKeys))
 }
 
 func validateTaskContextVariables(steps []Step) *apis.FieldError {
@@ -280,6 +407,39 @@ func validateStepArrayUsage(step Step, prefix string, vars sets.String) *apis.Fi
 	return errs
 }
 
+func validateObjectUsage(steps []Step, prefix string, objectKeys map[string]sets.String) (errs *apis.FieldError) {
+	if len(objectKeys) == 0 {
+		return nil
+	}
+	for idx, step := range steps {
+		errs = errs.Also(validateStepObjectUsage(step, prefix, objectKeys).ViaFieldIndex("steps", idx))
+	}
+	return errs
+}
+
+// validateStepObjectUsage ensures that the objects are only referenced through their keys, e.g. $(params.foo.key)
+func validateStepObjectUsage(step Step, prefix string, objectKeys map[string]sets.String) *apis.FieldError {
+	errs := substitution.ValidateObjectKeysP(step.Name, prefix, objectKeys).ViaField("name")
+	errs = errs.Also(substitution.ValidateObjectKeysP(step.Image, prefix, objectKeys).ViaField("image"))
+	errs = errs.Also(substitution.ValidateObjectKeysP(step.WorkingDir, prefix, objectKeys).ViaField("workingDir"))
+	errs = errs.Also(substitution.ValidateObjectKeysP(step.Script, prefix, objectKeys).ViaField("script"))
+	for i, cmd := range step.Command {
+		errs = errs.Also(substitution.ValidateObjectKeysP(cmd, prefix, objectKeys).ViaFieldIndex("command", i))
+	}
+	for i, arg := range step.Args {
+		errs = errs.Also(substitution.ValidateObjectKeysP(arg, prefix, objectKeys).ViaFieldIndex("args", i))
+	}
+	for _, env := range step.Env {
+		errs = errs.Also(substitution.ValidateObjectKeysP(env.Value, prefix, objectKeys).ViaFieldKey("env", env.Name))
+	}
+	for i, v := range step.VolumeMounts {
+		errs = errs.Also(substitution.ValidateObjectKeysP(v.Name, prefix, objectKeys).ViaField("name").ViaFieldIndex("volumeMount", i))
+		errs = errs.Also(substitution.ValidateObjectKeysP(v.MountPath, prefix, objectKeys).ViaField("mountPath").ViaFieldIndex("volumeMount", i))
+		errs = errs.Also(substitution.ValidateObjectKeysP(v.SubPath, prefix, objectKeys).ViaField("subPath").ViaFieldIndex("volumeMount", i))
+	}
+	return errs
+}
+
 func validateVariables(steps []Step, prefix string, vars sets.String) (errs *apis.FieldError) {
 	for idx, step := range steps {
 		errs = errs.Also(validateStepVariables(step, prefix, vars).ViaFieldIndex("steps", idx))
@@ -19,12 +19,14 @@ package v1beta1_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
 
@@ -54,12 +56,13 @@ var invalidSteps = []v1beta1.Step{{Container: corev1.Container{
 
 func TestTaskSpecValidate(t *testing.T) {
 	type fields struct {
-		Params       []v1beta1.ParamSpec
-		Resources    *v1beta1.TaskResources
-		Steps        []v1beta1.Step
-		StepTemplate *corev1.Container
-		Workspaces   []v1beta1.WorkspaceDeclaration
-		Results      []v1beta1.TaskResult
+		Params            []v1beta1.ParamSpec
+		Resources         *v1beta1.TaskResources
+		Steps             []v1beta1.Step
+		StepTemplate      *corev1.Container
+		Workspaces        []v1beta1.WorkspaceDeclaration
+		Results           []v1beta1.TaskResult
+		ScriptInterpreter []string
 	}
 	tests := []struct {
 		name   string
@@ -73,6 +76,31 @@ func TestTaskSpecValidate(t *testing.T) {
 				Image: "myotherimage",
 			}}},
 		},
+	}, {
+		name: "step with timeout",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{
+					Image: "myimage",
+				},
+				Timeout: &metav1.Duration{Duration: time.Minute},
+			}},
+		},
+	}, {
+		name: "step with onError",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{
+					Image: "myimage",
+				},
+				OnError: v1beta1.Continue,
+			}, {
+				Container: corev1.Container{
+					Image: "myotherimage",
+				},
+				OnError: v1beta1.StopAndFail,
+			}},
+		},
 	}, {
 		name: "valid input resources",
 		fields: fields{
@@ -316,16 +344,82 @@ func TestTaskSpecValidate(t *testing.T) {
 				hello "$(context.taskRun.namespace)"`,
 			}},
 		},
+	}, {
+		name: "valid object param",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name:       "repo",
+				Type:       v1beta1.ParamTypeObject,
+				Properties: map[string]v1beta1.PropertySpec{"url": {}, "commit": {}},
+				Default:    v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline", "commit": "main"}),
+			}},
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{
+					Image: "alpine/git",
+					Args:  []string{"clone", "$(params.repo.url)"},
+				},
+				Script: "git checkout $(params.repo.commit)",
+			}},
+		},
+	}, {
+		name: "valid object result",
+		fields: fields{
+			Steps: validSteps,
+			Results: []v1beta1.TaskResult{{
+				Name:       "repo",
+				Type:       v1beta1.ResultsTypeObject,
+				Properties: map[string]v1beta1.PropertySpec{"url": {Type: v1beta1.ParamTypeString}, "commit": {}},
+			}},
+		},
+	}, {
+		name: "valid enum and pattern params",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name:    "env",
+				Type:    v1beta1.ParamTypeString,
+				Enum:    []string{"dev", "staging", "prod"},
+				Default: v1beta1.NewArrayOrString("dev"),
+			}, {
+				Name:    "tags",
+				Type:    v1beta1.ParamTypeArray,
+				Pattern: "^v[0-9]+$",
+				Default: v1beta1.NewArrayOrString("v1", "v2"),
+			}},
+			Steps: validSteps,
+		},
+	}, {
+		name: "valid script interpreters",
+		fields: fields{
+			ScriptInterpreter: []string{"python3", "-u"},
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Image: "python"},
+				Script:    `print("hello")`,
+			}, {
+				Container:         corev1.Container{Image: "node"},
+				Script:            `console.log("hello")`,
+				ScriptInterpreter: []string{"node"},
+			}},
+		},
+	}, {
+		name: "valid hermetic step",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container:     corev1.Container{Image: "golang"},
+				Script:        "go build ./...",
+				ExecutionMode: v1beta1.ExecutionModeHermetic,
+			}},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ts := &v1beta1.TaskSpec{
-				Params:       tt.fields.Params,
-				Resources:    tt.fields.Resources,
-				Steps:        tt.fields.Steps,
-				StepTemplate: tt.fields.StepTemplate,
-				Workspaces:   tt.fields.Workspaces,
-				Results:      tt.fields.Results,
+				Params:            tt.fields.Params,
+				Resources:         tt.fields.Resources,
+				Steps:             tt.fields.Steps,
+				StepTemplate:      tt.fields.StepTemplate,
+				Workspaces:        tt.fields.Workspaces,
+				Results:           tt.fields.Results,
+				ScriptInterpreter: tt.fields.ScriptInterpreter,
 			}
 			ctx := context.Background()
 			ts.SetDefaults(ctx)
@@ -338,13 +432,14 @@ func TestTaskSpecValidate(t *testing.T) {
 
 func TestTaskSpecValidateError(t *testing.T) {
 	type fields struct {
-		Params       []v1beta1.ParamSpec
-		Resources    *v1beta1.TaskResources
-		Steps        []v1beta1.Step
-		Volumes      []corev1.Volume
-		StepTemplate *corev1.Container
-		Workspaces   []v1beta1.WorkspaceDeclaration
-		Results      []v1beta1.TaskResult
+		Params            []v1beta1.ParamSpec
+		Resources         *v1beta1.TaskResources
+		Steps             []v1beta1.Step
+		Volumes           []corev1.Volume
+		StepTemplate      *corev1.Container
+		Workspaces        []v1beta1.WorkspaceDeclaration
+		Results           []v1beta1.TaskResult
+		ScriptInterpreter []string
 	}
 	tests := []struct {
 		name          string
@@ -494,6 +589,193 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Message: `"string" type does not match default value's type: "array"`,
 			Paths:   []string{"params.task.type", "params.task.default.type"},
 		},
+	}, {
+		name: "object param without properties",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name: "repo",
+				Type: v1beta1.ParamTypeObject,
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `missing field(s)`,
+			Paths:   []string{"params.repo.properties"},
+		},
+	}, {
+		name: "properties on a string param",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name:       "repo",
+				Type:       v1beta1.ParamTypeString,
+				Properties: map[string]v1beta1.PropertySpec{"url": {}},
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `must not set the field(s)`,
+			Paths:   []string{"params.repo.properties"},
+		},
+	}, {
+		name: "object param default missing keys",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name:       "repo",
+				Type:       v1beta1.ParamTypeObject,
+				Properties: map[string]v1beta1.PropertySpec{"url": {}, "commit": {}},
+				Default:    v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline"}),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `default value is missing the keys [commit]`,
+			Paths:   []string{"params.repo.default"},
+		},
+	}, {
+		name: "default value not in enum",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name:    "env",
+				Type:    v1beta1.ParamTypeString,
+				Enum:    []string{"dev", "prod"},
+				Default: v1beta1.NewArrayOrString("prdo"),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: value "prdo" of param "env" is not one of the allowed values [dev prod]`,
+			Paths:   []string{"params.env.default"},
+		},
+	}, {
+		name: "default array element not matching pattern",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name:    "tags",
+				Type:    v1beta1.ParamTypeArray,
+				Pattern: "^v[0-9]+$",
+				Default: v1beta1.NewArrayOrString("v1", "latest"),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: value "latest" of param "tags" does not match the pattern "^v[0-9]+$"`,
+			Paths:   []string{"params.tags.default"},
+		},
+	}, {
+		name: "invalid pattern",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name:    "env",
+				Pattern: "(dev",
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: (dev: error parsing regexp: missing closing ): `(dev`",
+			Paths:   []string{"params.env.pattern"},
+		},
+	}, {
+		name: "duplicate enum value",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name: "env",
+				Enum: []string{"dev", "dev"},
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `duplicate value "dev"`,
+			Paths:   []string{"params.env.enum"},
+		},
+	}, {
+		name: "enum on an object param",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name:       "repo",
+				Type:       v1beta1.ParamTypeObject,
+				Properties: map[string]v1beta1.PropertySpec{"url": {}},
+				Enum:       []string{"dev"},
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `must not set the field(s)`,
+			Paths:   []string{"params.repo.enum"},
+		},
+	}, {
+		name: "object param with a non string property",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name:       "repo",
+				Type:       v1beta1.ParamTypeObject,
+				Properties: map[string]v1beta1.PropertySpec{"url": {Type: v1beta1.ParamTypeArray}},
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: array`,
+			Paths:   []string{"params.repo.properties[url].type"},
+		},
+	}, {
+		name: "undeclared key of object param",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name:       "repo",
+				Type:       v1beta1.ParamTypeObject,
+				Properties: map[string]v1beta1.PropertySpec{"url": {}},
+			}},
+			Steps: []v1beta1.Step{{Container: corev1.Container{
+				Image: "alpine/git",
+				Args:  []string{"checkout", "$(params.repo.commit)"},
+			}}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent key "commit" of object "repo" in "$(params.repo.commit)"`,
+			Paths:   []string{"steps[0].args[1]"},
+		},
+	}, {
+		name: "entire object param used in step",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name:       "repo",
+				Type:       v1beta1.ParamTypeObject,
+				Properties: map[string]v1beta1.PropertySpec{"url": {}},
+			}},
+			Steps: []v1beta1.Step{{Container: corev1.Container{
+				Image: "alpine/git",
+				Args:  []string{"$(params.repo[*])"},
+			}}},
+		},
+		expectedError: apis.FieldError{
+			Message: `object variable must reference one of its keys in "$(params.repo[*])"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "object result without properties",
+		fields: fields{
+			Steps: validSteps,
+			Results: []v1beta1.TaskResult{{
+				Name: "repo",
+				Type: v1beta1.ResultsTypeObject,
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `missing field(s)`,
+			Paths:   []string{"results[0].properties"},
+		},
+	}, {
+		name: "invalid result type",
+		fields: fields{
+			Steps: validSteps,
+			Results: []v1beta1.TaskResult{{
+				Name: "repo",
+				Type: "array",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: array`,
+			Paths:   []string{"results[0].type"},
+		},
 	}, {
 		name: "invalid step",
 		fields: fields{
@@ -519,6 +801,49 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Paths:   []string{"steps[0].name"},
 			Details: "Task step name must be a valid DNS Label, For more info refer to https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names",
 		},
+	}, {
+		name: "negative step timeout",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{
+					Name:  "mystep",
+					Image: "myimage",
+				},
+				Timeout: &metav1.Duration{Duration: -10 * time.Second},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: -10s should be >= 0`,
+			Paths:   []string{"steps[0].timeout"},
+		},
+	}, {
+		name: "invalid step onError",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{
+					Name:  "mystep",
+					Image: "myimage",
+				},
+				OnError: "onError",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: onError`,
+			Paths:   []string{"steps[0].onError"},
+			Details: "Task step onError must be either continue or stopAndFail",
+		},
+	}, {
+		name: "invalid step executionMode",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container:     corev1.Container{Image: "myimage"},
+				ExecutionMode: "offline",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: offline should be hermetic",
+			Paths:   []string{"steps[0].executionMode"},
+		},
 	}, {
 		name: "inexistent param variable",
 		fields: fields{
@@ -757,6 +1082,41 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Message: "script cannot be used with command",
 			Paths:   []string{"steps[0].script"},
 		},
+	}, {
+		name: "step with scriptInterpreter and no script",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container:         corev1.Container{Image: "myimage"},
+				ScriptInterpreter: []string{"python3"},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "scriptInterpreter can only be used with script",
+			Paths:   []string{"steps[0].scriptInterpreter"},
+		},
+	}, {
+		name: "step with empty scriptInterpreter command",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container:         corev1.Container{Image: "myimage"},
+				Script:            "script",
+				ScriptInterpreter: []string{"", "-u"},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: the command of the interpreter must not be empty",
+			Paths:   []string{"steps[0].scriptInterpreter[0]"},
+		},
+	}, {
+		name: "task with empty scriptInterpreter command",
+		fields: fields{
+			ScriptInterpreter: []string{" "},
+			Steps:             validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: the command of the interpreter must not be empty",
+			Paths:   []string{"scriptInterpreter[0]"},
+		},
 	}, {
 		name: "step volume mounts under /tekton/",
 		fields: fields{
@@ -934,13 +1294,14 @@ func TestTaskSpecValidateError(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ts := &v1beta1.TaskSpec{
-				Params:       tt.fields.Params,
-				Resources:    tt.fields.Resources,
-				Steps:        tt.fields.Steps,
-				Volumes:      tt.fields.Volumes,
-				StepTemplate: tt.fields.StepTemplate,
-				Workspaces:   tt.fields.Workspaces,
-				Results:      tt.fields.Results,
+				Params:            tt.fields.Params,
+				Resources:         tt.fields.Resources,
+				Steps:             tt.fields.Steps,
+				Volumes:           tt.fields.Volumes,
+				StepTemplate:      tt.fields.StepTemplate,
+				Workspaces:        tt.fields.Workspaces,
+				Results:           tt.fields.Results,
+				ScriptInterpreter: tt.fields.ScriptInterpreter,
 			}
 			ctx := context.Background()
 			ts.SetDefaults(ctx)
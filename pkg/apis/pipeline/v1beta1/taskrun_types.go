@@ -64,6 +64,41 @@ type TaskRunSpec struct {
 	// Workspaces is a list of WorkspaceBindings from volumes to workspaces.
 	// +optional
 	Workspaces []WorkspaceBinding `json:"workspaces,omitempty"`
+	// Debug holds the options to debug the TaskRun interactively
+	// +optional
+	Debug *TaskRunDebug `json:"debug,omitempty"`
+	// ExecutionMode is set to "hermetic" to run all the steps of the Task without
+	// network access. The steps which fetch and upload PipelineResources keep it.
+	// +optional
+	ExecutionMode ExecutionMode `json:"executionMode,omitempty"`
+}
+
+// BreakpointOnFailure is the breakpoint which pauses a step after it fails, keeping its
+// Pod alive so that it can be inspected
+const BreakpointOnFailure = "onFailure"
+
+// TaskRunDebug holds the breakpoints of a TaskRun
+type TaskRunDebug struct {
+	// Breakpoint lists the events on which the steps pause. Only "onFailure" is supported.
+	// +optional
+	Breakpoint []string `json:"breakpoint,omitempty"`
+	// Timeout is how long a step paused on a breakpoint waits to be resumed before it
+	// exits. If not set, the step waits until it is resumed or the TaskRun times out.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// HasBreakpointOnFailure returns true if the steps of the TaskRun pause after failing
+func (trd *TaskRunDebug) HasBreakpointOnFailure() bool {
+	if trd == nil {
+		return false
+	}
+	for _, b := range trd.Breakpoint {
+		if b == BreakpointOnFailure {
+			return true
+		}
+	}
+	return false
 }
 
 // TaskRunSpecStatus defines the taskrun spec status the user can provide
@@ -117,6 +152,12 @@ const (
 	TaskRunReasonCancelled TaskRunReason = "TaskRunCancelled"
 	// TaskRunReasonTimedOut is the reason set when the Taskrun has timed out
 	TaskRunReasonTimedOut TaskRunReason = "TaskRunTimeout"
+	// TaskRunReasonBreakpointHit is the reason set when a failed step of the TaskRun is paused on
+	// a breakpoint, waiting to be resumed
+	TaskRunReasonBreakpointHit TaskRunReason = "BreakpointHit"
+	// TaskRunReasonResultLargerThanAllowedLimit is the reason set when a Task result read from the
+	// results sidecar logs is larger than the max-result-size limit
+	TaskRunReasonResultLargerThanAllowedLimit TaskRunReason = "TaskRunResultLargerThanAllowedLimit"
 )
 
 func (t TaskRunReason) String() string {
@@ -183,6 +224,11 @@ type TaskRunStatusFields struct {
 	// +optional
 	CloudEvents []CloudEventDelivery `json:"cloudEvents,omitempty"`
 
+	// CloudEventSinks describe the delivery of the cloud events of the TaskRun
+	// to each of their sinks.
+	// +optional
+	CloudEventSinks []CloudEventDelivery `json:"cloudEventSinks,omitempty"`
+
 	// RetriesStatus contains the history of TaskRunStatus in case of a retry in order to keep record of failures.
 	// All TaskRunStatus stored in RetriesStatus will have no date within the RetriesStatus as is redundant.
 	// +optional
@@ -261,6 +307,10 @@ type StepState struct {
 	Name                  string `json:"name,omitempty"`
 	ContainerName         string `json:"container,omitempty"`
 	ImageID               string `json:"imageID,omitempty"`
+	// Hermetic is true if the step ran without network access
+	Hermetic bool `json:"hermetic,omitempty"`
+	// LogLocation is where the logs of the step are archived, when log archiving is enabled
+	LogLocation string `json:"logLocation,omitempty"`
 }
 
 // SidecarState reports the results of running a sidecar in a Task.
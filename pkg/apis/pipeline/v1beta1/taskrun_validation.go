@@ -31,6 +31,7 @@ var _ apis.Validatable = (*TaskRun)(nil)
 // Validate taskrun
 func (tr *TaskRun) Validate(ctx context.Context) *apis.FieldError {
 	errs := validate.ObjectMetadata(tr.GetObjectMeta()).ViaField("metadata")
+	errs = errs.Also(validateCloudEventsSink(tr.GetObjectMeta()).ViaField("metadata"))
 	return errs.Also(tr.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 }
 
@@ -46,12 +47,20 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 		errs = errs.Also(apis.ErrMissingField("taskref.name", "taskspec"))
 	}
 
+	// Validate TaskRef if it's present
+	if ts.TaskRef != nil {
+		errs = errs.Also(ts.TaskRef.Validate(ctx).ViaField("taskref"))
+	}
+
 	// Validate TaskSpec if it's present
 	if ts.TaskSpec != nil {
 		errs = errs.Also(ts.TaskSpec.Validate(ctx).ViaField("taskspec"))
 	}
 
 	errs = errs.Also(validateParameters(ts.Params).ViaField("params"))
+	if ts.TaskSpec != nil {
+		errs = errs.Also(ValidateParamValues(ts.TaskSpec.Params, ts.Params).ViaField("params"))
+	}
 	errs = errs.Also(validateWorkspaceBindings(ctx, ts.Workspaces).ViaField("workspaces"))
 	errs = errs.Also(ts.Resources.Validate(ctx).ViaField("resources"))
 
@@ -66,10 +75,28 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", ts.Timeout.Duration.String()), "timeout"))
 		}
 	}
+	errs = errs.Also(ts.Debug.Validate(ctx).ViaField("debug"))
+	errs = errs.Also(ts.ExecutionMode.Validate().ViaField("executionMode"))
 
 	return errs
 }
 
+// Validate validates the breakpoints and the timeout of a TaskRun's debug options
+func (trd *TaskRunDebug) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if trd == nil {
+		return nil
+	}
+	for i, b := range trd.Breakpoint {
+		if b != BreakpointOnFailure {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be %s", b, BreakpointOnFailure), fmt.Sprintf("breakpoint[%d]", i)))
+		}
+	}
+	if trd.Timeout != nil && trd.Timeout.Duration < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", trd.Timeout.Duration.String()), "timeout"))
+	}
+	return errs
+}
+
 // validateWorkspaceBindings makes sure the volumes provided for the Task's declared workspaces make sense.
 func validateWorkspaceBindings(ctx context.Context, wb []WorkspaceBinding) (errs *apis.FieldError) {
 	seen := sets.NewString()
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resource "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
 	"github.com/tektoncd/pipeline/test/diff"
@@ -53,6 +54,18 @@ func TestTaskRun_Invalidate(t *testing.T) {
 			Message: "Invalid resource name: special character . must not be present",
 			Paths:   []string{"metadata.name"},
 		},
+	}, {
+		name: "invalid cloudevents sink",
+		task: &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "taskname",
+				Annotations: map[string]string{"tekton.dev/cloudevents-sink": "events.example.com"},
+			},
+			Spec: v1beta1.TaskRunSpec{
+				TaskRef: &v1beta1.TaskRef{Name: "task"},
+			},
+		},
+		want: apis.ErrInvalidValue(`"events.example.com" must be an absolute URL`, "metadata.annotations.tekton.dev/cloudevents-sink"),
 	}}
 	for _, ts := range tests {
 		t.Run(ts.name, func(t *testing.T) {
@@ -201,6 +214,42 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 			TaskRef: &v1beta1.TaskRef{Name: "mytask"},
 		},
 		wantErr: apis.ErrMultipleOneOf("params[myname].name"),
+	}, {
+		name: "param value not allowed by the taskspec",
+		spec: v1beta1.TaskRunSpec{
+			Params: []v1beta1.Param{{
+				Name:  "env",
+				Value: *v1beta1.NewArrayOrString("prdo"),
+			}},
+			TaskSpec: &v1beta1.TaskSpec{
+				Params: []v1beta1.ParamSpec{{
+					Name: "env",
+					Type: v1beta1.ParamTypeString,
+					Enum: []string{"dev", "prod"},
+				}},
+				Steps: []v1beta1.Step{{Container: corev1.Container{
+					Name:  "mystep",
+					Image: "myimage",
+				}}},
+			},
+		},
+		wantErr: apis.ErrInvalidValue(`value "prdo" of param "env" is not one of the allowed values [dev prod]`, "params[env].value"),
+	}, {
+		name: "invalid breakpoint",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "mytask"},
+			Debug: &v1beta1.TaskRunDebug{
+				Breakpoint: []string{"onSuccess"},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("onSuccess should be onFailure", "debug.breakpoint[0]"),
+	}, {
+		name: "invalid execution mode",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:       &v1beta1.TaskRef{Name: "mytask"},
+			ExecutionMode: "offline",
+		},
+		wantErr: apis.ErrInvalidValue("offline should be hermetic", "executionMode"),
 	}}
 	for _, ts := range tests {
 		t.Run(ts.name, func(t *testing.T) {
@@ -265,6 +314,15 @@ func TestTaskRunSpec_Validate(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		name: "breakpoint on failure",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "mytask"},
+			Debug: &v1beta1.TaskRunDebug{
+				Breakpoint: []string{v1beta1.BreakpointOnFailure},
+				Timeout:    &metav1.Duration{Duration: time.Hour},
+			},
+		},
 	}}
 	for _, ts := range tests {
 		t.Run(ts.name, func(t *testing.T) {
@@ -275,6 +333,52 @@ func TestTaskRunSpec_Validate(t *testing.T) {
 	}
 }
 
+func TestTaskRunSpec_ValidateBundle(t *testing.T) {
+	enabled := config.ToContext(context.Background(), &config.Config{
+		FeatureFlags: &config.FeatureFlags{EnableTektonOCIBundles: true},
+	})
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		spec    v1beta1.TaskRunSpec
+		wantErr *apis.FieldError
+	}{{
+		name: "valid bundle",
+		ctx:  enabled,
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "mytask", Bundle: "docker.io/foo/bar:latest"},
+		},
+	}, {
+		name: "bundle without feature flag",
+		ctx:  context.Background(),
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "mytask", Bundle: "docker.io/foo/bar:latest"},
+		},
+		wantErr: apis.ErrDisallowedFields("taskref.bundle"),
+	}, {
+		name: "invalid bundle reference",
+		ctx:  enabled,
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "mytask", Bundle: "invalid reference"},
+		},
+		wantErr: apis.ErrInvalidValue(`invalid bundle reference "invalid reference": could not parse reference: invalid reference`, "taskref.bundle"),
+	}}
+	for _, ts := range tests {
+		t.Run(ts.name, func(t *testing.T) {
+			err := ts.spec.Validate(ts.ctx)
+			if ts.wantErr == nil {
+				if err != nil {
+					t.Errorf("TaskRunSpec.Validate()/%s error = %v", ts.name, err)
+				}
+				return
+			}
+			if d := cmp.Diff(ts.wantErr.Error(), err.Error()); d != "" {
+				t.Errorf("TaskRunSpec.Validate/%s %s", ts.name, diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestResources_Validate(t *testing.T) {
 	tests := []struct {
 		name      string
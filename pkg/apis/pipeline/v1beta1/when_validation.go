@@ -84,6 +84,18 @@ func (wes WhenExpressions) validatePipelineParametersVariables(prefix string, pa
 	}
 	return errs
 }
+
+// validatePipelineObjectParametersVariables ensures that the object params are referenced through their keys
+func (wes WhenExpressions) validatePipelineObjectParametersVariables(prefix string, objectParamKeys map[string]sets.String) (errs *apis.FieldError) {
+	for idx, we := range wes {
+		errs = errs.Also(substitution.ValidateObjectKeysP(we.Input, prefix, objectParamKeys).ViaField("input").ViaFieldIndex("when", idx))
+		for _, val := range we.Values {
+			errs = errs.Also(substitution.ValidateObjectKeysP(val, prefix, objectParamKeys).ViaField("values").ViaFieldIndex("when", idx))
+		}
+	}
+	return errs
+}
+
 func validateStringVariable(value, prefix string, stringVars sets.String, arrayVars sets.String) *apis.FieldError {
 	errs := substitution.ValidateVariableP(value, prefix, stringVars)
 	return errs.Also(substitution.ValidateVariableProhibitedP(value, prefix, arrayVars))
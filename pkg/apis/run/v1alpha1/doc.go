@@ -0,0 +1,21 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 contains the status types shared by the Run type of the pipeline
+// v1alpha1 API group and the PipelineRun type of the v1beta1 API group. They live
+// in their own package to avoid an import cycle between the two API versions.
+// +k8s:deepcopy-gen=package
+package v1alpha1
@@ -17,10 +17,14 @@ limitations under the License.
 package entrypoint
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
@@ -63,6 +67,20 @@ type Entrypointer struct {
 
 	// Results is the set of files that might contain task results
 	Results []string
+	// Timeout is an optional user-specified duration within which the Step must complete
+	Timeout *time.Duration
+	// OnError defines exiting behavior of the entrypoint
+	// set it to "stopAndFail" to indicate the entrypoint to exit the taskRun if the container exits with non zero exit code
+	// set it to "continue" to indicate the entrypoint to continue executing the rest of the steps irrespective of the container exit code
+	OnError string
+	// BreakpointOnFailure makes the step pause after failing, until it is resumed by writing the
+	// continue file next to PostFile, or until DebugTimeout elapses.
+	BreakpointOnFailure bool
+	// DebugTimeout is an optional duration after which a step paused on a breakpoint exits
+	DebugTimeout *time.Duration
+	// Hermetic indicates that the Runner runs the command without network access. It is
+	// recorded in the termination message once the command ran.
+	Hermetic bool
 }
 
 // Waiter encapsulates waiting for files to exist.
@@ -73,7 +91,8 @@ type Waiter interface {
 
 // Runner encapsulates running commands.
 type Runner interface {
-	Run(args ...string) error
+	// Run runs the command, and stops it when ctx is done.
+	Run(ctx context.Context, args ...string) error
 }
 
 // PostWriter encapsulates writing a file when complete.
@@ -120,7 +139,51 @@ func (e Entrypointer) Go() error {
 		ResultType: v1beta1.InternalTektonResultType,
 	})
 
-	err := e.Runner.Run(e.Args...)
+	var err error
+	if e.Timeout != nil && *e.Timeout < time.Duration(0) {
+		err = fmt.Errorf("negative timeout specified")
+	}
+
+	if err == nil {
+		ctx := context.Background()
+		var cancel context.CancelFunc
+		if e.Timeout != nil && *e.Timeout != time.Duration(0) {
+			ctx, cancel = context.WithTimeout(ctx, *e.Timeout)
+			defer cancel()
+		}
+		err = e.Runner.Run(ctx, e.Args...)
+		if err == context.DeadlineExceeded {
+			output = append(output, v1beta1.PipelineResourceResult{
+				Key:        "Reason",
+				Value:      "TimeoutExceeded",
+				ResultType: v1beta1.InternalTektonResultType,
+			})
+		}
+	}
+
+	var ee *exec.ExitError
+	if e.Hermetic && (err == nil || err == context.DeadlineExceeded || errors.As(err, &ee)) {
+		// The command started, so it ran without network access
+		output = append(output, v1beta1.PipelineResourceResult{
+			Key:        "Hermetic",
+			Value:      "true",
+			ResultType: v1beta1.InternalTektonResultType,
+		})
+	}
+	if err != nil && e.OnError == string(v1beta1.Continue) && errors.As(err, &ee) {
+		// Record the exit code and write a normal post file, so that the next steps run
+		logger.Infof("Step exited with code %d, continuing with the next steps", ee.ExitCode())
+		output = append(output, v1beta1.PipelineResourceResult{
+			Key:        "ExitCode",
+			Value:      strconv.Itoa(ee.ExitCode()),
+			ResultType: v1beta1.InternalTektonResultType,
+		})
+		err = nil
+	}
+
+	if err != nil && e.BreakpointOnFailure {
+		e.waitOnBreakpoint(logger, err)
+	}
 
 	// Write the post file *no matter what*
 	e.WritePostFile(e.PostFile, err)
@@ -164,6 +227,47 @@ func (e Entrypointer) readResultsFromDisk() error {
 	return nil
 }
 
+// BreakpointFile returns the file written by the step writing postFile when it is paused on a
+// breakpoint.
+func BreakpointFile(postFile string) string {
+	return postFile + ".breakpoint"
+}
+
+// ContinueFile returns the file which resumes the step writing postFile when it is paused on a
+// breakpoint.
+func ContinueFile(postFile string) string {
+	return postFile + ".continue"
+}
+
+// waitOnBreakpoint pauses the failed step, keeping its container and the Pod alive for
+// inspection, until it is resumed or the debug timeout elapses.
+func (e Entrypointer) waitOnBreakpoint(logger *zap.SugaredLogger, stepErr error) {
+	if e.PostFile == "" {
+		return
+	}
+	logger.Infof("Step failed with %v, pausing on breakpoint until %s is written", stepErr, ContinueFile(e.PostFile))
+	e.PostWriter.Write(BreakpointFile(e.PostFile))
+
+	resumed := make(chan error, 1)
+	go func() {
+		resumed <- e.Waiter.Wait(ContinueFile(e.PostFile), false)
+	}()
+	var timeout <-chan time.Time
+	if e.DebugTimeout != nil && *e.DebugTimeout > time.Duration(0) {
+		timeout = time.After(*e.DebugTimeout)
+	}
+	select {
+	case err := <-resumed:
+		if err != nil {
+			logger.Errorf("Error waiting to resume from breakpoint: %v", err)
+		} else {
+			logger.Info("Resuming from breakpoint")
+		}
+	case <-timeout:
+		logger.Infof("Debug timeout of %s elapsed, resuming from breakpoint", e.DebugTimeout)
+	}
+}
+
 // WritePostFile write the postfile
 func (e Entrypointer) WritePostFile(postFile string, err error) {
 	if err != nil && postFile != "" {
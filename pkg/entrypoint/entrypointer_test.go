@@ -17,12 +17,15 @@ limitations under the License.
 package entrypoint
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
@@ -205,6 +208,287 @@ func TestEntrypointer(t *testing.T) {
 	}
 }
 
+func TestEntrypointer_Timeout(t *testing.T) {
+	for _, c := range []struct {
+		desc          string
+		timeout       time.Duration
+		runner        Runner
+		expectedError string
+		wantReason    bool
+	}{{
+		desc:          "step timing out",
+		timeout:       10 * time.Millisecond,
+		runner:        &fakeLongRunner{},
+		expectedError: context.DeadlineExceeded.Error(),
+		wantReason:    true,
+	}, {
+		desc:    "step completing within its timeout",
+		timeout: time.Minute,
+		runner:  &fakeRunner{},
+	}, {
+		desc:          "negative timeout",
+		timeout:       -10 * time.Second,
+		runner:        &fakeRunner{},
+		expectedError: "negative timeout specified",
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			terminationPath := "termination"
+			if terminationFile, err := ioutil.TempFile("", "termination"); err != nil {
+				t.Fatalf("unexpected error creating temporary termination file: %v", err)
+			} else {
+				terminationPath = terminationFile.Name()
+				defer os.Remove(terminationFile.Name())
+			}
+			fpw := &fakePostWriter{}
+			err := Entrypointer{
+				Entrypoint:      "sleep",
+				Args:            []string{"3600"},
+				Waiter:          &fakeWaiter{},
+				Runner:          c.runner,
+				PostWriter:      fpw,
+				PostFile:        "writeme",
+				TerminationPath: terminationPath,
+				Timeout:         &c.timeout,
+			}.Go()
+			if c.expectedError == "" && err != nil {
+				t.Fatalf("Entrypointer failed: %v", err)
+			}
+			if c.expectedError != "" {
+				if err == nil {
+					t.Fatalf("Entrypointer didn't fail")
+				}
+				if d := cmp.Diff(c.expectedError, err.Error()); d != "" {
+					t.Errorf("Entrypointer error diff %s", diff.PrintWantGot(d))
+				}
+				if fpw.wrote == nil || *fpw.wrote != "writeme.err" {
+					t.Errorf("Wanted post file writeme.err written, got %v", fpw.wrote)
+				}
+			}
+
+			fileContents, err := ioutil.ReadFile(terminationPath)
+			if err != nil {
+				t.Fatalf("unexpected error reading termination file: %v", err)
+			}
+			var entries []v1alpha1.PipelineResourceResult
+			if err := json.Unmarshal(fileContents, &entries); err != nil {
+				t.Fatalf("unexpected error parsing termination file: %v", err)
+			}
+			foundReason := false
+			for _, result := range entries {
+				if result.Key == "Reason" && result.Value == "TimeoutExceeded" {
+					foundReason = true
+				}
+			}
+			if foundReason != c.wantReason {
+				t.Errorf("Expected the TimeoutExceeded reason to be written %t, got %s", c.wantReason, fileContents)
+			}
+		})
+	}
+}
+
+func TestEntrypointer_OnError(t *testing.T) {
+	for _, c := range []struct {
+		desc, onError    string
+		runner           Runner
+		expectedError    string
+		expectedPostFile string
+		wantExitCode     string
+	}{{
+		desc:             "continue on error",
+		onError:          "continue",
+		runner:           &fakeExitErrorRunner{},
+		expectedPostFile: "writeme",
+		wantExitCode:     "3",
+	}, {
+		desc:             "stop and fail on error",
+		onError:          "stopAndFail",
+		runner:           &fakeExitErrorRunner{},
+		expectedError:    "exit status 3",
+		expectedPostFile: "writeme.err",
+	}, {
+		desc:             "continue on an error other than an exit code",
+		onError:          "continue",
+		runner:           &fakeErrorRunner{},
+		expectedError:    "runner failed",
+		expectedPostFile: "writeme.err",
+	}, {
+		desc:             "continue without error",
+		onError:          "continue",
+		runner:           &fakeRunner{},
+		expectedPostFile: "writeme",
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			terminationFile, err := ioutil.TempFile("", "termination")
+			if err != nil {
+				t.Fatalf("unexpected error creating temporary termination file: %v", err)
+			}
+			defer os.Remove(terminationFile.Name())
+			fpw := &fakePostWriter{}
+			err = Entrypointer{
+				Entrypoint:      "lint",
+				Waiter:          &fakeWaiter{},
+				Runner:          c.runner,
+				PostWriter:      fpw,
+				PostFile:        "writeme",
+				TerminationPath: terminationFile.Name(),
+				OnError:         c.onError,
+			}.Go()
+			if c.expectedError == "" && err != nil {
+				t.Fatalf("Entrypointer failed: %v", err)
+			}
+			if c.expectedError != "" {
+				if err == nil {
+					t.Fatalf("Entrypointer didn't fail")
+				}
+				if d := cmp.Diff(c.expectedError, err.Error()); d != "" {
+					t.Errorf("Entrypointer error diff %s", diff.PrintWantGot(d))
+				}
+			}
+			if fpw.wrote == nil || *fpw.wrote != c.expectedPostFile {
+				t.Errorf("Wanted post file %s written, got %v", c.expectedPostFile, fpw.wrote)
+			}
+
+			fileContents, err := ioutil.ReadFile(terminationFile.Name())
+			if err != nil {
+				t.Fatalf("unexpected error reading termination file: %v", err)
+			}
+			var entries []v1alpha1.PipelineResourceResult
+			if err := json.Unmarshal(fileContents, &entries); err != nil {
+				t.Fatalf("unexpected error parsing termination file: %v", err)
+			}
+			exitCode := ""
+			for _, result := range entries {
+				if result.Key == "ExitCode" {
+					exitCode = result.Value
+				}
+			}
+			if d := cmp.Diff(c.wantExitCode, exitCode); d != "" {
+				t.Errorf("Unexpected exit code recorded %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestEntrypointer_Hermetic(t *testing.T) {
+	for _, c := range []struct {
+		desc         string
+		hermetic     bool
+		runner       Runner
+		wantHermetic bool
+	}{{
+		desc:         "hermetic step",
+		hermetic:     true,
+		runner:       &fakeRunner{},
+		wantHermetic: true,
+	}, {
+		desc:         "hermetic step exiting with an error",
+		hermetic:     true,
+		runner:       &fakeExitErrorRunner{},
+		wantHermetic: true,
+	}, {
+		desc:     "hermetic step which couldn't start",
+		hermetic: true,
+		runner:   &fakeErrorRunner{},
+	}, {
+		desc:   "step with network access",
+		runner: &fakeRunner{},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			terminationFile, err := ioutil.TempFile("", "termination")
+			if err != nil {
+				t.Fatalf("unexpected error creating temporary termination file: %v", err)
+			}
+			defer os.Remove(terminationFile.Name())
+			_ = Entrypointer{
+				Entrypoint:      "build",
+				Waiter:          &fakeWaiter{},
+				Runner:          c.runner,
+				PostWriter:      &fakePostWriter{},
+				PostFile:        "writeme",
+				TerminationPath: terminationFile.Name(),
+				Hermetic:        c.hermetic,
+			}.Go()
+
+			fileContents, err := ioutil.ReadFile(terminationFile.Name())
+			if err != nil {
+				t.Fatalf("unexpected error reading termination file: %v", err)
+			}
+			var entries []v1alpha1.PipelineResourceResult
+			if err := json.Unmarshal(fileContents, &entries); err != nil {
+				t.Fatalf("unexpected error parsing termination file: %v", err)
+			}
+			hermetic := false
+			for _, result := range entries {
+				if result.Key == "Hermetic" && result.Value == "true" {
+					hermetic = true
+				}
+			}
+			if hermetic != c.wantHermetic {
+				t.Errorf("Expected the step to be recorded as hermetic: %t, got %t", c.wantHermetic, hermetic)
+			}
+		})
+	}
+}
+
+func TestEntrypointer_BreakpointOnFailure(t *testing.T) {
+	debugTimeout := 10 * time.Millisecond
+	for _, c := range []struct {
+		desc           string
+		runner         Runner
+		waiter         Waiter
+		debugTimeout   *time.Duration
+		wantPostFiles  []string
+		wantWaitedFile string
+	}{{
+		desc:          "successful step does not pause",
+		runner:        &fakeRunner{},
+		waiter:        &fakeWaiter{},
+		wantPostFiles: []string{"writeme"},
+	}, {
+		desc:           "failed step pauses until resumed",
+		runner:         &fakeErrorRunner{},
+		waiter:         &fakeWaiter{},
+		wantPostFiles:  []string{"writeme.breakpoint", "writeme.err"},
+		wantWaitedFile: "writeme.continue",
+	}, {
+		desc:          "failed step pauses until the debug timeout elapses",
+		runner:        &fakeErrorRunner{},
+		waiter:        &fakeBlockingWaiter{},
+		debugTimeout:  &debugTimeout,
+		wantPostFiles: []string{"writeme.breakpoint", "writeme.err"},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			terminationFile, err := ioutil.TempFile("", "termination")
+			if err != nil {
+				t.Fatalf("unexpected error creating temporary termination file: %v", err)
+			}
+			defer os.Remove(terminationFile.Name())
+
+			fpw := &fakeRecordingPostWriter{}
+			_ = Entrypointer{
+				Entrypoint:          "echo",
+				Args:                []string{"some", "args"},
+				Waiter:              c.waiter,
+				Runner:              c.runner,
+				PostWriter:          fpw,
+				PostFile:            "writeme",
+				TerminationPath:     terminationFile.Name(),
+				BreakpointOnFailure: true,
+				DebugTimeout:        c.debugTimeout,
+			}.Go()
+
+			if d := cmp.Diff(c.wantPostFiles, fpw.wrote); d != "" {
+				t.Errorf("Unexpected post files written %s", diff.PrintWantGot(d))
+			}
+			if fw, ok := c.waiter.(*fakeWaiter); ok && c.wantWaitedFile != "" {
+				if d := cmp.Diff([]string{c.wantWaitedFile}, fw.waited); d != "" {
+					t.Errorf("Unexpected files waited for %s", diff.PrintWantGot(d))
+				}
+			}
+		})
+	}
+}
+
 type fakeWaiter struct{ waited []string }
 
 func (f *fakeWaiter) Wait(file string, _ bool) error {
@@ -214,11 +498,24 @@ func (f *fakeWaiter) Wait(file string, _ bool) error {
 
 type fakeRunner struct{ args *[]string }
 
-func (f *fakeRunner) Run(args ...string) error {
+func (f *fakeRunner) Run(ctx context.Context, args ...string) error {
 	f.args = &args
 	return nil
 }
 
+type fakeLongRunner struct{}
+
+func (f *fakeLongRunner) Run(ctx context.Context, args ...string) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+type fakeExitErrorRunner struct{}
+
+func (f *fakeExitErrorRunner) Run(ctx context.Context, args ...string) error {
+	return exec.Command("sh", "-c", "exit 3").Run()
+}
+
 type fakePostWriter struct{ wrote *string }
 
 func (f *fakePostWriter) Write(file string) { f.wrote = &file }
@@ -232,7 +529,17 @@ func (f *fakeErrorWaiter) Wait(file string, expectContent bool) error {
 
 type fakeErrorRunner struct{ args *[]string }
 
-func (f *fakeErrorRunner) Run(args ...string) error {
+func (f *fakeErrorRunner) Run(ctx context.Context, args ...string) error {
 	f.args = &args
 	return errors.New("runner failed")
 }
+
+type fakeBlockingWaiter struct{}
+
+func (f *fakeBlockingWaiter) Wait(file string, expectContent bool) error {
+	select {}
+}
+
+type fakeRecordingPostWriter struct{ wrote []string }
+
+func (f *fakeRecordingPostWriter) Write(file string) { f.wrote = append(f.wrote, file) }
@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logarchive
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/option"
+)
+
+// gcsSink uploads the logs to a GCS bucket.
+type gcsSink struct {
+	client *storage.Client
+	bucket string
+}
+
+var _ Sink = (*gcsSink)(nil)
+
+func newGCSSink(ctx context.Context, bucket string, serviceAccountKey []byte, opts ...option.ClientOption) (*gcsSink, error) {
+	if serviceAccountKey != nil {
+		opts = append(opts, option.WithCredentialsJSON(serviceAccountKey))
+	}
+	client, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the GCS client: %w", err)
+	}
+	return &gcsSink{client: client, bucket: bucket}, nil
+}
+
+// Put uploads the logs as an object of the bucket, and returns its location as
+// gs://<bucket>/<key>.
+func (s *gcsSink) Put(ctx context.Context, key string, r io.Reader) (string, error) {
+	w := s.client.Bucket(s.bucket).Object(key).NewWriter(ctx)
+	w.ContentType = "text/plain; charset=utf-8"
+	if _, err := io.Copy(w, r); err != nil {
+		w.Close()
+		return "", fmt.Errorf("failed to upload the logs to gs://%s/%s: %w", s.bucket, key, err)
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("failed to upload the logs to gs://%s/%s: %w", s.bucket, key, err)
+	}
+	return fmt.Sprintf("gs://%s/%s", s.bucket, key), nil
+}
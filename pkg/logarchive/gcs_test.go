@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logarchive
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/option"
+)
+
+func TestGCSSinkPut(t *testing.T) {
+	logs := "compiling...\ndone\n"
+	var gotPath, gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		gotPath, gotBody = r.URL.Path, string(body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"bucket": "tekton-logs", "name": "foo/build/compile.log"}`))
+	}))
+	defer srv.Close()
+
+	sink, err := newGCSSink(context.Background(), "tekton-logs", nil,
+		option.WithEndpoint(srv.URL+"/storage/v1/"), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("newGCSSink() = %v", err)
+	}
+
+	location, err := sink.Put(context.Background(), "foo/build/compile.log", strings.NewReader(logs))
+	if err != nil {
+		t.Fatalf("Put() = %v", err)
+	}
+	if want := "gs://tekton-logs/foo/build/compile.log"; location != want {
+		t.Errorf("Put() location = %q, want %q", location, want)
+	}
+	if want := "/upload/storage/v1/b/tekton-logs/o"; gotPath != want {
+		t.Errorf("Expected the logs to be uploaded to %s, got %s", want, gotPath)
+	}
+	// The object is uploaded as a multipart body, with its metadata and content.
+	if !strings.Contains(gotBody, "foo/build/compile.log") || !strings.Contains(gotBody, logs) {
+		t.Errorf("Expected the upload to contain the name and logs of the object, got %q", gotBody)
+	}
+}
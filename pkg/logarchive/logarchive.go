@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logarchive archives the logs of the steps of TaskRuns, so that they can be
+// fetched once the pods of the TaskRuns are deleted.
+package logarchive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/system"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// S3AccessKeyIDKey is the key of the Secret holding the access key ID of the
+	// S3-compatible endpoint
+	S3AccessKeyIDKey = "aws_access_key_id"
+
+	// S3SecretAccessKeyKey is the key of the Secret holding the secret access key of the
+	// S3-compatible endpoint
+	S3SecretAccessKeyKey = "aws_secret_access_key"
+
+	// ArchivedAnnotation marks the TaskRuns whose logs are archived
+	ArchivedAnnotation = pipeline.GroupName + "/logs-archived"
+)
+
+// Sink stores archived logs.
+type Sink interface {
+	// Put stores the logs read from r under key, and returns their location.
+	Put(ctx context.Context, key string, r io.Reader) (string, error)
+}
+
+// NewSink returns the Sink configured in cfg. The Secrets holding the credentials of the
+// sink are read from the namespace of the controller.
+func NewSink(ctx context.Context, cfg *config.LogArchive, kubeclient kubernetes.Interface) (Sink, error) {
+	switch cfg.Sink {
+	case config.LogArchiveSinkPVC:
+		return &pvcSink{claimName: cfg.PVCClaimName, mountPath: cfg.PVCMountPath}, nil
+	case config.LogArchiveSinkS3:
+		secret, err := kubeclient.CoreV1().Secrets(system.GetNamespace()).Get(cfg.S3SecretName, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error getting the log archive secret %q: %w", cfg.S3SecretName, err)
+		}
+		return newS3Sink(cfg.S3Endpoint, cfg.S3Region, cfg.S3Bucket,
+			string(secret.Data[S3AccessKeyIDKey]), string(secret.Data[S3SecretAccessKeyKey]))
+	case config.LogArchiveSinkGCS:
+		secret, err := kubeclient.CoreV1().Secrets(system.GetNamespace()).Get(cfg.GCSSecretName, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error getting the log archive secret %q: %w", cfg.GCSSecretName, err)
+		}
+		key, ok := secret.Data[cfg.GCSSecretKey]
+		if !ok {
+			return nil, fmt.Errorf("the log archive secret %q has no key %q", cfg.GCSSecretName, cfg.GCSSecretKey)
+		}
+		return newGCSSink(ctx, cfg.GCSBucket, key)
+	}
+	return nil, fmt.Errorf("unknown log archive sink %q", cfg.Sink)
+}
+
+// Key returns the key the logs of a step of a TaskRun are archived under. It includes the
+// UID of the TaskRun, so that the logs of a TaskRun recreated with the same name don't
+// overwrite the logs archived before.
+func Key(tr *v1beta1.TaskRun, step string) string {
+	return path.Join(tr.Namespace, tr.Name, string(tr.UID), step+".log")
+}
+
+// IsArchived returns true if the logs of the TaskRun are archived.
+func IsArchived(tr *v1beta1.TaskRun) bool {
+	_, ok := tr.Annotations[ArchivedAnnotation]
+	return ok
+}
+
+// MarkArchived marks the logs of the TaskRun as archived in its annotations, so that they
+// aren't archived again.
+func MarkArchived(tr *v1beta1.TaskRun) {
+	if tr.Annotations == nil {
+		tr.Annotations = map[string]string{}
+	}
+	tr.Annotations[ArchivedAnnotation] = "true"
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logarchive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/system"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestKey(t *testing.T) {
+	tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "foo", UID: "1234"}}
+	if got, want := Key(tr, "compile"), "foo/build/1234/compile.log"; got != want {
+		t.Errorf("Key() = %q, want %q", got, want)
+	}
+}
+
+func TestNewSink(t *testing.T) {
+	kubeclient := fakek8s.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3-credentials", Namespace: system.GetNamespace()},
+		Data: map[string][]byte{
+			S3AccessKeyIDKey:     []byte("AKIDEXAMPLE"),
+			S3SecretAccessKeyKey: []byte("secret"),
+		},
+	}, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "incomplete-s3-credentials", Namespace: system.GetNamespace()},
+		Data: map[string][]byte{
+			S3AccessKeyIDKey: []byte("AKIDEXAMPLE"),
+		},
+	}, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "gcs-credentials", Namespace: system.GetNamespace()},
+		Data: map[string][]byte{
+			"other.json": []byte("{}"),
+		},
+	})
+
+	for _, tc := range []struct {
+		desc    string
+		cfg     *config.LogArchive
+		wantErr bool
+	}{{
+		desc: "pvc",
+		cfg:  &config.LogArchive{Sink: config.LogArchiveSinkPVC, PVCClaimName: "logs", PVCMountPath: "/logs"},
+	}, {
+		desc: "s3",
+		cfg: &config.LogArchive{Sink: config.LogArchiveSinkS3, S3Endpoint: "https://s3.example.com",
+			S3Region: "us-east-1", S3Bucket: "logs", S3SecretName: "s3-credentials"},
+	}, {
+		desc: "s3 without secret",
+		cfg: &config.LogArchive{Sink: config.LogArchiveSinkS3, S3Endpoint: "https://s3.example.com",
+			S3Region: "us-east-1", S3Bucket: "logs", S3SecretName: "missing"},
+		wantErr: true,
+	}, {
+		desc: "s3 without secret access key",
+		cfg: &config.LogArchive{Sink: config.LogArchiveSinkS3, S3Endpoint: "https://s3.example.com",
+			S3Region: "us-east-1", S3Bucket: "logs", S3SecretName: "incomplete-s3-credentials"},
+		wantErr: true,
+	}, {
+		desc: "s3 with invalid endpoint",
+		cfg: &config.LogArchive{Sink: config.LogArchiveSinkS3, S3Endpoint: "s3.example.com",
+			S3Region: "us-east-1", S3Bucket: "logs", S3SecretName: "s3-credentials"},
+		wantErr: true,
+	}, {
+		desc: "gcs without secret key",
+		cfg: &config.LogArchive{Sink: config.LogArchiveSinkGCS, GCSBucket: "logs",
+			GCSSecretName: "gcs-credentials", GCSSecretKey: "service_account.json"},
+		wantErr: true,
+	}, {
+		desc:    "unknown sink",
+		cfg:     &config.LogArchive{Sink: "ftp"},
+		wantErr: true,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			sink, err := NewSink(context.Background(), tc.cfg, kubeclient)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got sink %v", sink)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewSink() = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logarchive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// pvcSink writes the logs to a PersistentVolumeClaim mounted in the controller.
+type pvcSink struct {
+	claimName string
+	mountPath string
+}
+
+var _ Sink = (*pvcSink)(nil)
+
+// Put writes the logs to a file of the claim, and returns its location as
+// pvc://<claim>/<key>. The file is written to a temporary file first, so that
+// partially written logs are never visible.
+func (s *pvcSink) Put(_ context.Context, key string, r io.Reader) (string, error) {
+	dst := filepath.Join(s.mountPath, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return "", fmt.Errorf("failed to create the directory of %s: %w", dst, err)
+	}
+	f, err := ioutil.TempFile(filepath.Dir(dst), ".tmp-")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return "", fmt.Errorf("failed to write %s: %w", dst, err)
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		return "", err
+	}
+	if err := os.Rename(f.Name(), dst); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", dst, err)
+	}
+	return fmt.Sprintf("pvc://%s/%s", s.claimName, key), nil
+}
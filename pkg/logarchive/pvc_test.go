@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logarchive
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPVCSinkPut(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	sink := &pvcSink{claimName: "tekton-logs", mountPath: dir}
+
+	for _, logs := range []string{"first attempt\n", "second attempt\n"} {
+		location, err := sink.Put(context.Background(), "foo/build/compile.log", strings.NewReader(logs))
+		if err != nil {
+			t.Fatalf("Put() = %v", err)
+		}
+		if want := "pvc://tekton-logs/foo/build/compile.log"; location != want {
+			t.Errorf("Put() location = %q, want %q", location, want)
+		}
+		got, err := ioutil.ReadFile(filepath.Join(dir, "foo", "build", "compile.log"))
+		if err != nil {
+			t.Fatalf("Expected the logs to be written: %v", err)
+		}
+		if string(got) != logs {
+			t.Errorf("Expected the logs to be %q, got %q", logs, got)
+		}
+	}
+
+	files, err := ioutil.ReadDir(filepath.Join(dir, "foo", "build"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("Expected no temporary file to be left, got %d files", len(files))
+	}
+}
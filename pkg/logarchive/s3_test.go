@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logarchive
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSigningKey(t *testing.T) {
+	// Example of the AWS Signature Version 4 documentation.
+	got := hex.EncodeToString(signingKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam"))
+	if want := "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d"; got != want {
+		t.Errorf("signingKey() = %s, want %s", got, want)
+	}
+}
+
+func TestS3SinkPut(t *testing.T) {
+	logs := "compiling...\ndone\n"
+	var gotPath, gotBody string
+	var gotHeaders http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Expected a PUT request, got %s", r.Method)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		gotPath, gotBody, gotHeaders = r.URL.Path, string(body), r.Header
+	}))
+	defer srv.Close()
+
+	sink, err := newS3Sink(srv.URL+"/s3", "eu-west-1", "tekton-logs", "AKIDEXAMPLE", "secret")
+	if err != nil {
+		t.Fatalf("newS3Sink() = %v", err)
+	}
+	sink.now = func() time.Time { return time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	location, err := sink.Put(context.Background(), "foo/build/compile.log", strings.NewReader(logs))
+	if err != nil {
+		t.Fatalf("Put() = %v", err)
+	}
+	if want := "s3://tekton-logs/foo/build/compile.log"; location != want {
+		t.Errorf("Put() location = %q, want %q", location, want)
+	}
+	if want := "/s3/tekton-logs/foo/build/compile.log"; gotPath != want {
+		t.Errorf("Expected the logs to be uploaded to %s, got %s", want, gotPath)
+	}
+	if gotBody != logs {
+		t.Errorf("Expected the logs %q to be uploaded, got %q", logs, gotBody)
+	}
+	sum := sha256.Sum256([]byte(logs))
+	if got, want := gotHeaders.Get("X-Amz-Content-Sha256"), hex.EncodeToString(sum[:]); got != want {
+		t.Errorf("Expected the payload hash %s, got %s", want, got)
+	}
+	if got, want := gotHeaders.Get("X-Amz-Date"), "20200102T030405Z"; got != want {
+		t.Errorf("Expected the date %s, got %s", want, got)
+	}
+	wantAuth := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20200102/eu-west-1/s3/aws4_request, " +
+		"SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date, Signature="
+	if got := gotHeaders.Get("Authorization"); !strings.HasPrefix(got, wantAuth) || len(got) != len(wantAuth)+64 {
+		t.Errorf("Expected the authorization to match %s<signature>, got %s", wantAuth, got)
+	}
+}
+
+func TestS3SinkPut_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "<Error><Code>AccessDenied</Code></Error>", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	sink, err := newS3Sink(srv.URL, "us-east-1", "tekton-logs", "AKIDEXAMPLE", "secret")
+	if err != nil {
+		t.Fatalf("newS3Sink() = %v", err)
+	}
+	_, err = sink.Put(context.Background(), "foo/build/compile.log", strings.NewReader("logs"))
+	if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Errorf("Expected an AccessDenied error, got %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logarchive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/logging"
+)
+
+func init() {
+	injection.Default.RegisterClient(withStreamer)
+}
+
+// StreamerKey is used to associate the Streamer inside the context.Context
+type StreamerKey struct{}
+
+// Streamer streams the logs of the containers of pods.
+type Streamer interface {
+	// Stream returns the logs of a container of a pod.
+	Stream(namespace, pod, container string) (io.ReadCloser, error)
+}
+
+type kubeStreamer struct {
+	kubeclient kubernetes.Interface
+}
+
+// Stream returns the logs of a container of a pod, read from the Kubernetes API.
+func (s kubeStreamer) Stream(namespace, pod, container string) (io.ReadCloser, error) {
+	return s.kubeclient.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{Container: container}).Stream()
+}
+
+// withStreamer streams the logs with the Kubernetes client injected in the context, which
+// is registered before the Streamer since this package imports the client injection.
+func withStreamer(ctx context.Context, cfg *rest.Config) context.Context {
+	return ToContext(ctx, kubeStreamer{kubeclient: kubeclient.Get(ctx)})
+}
+
+// Get extracts the Streamer from the context.
+func Get(ctx context.Context) Streamer {
+	untyped := ctx.Value(StreamerKey{})
+	if untyped == nil {
+		logging.FromContext(ctx).Errorf(
+			"Unable to fetch log streamer from context.")
+		return nil
+	}
+	return untyped.(Streamer)
+}
+
+// ToContext adds the Streamer to the context
+func ToContext(ctx context.Context, s Streamer) context.Context {
+	return context.WithValue(ctx, StreamerKey{}, s)
+}
+
+// FakeStreamer is a fake Streamer for unit testing, which returns the same
+// logs for every container.
+type FakeStreamer struct{}
+
+// Stream returns the fake logs of a container of a pod.
+func (FakeStreamer) Stream(namespace, pod, container string) (io.ReadCloser, error) {
+	return ioutil.NopCloser(strings.NewReader(FakeLogs(namespace, pod, container))), nil
+}
+
+// FakeLogs returns the logs the FakeStreamer returns for a container of a pod.
+func FakeLogs(namespace, pod, container string) string {
+	return fmt.Sprintf("logs of %s/%s/%s\n", namespace, pod, container)
+}
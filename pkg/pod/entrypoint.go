@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -86,7 +87,7 @@ var (
 // method, using entrypoint_lookup.go.
 //
 // TODO(#1605): Also use entrypoint injection to order sidecar start/stop.
-func orderContainers(entrypointImage string, extraEntrypointArgs []string, steps []corev1.Container, results []v1beta1.TaskResult) (corev1.Container, []corev1.Container, error) {
+func orderContainers(entrypointImage string, extraEntrypointArgs []string, steps []corev1.Container, taskSpec *v1beta1.TaskSpec, debug *v1beta1.TaskRunDebug) (corev1.Container, []corev1.Container, error) {
 	initContainer := corev1.Container{
 		Name:         "place-tools",
 		Image:        entrypointImage,
@@ -107,19 +108,50 @@ func orderContainers(entrypointImage string, extraEntrypointArgs []string, steps
 				"-wait_file", filepath.Join(downwardMountPoint, downwardMountReadyFile),
 				"-wait_file_content", // Wait for file contents, not just an empty file.
 				// Start next step.
-				"-post_file", filepath.Join(mountPoint, fmt.Sprintf("%d", i)),
+				"-post_file", postFile(i),
 				"-termination_path", terminationPath,
 			}
 		default:
 			// All other steps wait for previous file, write next file.
 			argsForEntrypoint = []string{
-				"-wait_file", filepath.Join(mountPoint, fmt.Sprintf("%d", i-1)),
-				"-post_file", filepath.Join(mountPoint, fmt.Sprintf("%d", i)),
+				"-wait_file", postFile(i - 1),
+				"-post_file", postFile(i),
 				"-termination_path", terminationPath,
 			}
 		}
 		argsForEntrypoint = append(argsForEntrypoint, extraEntrypointArgs...)
-		argsForEntrypoint = append(argsForEntrypoint, resultArgument(steps, results)...)
+		if taskSpec != nil {
+			argsForEntrypoint = append(argsForEntrypoint, resultArgument(steps, taskSpec.Results)...)
+			// The steps containers are built from the steps of the Task, in the same order
+			if len(taskSpec.Steps) > i {
+				if taskSpec.Steps[i].Timeout != nil {
+					argsForEntrypoint = append(argsForEntrypoint, "-timeout", taskSpec.Steps[i].Timeout.Duration.String())
+				}
+				if taskSpec.Steps[i].OnError != "" {
+					argsForEntrypoint = append(argsForEntrypoint, "-on_error", string(taskSpec.Steps[i].OnError))
+				}
+				if taskSpec.Steps[i].ExecutionMode == v1beta1.ExecutionModeHermetic {
+					argsForEntrypoint = append(argsForEntrypoint, "-hermetic")
+				}
+			}
+		}
+
+		if debug.HasBreakpointOnFailure() {
+			argsForEntrypoint = append(argsForEntrypoint, "-breakpoint_on_failure")
+			if debug.Timeout != nil {
+				argsForEntrypoint = append(argsForEntrypoint, "-debug_timeout", debug.Timeout.Duration.String())
+			}
+			// The step is ready only while it is paused on a breakpoint, which lets the
+			// controller report it in the TaskRun status.
+			steps[i].ReadinessProbe = &corev1.Probe{
+				Handler: corev1.Handler{
+					Exec: &corev1.ExecAction{
+						Command: []string{entrypointBinary, "-breakpoint_check", entrypoint.BreakpointFile(postFile(i))},
+					},
+				},
+				PeriodSeconds: 1,
+			}
+		}
 
 		cmd, args := s.Command, s.Args
 		if len(cmd) == 0 {
@@ -143,6 +175,17 @@ func orderContainers(entrypointImage string, extraEntrypointArgs []string, steps
 	return initContainer, steps, nil
 }
 
+// postFile returns the file written by the i-th step when it completes
+func postFile(i int) string {
+	return filepath.Join(mountPoint, fmt.Sprintf("%d", i))
+}
+
+// BreakpointContinueCommand returns the command which resumes the i-th step of a Pod, paused on
+// a breakpoint, when run in its container.
+func BreakpointContinueCommand(i int) []string {
+	return []string{entrypointBinary, "-breakpoint_continue", entrypoint.ContinueFile(postFile(i))}
+}
+
 func resultArgument(steps []corev1.Container, results []v1beta1.TaskResult) []string {
 	if len(results) == 0 {
 		return nil
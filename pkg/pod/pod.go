@@ -38,8 +38,14 @@ const (
 
 	// ResultsDir is the folder used by default to create the results file
 	ResultsDir = "/tekton/results"
+	// resultsVolumeName is the name of the volume mounted at ResultsDir
+	resultsVolumeName = "tekton-internal-results"
 
 	taskRunLabelKey = pipeline.GroupName + pipeline.TaskRunLabelKey
+
+	// ResultsSidecarContainerName is the name of the container which outputs the Task results
+	// to its logs when they are read from the sidecar logs
+	ResultsSidecarContainerName = sidecarPrefix + "tekton-log-results"
 )
 
 // These are effectively const, but Go doesn't have such an annotation.
@@ -60,7 +66,7 @@ var (
 		Name:      "tekton-internal-home",
 		MountPath: homeDir,
 	}, {
-		Name:      "tekton-internal-results",
+		Name:      resultsVolumeName,
 		MountPath: ResultsDir,
 	}}
 	implicitVolumes = []corev1.Volume{{
@@ -70,7 +76,7 @@ var (
 		Name:         "tekton-internal-home",
 		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
 	}, {
-		Name:         "tekton-internal-results",
+		Name:         resultsVolumeName,
 		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
 	}}
 )
@@ -122,7 +128,7 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 
 	// Convert any steps with Script to command+args.
 	// If any are found, append an init container to initialize scripts.
-	scriptsInit, stepContainers, sidecarContainers := convertScripts(b.Images.ShellImage, steps, taskSpec.Sidecars)
+	scriptsInit, stepContainers, sidecarContainers := convertScripts(b.Images.ShellImage, steps, taskSpec.Sidecars, taskSpec.ScriptInterpreter)
 	if scriptsInit != nil {
 		initContainers = append(initContainers, *scriptsInit)
 		volumes = append(volumes, scriptsVolume)
@@ -139,13 +145,22 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		return nil, err
 	}
 
-	// Rewrite steps with entrypoint binary. Append the entrypoint init
-	// container to place the entrypoint binary.
-	entrypointInit, stepContainers, err := orderContainers(b.Images.EntrypointImage, credEntrypointArgs, stepContainers, taskSpec.Results)
+	// When the results are read from the logs of the results sidecar, the steps
+	// don't write them to their termination message.
+	resultsFromSidecarLogs := len(taskSpec.Results) > 0 && shouldReadResultsFromSidecarLogs(ctx)
+	entrypointTaskSpec := taskSpec
+	if resultsFromSidecarLogs {
+		entrypointTaskSpec.Results = nil
+	}
+
+	// Rewrite steps with entrypoint binary. Prepend the entrypoint init
+	// container to place the entrypoint binary, which the place-scripts init
+	// container uses to decode the scripts.
+	entrypointInit, stepContainers, err := orderContainers(b.Images.EntrypointImage, credEntrypointArgs, stepContainers, &entrypointTaskSpec, taskRun.Spec.Debug)
 	if err != nil {
 		return nil, err
 	}
-	initContainers = append(initContainers, entrypointInit)
+	initContainers = append([]corev1.Container{entrypointInit}, initContainers...)
 	volumes = append(volumes, toolsVolume, downwardVolume)
 
 	limitRangeMin, err := getLimitRangeMinimum(taskRun.Namespace, b.KubeClient)
@@ -238,6 +253,9 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		sc.Name = names.SimpleNameGenerator.RestrictLength(fmt.Sprintf("%v%v", sidecarPrefix, sc.Name))
 		mergedPodContainers = append(mergedPodContainers, sc)
 	}
+	if resultsFromSidecarLogs {
+		mergedPodContainers = append(mergedPodContainers, resultsSidecar(b.Images.SidecarLogResultsImage, len(stepContainers), taskSpec.Results))
+	}
 
 	var dnsPolicy corev1.DNSPolicy
 	if podTemplate.DNSPolicy != nil {
@@ -384,6 +402,38 @@ func shouldOverrideWorkingDir(ctx context.Context) bool {
 	return !cfg.FeatureFlags.DisableWorkingDirOverwrite
 }
 
+// shouldReadResultsFromSidecarLogs returns a bool indicating whether the Task results
+// should be read from the logs of the results sidecar rather than from the termination
+// messages of the steps.
+func shouldReadResultsFromSidecarLogs(ctx context.Context) bool {
+	cfg := config.FromContextOrDefaults(ctx)
+	return cfg.FeatureFlags.ResultExtractionMethod == config.ResultExtractionMethodSidecarLogs
+}
+
+// resultsSidecar returns the sidecar container which waits for the last of the steps to
+// finish, then writes the Task results to its logs.
+func resultsSidecar(image string, stepsCount int, results []v1beta1.TaskResult) corev1.Container {
+	return corev1.Container{
+		Name:    ResultsSidecarContainerName,
+		Image:   image,
+		Command: []string{"/ko-app/sidecarlogresults"},
+		Args: []string{
+			"-wait_file", filepath.Join(mountPoint, fmt.Sprintf("%d", stepsCount-1)),
+			"-results_dir", ResultsDir,
+			"-result_names", collectResultsName(results),
+		},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      toolsVolumeName,
+			MountPath: mountPoint,
+			ReadOnly:  true,
+		}, {
+			Name:      resultsVolumeName,
+			MountPath: ResultsDir,
+			ReadOnly:  true,
+		}},
+	}
+}
+
 // shouldAddReadyAnnotationonPodCreate returns a bool indicating whether the
 // controller should add the `Ready` annotation when creating the Pod. We cannot
 // add the annotation if Tekton is running in a cluster with injected sidecars
@@ -43,6 +43,8 @@ var (
 		EntrypointImage: "entrypoint-image",
 		CredsImage:      "override-with-creds:latest",
 		ShellImage:      "busybox",
+
+		SidecarLogResultsImage: "sidecarlogresults-image",
 	}
 
 	ignoreReleaseAnnotation = func(k string, v string) bool {
@@ -398,6 +400,7 @@ func TestPodBuild(t *testing.T) {
 		want: &corev1.PodSpec{
 			RestartPolicy: corev1.RestartPolicyNever,
 			InitContainers: []corev1.Container{
+				placeToolsInit,
 				{
 					Name:         "working-dir-initializer",
 					Image:        images.ShellImage,
@@ -406,7 +409,6 @@ func TestPodBuild(t *testing.T) {
 					WorkingDir:   pipeline.WorkspaceDir,
 					VolumeMounts: implicitVolumeMounts,
 				},
-				placeToolsInit,
 			},
 			Containers: []corev1.Container{{
 				Name:    "step-name",
@@ -438,6 +440,77 @@ func TestPodBuild(t *testing.T) {
 				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
 			}),
 		},
+	}, {
+		desc: "results read from sidecar logs",
+		ts: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{Container: corev1.Container{
+				Name:    "name",
+				Image:   "image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+			}}},
+			Results: []v1beta1.TaskResult{{
+				Name: "sbom",
+			}, {
+				Name: "digest",
+			}},
+		},
+		featureFlags: map[string]string{
+			"results-from": "sidecar-logs",
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy:  corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{placeToolsInit},
+			Containers: []corev1.Container{{
+				Name:    "step-name",
+				Image:   "image",
+				Command: []string{"/tekton/tools/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/tools/0",
+					"-termination_path",
+					"/tekton/termination",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				Env: implicitEnvVars,
+				VolumeMounts: append([]corev1.VolumeMount{toolsMount, downwardMount, {
+					Name:      "tekton-creds-init-home-9l9zj",
+					MountPath: "/tekton/creds",
+				}}, implicitVolumeMounts...),
+				WorkingDir:             pipeline.WorkspaceDir,
+				Resources:              corev1.ResourceRequirements{Requests: allZeroQty()},
+				TerminationMessagePath: "/tekton/termination",
+			}, {
+				Name:    "sidecar-tekton-log-results",
+				Image:   "sidecarlogresults-image",
+				Command: []string{"/ko-app/sidecarlogresults"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/tools/0",
+					"-results_dir",
+					"/tekton/results",
+					"-result_names",
+					"sbom,digest",
+				},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "tekton-internal-tools",
+					MountPath: "/tekton/tools",
+					ReadOnly:  true,
+				}, {
+					Name:      "tekton-internal-results",
+					MountPath: "/tekton/results",
+					ReadOnly:  true,
+				}},
+			}},
+			Volumes: append(implicitVolumes, toolsVolume, downwardVolume, corev1.Volume{
+				Name:         "tekton-creds-init-home-9l9zj",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+			}),
+		},
 	}, {
 		desc: "sidecar container",
 		ts: v1beta1.TaskSpec{
@@ -513,20 +586,14 @@ func TestPodBuild(t *testing.T) {
 		want: &corev1.PodSpec{
 			RestartPolicy: corev1.RestartPolicyNever,
 			InitContainers: []corev1.Container{
+				placeToolsInit,
 				{
 					Name:         "place-scripts",
 					Image:        "busybox",
 					Command:      []string{"sh"},
-					VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
-					Args: []string{"-c", `tmpfile="/tekton/scripts/sidecar-script-0-9l9zj"
-touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << 'sidecar-script-heredoc-randomly-generated-mz4c7'
-#!/bin/sh
-echo hello from sidecar
-sidecar-script-heredoc-randomly-generated-mz4c7
-`},
+					VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount, toolsMount},
+					Args:         []string{"-c", placeScriptArgs("/tekton/scripts/sidecar-script-0-9l9zj", "#!/bin/sh\necho hello from sidecar")},
 				},
-				placeToolsInit,
 			},
 			Containers: []corev1.Container{{
 				Name:    "step-primary-name",
@@ -546,7 +613,7 @@ sidecar-script-heredoc-randomly-generated-mz4c7
 				},
 				Env: implicitEnvVars,
 				VolumeMounts: append([]corev1.VolumeMount{toolsMount, downwardMount, {
-					Name:      "tekton-creds-init-home-mssqb",
+					Name:      "tekton-creds-init-home-mz4c7",
 					MountPath: "/tekton/creds",
 				}}, implicitVolumeMounts...),
 				WorkingDir:             pipeline.WorkspaceDir,
@@ -562,7 +629,7 @@ sidecar-script-heredoc-randomly-generated-mz4c7
 				VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
 			}},
 			Volumes: append(implicitVolumes, scriptsVolume, toolsVolume, downwardVolume, corev1.Volume{
-				Name:         "tekton-creds-init-home-mssqb",
+				Name:         "tekton-creds-init-home-mz4c7",
 				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
 			}),
 		},
@@ -750,30 +817,20 @@ print("Hello from Python")`,
 		want: &corev1.PodSpec{
 			RestartPolicy: corev1.RestartPolicyNever,
 			InitContainers: []corev1.Container{
-				{
-					Name:    "place-scripts",
-					Image:   images.ShellImage,
-					Command: []string{"sh"},
-					Args: []string{"-c", `tmpfile="/tekton/scripts/script-0-9l9zj"
-touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << 'script-heredoc-randomly-generated-mz4c7'
-#!/bin/sh
-echo hello from step one
-script-heredoc-randomly-generated-mz4c7
-tmpfile="/tekton/scripts/script-1-mssqb"
-touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << 'script-heredoc-randomly-generated-78c5n'
-#!/usr/bin/env python
-print("Hello from Python")
-script-heredoc-randomly-generated-78c5n
-`},
-					VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
-				},
 				{
 					Name:         "place-tools",
 					Image:        images.EntrypointImage,
 					Command:      []string{"cp", "/ko-app/entrypoint", "/tekton/tools/entrypoint"},
 					VolumeMounts: []corev1.VolumeMount{toolsMount},
+				},
+				{
+					Name:    "place-scripts",
+					Image:   images.ShellImage,
+					Command: []string{"sh"},
+					Args: []string{"-c", placeScriptArgs("/tekton/scripts/script-0-9l9zj", "#!/bin/sh\necho hello from step one") +
+						placeScriptArgs("/tekton/scripts/script-1-mz4c7", `#!/usr/bin/env python
+print("Hello from Python")`)},
+					VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount, toolsMount},
 				}},
 			Containers: []corev1.Container{{
 				Name:    "step-one",
@@ -795,7 +852,7 @@ script-heredoc-randomly-generated-78c5n
 				},
 				Env: append(implicitEnvVars, corev1.EnvVar{Name: "FOO", Value: "bar"}),
 				VolumeMounts: append([]corev1.VolumeMount{scriptsVolumeMount, toolsMount, downwardMount, {
-					Name:      "tekton-creds-init-home-6nl7g",
+					Name:      "tekton-creds-init-home-mssqb",
 					MountPath: "/tekton/creds",
 				}}, implicitVolumeMounts...),
 				WorkingDir:             pipeline.WorkspaceDir,
@@ -813,14 +870,14 @@ script-heredoc-randomly-generated-78c5n
 					"-termination_path",
 					"/tekton/termination",
 					"-entrypoint",
-					"/tekton/scripts/script-1-mssqb",
+					"/tekton/scripts/script-1-mz4c7",
 					"--",
 					"template",
 					"args",
 				},
 				Env: append(implicitEnvVars, corev1.EnvVar{Name: "FOO", Value: "bar"}),
 				VolumeMounts: append([]corev1.VolumeMount{{Name: "i-have-a-volume-mount"}, scriptsVolumeMount, toolsMount, {
-					Name:      "tekton-creds-init-home-j2tds",
+					Name:      "tekton-creds-init-home-78c5n",
 					MountPath: "/tekton/creds",
 				}}, implicitVolumeMounts...),
 				WorkingDir:             pipeline.WorkspaceDir,
@@ -846,7 +903,7 @@ script-heredoc-randomly-generated-78c5n
 				},
 				Env: append(implicitEnvVars, corev1.EnvVar{Name: "FOO", Value: "bar"}),
 				VolumeMounts: append([]corev1.VolumeMount{toolsMount, {
-					Name:      "tekton-creds-init-home-vr6ds",
+					Name:      "tekton-creds-init-home-6nl7g",
 					MountPath: "/tekton/creds",
 				}}, implicitVolumeMounts...),
 				WorkingDir:             pipeline.WorkspaceDir,
@@ -854,13 +911,13 @@ script-heredoc-randomly-generated-78c5n
 				TerminationMessagePath: "/tekton/termination",
 			}},
 			Volumes: append(implicitVolumes, scriptsVolume, toolsVolume, downwardVolume, corev1.Volume{
-				Name:         "tekton-creds-init-home-6nl7g",
+				Name:         "tekton-creds-init-home-mssqb",
 				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
 			}, corev1.Volume{
-				Name:         "tekton-creds-init-home-j2tds",
+				Name:         "tekton-creds-init-home-78c5n",
 				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
 			}, corev1.Volume{
-				Name:         "tekton-creds-init-home-vr6ds",
+				Name:         "tekton-creds-init-home-6nl7g",
 				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
 			}),
 		},
@@ -17,6 +17,8 @@ limitations under the License.
 package pod
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -30,6 +32,9 @@ const (
 	scriptsVolumeName     = "tekton-internal-scripts"
 	scriptsDir            = "/tekton/scripts"
 	defaultScriptPreamble = "#!/bin/sh\nset -xe\n"
+	// scriptHeredoc is the "here document" delimiter used to write the
+	// base64 encoded scripts, which can't contain "_"
+	scriptHeredoc = "_EOF_"
 )
 
 var (
@@ -49,29 +54,35 @@ var (
 //
 // It does this by prepending a container that writes specified Script bodies
 // to executable files in a shared volumeMount, then produces Containers that
-// simply run those executable files.
-func convertScripts(shellImage string, steps []v1beta1.Step, sidecars []v1beta1.Sidecar) (*corev1.Container, []corev1.Container, []corev1.Container) {
+// simply run those executable files, or run them with the script interpreter
+// of the step or of the Task.
+//
+// The scripts are passed to the init container base64 encoded, and decoded by
+// the entrypoint binary which also verifies their checksum, so that their
+// content can't interfere with the shell script of the init container. This
+// requires the entrypoint binary to be placed before the init container runs.
+func convertScripts(shellImage string, steps []v1beta1.Step, sidecars []v1beta1.Sidecar, defaultInterpreter []string) (*corev1.Container, []corev1.Container, []corev1.Container) {
 	placeScripts := false
 	placeScriptsInit := corev1.Container{
 		Name:         "place-scripts",
 		Image:        shellImage,
 		Command:      []string{"sh"},
 		Args:         []string{"-c", ""},
-		VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
+		VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount, toolsMount},
 	}
 
-	convertedStepContainers := convertListOfSteps(steps, &placeScriptsInit, &placeScripts, "script")
+	convertedStepContainers := convertListOfSteps(steps, &placeScriptsInit, &placeScripts, "script", defaultInterpreter)
 	// convertListOfSteps operates on overlapping fields across Step and Sidecar, hence a conversion
 	// from Sidecar into Step
 	sideCarSteps := []v1beta1.Step{}
 	for _, step := range sidecars {
 		sidecarStep := v1beta1.Step{
-			step.Container,
-			step.Script,
+			Container: step.Container,
+			Script:    step.Script,
 		}
 		sideCarSteps = append(sideCarSteps, sidecarStep)
 	}
-	sidecarContainers := convertListOfSteps(sideCarSteps, &placeScriptsInit, &placeScripts, "sidecar-script")
+	sidecarContainers := convertListOfSteps(sideCarSteps, &placeScriptsInit, &placeScripts, "sidecar-script", defaultInterpreter)
 
 	if placeScripts {
 		return &placeScriptsInit, convertedStepContainers, sidecarContainers
@@ -81,9 +92,10 @@ func convertScripts(shellImage string, steps []v1beta1.Step, sidecars []v1beta1.
 
 // convertListOfSteps does the heavy lifting for convertScripts.
 //
-// It iterates through the list of steps (or sidecars), generates the script file name and heredoc termination string,
-// adds an entry to the init container args, sets up the step container to run the script, and sets the volume mounts.
-func convertListOfSteps(steps []v1beta1.Step, initContainer *corev1.Container, placeScripts *bool, namePrefix string) []corev1.Container {
+// It iterates through the list of steps (or sidecars), generates the script file name, adds an
+// entry to the init container args to write and decode the script, sets up the step container to
+// run the script, and sets the volume mounts.
+func convertListOfSteps(steps []v1beta1.Step, initContainer *corev1.Container, placeScripts *bool, namePrefix string, defaultInterpreter []string) []corev1.Container {
 	containers := []corev1.Container{}
 	for i, s := range steps {
 		if s.Script == "" {
@@ -92,13 +104,20 @@ func convertListOfSteps(steps []v1beta1.Step, initContainer *corev1.Container, p
 			continue
 		}
 
-		// Check for a shebang, and add a default if it's not set.
+		// Check for a shebang, and use the interpreter of the step or of
+		// the Task if it's not set, or add a default preamble otherwise.
 		// The shebang must be the first non-empty line.
 		cleaned := strings.TrimSpace(s.Script)
 		hasShebang := strings.HasPrefix(cleaned, "#!")
+		interpreter := s.ScriptInterpreter
+		if len(interpreter) == 0 {
+			interpreter = defaultInterpreter
+		}
 
 		script := s.Script
-		if !hasShebang {
+		if hasShebang {
+			interpreter = nil
+		} else if len(interpreter) == 0 {
 			script = defaultScriptPreamble + s.Script
 		}
 
@@ -108,28 +127,32 @@ func convertListOfSteps(steps []v1beta1.Step, initContainer *corev1.Container, p
 
 		// Append to the place-scripts script to place the
 		// script file in a known location in the scripts volume.
-		tmpFile := filepath.Join(scriptsDir, names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%d", namePrefix, i)))
-		// heredoc is the "here document" placeholder string
-		// used to cat script contents into the file. Typically
-		// this is the string "EOF" but if this value were
-		// "EOF" it would prevent users from including the
-		// string "EOF" in their own scripts. Instead we
-		// randomly generate a string to (hopefully) prevent
-		// collisions.
-		heredoc := names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-heredoc-randomly-generated", namePrefix))
+		// The encoded script only contains base64 characters, so
+		// it can't contain the heredoc delimiter.
+		scriptFile := filepath.Join(scriptsDir, names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%d", namePrefix, i)))
+		encoded := base64.StdEncoding.EncodeToString([]byte(script))
+		checksum := fmt.Sprintf("%x", sha256.Sum256([]byte(script)))
 		initContainer.Args[1] += fmt.Sprintf(`tmpfile="%s"
 touch ${tmpfile} && chmod +x ${tmpfile}
 cat > ${tmpfile} << '%s'
 %s
 %s
-`, tmpFile, heredoc, script, heredoc)
+%s -decode_script "${tmpfile}" -script_sha256 %s
+`, scriptFile, scriptHeredoc, encoded, scriptHeredoc, entrypointBinary, checksum)
 
 		// Set the command to execute the correct script in the mounted
-		// volume.
+		// volume, through the interpreter if any.
 		// A previous merge with stepTemplate may have populated
 		// Command and Args, even though this is not normally valid, so
-		// we'll clear out the Args and overwrite Command.
-		steps[i].Command = []string{tmpFile}
+		// we'll overwrite Command.
+		if len(interpreter) > 0 {
+			steps[i].Command = []string{interpreter[0]}
+			args := append([]string{}, interpreter[1:]...)
+			args = append(args, scriptFile)
+			steps[i].Args = append(args, steps[i].Args...)
+		} else {
+			steps[i].Command = []string{scriptFile}
+		}
 		steps[i].VolumeMounts = append(steps[i].VolumeMounts, scriptsVolumeMount)
 		containers = append(containers, steps[i].Container)
 	}
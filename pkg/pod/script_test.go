@@ -17,6 +17,9 @@ limitations under the License.
 package pod
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -35,7 +38,7 @@ func TestConvertScripts_NothingToConvert_EmptySidecars(t *testing.T) {
 		Container: corev1.Container{
 			Image: "step-2",
 		},
-	}}, []v1beta1.Sidecar{})
+	}}, []v1beta1.Sidecar{}, nil)
 	want := []corev1.Container{{
 		Image: "step-1",
 	}, {
@@ -62,7 +65,7 @@ func TestConvertScripts_NothingToConvert_NilSidecars(t *testing.T) {
 		Container: corev1.Container{
 			Image: "step-2",
 		},
-	}}, nil)
+	}}, nil, nil)
 	want := []corev1.Container{{
 		Image: "step-1",
 	}, {
@@ -93,7 +96,7 @@ func TestConvertScripts_NothingToConvert_WithSidecar(t *testing.T) {
 		Container: corev1.Container{
 			Image: "sidecar-1",
 		},
-	}})
+	}}, nil)
 	want := []corev1.Container{{
 		Image: "step-1",
 	}, {
@@ -153,33 +156,18 @@ script-3`,
 			VolumeMounts: preExistingVolumeMounts,
 			Args:         []string{"my", "args"},
 		},
-	}}, []v1beta1.Sidecar{})
+	}}, []v1beta1.Sidecar{}, nil)
 	wantInit := &corev1.Container{
 		Name:    "place-scripts",
 		Image:   images.ShellImage,
 		Command: []string{"sh"},
-		Args: []string{"-c", `tmpfile="/tekton/scripts/script-0-9l9zj"
-touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << 'script-heredoc-randomly-generated-mz4c7'
-#!/bin/sh
-script-1
-script-heredoc-randomly-generated-mz4c7
-tmpfile="/tekton/scripts/script-2-mssqb"
-touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << 'script-heredoc-randomly-generated-78c5n'
-
-#!/bin/sh
-script-3
-script-heredoc-randomly-generated-78c5n
-tmpfile="/tekton/scripts/script-3-6nl7g"
-touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << 'script-heredoc-randomly-generated-j2tds'
+		Args: []string{"-c", placeScriptArgs("/tekton/scripts/script-0-9l9zj", `#!/bin/sh
+script-1`) + placeScriptArgs("/tekton/scripts/script-2-mz4c7", `
 #!/bin/sh
+script-3`) + placeScriptArgs("/tekton/scripts/script-3-mssqb", `#!/bin/sh
 set -xe
-no-shebang
-script-heredoc-randomly-generated-j2tds
-`},
-		VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
+no-shebang`)},
+		VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount, toolsMount},
 	}
 	want := []corev1.Container{{
 		Image:        "step-1",
@@ -189,12 +177,12 @@ script-heredoc-randomly-generated-j2tds
 		Image: "step-2",
 	}, {
 		Image:        "step-3",
-		Command:      []string{"/tekton/scripts/script-2-mssqb"},
+		Command:      []string{"/tekton/scripts/script-2-mz4c7"},
 		Args:         []string{"my", "args"},
 		VolumeMounts: append(preExistingVolumeMounts, scriptsVolumeMount),
 	}, {
 		Image:   "step-3",
-		Command: []string{"/tekton/scripts/script-3-6nl7g"},
+		Command: []string{"/tekton/scripts/script-3-mssqb"},
 		Args:    []string{"my", "args"},
 		VolumeMounts: []corev1.VolumeMount{
 			{Name: "pre-existing-volume-mount", MountPath: "/mount/path"},
@@ -244,31 +232,16 @@ script-3`,
 		Script: `#!/bin/sh
 sidecar-1`,
 		Container: corev1.Container{Image: "sidecar-1"},
-	}})
+	}}, nil)
 	wantInit := &corev1.Container{
 		Name:    "place-scripts",
 		Image:   images.ShellImage,
 		Command: []string{"sh"},
-		Args: []string{"-c", `tmpfile="/tekton/scripts/script-0-9l9zj"
-touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << 'script-heredoc-randomly-generated-mz4c7'
-#!/bin/sh
-script-1
-script-heredoc-randomly-generated-mz4c7
-tmpfile="/tekton/scripts/script-2-mssqb"
-touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << 'script-heredoc-randomly-generated-78c5n'
-#!/bin/sh
-script-3
-script-heredoc-randomly-generated-78c5n
-tmpfile="/tekton/scripts/sidecar-script-0-6nl7g"
-touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << 'sidecar-script-heredoc-randomly-generated-j2tds'
-#!/bin/sh
-sidecar-1
-sidecar-script-heredoc-randomly-generated-j2tds
-`},
-		VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
+		Args: []string{"-c", placeScriptArgs("/tekton/scripts/script-0-9l9zj", `#!/bin/sh
+script-1`) + placeScriptArgs("/tekton/scripts/script-2-mz4c7", `#!/bin/sh
+script-3`) + placeScriptArgs("/tekton/scripts/sidecar-script-0-mssqb", `#!/bin/sh
+sidecar-1`)},
+		VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount, toolsMount},
 	}
 	want := []corev1.Container{{
 		Image:        "step-1",
@@ -278,7 +251,7 @@ sidecar-script-heredoc-randomly-generated-j2tds
 		Image: "step-2",
 	}, {
 		Image:   "step-3",
-		Command: []string{"/tekton/scripts/script-2-mssqb"},
+		Command: []string{"/tekton/scripts/script-2-mz4c7"},
 		Args:    []string{"my", "args"},
 		VolumeMounts: []corev1.VolumeMount{
 			{Name: "pre-existing-volume-mount", MountPath: "/mount/path"},
@@ -289,7 +262,7 @@ sidecar-script-heredoc-randomly-generated-j2tds
 
 	wantSidecars := []corev1.Container{{
 		Image:        "sidecar-1",
-		Command:      []string{"/tekton/scripts/sidecar-script-0-6nl7g"},
+		Command:      []string{"/tekton/scripts/sidecar-script-0-mssqb"},
 		VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
 	}}
 	if d := cmp.Diff(wantInit, gotInit); d != "" {
@@ -305,5 +278,65 @@ sidecar-script-heredoc-randomly-generated-j2tds
 	if len(gotSidecars) != 1 {
 		t.Errorf("Wanted 1 sidecar, got %v", len(gotSidecars))
 	}
+}
 
+func TestConvertScripts_WithInterpreter(t *testing.T) {
+	names.TestingSeed()
+
+	gotInit, gotSteps, _ := convertScripts(images.ShellImage, []v1beta1.Step{{
+		Script:    `print("default interpreter")`,
+		Container: corev1.Container{Image: "step-1", Args: []string{"my", "args"}},
+	}, {
+		Script:            `console.log("step interpreter")`,
+		ScriptInterpreter: []string{"node"},
+		Container:         corev1.Container{Image: "step-2"},
+	}, {
+		Script: `#!/bin/sh
+echo "shebang"`,
+		Container: corev1.Container{Image: "step-3", Args: []string{"my", "args"}},
+	}}, nil, []string{"python3", "-u"})
+	wantInit := &corev1.Container{
+		Name:    "place-scripts",
+		Image:   images.ShellImage,
+		Command: []string{"sh"},
+		Args: []string{"-c", placeScriptArgs("/tekton/scripts/script-0-9l9zj", `print("default interpreter")`) +
+			placeScriptArgs("/tekton/scripts/script-1-mz4c7", `console.log("step interpreter")`) +
+			placeScriptArgs("/tekton/scripts/script-2-mssqb", `#!/bin/sh
+echo "shebang"`)},
+		VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount, toolsMount},
+	}
+	want := []corev1.Container{{
+		Image:        "step-1",
+		Command:      []string{"python3"},
+		Args:         []string{"-u", "/tekton/scripts/script-0-9l9zj", "my", "args"},
+		VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
+	}, {
+		Image:        "step-2",
+		Command:      []string{"node"},
+		Args:         []string{"/tekton/scripts/script-1-mz4c7"},
+		VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
+	}, {
+		Image:        "step-3",
+		Command:      []string{"/tekton/scripts/script-2-mssqb"},
+		Args:         []string{"my", "args"},
+		VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
+	}}
+	if d := cmp.Diff(wantInit, gotInit); d != "" {
+		t.Errorf("Init Container Diff %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(want, gotSteps); d != "" {
+		t.Errorf("Containers Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+// placeScriptArgs returns the commands the place-scripts init container runs
+// to write the script to file.
+func placeScriptArgs(file, script string) string {
+	return fmt.Sprintf(`tmpfile="%s"
+touch ${tmpfile} && chmod +x ${tmpfile}
+cat > ${tmpfile} << '_EOF_'
+%s
+_EOF_
+/tekton/tools/entrypoint -decode_script "${tmpfile}" -script_sha256 %x
+`, file, base64.StdEncoding.EncodeToString([]byte(script)), sha256.Sum256([]byte(script)))
 }
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -46,6 +47,14 @@ const (
 	// that taskrun failed runtime validation
 	ReasonFailedValidation = "TaskRunValidationFailed"
 
+	// ReasonInvalidParamValue indicates that the reason for failure status is that the value
+	// of a param isn't allowed by the enum or pattern declared by the Task
+	ReasonInvalidParamValue = "InvalidParamValue"
+
+	// ReasonResourceVerificationFailed indicates that the reason for failure status is that the
+	// signature of the Task, or of the Pipeline or one of its Tasks, couldn't be verified
+	ReasonResourceVerificationFailed = "ResourceVerificationFailed"
+
 	// ReasonExceededResourceQuota indicates that the TaskRun failed to create a pod due to
 	// a ResourceQuota in the namespace
 	ReasonExceededResourceQuota = "ExceededResourceQuota"
@@ -66,6 +75,10 @@ const (
 	// ReasonExceededNodeResources or IsPodHitConfigError
 	ReasonPending = "Pending"
 
+	// ReasonStepTimeoutExceeded indicates that a Step was killed by the entrypoint
+	// because it did not complete within its timeout
+	ReasonStepTimeoutExceeded = "TimeoutExceeded"
+
 	//timeFormat is RFC3339 with millisecond
 	timeFormat = "2006-01-02T15:04:05.000Z07:00"
 )
@@ -108,7 +121,7 @@ func MakeTaskRunStatus(logger *zap.SugaredLogger, tr v1beta1.TaskRun, pod *corev
 	complete := areStepsComplete(pod) || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
 
 	if complete {
-		updateCompletedTaskRun(trs, pod)
+		updateCompletedTaskRun(logger, trs, pod)
 	} else {
 		updateIncompleteTaskRun(trs, pod)
 	}
@@ -147,6 +160,7 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 	var merr *multierror.Error
 
 	for _, s := range stepStatuses {
+		hermetic := false
 		if s.State.Terminated != nil && len(s.State.Terminated.Message) != 0 {
 			msg := s.State.Terminated.Message
 
@@ -175,6 +189,19 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 				if time != nil {
 					s.State.Terminated.StartedAt = *time
 				}
+				if isStepTimeoutExceeded(results) {
+					s.State.Terminated.Reason = ReasonStepTimeoutExceeded
+				}
+				exitCode, err := extractExitCodeFromResults(results)
+				if err != nil {
+					logger.Errorf("error extracting the exit code of step %q in taskrun %q: %v", s.Name, tr.Name, err)
+					merr = multierror.Append(merr, err)
+				}
+				if exitCode != nil {
+					// The step was allowed to fail, surface the exit code it was recorded with
+					s.State.Terminated.ExitCode = *exitCode
+				}
+				hermetic = isStepHermetic(results)
 			}
 		}
 		trs.Steps = append(trs.Steps, v1beta1.StepState{
@@ -182,6 +209,7 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 			Name:           trimStepPrefix(s.Name),
 			ContainerName:  s.Name,
 			ImageID:        s.ImageID,
+			Hermetic:       hermetic,
 		})
 	}
 
@@ -271,9 +299,47 @@ func extractStartedAtTimeFromResults(results []v1beta1.PipelineResourceResult) (
 	return nil, nil
 }
 
-func updateCompletedTaskRun(trs *v1beta1.TaskRunStatus, pod *corev1.Pod) {
+// extractExitCodeFromResults returns the exit code of a step that failed but was
+// allowed to continue with onError, or nil if it wasn't recorded
+func extractExitCodeFromResults(results []v1beta1.PipelineResourceResult) (*int32, error) {
+	for _, result := range results {
+		if result.ResultType == v1beta1.InternalTektonResultType && result.Key == "ExitCode" {
+			exitCode, err := strconv.ParseInt(result.Value, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse int value %q in ExitCode field: %w", result.Value, err)
+			}
+			e := int32(exitCode)
+			return &e, nil
+		}
+	}
+	return nil, nil
+}
+
+// isStepTimeoutExceeded returns true if the termination message results of a Step
+// indicate that the entrypoint killed it because it exceeded its timeout
+func isStepTimeoutExceeded(results []v1beta1.PipelineResourceResult) bool {
+	for _, result := range results {
+		if result.ResultType == v1beta1.InternalTektonResultType && result.Key == "Reason" && result.Value == ReasonStepTimeoutExceeded {
+			return true
+		}
+	}
+	return false
+}
+
+// isStepHermetic returns true if the termination message results of a Step
+// indicate that the entrypoint ran it without network access
+func isStepHermetic(results []v1beta1.PipelineResourceResult) bool {
+	for _, result := range results {
+		if result.ResultType == v1beta1.InternalTektonResultType && result.Key == "Hermetic" && result.Value == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+func updateCompletedTaskRun(logger *zap.SugaredLogger, trs *v1beta1.TaskRunStatus, pod *corev1.Pod) {
 	if DidTaskRunFail(pod) {
-		msg := getFailureMessage(pod)
+		msg := getFailureMessage(logger, pod)
 		MarkStatusFailure(trs, msg)
 	} else {
 		MarkStatusSuccess(trs)
@@ -286,6 +352,10 @@ func updateCompletedTaskRun(trs *v1beta1.TaskRunStatus, pod *corev1.Pod) {
 func updateIncompleteTaskRun(trs *v1beta1.TaskRunStatus, pod *corev1.Pod) {
 	switch pod.Status.Phase {
 	case corev1.PodRunning:
+		if msg, ok := getBreakpointMessage(pod); ok {
+			MarkStatusRunning(trs, v1beta1.TaskRunReasonBreakpointHit.String(), msg)
+			return
+		}
 		MarkStatusRunning(trs, v1beta1.TaskRunReasonRunning.String(), "Not all Steps in the Task have finished executing")
 	case corev1.PodPending:
 		var reason, msg string
@@ -304,6 +374,27 @@ func updateIncompleteTaskRun(trs *v1beta1.TaskRunStatus, pod *corev1.Pod) {
 	}
 }
 
+// getBreakpointMessage returns a message with the command to resume the step paused on a
+// breakpoint, if any. The steps of TaskRuns with breakpoints are only ready while paused.
+func getBreakpointMessage(pod *corev1.Pod) (string, bool) {
+	ready := map[string]bool{}
+	for _, s := range pod.Status.ContainerStatuses {
+		ready[s.Name] = s.State.Running != nil && s.Ready
+	}
+	i := 0
+	for _, c := range pod.Spec.Containers {
+		if !IsContainerStep(c.Name) {
+			continue
+		}
+		if c.ReadinessProbe != nil && ready[c.Name] {
+			return fmt.Sprintf("Step %q failed and is paused on a breakpoint, resume it with: kubectl exec -n %s %s -c %s -- %s",
+				trimStepPrefix(c.Name), pod.Namespace, pod.Name, c.Name, strings.Join(BreakpointContinueCommand(i), " ")), true
+		}
+		i++
+	}
+	return "", false
+}
+
 // DidTaskRunFail check the status of pod to decide if related taskrun is failed
 func DidTaskRunFail(pod *corev1.Pod) bool {
 	f := pod.Status.Phase == corev1.PodFailed
@@ -320,7 +411,8 @@ func DidTaskRunFail(pod *corev1.Pod) bool {
 func areStepsComplete(pod *corev1.Pod) bool {
 	stepsComplete := len(pod.Status.ContainerStatuses) > 0 && pod.Status.Phase == corev1.PodRunning
 	for _, s := range pod.Status.ContainerStatuses {
-		if IsContainerStep(s.Name) {
+		// The results sidecar has to finish writing the results to its logs as well
+		if IsContainerStep(s.Name) || s.Name == ResultsSidecarContainerName {
 			if s.State.Terminated == nil {
 				stepsComplete = false
 			}
@@ -350,12 +442,20 @@ func SortContainerStatuses(podInstance *corev1.Pod) {
 
 }
 
-func getFailureMessage(pod *corev1.Pod) string {
+func getFailureMessage(logger *zap.SugaredLogger, pod *corev1.Pod) string {
 	SortContainerStatuses(pod)
 	// First, try to surface an error about the actual build step that failed.
 	for _, status := range pod.Status.ContainerStatuses {
 		term := status.State.Terminated
-		if term != nil && term.ExitCode != 0 {
+		if term == nil {
+			continue
+		}
+		if results, err := termination.ParseMessage(logger, term.Message); err == nil && isStepTimeoutExceeded(results) {
+			// Newline required at end to prevent yaml parser from breaking the log help text at 80 chars
+			return fmt.Sprintf("%q exited because the step exceeded the specified timeout limit; for logs run: kubectl -n %s logs %s -c %s\n",
+				status.Name, pod.Namespace, pod.Name, status.Name)
+		}
+		if term.ExitCode != 0 {
 			// Newline required at end to prevent yaml parser from breaking the log help text at 80 chars
 			return fmt.Sprintf("%q exited with code %d (image: %q); for logs run: kubectl -n %s logs %s -c %s\n",
 				status.Name, term.ExitCode, status.ImageID,
@@ -511,6 +511,46 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		desc: "steps-complete-with-results-sidecar-running",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-completed-step",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{},
+				},
+			}, {
+				Name:    "sidecar-tekton-log-results",
+				ImageID: "image-id",
+				State: corev1.ContainerState{
+					Running: &corev1.ContainerStateRunning{},
+				},
+				Ready: true,
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{conditionRunning},
+			},
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{},
+					},
+					Name:          "completed-step",
+					ContainerName: "step-completed-step",
+				}},
+				Sidecars: []v1beta1.SidecarState{{
+					ContainerState: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+					Name:          "tekton-log-results",
+					ImageID:       "image-id",
+					ContainerName: "sidecar-tekton-log-results",
+				}},
+			},
+		},
 	}, {
 		desc: "with-sidecar-waiting",
 		podStatus: corev1.PodStatus{
@@ -878,6 +918,147 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
 		},
+	}, {
+		desc: "step failed with onError continue",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:    "step-lint",
+				ImageID: "image-id",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `[{"key":"ExitCode","value":"11","type":"InternalTektonResult"}]`,
+					},
+				},
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{conditionSucceeded},
+			},
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 11,
+						}},
+					Name:          "lint",
+					ContainerName: "step-lint",
+					ImageID:       "image-id",
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}, {
+		desc: "step timed out",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:    "step-first",
+				ImageID: "image-id-first",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Reason: "Completed",
+					},
+				},
+			}, {
+				Name:    "step-slow",
+				ImageID: "image-id",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 1,
+						Reason:   "Error",
+						Message:  `[{"key":"Reason","value":"TimeoutExceeded","type":"InternalTektonResult"}]`,
+					},
+				},
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{{
+					Type:    apis.ConditionSucceeded,
+					Status:  corev1.ConditionFalse,
+					Reason:  v1beta1.TaskRunReasonFailed.String(),
+					Message: "\"step-slow\" exited because the step exceeded the specified timeout limit; for logs run: kubectl -n foo logs pod -c step-slow\n",
+				}},
+			},
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Reason: "Completed",
+						}},
+					Name:          "first",
+					ContainerName: "step-first",
+					ImageID:       "image-id-first",
+				}, {
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 1,
+							Reason:   ReasonStepTimeoutExceeded,
+						}},
+					Name:          "slow",
+					ContainerName: "step-slow",
+					ImageID:       "image-id",
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}, {
+		desc: "hermetic step",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:    "step-git-source",
+				ImageID: "image-id-git",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Reason: "Completed",
+					},
+				},
+			}, {
+				Name:    "step-build",
+				ImageID: "image-id",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Reason:  "Completed",
+						Message: `[{"key":"Hermetic","value":"true","type":"InternalTektonResult"}]`,
+					},
+				},
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{conditionSucceeded},
+			},
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Reason: "Completed",
+						}},
+					Name:          "git-source",
+					ContainerName: "step-git-source",
+					ImageID:       "image-id-git",
+				}, {
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Reason: "Completed",
+						}},
+					Name:          "build",
+					ContainerName: "step-build",
+					ImageID:       "image-id",
+					Hermetic:      true,
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			now := metav1.Now()
@@ -928,6 +1109,58 @@ func TestMakeTaskRunStatus(t *testing.T) {
 	}
 }
 
+func TestMakeTaskRunStatusBreakpointHit(t *testing.T) {
+	probe := &corev1.Probe{}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod",
+			Namespace: "foo",
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "step-build", ReadinessProbe: probe},
+				{Name: "step-test", ReadinessProbe: probe},
+				{Name: "step-push", ReadinessProbe: probe},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "step-build",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+			}, {
+				Name:  "step-test",
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				Ready: true,
+			}, {
+				Name:  "step-push",
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}},
+		},
+	}
+	tr := v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "task-run",
+			Namespace: "foo",
+		},
+	}
+
+	logger, _ := logging.NewLogger("", "status")
+	got, err := MakeTaskRunStatus(logger, tr, pod, v1beta1.TaskSpec{})
+	if err != nil {
+		t.Fatalf("MakeTaskRunStatus: %s", err)
+	}
+	want := apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionUnknown,
+		Reason:  v1beta1.TaskRunReasonBreakpointHit.String(),
+		Message: `Step "test" failed and is paused on a breakpoint, resume it with: kubectl exec -n foo pod -c step-test -- /tekton/tools/entrypoint -breakpoint_continue /tekton/tools/1.continue`,
+	}
+	if d := cmp.Diff(&want, got.GetCondition(apis.ConditionSucceeded), ignoreVolatileTime); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestMakeRunStatusErrors(t *testing.T) {
 	for _, c := range []struct {
 		desc      string
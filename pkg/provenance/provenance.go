@@ -0,0 +1,255 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package provenance builds signed SLSA provenance, in the in-toto attestation
+// format, for the artifacts built by TaskRuns.
+package provenance
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+const (
+	// StatementType is the type of the in-toto statements
+	StatementType = "https://in-toto.io/Statement/v0.1"
+	// PredicateType is the type of the SLSA provenance predicate of the statements
+	PredicateType = "https://slsa.dev/provenance/v0.1"
+	// RecipeType is the type of the recipe of the provenance of TaskRuns
+	RecipeType = "https://tekton.dev/attestations/taskrun@v1"
+
+	// ImageURLResult and ImageDigestResult are the names of the Task results which report an
+	// image built by a Task, in addition to the image output PipelineResources
+	ImageURLResult    = "IMAGE_URL"
+	ImageDigestResult = "IMAGE_DIGEST"
+)
+
+// Statement is an in-toto statement holding the provenance of the artifacts built by a TaskRun
+type Statement struct {
+	Type          string    `json:"_type"`
+	PredicateType string    `json:"predicateType"`
+	Subject       []Subject `json:"subject"`
+	Predicate     Predicate `json:"predicate"`
+}
+
+// Subject is an artifact built by a TaskRun
+type Subject struct {
+	Name   string    `json:"name"`
+	Digest DigestSet `json:"digest"`
+}
+
+// DigestSet maps the names of hash algorithms to the hex encoded digests of an artifact
+type DigestSet map[string]string
+
+// Predicate is the SLSA provenance of the artifacts built by a TaskRun
+type Predicate struct {
+	Builder   Builder    `json:"builder"`
+	Recipe    Recipe     `json:"recipe"`
+	Metadata  Metadata   `json:"metadata"`
+	Materials []Material `json:"materials,omitempty"`
+}
+
+// Builder identifies the entity which ran the TaskRun
+type Builder struct {
+	ID string `json:"id"`
+}
+
+// Recipe describes how the TaskRun built the artifacts
+type Recipe struct {
+	Type        string          `json:"type"`
+	EntryPoint  string          `json:"entryPoint,omitempty"`
+	Arguments   []v1beta1.Param `json:"arguments,omitempty"`
+	Environment Environment     `json:"environment"`
+}
+
+// Environment holds the definition of the Task and the steps the TaskRun ran
+type Environment struct {
+	TaskSpec *v1beta1.TaskSpec `json:"taskSpec,omitempty"`
+	Steps    []Step            `json:"steps,omitempty"`
+}
+
+// Step is a step the TaskRun ran
+type Step struct {
+	Name     string `json:"name"`
+	ImageID  string `json:"imageID,omitempty"`
+	Hermetic bool   `json:"hermetic,omitempty"`
+}
+
+// Metadata holds the timing of the TaskRun
+type Metadata struct {
+	BuildInvocationID string       `json:"buildInvocationId,omitempty"`
+	BuildStartedOn    *time.Time   `json:"buildStartedOn,omitempty"`
+	BuildFinishedOn   *time.Time   `json:"buildFinishedOn,omitempty"`
+	Completeness      Completeness `json:"completeness"`
+	Reproducible      bool         `json:"reproducible"`
+}
+
+// Completeness indicates which parts of the provenance are known to be complete
+type Completeness struct {
+	Arguments   bool `json:"arguments"`
+	Environment bool `json:"environment"`
+	Materials   bool `json:"materials"`
+}
+
+// Material is an input of the TaskRun: the image of a step or a git repository
+type Material struct {
+	URI    string    `json:"uri"`
+	Digest DigestSet `json:"digest,omitempty"`
+}
+
+// NewStatement builds the provenance of the artifacts built by the TaskRun from its status:
+// the images reported by the image digest exporter and the IMAGE_URL and IMAGE_DIGEST results are
+// its subjects, and the images of its steps and the git commits it fetched are its materials.
+func NewStatement(tr *v1beta1.TaskRun, builderID string) *Statement {
+	s := &Statement{
+		Type:          StatementType,
+		PredicateType: PredicateType,
+		Subject:       subjects(tr),
+		Predicate: Predicate{
+			Builder: Builder{ID: builderID},
+			Recipe: Recipe{
+				Type:      RecipeType,
+				Arguments: tr.Spec.Params,
+				Environment: Environment{
+					TaskSpec: tr.Status.TaskSpec,
+				},
+			},
+			Metadata: Metadata{
+				BuildInvocationID: string(tr.UID),
+				Completeness: Completeness{
+					Arguments: true,
+				},
+			},
+			Materials: materials(tr),
+		},
+	}
+	if tr.Spec.TaskRef != nil {
+		s.Predicate.Recipe.EntryPoint = tr.Spec.TaskRef.Name
+	}
+	for _, step := range tr.Status.Steps {
+		s.Predicate.Recipe.Environment.Steps = append(s.Predicate.Recipe.Environment.Steps, Step{
+			Name:     step.Name,
+			ImageID:  step.ImageID,
+			Hermetic: step.Hermetic,
+		})
+	}
+	if tr.Status.StartTime != nil {
+		t := tr.Status.StartTime.Time.UTC()
+		s.Predicate.Metadata.BuildStartedOn = &t
+	}
+	if tr.Status.CompletionTime != nil {
+		t := tr.Status.CompletionTime.Time.UTC()
+		s.Predicate.Metadata.BuildFinishedOn = &t
+	}
+	return s
+}
+
+// subjects returns the images built by the TaskRun, sorted by name
+func subjects(tr *v1beta1.TaskRun) []Subject {
+	subjects := []Subject{}
+	for _, values := range resourceResults(tr) {
+		if values["digest"] != "" && values["url"] != "" {
+			if digest, ok := parseDigest(values["digest"]); ok {
+				subjects = append(subjects, Subject{Name: values["url"], Digest: digest})
+			}
+		}
+	}
+	var url, digest string
+	for _, result := range tr.Status.TaskRunResults {
+		switch result.Name {
+		case ImageURLResult:
+			url = strings.TrimSpace(result.Value)
+		case ImageDigestResult:
+			digest = strings.TrimSpace(result.Value)
+		}
+	}
+	if d, ok := parseDigest(digest); ok && url != "" {
+		subjects = append(subjects, Subject{Name: url, Digest: d})
+	}
+	sort.Slice(subjects, func(i, j int) bool { return subjects[i].Name < subjects[j].Name })
+	return subjects
+}
+
+// materials returns the images of the steps of the TaskRun and the git commits it fetched
+func materials(tr *v1beta1.TaskRun) []Material {
+	var materials []Material
+	seen := map[string]bool{}
+	for _, step := range tr.Status.Steps {
+		// Image IDs look like docker-pullable://gcr.io/project/image@sha256:1234
+		imageID := step.ImageID
+		if i := strings.Index(imageID, "://"); i >= 0 {
+			imageID = imageID[i+3:]
+		}
+		parts := strings.SplitN(imageID, "@", 2)
+		if len(parts) != 2 || seen[imageID] {
+			continue
+		}
+		digest, ok := parseDigest(parts[1])
+		if !ok {
+			continue
+		}
+		seen[imageID] = true
+		materials = append(materials, Material{URI: "oci://" + parts[0], Digest: digest})
+	}
+	for _, values := range resourceResults(tr) {
+		if values["commit"] != "" && values["url"] != "" {
+			materials = append(materials, Material{
+				URI:    "git+" + values["url"],
+				Digest: DigestSet{"sha1": values["commit"]},
+			})
+		}
+	}
+	return materials
+}
+
+// resourceResults groups the results of the PipelineResources of the TaskRun by resource, sorted
+// by resource name
+func resourceResults(tr *v1beta1.TaskRun) []map[string]string {
+	byResource := map[string]map[string]string{}
+	var names []string
+	for _, result := range tr.Status.ResourcesResult {
+		name := result.ResourceName
+		if name == "" && result.ResourceRef != nil {
+			name = result.ResourceRef.Name
+		}
+		if name == "" {
+			continue
+		}
+		if _, ok := byResource[name]; !ok {
+			byResource[name] = map[string]string{}
+			names = append(names, name)
+		}
+		byResource[name][result.Key] = result.Value
+	}
+	sort.Strings(names)
+	results := make([]map[string]string, 0, len(names))
+	for _, name := range names {
+		results = append(results, byResource[name])
+	}
+	return results
+}
+
+// parseDigest parses a digest like sha256:1234 into a DigestSet
+func parseDigest(digest string) (DigestSet, bool) {
+	parts := strings.SplitN(digest, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, false
+	}
+	return DigestSet{parts[0]: parts[1]}, true
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provenance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewStatement(t *testing.T) {
+	start := time.Date(2020, 11, 2, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Minute)
+	taskSpec := &v1beta1.TaskSpec{Steps: []v1beta1.Step{{Container: corev1.Container{Name: "build", Image: "golang"}}}}
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "foo", UID: "1234"},
+		Spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "build-task"},
+			Params: []v1beta1.Param{{
+				Name:  "revision",
+				Value: *v1beta1.NewArrayOrString("main"),
+			}},
+		},
+		Status: v1beta1.TaskRunStatus{
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				StartTime:      &metav1.Time{Time: start},
+				CompletionTime: &metav1.Time{Time: end},
+				TaskSpec:       taskSpec,
+				Steps: []v1beta1.StepState{{
+					Name:    "git-source-source-abcde",
+					ImageID: "docker-pullable://gcr.io/tekton/git-init@sha256:aaaa",
+				}, {
+					Name:     "build",
+					ImageID:  "docker-pullable://golang@sha256:bbbb",
+					Hermetic: true,
+				}, {
+					Name:    "image-digest-exporter-fghij",
+					ImageID: "docker-pullable://gcr.io/tekton/imagedigestexporter@sha256:cccc",
+				}},
+				ResourcesResult: []v1beta1.PipelineResourceResult{{
+					Key:          "commit",
+					Value:        "0123abcd",
+					ResourceName: "source",
+				}, {
+					Key:          "url",
+					Value:        "https://github.com/tektoncd/pipeline",
+					ResourceName: "source",
+				}, {
+					Key:          "digest",
+					Value:        "sha256:dddd",
+					ResourceName: "image",
+				}, {
+					Key:          "url",
+					Value:        "gcr.io/foo/app",
+					ResourceName: "image",
+				}, {
+					// Not an image: without a url, the digest can't be a subject
+					Key:          "digest",
+					Value:        "sha256:eeee",
+					ResourceName: "other",
+				}},
+				TaskRunResults: []v1beta1.TaskRunResult{{
+					Name:  ImageURLResult,
+					Value: "gcr.io/foo/bar\n",
+				}, {
+					Name:  ImageDigestResult,
+					Value: "sha256:ffff",
+				}},
+			},
+		},
+	}
+
+	want := &Statement{
+		Type:          StatementType,
+		PredicateType: PredicateType,
+		Subject: []Subject{{
+			Name:   "gcr.io/foo/app",
+			Digest: DigestSet{"sha256": "dddd"},
+		}, {
+			Name:   "gcr.io/foo/bar",
+			Digest: DigestSet{"sha256": "ffff"},
+		}},
+		Predicate: Predicate{
+			Builder: Builder{ID: "https://example.com/builder"},
+			Recipe: Recipe{
+				Type:       RecipeType,
+				EntryPoint: "build-task",
+				Arguments:  tr.Spec.Params,
+				Environment: Environment{
+					TaskSpec: taskSpec,
+					Steps: []Step{{
+						Name:    "git-source-source-abcde",
+						ImageID: "docker-pullable://gcr.io/tekton/git-init@sha256:aaaa",
+					}, {
+						Name:     "build",
+						ImageID:  "docker-pullable://golang@sha256:bbbb",
+						Hermetic: true,
+					}, {
+						Name:    "image-digest-exporter-fghij",
+						ImageID: "docker-pullable://gcr.io/tekton/imagedigestexporter@sha256:cccc",
+					}},
+				},
+			},
+			Metadata: Metadata{
+				BuildInvocationID: "1234",
+				BuildStartedOn:    &start,
+				BuildFinishedOn:   &end,
+				Completeness:      Completeness{Arguments: true},
+			},
+			Materials: []Material{{
+				URI:    "oci://gcr.io/tekton/git-init",
+				Digest: DigestSet{"sha256": "aaaa"},
+			}, {
+				URI:    "oci://golang",
+				Digest: DigestSet{"sha256": "bbbb"},
+			}, {
+				URI:    "oci://gcr.io/tekton/imagedigestexporter",
+				Digest: DigestSet{"sha256": "cccc"},
+			}, {
+				URI:    "git+https://github.com/tektoncd/pipeline",
+				Digest: DigestSet{"sha1": "0123abcd"},
+			}},
+		},
+	}
+	got := NewStatement(tr, "https://example.com/builder")
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected statement (-want, +got): %s", d)
+	}
+}
+
+func TestNewStatement_NoArtifacts(t *testing.T) {
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "foo"},
+		Spec: v1beta1.TaskRunSpec{
+			TaskSpec: &v1beta1.TaskSpec{},
+		},
+	}
+	got := NewStatement(tr, "builder")
+	if len(got.Subject) != 0 {
+		t.Errorf("Expected no subjects, got %v", got.Subject)
+	}
+	if len(got.Predicate.Materials) != 0 {
+		t.Errorf("Expected no materials, got %v", got.Predicate.Materials)
+	}
+	if got.Predicate.Recipe.EntryPoint != "" {
+		t.Errorf("Expected no entry point for an embedded TaskSpec, got %q", got.Predicate.Recipe.EntryPoint)
+	}
+}
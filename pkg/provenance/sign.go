@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provenance
+
+import (
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/signature"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// PayloadType is the type of the payload of the envelopes: an in-toto statement
+	PayloadType = "application/vnd.in-toto+json"
+
+	// SigningSecretKey is the key of the signing Secret holding the PEM encoded private key
+	SigningSecretKey = "private.key"
+)
+
+// Envelope is a DSSE envelope holding a signed in-toto statement
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+// Signature is the signature of an envelope payload
+type Signature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// LoadSigner reads the private key of the signing Secret.
+func LoadSigner(secret *corev1.Secret) (crypto.Signer, error) {
+	key, ok := secret.Data[SigningSecretKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %q has no %q key", secret.Name, SigningSecretKey)
+	}
+	signer, err := signature.ParsePrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the private key of secret %q: %w", secret.Name, err)
+	}
+	return signer, nil
+}
+
+// Sign signs the statement, returning it in a DSSE envelope.
+func Sign(s *Statement, signer crypto.Signer) (*Envelope, error) {
+	payload, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding the provenance: %w", err)
+	}
+	sig, err := signature.Sign(signer, pae(PayloadType, payload))
+	if err != nil {
+		return nil, fmt.Errorf("error signing the provenance: %w", err)
+	}
+	keyID, err := signature.KeyID(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &Envelope{
+		PayloadType: PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures: []Signature{{
+			KeyID: keyID,
+			Sig:   base64.StdEncoding.EncodeToString(sig),
+		}},
+	}, nil
+}
+
+// Verify checks that the envelope was signed with the private key of pub, and returns the
+// statement it holds.
+func Verify(env *Envelope, pub crypto.PublicKey) (*Statement, error) {
+	payload, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding the payload: %w", err)
+	}
+	var verifyErr error = fmt.Errorf("envelope has no signatures")
+	for _, s := range env.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(s.Sig)
+		if err != nil {
+			verifyErr = fmt.Errorf("error decoding the signature: %w", err)
+			continue
+		}
+		if verifyErr = signature.Verify(pub, pae(env.PayloadType, payload), sig); verifyErr == nil {
+			break
+		}
+	}
+	if verifyErr != nil {
+		return nil, verifyErr
+	}
+	var s Statement
+	if err := json.Unmarshal(payload, &s); err != nil {
+		return nil, fmt.Errorf("error decoding the statement: %w", err)
+	}
+	return &s, nil
+}
+
+// pae returns the DSSE pre-authentication encoding of the payload, which is what gets signed.
+func pae(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provenance
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/signature"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func signingSecret(t *testing.T) (*corev1.Secret, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Couldn't generate key: %v", err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Couldn't encode key: %v", err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "signing-secret"},
+		Data: map[string][]byte{
+			SigningSecretKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}),
+		},
+	}, key
+}
+
+func TestSignAndVerify(t *testing.T) {
+	secret, key := signingSecret(t)
+	signer, err := LoadSigner(secret)
+	if err != nil {
+		t.Fatalf("Unexpected error loading the signer: %v", err)
+	}
+	statement := &Statement{
+		Type:          StatementType,
+		PredicateType: PredicateType,
+		Subject:       []Subject{{Name: "gcr.io/foo/bar", Digest: DigestSet{"sha256": "1234"}}},
+		Predicate:     Predicate{Builder: Builder{ID: "builder"}},
+	}
+	env, err := Sign(statement, signer)
+	if err != nil {
+		t.Fatalf("Unexpected error signing: %v", err)
+	}
+	if env.PayloadType != PayloadType {
+		t.Errorf("Expected payload type %q, got %q", PayloadType, env.PayloadType)
+	}
+	keyID, err := signature.KeyID(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(env.Signatures) != 1 || env.Signatures[0].KeyID != keyID {
+		t.Errorf("Expected one signature with key ID %q, got %v", keyID, env.Signatures)
+	}
+
+	got, err := Verify(env, key.Public())
+	if err != nil {
+		t.Fatalf("Unexpected error verifying: %v", err)
+	}
+	if d := cmp.Diff(statement, got); d != "" {
+		t.Errorf("Unexpected statement (-want, +got): %s", d)
+	}
+
+	// Tampering with the payload must invalidate the signature
+	env.Payload = base64.StdEncoding.EncodeToString([]byte(`{"_type":"tampered"}`))
+	if _, err := Verify(env, key.Public()); err == nil {
+		t.Error("Expected an error verifying a tampered envelope")
+	}
+
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Verify(&Envelope{PayloadType: PayloadType}, other.Public()); err == nil {
+		t.Error("Expected an error verifying an envelope without signatures")
+	}
+}
+
+func TestLoadSigner_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		desc   string
+		secret *corev1.Secret
+	}{{
+		desc:   "missing key",
+		secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "s"}, Data: map[string][]byte{"other": []byte("foo")}},
+	}, {
+		desc:   "invalid key",
+		secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "s"}, Data: map[string][]byte{SigningSecretKey: []byte("foo")}},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := LoadSigner(tc.secret); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provenance
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+const (
+	// SignedAnnotation marks the TaskRuns whose provenance has been signed
+	SignedAnnotation = pipeline.GroupName + "/provenance-signed"
+
+	// PayloadAnnotation holds the base64 encoded in-toto statement of the TaskRun
+	PayloadAnnotation = pipeline.GroupName + "/provenance-payload"
+
+	// SignatureAnnotation holds the base64 encoded signature of the in-toto statement
+	SignatureAnnotation = pipeline.GroupName + "/provenance-signature"
+
+	// KeyIDAnnotation holds the ID of the key that signed the in-toto statement
+	KeyIDAnnotation = pipeline.GroupName + "/provenance-keyid"
+
+	// AttestationsAnnotation lists the references of the attestations pushed to the registry
+	AttestationsAnnotation = pipeline.GroupName + "/provenance-attestations"
+
+	// FailedAnnotation holds why the provenance of the TaskRun couldn't be signed or stored
+	FailedAnnotation = pipeline.GroupName + "/provenance-failed"
+
+	// maxAnnotationsSize is the maximum total size of the annotations of a Kubernetes object
+	maxAnnotationsSize = 256 * (1 << 10)
+
+	// EnvelopeMediaType is the media type of the layer holding the envelope of an attestation
+	EnvelopeMediaType types.MediaType = "application/vnd.dsse.envelope.v1+json"
+
+	// attestationTagSuffix is the suffix of the tags of the attestations
+	attestationTagSuffix = ".att"
+)
+
+// IsSigned returns true if the provenance of the TaskRun has already been signed.
+func IsSigned(tr *v1beta1.TaskRun) bool {
+	return tr.Annotations[SignedAnnotation] == "true"
+}
+
+// HasFailed returns true if the provenance of the TaskRun couldn't be signed or stored, and
+// won't be retried.
+func HasFailed(tr *v1beta1.TaskRun) bool {
+	_, ok := tr.Annotations[FailedAnnotation]
+	return ok
+}
+
+// MarkFailed records why the provenance of the TaskRun couldn't be signed or stored in the
+// annotations of the TaskRun, so that it isn't retried.
+func MarkFailed(tr *v1beta1.TaskRun, err error) {
+	if tr.Annotations == nil {
+		tr.Annotations = map[string]string{}
+	}
+	tr.Annotations[FailedAnnotation] = err.Error()
+}
+
+// StoreInAnnotations stores the signed provenance in the annotations of the TaskRun. It returns
+// an error, leaving the annotations untouched, if they would exceed the size allowed by Kubernetes.
+func StoreInAnnotations(tr *v1beta1.TaskRun, env *Envelope) error {
+	annotations := map[string]string{}
+	for k, v := range tr.Annotations {
+		annotations[k] = v
+	}
+	annotations[PayloadAnnotation] = env.Payload
+	if len(env.Signatures) > 0 {
+		annotations[SignatureAnnotation] = env.Signatures[0].Sig
+		annotations[KeyIDAnnotation] = env.Signatures[0].KeyID
+	}
+	annotations[SignedAnnotation] = "true"
+
+	size := 0
+	for k, v := range annotations {
+		size += len(k) + len(v)
+	}
+	if size > maxAnnotationsSize {
+		return fmt.Errorf("the signed provenance would make the annotations of the TaskRun %d bytes long, more than the %d bytes allowed", size, maxAnnotationsSize)
+	}
+	tr.Annotations = annotations
+	return nil
+}
+
+// MarkPushed records the references of the attestations pushed to the registry in the
+// annotations of the TaskRun.
+func MarkPushed(tr *v1beta1.TaskRun, refs []string) {
+	if tr.Annotations == nil {
+		tr.Annotations = map[string]string{}
+	}
+	tr.Annotations[AttestationsAnnotation] = strings.Join(refs, ",")
+	tr.Annotations[SignedAnnotation] = "true"
+}
+
+// AttestationTag returns the reference the attestation of the subject is pushed to: the
+// repository of the subject, tagged with its digest, e.g. gcr.io/foo/bar:sha256-1234.att.
+func AttestationTag(subject Subject, opts ...name.Option) (name.Tag, error) {
+	algorithm, digest := "sha256", subject.Digest["sha256"]
+	if digest == "" {
+		return name.Tag{}, fmt.Errorf("subject %q has no sha256 digest", subject.Name)
+	}
+	ref, err := name.ParseReference(subject.Name, opts...)
+	if err != nil {
+		return name.Tag{}, fmt.Errorf("invalid subject %q: %w", subject.Name, err)
+	}
+	return ref.Context().Tag(algorithm + "-" + digest + attestationTagSuffix), nil
+}
+
+// PushToOCI pushes the signed provenance next to each of its subjects in their registry,
+// returning the references of the pushed attestations.
+func PushToOCI(env *Envelope, subjects []Subject, opts ...remote.Option) ([]string, error) {
+	b, err := json.Marshal(env)
+	if err != nil {
+		return nil, err
+	}
+	img, err := mutate.AppendLayers(empty.Image, newEnvelopeLayer(b))
+	if err != nil {
+		return nil, err
+	}
+	refs := []string{}
+	for _, subject := range subjects {
+		tag, err := AttestationTag(subject)
+		if err != nil {
+			return refs, err
+		}
+		if err := remote.Write(tag, img, opts...); err != nil {
+			return refs, fmt.Errorf("error pushing the attestation of %q: %w", subject.Name, err)
+		}
+		refs = append(refs, tag.String())
+	}
+	return refs, nil
+}
+
+// envelopeLayer is an uncompressed layer holding an envelope
+type envelopeLayer struct {
+	content []byte
+	digest  v1.Hash
+}
+
+var _ v1.Layer = (*envelopeLayer)(nil)
+
+func newEnvelopeLayer(content []byte) *envelopeLayer {
+	sum := sha256.Sum256(content)
+	return &envelopeLayer{
+		content: content,
+		digest:  v1.Hash{Algorithm: "sha256", Hex: hex.EncodeToString(sum[:])},
+	}
+}
+
+func (l *envelopeLayer) Digest() (v1.Hash, error) { return l.digest, nil }
+
+func (l *envelopeLayer) DiffID() (v1.Hash, error) { return l.digest, nil }
+
+func (l *envelopeLayer) Compressed() (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewReader(l.content)), nil
+}
+
+func (l *envelopeLayer) Uncompressed() (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewReader(l.content)), nil
+}
+
+func (l *envelopeLayer) Size() (int64, error) { return int64(len(l.content)), nil }
+
+func (l *envelopeLayer) MediaType() (types.MediaType, error) { return EnvelopeMediaType, nil }
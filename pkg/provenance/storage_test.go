@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provenance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStoreInAnnotations(t *testing.T) {
+	tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	if IsSigned(tr) {
+		t.Fatal("Expected the TaskRun not to be signed")
+	}
+	if err := StoreInAnnotations(tr, &Envelope{
+		PayloadType: PayloadType,
+		Payload:     "cGF5bG9hZA==",
+		Signatures:  []Signature{{KeyID: "1234", Sig: "c2ln"}},
+	}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := map[string]string{
+		SignedAnnotation:    "true",
+		PayloadAnnotation:   "cGF5bG9hZA==",
+		SignatureAnnotation: "c2ln",
+		KeyIDAnnotation:     "1234",
+	}
+	if d := cmp.Diff(want, tr.Annotations); d != "" {
+		t.Errorf("Unexpected annotations (-want, +got): %s", d)
+	}
+	if !IsSigned(tr) {
+		t.Error("Expected the TaskRun to be signed")
+	}
+}
+
+func TestStoreInAnnotationsTooLarge(t *testing.T) {
+	tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{
+		Name:        "test",
+		Annotations: map[string]string{"foo": "bar"},
+	}}
+	err := StoreInAnnotations(tr, &Envelope{
+		PayloadType: PayloadType,
+		Payload:     strings.Repeat("a", maxAnnotationsSize),
+		Signatures:  []Signature{{KeyID: "1234", Sig: "c2ln"}},
+	})
+	if err == nil {
+		t.Fatal("Expected an error storing a provenance larger than the annotations size limit")
+	}
+	if d := cmp.Diff(map[string]string{"foo": "bar"}, tr.Annotations); d != "" {
+		t.Errorf("Expected the annotations to be left untouched (-want, +got): %s", d)
+	}
+}
+
+func TestMarkFailed(t *testing.T) {
+	tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	if HasFailed(tr) {
+		t.Fatal("Expected the TaskRun provenance not to have failed")
+	}
+	MarkFailed(tr, errors.New("secret not found"))
+	if !HasFailed(tr) || tr.Annotations[FailedAnnotation] != "secret not found" {
+		t.Errorf("Expected the failure to be recorded, got annotations %v", tr.Annotations)
+	}
+}
+
+func TestAttestationTag(t *testing.T) {
+	tag, err := AttestationTag(Subject{Name: "gcr.io/foo/bar:latest", Digest: DigestSet{"sha256": "1234"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := "gcr.io/foo/bar:sha256-1234.att"; tag.String() != want {
+		t.Errorf("Expected tag %q, got %q", want, tag.String())
+	}
+	if _, err := AttestationTag(Subject{Name: "gcr.io/foo/bar", Digest: DigestSet{"sha512": "1234"}}); err == nil {
+		t.Error("Expected an error for a subject without a sha256 digest")
+	}
+}
+
+func TestPushToOCI(t *testing.T) {
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env := &Envelope{
+		PayloadType: PayloadType,
+		Payload:     "cGF5bG9hZA==",
+		Signatures:  []Signature{{KeyID: "1234", Sig: "c2ln"}},
+	}
+	subjects := []Subject{{
+		Name:   fmt.Sprintf("%s/foo/bar", u.Host),
+		Digest: DigestSet{"sha256": "1234"},
+	}}
+	refs, err := PushToOCI(env, subjects)
+	if err != nil {
+		t.Fatalf("Unexpected error pushing the attestation: %v", err)
+	}
+	wantRef := fmt.Sprintf("%s/foo/bar:sha256-1234.att", u.Host)
+	if d := cmp.Diff([]string{wantRef}, refs); d != "" {
+		t.Errorf("Unexpected references (-want, +got): %s", d)
+	}
+
+	ref, err := name.ParseReference(wantRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := remote.Image(ref)
+	if err != nil {
+		t.Fatalf("Couldn't pull the attestation: %v", err)
+	}
+	layers, err := img.Layers()
+	if err != nil || len(layers) != 1 {
+		t.Fatalf("Expected one layer, got %d (%v)", len(layers), err)
+	}
+	if mt, err := layers[0].MediaType(); err != nil || mt != EnvelopeMediaType {
+		t.Errorf("Expected media type %q, got %q (%v)", EnvelopeMediaType, mt, err)
+	}
+	// The envelope is stored as is, so the blob is read without decompressing it
+	rc, err := layers[0].Compressed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	b, err := ioutil.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Envelope
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Couldn't decode the envelope: %v", err)
+	}
+	if d := cmp.Diff(env, &got); d != "" {
+		t.Errorf("Unexpected envelope (-want, +got): %s", d)
+	}
+}
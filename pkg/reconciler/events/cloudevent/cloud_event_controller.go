@@ -115,8 +115,8 @@ func SendCloudEvents(tr *v1beta1.TaskRun, ceclient CEClient, logger *zap.Sugared
 }
 
 // SendCloudEventWithRetries sends a cloud event for the specified resource.
-// It does not block and it perform retries with backoff using the cloudevents
-// sdk-go capabilities.
+// It does not block and it perform retries with backoff, through the Dispatcher
+// of the context when there is one, or else using the cloudevents sdk-go capabilities.
 // It accepts a runtime.Object to avoid making objectWithCondition public since
 // it's only used within the events/cloudevents packages.
 func SendCloudEventWithRetries(ctx context.Context, object runtime.Object) error {
@@ -127,7 +127,6 @@ func SendCloudEventWithRetries(ctx context.Context, object runtime.Object) error
 	if o, ok = object.(objectWithCondition); !ok {
 		return errors.New("Input object does not satisfy objectWithCondition")
 	}
-	logger := logging.FromContext(ctx)
 	ceClient := Get(ctx)
 	if ceClient == nil {
 		return errors.New("No cloud events client found in the context")
@@ -136,7 +135,25 @@ func SendCloudEventWithRetries(ctx context.Context, object runtime.Object) error
 	if err != nil {
 		return err
 	}
+	return sendWithRetries(ctx, ceClient, object, event)
+}
+
+// SendEventWithRetries sends a cloud event about the specified resource.
+// It does not block and it perform retries with backoff, through the Dispatcher
+// of the context when there is one, or else using the cloudevents sdk-go capabilities.
+func SendEventWithRetries(ctx context.Context, object runtime.Object, event *cloudevents.Event) error {
+	ceClient := Get(ctx)
+	if ceClient == nil {
+		return errors.New("No cloud events client found in the context")
+	}
+	return sendWithRetries(ctx, ceClient, object, event)
+}
 
+func sendWithRetries(ctx context.Context, ceClient CEClient, object runtime.Object, event *cloudevents.Event) error {
+	if d := GetDispatcher(ctx); d != nil {
+		return d.Dispatch(ctx, object, *event)
+	}
+	logger := logging.FromContext(ctx)
 	wasIn := make(chan error)
 	go func() {
 		wasIn <- nil
@@ -61,6 +61,20 @@ const (
 	PipelineRunSuccessfulEventV1 TektonEventType = "dev.tekton.event.pipelinerun.successful.v1"
 	// PipelineRunFailedEventV1 is sent for PipelineRuns with "ConditionSucceeded" "False"
 	PipelineRunFailedEventV1 TektonEventType = "dev.tekton.event.pipelinerun.failed.v1"
+	// PipelineRunQueuedEventV1 is sent for PipelineRuns with "ConditionSucceeded" "Unknown"
+	// while they wait for other PipelineRuns to complete before they start
+	PipelineRunQueuedEventV1 TektonEventType = "dev.tekton.event.pipelinerun.queued.v1"
+	// PipelineRunTaskStartedEventV1 is sent when the TaskRun of a pipeline task of a
+	// PipelineRun is created
+	PipelineRunTaskStartedEventV1 TektonEventType = "dev.tekton.event.pipelinerun.task.started.v1"
+	// PipelineRunTaskSkippedEventV1 is sent when a pipeline task of a PipelineRun is skipped
+	PipelineRunTaskSkippedEventV1 TektonEventType = "dev.tekton.event.pipelinerun.task.skipped.v1"
+	// PipelineRunTaskRetriedEventV1 is sent when the TaskRun of a pipeline task of a
+	// PipelineRun is retried
+	PipelineRunTaskRetriedEventV1 TektonEventType = "dev.tekton.event.pipelinerun.task.retried.v1"
+	// PipelineRunResultsAvailableEventV1 is sent when a pipeline task of a PipelineRun
+	// succeeds with results, and when the results of the PipelineRun are available
+	PipelineRunResultsAvailableEventV1 TektonEventType = "dev.tekton.event.pipelinerun.results.available.v1"
 )
 
 func (t TektonEventType) String() string {
@@ -75,6 +89,24 @@ type CEClient cloudevents.Client
 type TektonCloudEventData struct {
 	TaskRun     *v1beta1.TaskRun     `json:"taskRun,omitempty"`
 	PipelineRun *v1beta1.PipelineRun `json:"pipelineRun,omitempty"`
+	// PipelineRunProgress is set instead of PipelineRun for the events about the
+	// progress of a PipelineRun
+	PipelineRunProgress *PipelineRunProgress `json:"pipelineRunProgress,omitempty"`
+}
+
+// PipelineRunProgress holds the slice of the status of a PipelineRun which an event
+// about its progress is about.
+type PipelineRunProgress struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// TaskRunName and TaskRun are the name and status of the TaskRun of the pipeline
+	// task which was started, retried, or whose results are available
+	TaskRunName string                            `json:"taskRunName,omitempty"`
+	TaskRun     *v1beta1.PipelineRunTaskRunStatus `json:"taskRun,omitempty"`
+	// SkippedTask is the pipeline task which was skipped
+	SkippedTask *v1beta1.SkippedTask `json:"skippedTask,omitempty"`
+	// PipelineResults are the results of the PipelineRun, once they are available
+	PipelineResults []v1beta1.PipelineRunResult `json:"pipelineResults,omitempty"`
 }
 
 // NewTektonCloudEventData returns a new instance of NewTektonCloudEventData
@@ -155,6 +187,8 @@ func getEventType(runObject objectWithCondition) (*TektonEventType, error) {
 				eventType = PipelineRunStartedEventV1
 			case v1beta1.PipelineRunReasonRunning.String():
 				eventType = PipelineRunRunningEventV1
+			case v1beta1.PipelineRunReasonQueued.String():
+				eventType = PipelineRunQueuedEventV1
 			default:
 				eventType = PipelineRunUnknownEventV1
 			}
@@ -153,6 +153,10 @@ func TestEventForPipelineRun(t *testing.T) {
 		desc:          "send a cloud event with unknown status pipelinerun, just started running",
 		pipelineRun:   getPipelineRunByCondition(corev1.ConditionUnknown, v1beta1.PipelineRunReasonRunning.String()),
 		wantEventType: PipelineRunRunningEventV1,
+	}, {
+		desc:          "send a cloud event with unknown status pipelinerun, queued",
+		pipelineRun:   getPipelineRunByCondition(corev1.ConditionUnknown, v1beta1.PipelineRunReasonQueued.String()),
+		wantEventType: PipelineRunQueuedEventV1,
 	}, {
 		desc:          "send a cloud event with unknown status pipelinerun",
 		pipelineRun:   getPipelineRunByCondition(corev1.ConditionUnknown, "doesn't matter"),
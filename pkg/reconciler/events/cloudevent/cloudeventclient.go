@@ -56,6 +56,9 @@ func withCloudEventClient(ctx context.Context, cfg *rest.Config) context.Context
 		logger.Panicf("Error creating the cloudevents client: %s", err)
 	}
 
+	dispatcher := NewDispatcher(cloudEventClient, logger, DefaultDispatcherOptions)
+	dispatcher.Start(ctx.Done())
+	ctx = WithDispatcher(ctx, dispatcher)
 	return context.WithValue(ctx, CECKey{}, cloudEventClient)
 }
 
@@ -0,0 +1,451 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/controller"
+)
+
+// DispatcherOptions configures the delivery of cloud events by a Dispatcher
+type DispatcherOptions struct {
+	// QueueSize is the maximum number of cloud events waiting to be delivered,
+	// including the ones waiting to be retried
+	QueueSize int
+	// Workers is the number of cloud events delivered at the same time
+	Workers int
+	// MaxAttempts is the number of attempts to deliver a cloud event before
+	// giving up and sending it to the dead-letter sink, if any
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry, it doubles with each
+	// retry up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// DefaultDispatcherOptions retry the delivery of a cloud event for about 4 minutes
+var DefaultDispatcherOptions = DispatcherOptions{
+	QueueSize:      1000,
+	Workers:        4,
+	MaxAttempts:    10,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     5 * time.Minute,
+}
+
+var (
+	// errQueueFull is the error of the cloud events dropped because the delivery queue is full
+	errQueueFull = errors.New("the delivery queue is full")
+	// errStopped is the error of the cloud events given up on because the dispatcher is stopped
+	errStopped = errors.New("the dispatcher is stopped")
+)
+
+// run identifies the PipelineRun or TaskRun a cloud event is about
+type run struct {
+	kind string
+	uid  types.UID
+	key  types.NamespacedName
+}
+
+// delivery is a cloud event on its way to a target
+type delivery struct {
+	event  cloudevents.Event
+	target string
+	// deadLetterSink is where the cloud event is sent when it cannot be delivered to target
+	deadLetterSink string
+	attempts       int
+
+	run      run
+	object   runtime.Object
+	recorder record.EventRecorder
+}
+
+// runDeliveries is the state of the delivery of the cloud events of a run to each target
+type runDeliveries struct {
+	// pending is the number of cloud events of the run neither delivered nor given up on yet
+	pending int
+	states  map[string]*v1beta1.CloudEventDeliveryState
+}
+
+// state returns the delivery state of the cloud events of the run to target
+func (rd *runDeliveries) state(target string) *v1beta1.CloudEventDeliveryState {
+	if rd == nil {
+		// The run was forgotten, the state of its cloud events is not recorded anymore
+		return &v1beta1.CloudEventDeliveryState{}
+	}
+	state, ok := rd.states[target]
+	if !ok {
+		state = &v1beta1.CloudEventDeliveryState{Condition: v1beta1.CloudEventConditionUnknown}
+		rd.states[target] = state
+	}
+	return state
+}
+
+// Dispatcher delivers the cloud events of PipelineRuns and TaskRuns in the background.
+// Cloud events wait in a bounded in-memory queue, their delivery is retried with an
+// exponential backoff, and the ones which cannot be delivered are sent to the dead-letter
+// sink, if any. The state of the delivery of the cloud events of each run is kept until
+// it is recorded in the status of the run.
+type Dispatcher struct {
+	client  CEClient
+	logger  *zap.SugaredLogger
+	metrics *Recorder
+	options DispatcherOptions
+	queue   chan *delivery
+	stopCh  <-chan struct{}
+
+	mu sync.Mutex
+	// size is the number of cloud events queued or waiting to be retried
+	size      int
+	runs      map[types.UID]*runDeliveries
+	callbacks map[string]func(types.NamespacedName)
+}
+
+// NewDispatcher returns a Dispatcher delivering cloud events with client
+func NewDispatcher(client CEClient, logger *zap.SugaredLogger, options DispatcherOptions) *Dispatcher {
+	metrics, err := NewRecorder()
+	if err != nil {
+		logger.Errorf("Failed to create cloud events metrics recorder %v", err)
+	}
+	return &Dispatcher{
+		client:    client,
+		logger:    logger,
+		metrics:   metrics,
+		options:   options,
+		queue:     make(chan *delivery, options.QueueSize),
+		runs:      map[types.UID]*runDeliveries{},
+		callbacks: map[string]func(types.NamespacedName){},
+	}
+}
+
+// Start starts delivering the queued cloud events, until stopCh is closed
+func (d *Dispatcher) Start(stopCh <-chan struct{}) {
+	d.stopCh = stopCh
+	for i := 0; i < d.options.Workers; i++ {
+		go func() {
+			for {
+				select {
+				case <-stopCh:
+					return
+				case dl := <-d.queue:
+					d.deliver(dl)
+				}
+			}
+		}()
+	}
+}
+
+// SetCallbackFunc sets the function called with the key of a run of kind once all its
+// cloud events are delivered or given up on, so that their state can be recorded in
+// the status of the run
+func (d *Dispatcher) SetCallbackFunc(kind string, f func(types.NamespacedName)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.callbacks[kind] = f
+}
+
+// Dispatch queues event about object for delivery to the target of ctx. The cloud events
+// which cannot be delivered are sent to the dead-letter sink of the configuration of ctx,
+// if any. It does not block, and it returns an error if the event is dropped because the
+// queue is full.
+func (d *Dispatcher) Dispatch(ctx context.Context, object runtime.Object, event cloudevents.Event) error {
+	r, err := runOf(object)
+	if err != nil {
+		return err
+	}
+	target := cloudevents.TargetFromContext(ctx)
+	if target == nil {
+		return errors.New("No target for the cloud event found in the context")
+	}
+	dl := &delivery{
+		event:          event,
+		target:         target.String(),
+		deadLetterSink: config.FromContextOrDefaults(ctx).Defaults.DefaultCloudEventsDeadLetterSink,
+		run:            r,
+		object:         object,
+		recorder:       controller.GetEventRecorder(ctx),
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	rd, ok := d.runs[r.uid]
+	if !ok {
+		rd = &runDeliveries{states: map[string]*v1beta1.CloudEventDeliveryState{}}
+		d.runs[r.uid] = rd
+	}
+	if err := d.enqueue(dl, rd); err != nil {
+		return fmt.Errorf("dropped cloud event %s for %s: %w", event.ID(), dl.target, err)
+	}
+	return nil
+}
+
+// enqueue queues dl, it must be called with the lock held
+func (d *Dispatcher) enqueue(dl *delivery, rd *runDeliveries) error {
+	state := rd.state(dl.target)
+	if d.size >= d.options.QueueSize {
+		state.Condition = v1beta1.CloudEventConditionFailed
+		state.Error = errQueueFull.Error()
+		d.count(d.metrics.Dropped, dl)
+		return errQueueFull
+	}
+	d.size++
+	if rd != nil {
+		rd.pending++
+	}
+	// The queue has room for all the cloud events counted in size, but the send must not
+	// block while the lock is held regardless.
+	select {
+	case d.queue <- dl:
+	default:
+		d.size--
+		if rd != nil {
+			rd.pending--
+		}
+		state.Condition = v1beta1.CloudEventConditionFailed
+		state.Error = errQueueFull.Error()
+		d.count(d.metrics.Dropped, dl)
+		return errQueueFull
+	}
+	return nil
+}
+
+// deliver sends dl to its target, and retries it later if it fails
+func (d *Dispatcher) deliver(dl *delivery) {
+	dl.attempts++
+	result := d.client.Send(cloudevents.ContextWithTarget(context.Background(), dl.target), dl.event)
+	// The time is truncated to the precision it has in the status of the run
+	sentAt := metav1.Now().Rfc3339Copy()
+
+	d.mu.Lock()
+	rd := d.runs[dl.run.uid]
+	state := rd.state(dl.target)
+	state.SentAt = &sentAt
+	state.RetryCount++
+	if cloudevents.IsACK(result) {
+		state.Condition = v1beta1.CloudEventConditionSent
+		state.Error = ""
+		d.count(d.metrics.Delivered, dl)
+		callback := d.done(dl, rd)
+		d.mu.Unlock()
+		callback()
+		return
+	}
+
+	state.Condition = v1beta1.CloudEventConditionFailed
+	state.Error = result.Error()
+	if dl.attempts < d.options.MaxAttempts {
+		d.mu.Unlock()
+		// The cloud event is still counted in the size of the queue while waiting
+		time.AfterFunc(d.backoff(dl.attempts), func() { d.retry(dl) })
+		return
+	}
+	callback := d.giveUp(dl, rd, result)
+	d.mu.Unlock()
+	callback()
+}
+
+// retry queues dl again for delivery. It does not block: when the queue is full or the
+// dispatcher is stopped, dl is given up on and sent to the dead-letter sink, if any.
+func (d *Dispatcher) retry(dl *delivery) {
+	err := errStopped
+	select {
+	case <-d.stopCh:
+	default:
+		select {
+		case d.queue <- dl:
+			return
+		case <-d.stopCh:
+		default:
+			err = errQueueFull
+		}
+	}
+
+	d.mu.Lock()
+	rd := d.runs[dl.run.uid]
+	state := rd.state(dl.target)
+	state.Condition = v1beta1.CloudEventConditionFailed
+	state.Error = err.Error()
+	callback := d.giveUp(dl, rd, err)
+	d.mu.Unlock()
+	callback()
+}
+
+// giveUp gives up on delivering dl because of err, and sends it to the dead-letter sink, if
+// any, unless the dispatcher is stopped. It must be called with the lock held, and it returns
+// the function to call once the lock is released.
+func (d *Dispatcher) giveUp(dl *delivery, rd *runDeliveries, err error) func() {
+	d.logger.Warnf("Failed to send cloudevent %s to %s after %d attempts: %s", dl.event.ID(), dl.target, dl.attempts, err.Error())
+	d.count(d.metrics.Failed, dl)
+	if dl.deadLetterSink != "" && dl.deadLetterSink != dl.target && err != errStopped {
+		deadLetter := &delivery{
+			event:    dl.event,
+			target:   dl.deadLetterSink,
+			run:      dl.run,
+			object:   dl.object,
+			recorder: dl.recorder,
+		}
+		if err := d.enqueue(deadLetter, rd); err != nil {
+			d.logger.Warnf("Failed to send cloudevent %s to the dead-letter sink %s: %v", dl.event.ID(), dl.deadLetterSink, err)
+		}
+	}
+	callback := d.done(dl, rd)
+	return func() {
+		callback()
+		if dl.recorder != nil {
+			dl.recorder.Event(dl.object, corev1.EventTypeWarning, "Cloud Event Failure", err.Error())
+		}
+	}
+}
+
+// done removes dl from the queue, it must be called with the lock held. It returns the
+// function to call once the lock is released.
+func (d *Dispatcher) done(dl *delivery, rd *runDeliveries) func() {
+	d.size--
+	if rd == nil {
+		return func() {}
+	}
+	rd.pending--
+	callback, ok := d.callbacks[dl.run.kind]
+	if rd.pending > 0 || !ok {
+		return func() {}
+	}
+	return func() { callback(dl.run.key) }
+}
+
+// backoff returns the delay before the next attempt to deliver a cloud event
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.options.InitialBackoff
+	for i := 1; i < attempts && delay < d.options.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.options.MaxBackoff {
+		delay = d.options.MaxBackoff
+	}
+	return delay
+}
+
+func (d *Dispatcher) count(record func(namespace, eventType string) error, dl *delivery) {
+	if err := record(dl.run.key.Namespace, dl.event.Type()); err != nil {
+		d.logger.Debugf("Failed to log the metrics : %v", err)
+	}
+}
+
+// Deliveries returns the state of the delivery of the cloud events of object to each
+// of their targets, merged into the state recorded in its status. The state of the run
+// is forgotten once all its cloud events are done and their state is recorded.
+func (d *Dispatcher) Deliveries(object metav1.Object, recorded []v1beta1.CloudEventDelivery) []v1beta1.CloudEventDelivery {
+	if d == nil {
+		return recorded
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	rd, ok := d.runs[object.GetUID()]
+	if !ok {
+		return recorded
+	}
+
+	deliveries := make([]v1beta1.CloudEventDelivery, len(recorded))
+	copy(deliveries, recorded)
+	targets := make([]string, 0, len(rd.states))
+	for target := range rd.states {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	for _, target := range targets {
+		state := rd.states[target].DeepCopy()
+		found := false
+		for i := range deliveries {
+			if deliveries[i].Target == target {
+				deliveries[i].Status = *state
+				found = true
+			}
+		}
+		if !found {
+			deliveries = append(deliveries, v1beta1.CloudEventDelivery{Target: target, Status: *state})
+		}
+	}
+
+	if rd.pending == 0 && equality.Semantic.DeepEqual(deliveries, recorded) {
+		delete(d.runs, object.GetUID())
+	}
+	return deliveries
+}
+
+// Forget forgets the state of the delivery of the cloud events of a deleted run
+func (d *Dispatcher) Forget(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.runs, m.GetUID())
+}
+
+// runOf returns the run identified by object
+func runOf(object runtime.Object) (run, error) {
+	m, err := meta.Accessor(object)
+	if err != nil {
+		return run{}, err
+	}
+	var kind string
+	switch object.(type) {
+	case *v1beta1.PipelineRun:
+		kind = pipeline.PipelineRunControllerName
+	case *v1beta1.TaskRun:
+		kind = pipeline.TaskRunControllerName
+	}
+	return run{
+		kind: kind,
+		uid:  m.GetUID(),
+		key:  types.NamespacedName{Namespace: m.GetNamespace(), Name: m.GetName()},
+	}, nil
+}
+
+type dispatcherKey struct{}
+
+// WithDispatcher returns a copy of ctx in which the cloud events are delivered by d
+func WithDispatcher(ctx context.Context, d *Dispatcher) context.Context {
+	return context.WithValue(ctx, dispatcherKey{}, d)
+}
+
+// GetDispatcher returns the Dispatcher of ctx, or nil if there is none
+func GetDispatcher(ctx context.Context) *Dispatcher {
+	d, _ := ctx.Value(dispatcherKey{}).(*Dispatcher)
+	return d
+}
@@ -0,0 +1,321 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.uber.org/zap/zaptest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	sink           = "http://sink.example.com"
+	deadLetterSink = "http://dead-letters.example.com"
+)
+
+var ignoreSentAt = cmpopts.IgnoreFields(v1beta1.CloudEventDeliveryState{}, "SentAt")
+
+// targetClient sends the targets of the cloud events to its Events channel,
+// and fails to deliver the cloud events to the failing targets
+type targetClient struct {
+	FakeClient
+	failing map[string]bool
+}
+
+func newTargetClient(failing ...string) targetClient {
+	c := targetClient{
+		FakeClient: NewFakeClient(&FakeClientBehaviour{SendSuccessfully: true}).(FakeClient),
+		failing:    map[string]bool{},
+	}
+	for _, target := range failing {
+		c.failing[target] = true
+	}
+	return c
+}
+
+func (c targetClient) Send(ctx context.Context, event cloudevents.Event) protocol.Result {
+	target := cloudevents.TargetFromContext(ctx).String()
+	if c.failing[target] {
+		return fmt.Errorf("%s is down", target)
+	}
+	c.Events <- target
+	return nil
+}
+
+func testEvent(id string) cloudevents.Event {
+	event := cloudevents.NewEvent()
+	event.SetID(id)
+	event.SetSource("/apis/tekton.dev/v1beta1/namespaces/foo/pipelineruns/test-pipelinerun")
+	event.SetType(PipelineRunSuccessfulEventV1.String())
+	return event
+}
+
+func testPipelineRun() *v1beta1.PipelineRun {
+	return &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "test-pipelinerun", Namespace: "foo", UID: "1234"}}
+}
+
+func dispatcherContext(deadLetterSink string) context.Context {
+	ctx := cloudevents.ContextWithTarget(context.Background(), sink)
+	return config.ToContext(ctx, &config.Config{Defaults: &config.Defaults{DefaultCloudEventsDeadLetterSink: deadLetterSink}})
+}
+
+func newTestDispatcher(t *testing.T, client CEClient, queueSize int) *Dispatcher {
+	t.Helper()
+	return NewDispatcher(client, zaptest.NewLogger(t).Sugar(), DispatcherOptions{
+		QueueSize:      queueSize,
+		Workers:        2,
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+	})
+}
+
+func TestDispatcher(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		failing        []string
+		deadLetterSink string
+		wantTargets    []string
+		want           []v1beta1.CloudEventDelivery
+	}{{
+		name:        "delivered",
+		wantTargets: []string{sink},
+		want: []v1beta1.CloudEventDelivery{{
+			Target: sink,
+			Status: v1beta1.CloudEventDeliveryState{Condition: v1beta1.CloudEventConditionSent, RetryCount: 1},
+		}},
+	}, {
+		name:    "failed",
+		failing: []string{sink},
+		want: []v1beta1.CloudEventDelivery{{
+			Target: sink,
+			Status: v1beta1.CloudEventDeliveryState{Condition: v1beta1.CloudEventConditionFailed, RetryCount: 3, Error: "http://sink.example.com is down"},
+		}},
+	}, {
+		name:           "sent to the dead-letter sink",
+		failing:        []string{sink},
+		deadLetterSink: deadLetterSink,
+		wantTargets:    []string{deadLetterSink},
+		want: []v1beta1.CloudEventDelivery{{
+			Target: deadLetterSink,
+			Status: v1beta1.CloudEventDeliveryState{Condition: v1beta1.CloudEventConditionSent, RetryCount: 1},
+		}, {
+			Target: sink,
+			Status: v1beta1.CloudEventDeliveryState{Condition: v1beta1.CloudEventConditionFailed, RetryCount: 3, Error: "http://sink.example.com is down"},
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newTargetClient(tc.failing...)
+			dispatcher := newTestDispatcher(t, client, 10)
+			done := make(chan types.NamespacedName, 1)
+			dispatcher.SetCallbackFunc(pipeline.PipelineRunControllerName, func(key types.NamespacedName) { done <- key })
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			dispatcher.Start(stopCh)
+
+			pr := testPipelineRun()
+			if err := dispatcher.Dispatch(dispatcherContext(tc.deadLetterSink), pr, testEvent("1")); err != nil {
+				t.Fatalf("Dispatch() = %v", err)
+			}
+			select {
+			case key := <-done:
+				if want := (types.NamespacedName{Namespace: "foo", Name: "test-pipelinerun"}); key != want {
+					t.Errorf("Expected the callback to be called with %v, got %v", want, key)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Timed out waiting for the delivery of the cloud event")
+			}
+			close(client.Events)
+			var targets []string
+			for target := range client.Events {
+				targets = append(targets, target)
+			}
+			if d := cmp.Diff(tc.wantTargets, targets); d != "" {
+				t.Errorf("Unexpected targets of the cloud event %s", diff.PrintWantGot(d))
+			}
+
+			got := dispatcher.Deliveries(pr, nil)
+			if d := cmp.Diff(tc.want, got, ignoreSentAt); d != "" {
+				t.Errorf("Unexpected deliveries %s", diff.PrintWantGot(d))
+			}
+			for _, delivery := range got {
+				if delivery.Status.SentAt == nil {
+					t.Errorf("Expected the time of the delivery to %s to be set", delivery.Target)
+				}
+			}
+			// Once their state is recorded in the status of the run, it is forgotten.
+			if d := cmp.Diff(got, dispatcher.Deliveries(pr, got)); d != "" {
+				t.Errorf("Unexpected deliveries once recorded %s", diff.PrintWantGot(d))
+			}
+			if len(dispatcher.runs) != 0 {
+				t.Errorf("Expected the deliveries to be forgotten once recorded, got %v", dispatcher.runs)
+			}
+		})
+	}
+}
+
+func TestDispatcher_QueueFull(t *testing.T) {
+	// The dispatcher is not started, so that the cloud events stay in the queue.
+	dispatcher := newTestDispatcher(t, newTargetClient(), 1)
+	pr := testPipelineRun()
+	ctx := dispatcherContext("")
+	if err := dispatcher.Dispatch(ctx, pr, testEvent("1")); err != nil {
+		t.Fatalf("Dispatch() = %v", err)
+	}
+	err := dispatcher.Dispatch(ctx, pr, testEvent("2"))
+	if err == nil {
+		t.Fatal("Expected an error dispatching a cloud event to a full queue")
+	}
+	if d := cmp.Diff("dropped cloud event 2 for http://sink.example.com: the delivery queue is full", err.Error()); d != "" {
+		t.Errorf("Unexpected error %s", diff.PrintWantGot(d))
+	}
+
+	recorded := []v1beta1.CloudEventDelivery{{Target: "http://other.example.com"}}
+	want := []v1beta1.CloudEventDelivery{{Target: "http://other.example.com"}, {
+		Target: sink,
+		Status: v1beta1.CloudEventDeliveryState{Condition: v1beta1.CloudEventConditionFailed, Error: "the delivery queue is full"},
+	}}
+	if d := cmp.Diff(want, dispatcher.Deliveries(pr, recorded)); d != "" {
+		t.Errorf("Unexpected deliveries %s", diff.PrintWantGot(d))
+	}
+	// The deliveries are not forgotten while a cloud event is pending.
+	if _, ok := dispatcher.runs[pr.UID]; !ok {
+		t.Error("Expected the deliveries of a run with pending cloud events to be kept")
+	}
+}
+
+func TestDispatcher_Retry(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		stopped bool
+		want    string
+	}{{
+		name:    "stopped",
+		stopped: true,
+		want:    "the dispatcher is stopped",
+	}, {
+		name: "queue full",
+		want: "the delivery queue is full",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			// The dispatcher is not started, so that the cloud events stay in the queue.
+			dispatcher := newTestDispatcher(t, newTargetClient(), 1)
+			done := make(chan types.NamespacedName, 1)
+			dispatcher.SetCallbackFunc(pipeline.PipelineRunControllerName, func(key types.NamespacedName) { done <- key })
+			stopCh := make(chan struct{})
+			dispatcher.stopCh = stopCh
+			pr := testPipelineRun()
+			if err := dispatcher.Dispatch(dispatcherContext(deadLetterSink), pr, testEvent("1")); err != nil {
+				t.Fatalf("Dispatch() = %v", err)
+			}
+			dl := <-dispatcher.queue
+			dl.attempts = 1
+			if tc.stopped {
+				close(stopCh)
+			} else {
+				dispatcher.queue <- &delivery{}
+			}
+
+			// The retry does not block, and the cloud event is given up on.
+			dispatcher.retry(dl)
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("Timed out waiting for the cloud event to be given up on")
+			}
+			want := []v1beta1.CloudEventDelivery{{
+				Target: deadLetterSink,
+				Status: v1beta1.CloudEventDeliveryState{Condition: v1beta1.CloudEventConditionFailed, Error: "the delivery queue is full"},
+			}, {
+				Target: sink,
+				Status: v1beta1.CloudEventDeliveryState{Condition: v1beta1.CloudEventConditionFailed, Error: tc.want},
+			}}
+			if tc.stopped {
+				// Nothing is sent to the dead-letter sink once the dispatcher is stopped.
+				want = want[1:]
+			}
+			if d := cmp.Diff(want, dispatcher.Deliveries(pr, nil)); d != "" {
+				t.Errorf("Unexpected deliveries %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestDispatcher_Forget(t *testing.T) {
+	dispatcher := newTestDispatcher(t, newTargetClient(), 10)
+	pr := testPipelineRun()
+	if err := dispatcher.Dispatch(dispatcherContext(""), pr, testEvent("1")); err != nil {
+		t.Fatalf("Dispatch() = %v", err)
+	}
+	dispatcher.Forget(cache.DeletedFinalStateUnknown{Key: "foo/test-pipelinerun", Obj: pr})
+	if got := dispatcher.Deliveries(pr, nil); got != nil {
+		t.Errorf("Expected the deliveries of a deleted run to be forgotten, got %v", got)
+	}
+}
+
+func TestDispatcher_Backoff(t *testing.T) {
+	dispatcher := &Dispatcher{options: DispatcherOptions{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}}
+	for attempts, want := range map[int]time.Duration{
+		1: time.Second,
+		2: 2 * time.Second,
+		3: 4 * time.Second,
+		4: 5 * time.Second,
+		9: 5 * time.Second,
+	} {
+		if got := dispatcher.backoff(attempts); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
+
+func TestSendEventWithRetries_Dispatcher(t *testing.T) {
+	client := newTargetClient()
+	dispatcher := newTestDispatcher(t, client, 10)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	dispatcher.Start(stopCh)
+
+	ctx := setupFakeContext(t, FakeClientBehaviour{SendSuccessfully: true}, true)
+	ctx = WithDispatcher(cloudevents.ContextWithTarget(ctx, sink), dispatcher)
+	event := testEvent("1")
+	if err := SendEventWithRetries(ctx, testPipelineRun(), &event); err != nil {
+		t.Fatalf("SendEventWithRetries() = %v", err)
+	}
+	// The cloud event is delivered through the dispatcher, with its client.
+	select {
+	case target := <-client.Events:
+		if target != sink {
+			t.Errorf("Expected the cloud event to be delivered to %s, got %s", sink, target)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the delivery of the cloud event")
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"context"
+	"errors"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"knative.dev/pkg/metrics"
+)
+
+var (
+	deliveredCount = stats.Float64("cloudevent_delivered_count",
+		"number of cloud events delivered to their sink",
+		stats.UnitDimensionless)
+
+	failedCount = stats.Float64("cloudevent_failed_count",
+		"number of cloud events which could not be delivered to their sink after all retries",
+		stats.UnitDimensionless)
+
+	droppedCount = stats.Float64("cloudevent_dropped_count",
+		"number of cloud events dropped because the delivery queue was full",
+		stats.UnitDimensionless)
+)
+
+// Recorder holds keys for the metrics of the delivery of cloud events
+type Recorder struct {
+	initialized bool
+
+	namespace tag.Key
+	eventType tag.Key
+}
+
+// NewRecorder creates a new metrics recorder instance
+// to log the metrics of the delivery of cloud events
+func NewRecorder() (*Recorder, error) {
+	r := &Recorder{
+		initialized: true,
+	}
+
+	namespace, err := tag.NewKey("namespace")
+	if err != nil {
+		return nil, err
+	}
+	r.namespace = namespace
+
+	eventType, err := tag.NewKey("type")
+	if err != nil {
+		return nil, err
+	}
+	r.eventType = eventType
+
+	var views []*view.View
+	for _, m := range []*stats.Float64Measure{deliveredCount, failedCount, droppedCount} {
+		views = append(views, &view.View{
+			Description: m.Description(),
+			Measure:     m,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{r.namespace, r.eventType},
+		})
+	}
+	if err := view.Register(views...); err != nil {
+		r.initialized = false
+		return r, err
+	}
+
+	return r, nil
+}
+
+// Delivered counts a cloud event delivered to its sink
+// returns an error if its failed to log the metrics
+func (r *Recorder) Delivered(namespace, eventType string) error {
+	return r.count(namespace, eventType, deliveredCount)
+}
+
+// Failed counts a cloud event which could not be delivered after all retries
+// returns an error if its failed to log the metrics
+func (r *Recorder) Failed(namespace, eventType string) error {
+	return r.count(namespace, eventType, failedCount)
+}
+
+// Dropped counts a cloud event dropped because the delivery queue was full
+// returns an error if its failed to log the metrics
+func (r *Recorder) Dropped(namespace, eventType string) error {
+	return r.count(namespace, eventType, droppedCount)
+}
+
+func (r *Recorder) count(namespace, eventType string, m *stats.Float64Measure) error {
+	if r == nil || !r.initialized {
+		return errors.New("ignoring the metrics recording, failed to initialize the metrics recorder")
+	}
+
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(r.namespace, namespace),
+		tag.Insert(r.eventType, eventType),
+	)
+	if err != nil {
+		return err
+	}
+
+	metrics.Record(ctx, m.M(1))
+	return nil
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/uuid"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+// MaxProgressRecords is the maximum number of TaskRuns and skipped pipeline tasks of a
+// PipelineRun whose progress events are recorded. The events about the progress of the
+// TaskRuns and the skipped pipeline tasks past this limit are not sent, so that the record
+// stays bounded.
+const MaxProgressRecords = 256
+
+// progressAnnotation is the annotation recording the events about the progress of a
+// PipelineRun which were sent.
+const progressAnnotation = pipeline.GroupName + pipeline.ProgressEventsAnnotationKey
+
+// progressRecord records the events about the progress of a PipelineRun which were sent,
+// collapsed to one entry per TaskRun and per skipped pipeline task.
+type progressRecord struct {
+	TaskRuns map[string]*taskRunProgressRecord `json:"taskRuns,omitempty"`
+	Skipped  []string                          `json:"skipped,omitempty"`
+	Results  bool                              `json:"results,omitempty"`
+}
+
+// taskRunProgressRecord records the events about the progress of a TaskRun which were sent.
+type taskRunProgressRecord struct {
+	Started bool `json:"started,omitempty"`
+	Retries int  `json:"retries,omitempty"`
+	Results bool `json:"results,omitempty"`
+}
+
+func (r *progressRecord) taskRun(name string) *taskRunProgressRecord {
+	if r.TaskRuns == nil {
+		r.TaskRuns = map[string]*taskRunProgressRecord{}
+	}
+	if r.TaskRuns[name] == nil {
+		r.TaskRuns[name] = &taskRunProgressRecord{}
+	}
+	return r.TaskRuns[name]
+}
+
+func readProgressRecord(pr *v1beta1.PipelineRun) (*progressRecord, error) {
+	record := &progressRecord{}
+	if value, ok := pr.Annotations[progressAnnotation]; ok {
+		if err := json.Unmarshal([]byte(value), record); err != nil {
+			return nil, fmt.Errorf("invalid annotation %s: %w", progressAnnotation, err)
+		}
+	}
+	return record, nil
+}
+
+// ProgressEvent is an event about the progress of a PipelineRun, along with how it is recorded
+// once it was sent.
+type ProgressEvent struct {
+	Event  *cloudevents.Event
+	record func(*progressRecord)
+}
+
+// EventsForPipelineRunProgress returns the events about the progress of a PipelineRun which
+// weren't sent yet, according to the record in its annotations: the pipeline tasks which
+// were started, retried or skipped, and the results which became available.
+func EventsForPipelineRunProgress(pr *v1beta1.PipelineRun) ([]ProgressEvent, error) {
+	sent, err := readProgressRecord(pr)
+	if err != nil {
+		return nil, err
+	}
+	records := len(sent.TaskRuns) + len(sent.Skipped)
+	var events []ProgressEvent
+	add := func(eventType TektonEventType, progress PipelineRunProgress, record func(*progressRecord)) error {
+		progress.Namespace, progress.Name = pr.Namespace, pr.Name
+		event, err := eventForPipelineRunProgress(pr, eventType, &progress)
+		if err != nil {
+			return err
+		}
+		events = append(events, ProgressEvent{Event: event, record: record})
+		return nil
+	}
+
+	// Sort the TaskRuns, so that the events are sent in a stable order.
+	taskRunNames := make([]string, 0, len(pr.Status.TaskRuns))
+	for name := range pr.Status.TaskRuns {
+		taskRunNames = append(taskRunNames, name)
+	}
+	sort.Strings(taskRunNames)
+	for _, name := range taskRunNames {
+		name := name
+		s := pr.Status.TaskRuns[name]
+		// The status of a TaskRun is only set once it is created, after its condition checks.
+		if s.Status == nil {
+			continue
+		}
+		r, ok := sent.TaskRuns[name]
+		if !ok {
+			if records >= MaxProgressRecords {
+				continue
+			}
+			records++
+			r = &taskRunProgressRecord{}
+		}
+		progress := PipelineRunProgress{TaskRunName: name, TaskRun: s}
+		if !r.Started {
+			if err := add(PipelineRunTaskStartedEventV1, progress, func(record *progressRecord) {
+				record.taskRun(name).Started = true
+			}); err != nil {
+				return nil, err
+			}
+		}
+		if n := retries(s); n > r.Retries {
+			if err := add(PipelineRunTaskRetriedEventV1, progress, func(record *progressRecord) {
+				record.taskRun(name).Retries = n
+			}); err != nil {
+				return nil, err
+			}
+		}
+		if !r.Results && hasResults(s) {
+			if err := add(PipelineRunResultsAvailableEventV1, progress, func(record *progressRecord) {
+				record.taskRun(name).Results = true
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	skipped := sets.NewString(sent.Skipped...)
+	for i := range pr.Status.SkippedTasks {
+		task := pr.Status.SkippedTasks[i]
+		if skipped.Has(task.Name) || records >= MaxProgressRecords {
+			continue
+		}
+		records++
+		if err := add(PipelineRunTaskSkippedEventV1, PipelineRunProgress{SkippedTask: &task}, func(record *progressRecord) {
+			record.Skipped = append(record.Skipped, task.Name)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if !sent.Results && len(pr.Status.PipelineResults) > 0 {
+		if err := add(PipelineRunResultsAvailableEventV1, PipelineRunProgress{PipelineResults: pr.Status.PipelineResults}, func(record *progressRecord) {
+			record.Results = true
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// RecordProgressEvents records in the annotations of a PipelineRun that the events about its
+// progress were sent, so that they aren't sent again.
+func RecordProgressEvents(pr *v1beta1.PipelineRun, events []ProgressEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	sent, err := readProgressRecord(pr)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		event.record(sent)
+	}
+	value, err := json.Marshal(sent)
+	if err != nil {
+		return err
+	}
+	if pr.Annotations == nil {
+		pr.Annotations = map[string]string{}
+	}
+	pr.Annotations[progressAnnotation] = string(value)
+	return nil
+}
+
+func eventForPipelineRunProgress(pr *v1beta1.PipelineRun, eventType TektonEventType, progress *PipelineRunProgress) (*cloudevents.Event, error) {
+	event := cloudevents.NewEvent()
+	event.SetID(uuid.New().String())
+	event.SetSubject(pr.Name)
+	event.SetSource(pr.GetSelfLink()) // TODO: SelfLink is deprecated https://github.com/tektoncd/pipeline/issues/2676
+	event.SetType(eventType.String())
+	if err := event.SetData(cloudevents.ApplicationJSON, TektonCloudEventData{PipelineRunProgress: progress}); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// retries returns how many times the TaskRun of a pipeline task was retried
+func retries(s *v1beta1.PipelineRunTaskRunStatus) int {
+	if s == nil || s.Status == nil {
+		return 0
+	}
+	return len(s.Status.RetriesStatus)
+}
+
+// hasResults returns whether the TaskRun of a pipeline task succeeded with results
+func hasResults(s *v1beta1.PipelineRunTaskRunStatus) bool {
+	if s == nil || s.Status == nil || len(s.Status.TaskRunResults) == 0 {
+		return false
+	}
+	return s.Status.GetCondition(apis.ConditionSucceeded).IsTrue()
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+func pipelineTaskStatus(pipelineTask string, status corev1.ConditionStatus, retries int, results ...v1beta1.TaskRunResult) *v1beta1.PipelineRunTaskRunStatus {
+	s := &v1beta1.PipelineRunTaskRunStatus{
+		PipelineTaskName: pipelineTask,
+		Status:           &v1beta1.TaskRunStatus{},
+	}
+	s.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: status})
+	s.Status.TaskRunResults = results
+	for i := 0; i < retries; i++ {
+		s.Status.RetriesStatus = append(s.Status.RetriesStatus, v1beta1.TaskRunStatus{})
+	}
+	return s
+}
+
+func TestEventsForPipelineRunProgress(t *testing.T) {
+	digest := v1beta1.TaskRunResult{Name: "digest", Value: "sha256:abc"}
+	pr := getPipelineRunByCondition(corev1.ConditionUnknown, v1beta1.PipelineRunReasonRunning.String())
+	pr.Status.TaskRuns = map[string]*v1beta1.PipelineRunTaskRunStatus{
+		"pr-build":   pipelineTaskStatus("build", corev1.ConditionTrue, 0, digest),
+		"pr-test":    pipelineTaskStatus("test", corev1.ConditionUnknown, 1),
+		"pr-lint":    pipelineTaskStatus("lint", corev1.ConditionTrue, 0),
+		"pr-deploy":  pipelineTaskStatus("deploy", corev1.ConditionUnknown, 0),
+		"pr-package": pipelineTaskStatus("package", corev1.ConditionUnknown, 0),
+	}
+	pr.Status.SkippedTasks = []v1beta1.SkippedTask{{Name: "notify"}, {Name: "release"}}
+	pr.Status.PipelineResults = []v1beta1.PipelineRunResult{{Name: "image", Value: "sha256:abc"}}
+	// The events about the progress recorded in the annotations were sent already.
+	pr.Annotations = map[string]string{
+		"tekton.dev/progress-events": `{"taskRuns":{"pr-build":{"started":true},"pr-lint":{"started":true},"pr-test":{"started":true}},"skipped":["notify"]}`,
+	}
+
+	events, err := EventsForPipelineRunProgress(pr)
+	if err != nil {
+		t.Fatalf("EventsForPipelineRunProgress() = %v", err)
+	}
+
+	type event struct {
+		Type     string
+		Progress PipelineRunProgress
+	}
+	var got []event
+	for _, pe := range events {
+		e := pe.Event
+		if e.Subject() != pipelineRunName || e.Source() != defaultEventSourceURI {
+			t.Errorf("Expected the events to be about %s at %s, got %s at %s", pipelineRunName, defaultEventSourceURI, e.Subject(), e.Source())
+		}
+		data := TektonCloudEventData{}
+		if err := e.DataAs(&data); err != nil {
+			t.Fatalf("Failed to unmarshal the data of event %s: %v", e.Type(), err)
+		}
+		if data.PipelineRun != nil || data.PipelineRunProgress == nil {
+			t.Fatalf("Expected the event %s to only hold the progress of the PipelineRun, got %+v", e.Type(), data)
+		}
+		got = append(got, event{Type: e.Type(), Progress: *data.PipelineRunProgress})
+	}
+
+	progress := func(taskRunName string, s *v1beta1.PipelineRunTaskRunStatus) PipelineRunProgress {
+		return PipelineRunProgress{Namespace: "marshmallow", Name: pipelineRunName, TaskRunName: taskRunName, TaskRun: s}
+	}
+	want := []event{{
+		Type:     PipelineRunResultsAvailableEventV1.String(),
+		Progress: progress("pr-build", pr.Status.TaskRuns["pr-build"]),
+	}, {
+		Type:     PipelineRunTaskStartedEventV1.String(),
+		Progress: progress("pr-deploy", pr.Status.TaskRuns["pr-deploy"]),
+	}, {
+		Type:     PipelineRunTaskStartedEventV1.String(),
+		Progress: progress("pr-package", pr.Status.TaskRuns["pr-package"]),
+	}, {
+		Type:     PipelineRunTaskRetriedEventV1.String(),
+		Progress: progress("pr-test", pr.Status.TaskRuns["pr-test"]),
+	}, {
+		Type:     PipelineRunTaskSkippedEventV1.String(),
+		Progress: PipelineRunProgress{Namespace: "marshmallow", Name: pipelineRunName, SkippedTask: &v1beta1.SkippedTask{Name: "release"}},
+	}, {
+		Type:     PipelineRunResultsAvailableEventV1.String(),
+		Progress: PipelineRunProgress{Namespace: "marshmallow", Name: pipelineRunName, PipelineResults: pr.Status.PipelineResults},
+	}}
+	// The transition times are truncated to the second when marshalled.
+	if d := cmp.Diff(want, got, cmpopts.IgnoreTypes(apis.VolatileTime{})); d != "" {
+		t.Errorf("Unexpected events %s", diff.PrintWantGot(d))
+	}
+
+	if err := RecordProgressEvents(pr, events); err != nil {
+		t.Fatalf("RecordProgressEvents() = %v", err)
+	}
+	wantRecord := `{"taskRuns":{"pr-build":{"started":true,"results":true},"pr-deploy":{"started":true},"pr-lint":{"started":true},"pr-package":{"started":true},"pr-test":{"started":true,"retries":1}},"skipped":["notify","release"],"results":true}`
+	if d := cmp.Diff(wantRecord, pr.Annotations["tekton.dev/progress-events"]); d != "" {
+		t.Errorf("Unexpected record of the events %s", diff.PrintWantGot(d))
+	}
+	// The events which were recorded aren't sent again.
+	if events, err := EventsForPipelineRunProgress(pr); err != nil || len(events) != 0 {
+		t.Errorf("Expected no events once recorded, got %d, %v", len(events), err)
+	}
+}
+
+func TestEventsForPipelineRunProgress_NoProgress(t *testing.T) {
+	pr := getPipelineRunByCondition(corev1.ConditionUnknown, v1beta1.PipelineRunReasonRunning.String())
+	pr.Status.TaskRuns = map[string]*v1beta1.PipelineRunTaskRunStatus{
+		"pr-build": pipelineTaskStatus("build", corev1.ConditionUnknown, 0),
+	}
+	pr.Annotations = map[string]string{"tekton.dev/progress-events": `{"taskRuns":{"pr-build":{"started":true}}}`}
+	events, err := EventsForPipelineRunProgress(pr)
+	if err != nil {
+		t.Fatalf("EventsForPipelineRunProgress() = %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("Expected no events, got %d", len(events))
+	}
+}
+
+func TestEventsForPipelineRunProgress_MaxProgressRecords(t *testing.T) {
+	pr := getPipelineRunByCondition(corev1.ConditionUnknown, v1beta1.PipelineRunReasonRunning.String())
+	pr.Status.TaskRuns = map[string]*v1beta1.PipelineRunTaskRunStatus{}
+	for i := 0; i < MaxProgressRecords; i++ {
+		pr.Status.TaskRuns[fmt.Sprintf("pr-task-%03d", i)] = pipelineTaskStatus("task", corev1.ConditionUnknown, 0)
+	}
+	events, err := EventsForPipelineRunProgress(pr)
+	if err != nil {
+		t.Fatalf("EventsForPipelineRunProgress() = %v", err)
+	}
+	if err := RecordProgressEvents(pr, events); err != nil {
+		t.Fatalf("RecordProgressEvents() = %v", err)
+	}
+	if len(events) != MaxProgressRecords {
+		t.Errorf("Expected %d events, got %d", MaxProgressRecords, len(events))
+	}
+
+	// Past the limit, the events about the new TaskRuns and skipped tasks are neither sent
+	// nor recorded, while those about the recorded TaskRuns still are.
+	record := pr.Annotations["tekton.dev/progress-events"]
+	pr.Status.TaskRuns["pr-task-000"] = pipelineTaskStatus("task", corev1.ConditionUnknown, 1)
+	pr.Status.TaskRuns["pr-task-new"] = pipelineTaskStatus("task", corev1.ConditionUnknown, 0)
+	pr.Status.SkippedTasks = []v1beta1.SkippedTask{{Name: "notify"}}
+	events, err = EventsForPipelineRunProgress(pr)
+	if err != nil {
+		t.Fatalf("EventsForPipelineRunProgress() = %v", err)
+	}
+	if len(events) != 1 || events[0].Event.Type() != PipelineRunTaskRetriedEventV1.String() {
+		t.Fatalf("Expected only the retried event of a recorded TaskRun, got %d events", len(events))
+	}
+	if err := RecordProgressEvents(pr, events); err != nil {
+		t.Fatalf("RecordProgressEvents() = %v", err)
+	}
+	if got := pr.Annotations["tekton.dev/progress-events"]; len(got) != len(record)+len(`,"retries":1`) {
+		t.Errorf("Expected the record to only grow by the retry, got %d bytes from %d", len(got), len(record))
+	}
+}
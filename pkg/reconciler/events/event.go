@@ -20,9 +20,12 @@ import (
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	"knative.dev/pkg/apis"
@@ -45,14 +48,15 @@ const (
 // Two types of events are supported, k8s and cloud events.
 //
 // k8s events are always sent if afterCondition is different from beforeCondition
-// Cloud events are always sent if enabled, i.e. if a sink is available
+// Cloud events are always sent if enabled, i.e. if a sink is available, either
+// the sink of the object or the default sink
 func Emit(ctx context.Context, beforeCondition *apis.Condition, afterCondition *apis.Condition, object runtime.Object) {
 	recorder := controller.GetEventRecorder(ctx)
 	logger := logging.FromContext(ctx)
-	configs := config.FromContextOrDefaults(ctx)
-	sendCloudEvents := (configs.Defaults.DefaultCloudEventsSink != "")
+	sink := cloudEventsSink(ctx, object)
+	sendCloudEvents := (sink != "")
 	if sendCloudEvents {
-		ctx = cloudevents.ContextWithTarget(ctx, configs.Defaults.DefaultCloudEventsSink)
+		ctx = cloudevents.ContextWithTarget(ctx, sink)
 	}
 
 	sendKubernetesEvents(recorder, beforeCondition, afterCondition, object)
@@ -65,6 +69,47 @@ func Emit(ctx context.Context, beforeCondition *apis.Condition, afterCondition *
 	}
 }
 
+// EmitPipelineRunProgress emits cloud events about the progress of pr which weren't sent yet:
+// the pipeline tasks which were started, retried or skipped, and the results which became
+// available. They are only sent if a sink is available. The events which were sent are
+// recorded in the annotations of pr, so that they aren't sent again.
+func EmitPipelineRunProgress(ctx context.Context, pr *v1beta1.PipelineRun) {
+	logger := logging.FromContext(ctx)
+	sink := cloudEventsSink(ctx, pr)
+	if sink == "" {
+		return
+	}
+	ctx = cloudevents.ContextWithTarget(ctx, sink)
+
+	progressEvents, err := cloudevent.EventsForPipelineRunProgress(pr)
+	if err != nil {
+		logger.Warnf("Failed to create the cloud events about the progress of PipelineRun %s: %v", pr.Name, err)
+		return
+	}
+	var sent []cloudevent.ProgressEvent
+	for _, event := range progressEvents {
+		if err := cloudevent.SendEventWithRetries(ctx, pr, event.Event); err != nil {
+			logger.Warnf("Failed to emit cloud events %v", err.Error())
+			continue
+		}
+		sent = append(sent, event)
+	}
+	if err := cloudevent.RecordProgressEvents(pr, sent); err != nil {
+		logger.Warnf("Failed to record the cloud events about the progress of PipelineRun %s: %v", pr.Name, err)
+	}
+}
+
+// cloudEventsSink returns the sink of the cloud events of object: the sink of its
+// annotation when it has one, or else the default sink.
+func cloudEventsSink(ctx context.Context, object runtime.Object) string {
+	if m, err := meta.Accessor(object); err == nil {
+		if sink, ok := m.GetAnnotations()[pipeline.GroupName+pipeline.CloudEventsSinkAnnotationKey]; ok {
+			return sink
+		}
+	}
+	return config.FromContextOrDefaults(ctx).Defaults.DefaultCloudEventsSink
+}
+
 func sendKubernetesEvents(c record.EventRecorder, beforeCondition *apis.Condition, afterCondition *apis.Condition, object runtime.Object) {
 	// Events that are going to be sent
 	//
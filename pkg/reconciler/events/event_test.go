@@ -17,15 +17,18 @@ limitations under the License.
 package events
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"regexp"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
+	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
@@ -200,6 +203,7 @@ func TestEmit(t *testing.T) {
 	testcases := []struct {
 		name           string
 		data           map[string]string
+		annotations    map[string]string
 		wantEvent      string
 		wantCloudEvent string
 	}{{
@@ -217,9 +221,18 @@ func TestEmit(t *testing.T) {
 		data:           map[string]string{"default-cloud-events-sink": "http://mysink"},
 		wantEvent:      "Normal Started",
 		wantCloudEvent: `(?s)dev.tekton.event.pipelinerun.started.v1.*test1`,
+	}, {
+		name:           "with the sink of the run",
+		data:           map[string]string{},
+		annotations:    map[string]string{"tekton.dev/cloudevents-sink": "http://runsink"},
+		wantEvent:      "Normal Started",
+		wantCloudEvent: `(?s)dev.tekton.event.pipelinerun.started.v1.*test1`,
 	}}
 
 	for _, tc := range testcases {
+		object := object.DeepCopy()
+		object.Annotations = tc.annotations
+
 		// Setup the context and seed test data
 		ctx, _ := rtesting.SetupFakeContext(t)
 		ctx = cloudevent.WithClient(ctx, &cloudevent.FakeClientBehaviour{SendSuccessfully: true})
@@ -245,6 +258,71 @@ func TestEmit(t *testing.T) {
 	}
 }
 
+func TestCloudEventsSink(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		defaultSink string
+		annotations map[string]string
+		want        string
+	}{{
+		name: "no sink",
+	}, {
+		name:        "default sink",
+		defaultSink: "http://default",
+		want:        "http://default",
+	}, {
+		name:        "sink of the run",
+		defaultSink: "http://default",
+		annotations: map[string]string{"tekton.dev/cloudevents-sink": "http://run"},
+		want:        "http://run",
+	}, {
+		name:        "sink of the run disabled",
+		defaultSink: "http://default",
+		annotations: map[string]string{"tekton.dev/cloudevents-sink": ""},
+		want:        "",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			defaults, _ := config.NewDefaultsFromMap(map[string]string{"default-cloud-events-sink": tc.defaultSink})
+			ctx := config.ToContext(context.Background(), &config.Config{Defaults: defaults})
+			tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "test1", Annotations: tc.annotations}}
+			if got := cloudEventsSink(ctx, tr); got != tc.want {
+				t.Errorf("cloudEventsSink() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEmitPipelineRunProgress(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	ctx = cloudevent.WithClient(ctx, &cloudevent.FakeClientBehaviour{SendSuccessfully: true})
+	fakeClient := cloudevent.Get(ctx).(cloudevent.FakeClient)
+
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test1",
+			SelfLink:    "/pipelineruns/test1",
+			Annotations: map[string]string{"tekton.dev/cloudevents-sink": "http://runsink"},
+		},
+		Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+			SkippedTasks: []v1beta1.SkippedTask{{Name: "deploy"}},
+		}},
+	}
+	EmitPipelineRunProgress(ctx, pr)
+	if err := checkCloudEvents(t, &fakeClient, "skipped task", `(?s)dev.tekton.event.pipelinerun.task.skipped.v1.*"name": "deploy"`); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if d := cmp.Diff(`{"skipped":["deploy"]}`, pr.Annotations["tekton.dev/progress-events"]); d != "" {
+		t.Errorf("Unexpected progress events recorded in the annotations %s", diff.PrintWantGot(d))
+	}
+
+	// Without any progress since, no event is sent again.
+	EmitPipelineRunProgress(ctx, pr)
+	if err := checkCloudEvents(t, &fakeClient, "no progress", ""); err != nil {
+		t.Fatalf(err.Error())
+	}
+}
+
 func eventFromChannel(c chan string, testName string, wantEvent string) error {
 	timer := time.NewTimer(1 * time.Second)
 	select {
@@ -89,6 +89,19 @@ func Build(tasks Tasks) (*Graph, error) {
 	return d, nil
 }
 
+// BuildWithoutLinks returns a Graph of tasks without any dependency between them, all of them
+// are roots of the Graph. It is used for the finally tasks, which all run in parallel even
+// though they can consume the results of the tasks of the DAG.
+func BuildWithoutLinks(tasks Tasks) (*Graph, error) {
+	d := newGraph()
+	for _, pt := range tasks.Items() {
+		if _, err := d.addPipelineTask(pt); err != nil {
+			return nil, fmt.Errorf("task %s is already present in Graph, can't add it again: %w", pt.HashKey(), err)
+		}
+	}
+	return d, nil
+}
+
 // GetSchedulable returns a map of PipelineTask that can be scheduled (keyed
 // by the name of the PipelineTask) given a list of successfully finished doneTasks.
 // It returns tasks which have all dependencies marked as done, and thus can be scheduled. If the
@@ -669,3 +669,36 @@ func TestBuild_ConditionsParamsFromTaskResults(t *testing.T) {
 	}
 	assertSameDAG(t, expectedDAG, g)
 }
+
+func TestBuildWithoutLinks(t *testing.T) {
+	a := v1beta1.PipelineTask{Name: "a"}
+	xConsumesA := v1beta1.PipelineTask{
+		Name: "x",
+		Params: []v1beta1.Param{{
+			Name:  "paramX",
+			Value: *v1beta1.NewArrayOrString("$(tasks.a.results.resultA)"),
+		}},
+		WhenExpressions: []v1beta1.WhenExpression{{
+			Input:    "$(tasks.b.results.resultB)",
+			Operator: "in",
+			Values:   []string{"foo"},
+		}},
+	}
+
+	// a   x
+	expectedDAG := &dag.Graph{
+		Nodes: map[string]*dag.Node{
+			"a": {Task: a},
+			"x": {Task: xConsumesA},
+		},
+	}
+	g, err := dag.BuildWithoutLinks(v1beta1.PipelineTaskList([]v1beta1.PipelineTask{a, xConsumesA}))
+	if err != nil {
+		t.Fatalf("didn't expect error building the graph but got %v", err)
+	}
+	assertSameDAG(t, expectedDAG, g)
+
+	if _, err := dag.BuildWithoutLinks(v1beta1.PipelineTaskList([]v1beta1.PipelineTask{a, a})); err == nil {
+		t.Errorf("expected an error building a graph with duplicate tasks")
+	}
+}
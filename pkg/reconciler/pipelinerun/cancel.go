@@ -25,34 +25,24 @@ import (
 	"go.uber.org/zap"
 	jsonpatch "gomodules.xyz/jsonpatch/v2"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 )
 
 // cancelPipelineRun marks the PipelineRun as cancelled and any resolved TaskRun(s) too.
 func cancelPipelineRun(logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, clientSet clientset.Interface) error {
-	errs := []string{}
-
-	// Use Patch to update the TaskRuns since the TaskRun controller may be operating on the
-	// TaskRuns at the same time and trying to update the entire object may cause a race
-	b, err := getCancelPatch()
+	errs, err := cancelPipelineTasks(logger, pr, clientSet, nil, func(string, string, *apis.Condition) bool { return true })
 	if err != nil {
-		return fmt.Errorf("couldn't make patch to update TaskRun cancellation: %v", err)
-	}
-
-	// Loop over the TaskRuns in the PipelineRun status.
-	// If a TaskRun is not in the status yet we should not cancel it anyways.
-	for taskRunName := range pr.Status.TaskRuns {
-		logger.Infof("cancelling TaskRun %s", taskRunName)
-
-		if _, err := clientSet.TektonV1beta1().TaskRuns(pr.Namespace).Patch(taskRunName, types.JSONPatchType, b, ""); err != nil {
-			errs = append(errs, fmt.Errorf("Failed to patch TaskRun `%s` with cancellation: %s", taskRunName, err).Error())
-			continue
-		}
+		return err
 	}
 	// If we successfully cancelled all the TaskRuns, we can consider the PipelineRun cancelled.
 	if len(errs) == 0 {
@@ -78,11 +68,150 @@ func cancelPipelineRun(logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, clien
 	return nil
 }
 
-func getCancelPatch() ([]byte, error) {
+// gracefullyCancelPipelineRun cancels the TaskRuns, Runs and child PipelineRuns still running
+// the DAG tasks of a PipelineRun cancelled with CancelledRunFinally. Its finally tasks are left
+// alone: they get scheduled once the DAG tasks are done, and the PipelineRun completes after them.
+// The ones of pipelineRunState which are cancelled already aren't patched again.
+func gracefullyCancelPipelineRun(logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, dfinally *dag.Graph, pipelineRunState resources.PipelineRunState, clientSet clientset.Interface) error {
+	errs, err := cancelPipelineTasks(logger, pr, clientSet, cancelledNames(pipelineRunState), func(_, pipelineTaskName string, c *apis.Condition) bool {
+		_, finally := dfinally.Nodes[pipelineTaskName]
+		return !finally && (c == nil || c.IsUnknown())
+	})
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("error(s) from cancelling TaskRun(s) from PipelineRun %s: %s", pr.Name, strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// cancelTimedOutPipelineTasks cancels the Runs and child PipelineRuns still running the tasks of a
+// PipelineRun whose spec.timeouts.tasks elapsed, and the finally tasks of a PipelineRun whose
+// spec.timeouts.finally elapsed. A Custom Task may ignore the timeout of its Run, so they are
+// cancelled instead, like the child PipelineRuns. The TaskRuns time out on their own. The ones
+// of pipelineRunState which are cancelled already aren't patched again.
+func cancelTimedOutPipelineTasks(logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, dfinally *dag.Graph, pipelineRunState resources.PipelineRunState, clientSet clientset.Interface) error {
+	tasksTimedOut, finallyTimedOut := pr.HaveTasksTimedOut(), pr.HaveFinallyTimedOut()
+	errs, err := cancelPipelineTasks(logger, pr, clientSet, cancelledNames(pipelineRunState), func(kind, pipelineTaskName string, c *apis.Condition) bool {
+		if kind == pipeline.TaskRunControllerName || (c != nil && !c.IsUnknown()) {
+			return false
+		}
+		if _, finally := dfinally.Nodes[pipelineTaskName]; finally {
+			return finallyTimedOut
+		}
+		return tasksTimedOut
+	})
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("error(s) from cancelling the timed out tasks of PipelineRun %s: %s", pr.Name, strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// cancelledNames returns the names of the TaskRuns, Runs and child PipelineRuns of
+// pipelineRunState whose spec.status is cancelled already.
+func cancelledNames(pipelineRunState resources.PipelineRunState) sets.String {
+	cancelled := sets.NewString()
+	for _, rprt := range pipelineRunState {
+		if rprt.TaskRun != nil && rprt.TaskRun.IsCancelled() {
+			cancelled.Insert(rprt.TaskRun.Name)
+		}
+		for _, tr := range rprt.TaskRuns {
+			if tr != nil && tr.IsCancelled() {
+				cancelled.Insert(tr.Name)
+			}
+		}
+		if rprt.Run != nil && rprt.Run.IsCancelled() {
+			cancelled.Insert(rprt.Run.Name)
+		}
+		if rprt.ChildPipelineRun != nil && rprt.ChildPipelineRun.IsCancelled() {
+			cancelled.Insert(rprt.ChildPipelineRun.Name)
+		}
+	}
+	return cancelled
+}
+
+// cancelPipelineTasks patches the TaskRuns, Runs and child PipelineRuns of the PipelineRun with
+// cancellation when shouldCancel returns true for their kind, their PipelineTask and their
+// condition, unless their name is in cancelled. It returns the errors of the patches which failed.
+func cancelPipelineTasks(logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, clientSet clientset.Interface, cancelled sets.String, shouldCancel func(kind, pipelineTaskName string, c *apis.Condition) bool) ([]string, error) {
+	errs := []string{}
+
+	// Use Patch to update the TaskRuns since the TaskRun controller may be operating on the
+	// TaskRuns at the same time and trying to update the entire object may cause a race
+	b, err := getCancelPatch(v1beta1.TaskRunSpecStatusCancelled)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't make patch to update TaskRun cancellation: %v", err)
+	}
+	runPatch, err := getCancelPatch(string(v1alpha1.RunSpecStatusCancelled))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't make patch to update Run cancellation: %v", err)
+	}
+	pipelineRunPatch, err := getCancelPatch(v1beta1.PipelineRunSpecStatusCancelled)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't make patch to update PipelineRun cancellation: %v", err)
+	}
+
+	// Loop over the TaskRuns in the PipelineRun status.
+	// If a TaskRun is not in the status yet we should not cancel it anyways.
+	for taskRunName, trs := range pr.Status.TaskRuns {
+		var c *apis.Condition
+		if trs.Status != nil {
+			c = trs.Status.GetCondition(apis.ConditionSucceeded)
+		}
+		if cancelled.Has(taskRunName) || !shouldCancel(pipeline.TaskRunControllerName, trs.PipelineTaskName, c) {
+			continue
+		}
+		logger.Infof("cancelling TaskRun %s", taskRunName)
+
+		if _, err := clientSet.TektonV1beta1().TaskRuns(pr.Namespace).Patch(taskRunName, types.JSONPatchType, b, ""); err != nil {
+			errs = append(errs, fmt.Errorf("Failed to patch TaskRun `%s` with cancellation: %s", taskRunName, err).Error())
+			continue
+		}
+	}
+	// Custom Tasks are cancelled the same way, through their Run.
+	for runName, rs := range pr.Status.Runs {
+		var c *apis.Condition
+		if rs.Status != nil {
+			c = rs.Status.GetCondition(apis.ConditionSucceeded)
+		}
+		if cancelled.Has(runName) || !shouldCancel(pipeline.RunControllerName, rs.PipelineTaskName, c) {
+			continue
+		}
+		logger.Infof("cancelling Run %s", runName)
+
+		if _, err := clientSet.TektonV1alpha1().Runs(pr.Namespace).Patch(runName, types.JSONPatchType, runPatch, ""); err != nil {
+			errs = append(errs, fmt.Errorf("Failed to patch Run `%s` with cancellation: %s", runName, err).Error())
+			continue
+		}
+	}
+	// Child PipelineRuns cancel their own TaskRuns when they get cancelled.
+	for childName, cs := range pr.Status.ChildPipelineRuns {
+		var c *apis.Condition
+		if cs.Status != nil {
+			c = cs.Status.GetCondition(apis.ConditionSucceeded)
+		}
+		if cancelled.Has(childName) || !shouldCancel(pipeline.PipelineRunControllerName, cs.PipelineTaskName, c) {
+			continue
+		}
+		logger.Infof("cancelling child PipelineRun %s", childName)
+
+		if _, err := clientSet.TektonV1beta1().PipelineRuns(pr.Namespace).Patch(childName, types.JSONPatchType, pipelineRunPatch, ""); err != nil {
+			errs = append(errs, fmt.Errorf("Failed to patch PipelineRun `%s` with cancellation: %s", childName, err).Error())
+			continue
+		}
+	}
+	return errs, nil
+}
+
+func getCancelPatch(status string) ([]byte, error) {
 	patches := []jsonpatch.JsonPatchOperation{{
 		Operation: "add",
 		Path:      "/spec/status",
-		Value:     v1beta1.TaskRunSpecStatusCancelled,
+		Value:     status,
 	}}
 	patchBytes, err := json.Marshal(patches)
 	if err != nil {
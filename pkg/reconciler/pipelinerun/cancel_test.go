@@ -19,12 +19,20 @@ package pipelinerun
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"github.com/tektoncd/pipeline/test"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktesting "k8s.io/client-go/testing"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 	logtesting "knative.dev/pkg/logging/testing"
 )
 
@@ -33,6 +41,8 @@ func TestCancelPipelineRun(t *testing.T) {
 		name        string
 		pipelineRun *v1beta1.PipelineRun
 		taskRuns    []*v1beta1.TaskRun
+		runs        []*v1alpha1.Run
+		children    []*v1beta1.PipelineRun
 	}{{
 		name: "no-resolved-taskrun",
 		pipelineRun: &v1beta1.PipelineRun{
@@ -75,13 +85,62 @@ func TestCancelPipelineRun(t *testing.T) {
 			{ObjectMeta: metav1.ObjectMeta{Name: "t1"}},
 			{ObjectMeta: metav1.ObjectMeta{Name: "t2"}},
 		},
+	}, {
+		name: "multiple-runs",
+		pipelineRun: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run-cancelled"},
+			Spec: v1beta1.PipelineRunSpec{
+				Status: v1beta1.PipelineRunSpecStatusCancelled,
+			},
+			Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				TaskRuns: map[string]*v1beta1.PipelineRunTaskRunStatus{
+					"t1": {PipelineTaskName: "task-1"},
+				},
+				Runs: map[string]*v1beta1.PipelineRunRunStatus{
+					"r1": {PipelineTaskName: "custom-task-1"},
+					"r2": {PipelineTaskName: "custom-task-2"},
+				},
+			}},
+		},
+		taskRuns: []*v1beta1.TaskRun{
+			{ObjectMeta: metav1.ObjectMeta{Name: "t1"}},
+		},
+		runs: []*v1alpha1.Run{
+			{ObjectMeta: metav1.ObjectMeta{Name: "r1"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "r2"}},
+		},
+	}, {
+		name: "multiple-child-pipelineruns",
+		pipelineRun: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run-cancelled"},
+			Spec: v1beta1.PipelineRunSpec{
+				Status: v1beta1.PipelineRunSpecStatusCancelled,
+			},
+			Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				TaskRuns: map[string]*v1beta1.PipelineRunTaskRunStatus{
+					"t1": {PipelineTaskName: "task-1"},
+				},
+				ChildPipelineRuns: map[string]*v1beta1.PipelineRunChildStatus{
+					"p1": {PipelineTaskName: "build"},
+					"p2": {PipelineTaskName: "deploy"},
+				},
+			}},
+		},
+		taskRuns: []*v1beta1.TaskRun{
+			{ObjectMeta: metav1.ObjectMeta{Name: "t1"}},
+		},
+		children: []*v1beta1.PipelineRun{
+			{ObjectMeta: metav1.ObjectMeta{Name: "p1"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "p2"}},
+		},
 	}}
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			d := test.Data{
-				PipelineRuns: []*v1beta1.PipelineRun{tc.pipelineRun},
+				PipelineRuns: append([]*v1beta1.PipelineRun{tc.pipelineRun}, tc.children...),
 				TaskRuns:     tc.taskRuns,
+				Runs:         tc.runs,
 			}
 			ctx, _ := ttesting.SetupFakeContext(t)
 			ctx, cancel := context.WithCancel(ctx)
@@ -104,6 +163,195 @@ func TestCancelPipelineRun(t *testing.T) {
 					t.Errorf("expected task %q to be marked as cancelled, was %q", tr.Name, tr.Spec.Status)
 				}
 			}
+			runs, err := c.Pipeline.TektonV1alpha1().Runs("").List(metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, r := range runs.Items {
+				if r.Spec.Status != v1alpha1.RunSpecStatusCancelled {
+					t.Errorf("expected run %q to be marked as cancelled, was %q", r.Name, r.Spec.Status)
+				}
+			}
+			prs, err := c.Pipeline.TektonV1beta1().PipelineRuns("").List(metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, pr := range prs.Items {
+				if pr.Spec.Status != v1beta1.PipelineRunSpecStatusCancelled {
+					t.Errorf("expected pipelinerun %q to be marked as cancelled, was %q", pr.Name, pr.Spec.Status)
+				}
+			}
+		})
+	}
+}
+
+func TestGracefullyCancelPipelineRun(t *testing.T) {
+	running := duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown}}}
+	succeeded := duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}}}
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run-cancelled"},
+		Spec: v1beta1.PipelineRunSpec{
+			Status: v1beta1.PipelineRunSpecStatusCancelledRunFinally,
+		},
+		Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+			TaskRuns: map[string]*v1beta1.PipelineRunTaskRunStatus{
+				"t1": {PipelineTaskName: "task-1", Status: &v1beta1.TaskRunStatus{Status: running}},
+				"t2": {PipelineTaskName: "task-2", Status: &v1beta1.TaskRunStatus{Status: succeeded}},
+				"t3": {PipelineTaskName: "final-task-1", Status: &v1beta1.TaskRunStatus{Status: running}},
+				"t4": {PipelineTaskName: "task-4", Status: &v1beta1.TaskRunStatus{Status: running}},
+			},
+			Runs: map[string]*v1beta1.PipelineRunRunStatus{
+				"r1": {PipelineTaskName: "task-3"},
+			},
+		}},
+	}
+	// t4 was cancelled by a previous reconcile, but it is still running
+	t4 := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "t4"},
+		Spec:       v1beta1.TaskRunSpec{Status: v1beta1.TaskRunSpecStatusCancelled},
+	}
+	d := test.Data{
+		PipelineRuns: []*v1beta1.PipelineRun{pr},
+		TaskRuns: []*v1beta1.TaskRun{
+			{ObjectMeta: metav1.ObjectMeta{Name: "t1"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "t2"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "t3"}},
+			t4,
+		},
+		Runs: []*v1alpha1.Run{
+			{ObjectMeta: metav1.ObjectMeta{Name: "r1"}},
+		},
+	}
+	dfinally, err := dag.Build(v1beta1.PipelineTaskList{{Name: "final-task-1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, _ := ttesting.SetupFakeContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	c, _ := test.SeedTestData(t, ctx, d)
+	state := resources.PipelineRunState{{TaskRunName: "t4", TaskRun: t4}}
+	if err := gracefullyCancelPipelineRun(logtesting.TestLogger(t), pr, dfinally, state, c.Pipeline); err != nil {
+		t.Fatal(err)
+	}
+	// The PipelineRun keeps running until its finally tasks are done
+	if cond := pr.Status.GetCondition(apis.ConditionSucceeded); cond != nil {
+		t.Errorf("Expected the condition of the PipelineRun to be left alone, got %v", cond)
+	}
+
+	// Only the running DAG tasks are cancelled
+	wantCancelled := map[string]bool{"t1": true, "t2": false, "t3": false, "t4": true}
+	l, err := c.Pipeline.TektonV1beta1().TaskRuns("").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tr := range l.Items {
+		if cancelled := tr.Spec.Status == v1beta1.TaskRunSpecStatusCancelled; cancelled != wantCancelled[tr.Name] {
+			t.Errorf("expected TaskRun %q to be cancelled: %t, its status is %q", tr.Name, wantCancelled[tr.Name], tr.Spec.Status)
+		}
+	}
+	r, err := c.Pipeline.TektonV1alpha1().Runs("").Get("r1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Spec.Status != v1alpha1.RunSpecStatusCancelled {
+		t.Errorf("expected run %q to be marked as cancelled, was %q", r.Name, r.Spec.Status)
+	}
+	// The TaskRun cancelled already is not patched again
+	for _, a := range c.Pipeline.Actions() {
+		if patch, ok := a.(ktesting.PatchAction); ok && patch.GetName() == "t4" {
+			t.Errorf("expected the cancelled TaskRun t4 not to be patched again, got %v", patch)
+		}
+	}
+}
+
+func TestCancelTimedOutPipelineTasks(t *testing.T) {
+	running := duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown}}}
+	runRunning := duckv1.Status{Conditions: duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown}}}
+	runSucceeded := duckv1.Status{Conditions: duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}}}
+	for _, tc := range []struct {
+		name             string
+		finallyStartTime *metav1.Time
+		wantCancelled    map[string]bool
+	}{{
+		name:          "tasks timed out",
+		wantCancelled: map[string]bool{"r1": true, "c1": true},
+	}, {
+		name:             "finally timed out",
+		finallyStartTime: &metav1.Time{Time: time.Now().Add(-20 * time.Minute)},
+		wantCancelled:    map[string]bool{"r1": true, "c1": true, "r3": true},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run-timed-out"},
+				Spec: v1beta1.PipelineRunSpec{
+					Timeouts: &v1beta1.TimeoutFields{
+						Pipeline: &metav1.Duration{Duration: time.Hour},
+						Tasks:    &metav1.Duration{Duration: 10 * time.Minute},
+						Finally:  &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+				Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+					StartTime:        &metav1.Time{Time: time.Now().Add(-40 * time.Minute)},
+					FinallyStartTime: tc.finallyStartTime,
+					TaskRuns: map[string]*v1beta1.PipelineRunTaskRunStatus{
+						"t1": {PipelineTaskName: "task-1", Status: &v1beta1.TaskRunStatus{Status: running}},
+					},
+					Runs: map[string]*v1beta1.PipelineRunRunStatus{
+						"r1": {PipelineTaskName: "task-2", Status: &v1alpha1.RunStatus{Status: runRunning}},
+						"r2": {PipelineTaskName: "task-3", Status: &v1alpha1.RunStatus{Status: runSucceeded}},
+						"r3": {PipelineTaskName: "final-task-1", Status: &v1alpha1.RunStatus{Status: runRunning}},
+					},
+					ChildPipelineRuns: map[string]*v1beta1.PipelineRunChildStatus{
+						"c1": {PipelineTaskName: "task-4", Status: &v1beta1.PipelineRunStatus{Status: running}},
+					},
+				}},
+			}
+			d := test.Data{
+				PipelineRuns: []*v1beta1.PipelineRun{pr, {ObjectMeta: metav1.ObjectMeta{Name: "c1"}}},
+				TaskRuns:     []*v1beta1.TaskRun{{ObjectMeta: metav1.ObjectMeta{Name: "t1"}}},
+				Runs: []*v1alpha1.Run{
+					{ObjectMeta: metav1.ObjectMeta{Name: "r1"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "r2"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "r3"}},
+				},
+			}
+			dfinally, err := dag.Build(v1beta1.PipelineTaskList{{Name: "final-task-1"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx, _ := ttesting.SetupFakeContext(t)
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			c, _ := test.SeedTestData(t, ctx, d)
+			if err := cancelTimedOutPipelineTasks(logtesting.TestLogger(t), pr, dfinally, nil, c.Pipeline); err != nil {
+				t.Fatal(err)
+			}
+
+			// The TaskRuns time out on their own
+			tr, err := c.Pipeline.TektonV1beta1().TaskRuns("").Get("t1", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tr.Spec.Status != "" {
+				t.Errorf("expected TaskRun %q not to be cancelled, its status is %q", tr.Name, tr.Spec.Status)
+			}
+			l, err := c.Pipeline.TektonV1alpha1().Runs("").List(metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, r := range l.Items {
+				if cancelled := r.Spec.Status == v1alpha1.RunSpecStatusCancelled; cancelled != tc.wantCancelled[r.Name] {
+					t.Errorf("expected Run %q to be cancelled: %t, its status is %q", r.Name, tc.wantCancelled[r.Name], r.Spec.Status)
+				}
+			}
+			child, err := c.Pipeline.TektonV1beta1().PipelineRuns("").Get("c1", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if child.Spec.Status != v1beta1.PipelineRunSpecStatusCancelled {
+				t.Errorf("expected child PipelineRun %q to be cancelled, its status is %q", child.Name, child.Spec.Status)
+			}
 		})
 	}
 }
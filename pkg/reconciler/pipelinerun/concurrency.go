@@ -0,0 +1,260 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// MaxConcurrentRunsAnnotationKey is the annotation, set on a Pipeline or a PipelineRun, which
+	// limits the number of PipelineRuns of the same concurrency group running at the same time
+	MaxConcurrentRunsAnnotationKey = pipeline.GroupName + "/max-concurrent-runs"
+
+	// ConcurrencyGroupAnnotationKey is the annotation which sets the concurrency group of a
+	// PipelineRun. PipelineRuns default to the group named after the Pipeline they reference.
+	ConcurrencyGroupAnnotationKey = pipeline.GroupName + "/concurrency-group"
+
+	// ConcurrencyStrategyAnnotationKey is the annotation, set on a Pipeline or a PipelineRun, which
+	// selects how older PipelineRuns of the same concurrency group are handled
+	ConcurrencyStrategyAnnotationKey = pipeline.GroupName + "/concurrency-strategy"
+
+	// ConcurrencyStrategyQueue queues new PipelineRuns until older ones complete
+	ConcurrencyStrategyQueue = v1beta1.ConcurrencyStrategyQueue
+
+	// ConcurrencyStrategyCancelOlder cancels the older PipelineRuns of the same concurrency
+	// group when a new PipelineRun is created
+	ConcurrencyStrategyCancelOlder = v1beta1.ConcurrencyStrategyCancelOlder
+)
+
+// concurrencyPolicy holds the concurrency limits which apply to a PipelineRun
+type concurrencyPolicy struct {
+	namespaceLimit int
+	group          string
+	groupLimit     int
+	cancelOlder    bool
+}
+
+// concurrencyGroup returns the concurrency group of the PipelineRun: the group set through
+// annotation if any, otherwise the name of the Pipeline it references.
+func concurrencyGroup(pr *v1beta1.PipelineRun) string {
+	if group := pr.ObjectMeta.Annotations[ConcurrencyGroupAnnotationKey]; group != "" {
+		return group
+	}
+	if pr.Spec.PipelineRef != nil && pr.Spec.PipelineRef.Bundle == "" {
+		return pr.Spec.PipelineRef.Name
+	}
+	return ""
+}
+
+// isChildPipelineRun returns true if the PipelineRun was created by another PipelineRun to run
+// one of its PipelineTasks. Child PipelineRuns are not subject to the concurrency limits: their
+// parent already took a slot, and queuing them behind it would never let it complete.
+func isChildPipelineRun(pr *v1beta1.PipelineRun) bool {
+	owner := metav1.GetControllerOf(pr)
+	return owner != nil && owner.Kind == pipeline.PipelineRunControllerName
+}
+
+// createdBefore returns true if the PipelineRun a was created before b. PipelineRuns created
+// within the same second are ordered by name, so that the order is stable.
+func createdBefore(a, b *v1beta1.PipelineRun) bool {
+	if a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.Name < b.Name
+	}
+	return a.CreationTimestamp.Before(&b.CreationTimestamp)
+}
+
+// getConcurrencyPolicy builds the concurrency policy of the PipelineRun from the config-defaults
+// ConfigMap, the concurrency of the spec of its Pipeline and the annotations of its Pipeline, which
+// the PipelineRun annotations override.
+func (c *Reconciler) getConcurrencyPolicy(ctx context.Context, pr *v1beta1.PipelineRun) concurrencyPolicy {
+	logger := logging.FromContext(ctx)
+	policy := concurrencyPolicy{group: concurrencyGroup(pr)}
+	if defaults := config.FromContextOrDefaults(ctx).Defaults; defaults != nil {
+		policy.namespaceLimit = defaults.DefaultMaxConcurrentPipelineRuns
+	}
+
+	annotations := map[string]string{}
+	spec := pr.Spec.PipelineSpec
+	if pr.Spec.PipelineRef != nil && pr.Spec.PipelineRef.Name != "" && pr.Spec.PipelineRef.Bundle == "" {
+		// The Pipeline may not exist yet, in which case the PipelineRun will fail once started
+		if p, err := c.pipelineLister.Pipelines(pr.Namespace).Get(pr.Spec.PipelineRef.Name); err == nil {
+			spec = &p.Spec
+			for k, v := range p.ObjectMeta.Annotations {
+				annotations[k] = v
+			}
+		}
+	}
+	for k, v := range pr.ObjectMeta.Annotations {
+		annotations[k] = v
+	}
+
+	if spec != nil && spec.Concurrency != nil {
+		policy.groupLimit = spec.Concurrency.MaxRuns
+		policy.cancelOlder = spec.Concurrency.Strategy == ConcurrencyStrategyCancelOlder
+	}
+
+	if limit, ok := annotations[MaxConcurrentRunsAnnotationKey]; ok {
+		l, err := strconv.Atoi(limit)
+		if err != nil || l < 0 {
+			logger.Warnf("Ignoring invalid %s annotation %q for PipelineRun %s", MaxConcurrentRunsAnnotationKey, limit, pr.Name)
+		} else {
+			policy.groupLimit = l
+		}
+	}
+	switch strategy := annotations[ConcurrencyStrategyAnnotationKey]; strategy {
+	case "":
+	case ConcurrencyStrategyQueue:
+		policy.cancelOlder = false
+	case ConcurrencyStrategyCancelOlder:
+		policy.cancelOlder = true
+	default:
+		logger.Warnf("Ignoring invalid %s annotation %q for PipelineRun %s", ConcurrencyStrategyAnnotationKey, strategy, pr.Name)
+	}
+	if policy.group == "" {
+		policy.groupLimit = 0
+		policy.cancelOlder = false
+	}
+	return policy
+}
+
+// enforceConcurrencyPolicy is called for PipelineRuns which have not started yet. It cancels older
+// PipelineRuns of the same concurrency group when requested to, and queues the PipelineRun when
+// starting it would exceed the concurrency limits. It returns true if the PipelineRun is queued.
+//
+// PipelineRuns are started in the order they were created: a queued PipelineRun takes a slot
+// ahead of the PipelineRuns created after it. Child PipelineRuns are never queued, and don't
+// take a slot.
+func (c *Reconciler) enforceConcurrencyPolicy(ctx context.Context, pr *v1beta1.PipelineRun) (bool, error) {
+	logger := logging.FromContext(ctx)
+	if isChildPipelineRun(pr) {
+		return false, nil
+	}
+	policy := c.getConcurrencyPolicy(ctx, pr)
+	if policy.namespaceLimit <= 0 && policy.groupLimit <= 0 && !policy.cancelOlder {
+		return false, nil
+	}
+
+	prs, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).List(labels.Everything())
+	if err != nil {
+		return false, fmt.Errorf("failed to list PipelineRuns in namespace %s: %w", pr.Namespace, err)
+	}
+
+	var namespaceAhead, groupAhead int
+	for _, other := range prs {
+		if other.Name == pr.Name || other.IsDone() || isChildPipelineRun(other) {
+			continue
+		}
+		sameGroup := policy.group != "" && concurrencyGroup(other) == policy.group
+		if policy.cancelOlder && sameGroup && !other.IsCancelled() && createdBefore(other, pr) {
+			logger.Infof("Cancelling PipelineRun %s, superseded by PipelineRun %s", other.Name, pr.Name)
+			if err := c.cancelSupersededPipelineRun(other); err != nil {
+				return false, err
+			}
+			continue
+		}
+		if !other.HasStarted() && (other.IsPending() || other.IsCancelled() || !createdBefore(other, pr)) {
+			// Pending PipelineRuns do not take a slot, and neither do the ones created after this one
+			continue
+		}
+		namespaceAhead++
+		if sameGroup {
+			groupAhead++
+		}
+	}
+
+	var message string
+	switch {
+	case policy.groupLimit > 0 && groupAhead >= policy.groupLimit:
+		message = fmt.Sprintf("PipelineRun %q is queued: the limit of %d concurrent PipelineRuns for group %q is reached",
+			pr.Name, policy.groupLimit, policy.group)
+	case policy.namespaceLimit > 0 && namespaceAhead >= policy.namespaceLimit:
+		message = fmt.Sprintf("PipelineRun %q is queued: the limit of %d concurrent PipelineRuns in namespace %q is reached",
+			pr.Name, policy.namespaceLimit, pr.Namespace)
+	default:
+		return false, nil
+	}
+	pr.Status.SetCondition(&apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionUnknown,
+		Reason:  v1beta1.PipelineRunReasonQueued.String(),
+		Message: message,
+	})
+	return true, nil
+}
+
+// cancelSupersededPipelineRun requests the cancellation of a PipelineRun by patching its spec status
+func (c *Reconciler) cancelSupersededPipelineRun(pr *v1beta1.PipelineRun) error {
+	b, err := getCancelPatch(v1beta1.PipelineRunSpecStatusCancelled)
+	if err != nil {
+		return fmt.Errorf("couldn't make patch to update PipelineRun cancellation: %v", err)
+	}
+	if _, err := c.PipelineClientSet.TektonV1beta1().PipelineRuns(pr.Namespace).Patch(pr.Name, types.JSONPatchType, b, ""); err != nil {
+		return fmt.Errorf("failed to patch PipelineRun %s with cancellation: %w", pr.Name, err)
+	}
+	return nil
+}
+
+// enqueueQueuedPipelineRuns returns the informer event handlers which, when a PipelineRun
+// completes, i.e. is updated from not done to done, or is deleted, enqueue the PipelineRuns queued in the same namespace so that they
+// can start as soon as a slot frees up.
+func enqueueQueuedPipelineRuns(lister listers.PipelineRunLister, enqueueKey func(types.NamespacedName)) cache.ResourceEventHandlerFuncs {
+	enqueue := func(namespace string) {
+		prs, err := lister.PipelineRuns(namespace).List(labels.Everything())
+		if err != nil {
+			return
+		}
+		for _, pr := range prs {
+			if pr.IsQueued() {
+				enqueueKey(pr.GetNamespacedName())
+			}
+		}
+	}
+	return cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, obj interface{}) {
+			old, ok := oldObj.(*v1beta1.PipelineRun)
+			if !ok || old.IsDone() {
+				return
+			}
+			if pr, ok := obj.(*v1beta1.PipelineRun); ok && pr.IsDone() {
+				enqueue(pr.Namespace)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if pr, ok := obj.(*v1beta1.PipelineRun); ok {
+				enqueue(pr.Namespace)
+			}
+		},
+	}
+}
@@ -0,0 +1,409 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	tb "github.com/tektoncd/pipeline/internal/builder/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	fakepipelineruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipelinerun/fake"
+	"github.com/tektoncd/pipeline/pkg/system"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+var concurrencyTestStart = time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+// concurrencyPipelineRun returns a PipelineRun of the test-pipeline Pipeline created
+// the given number of minutes after concurrencyTestStart.
+func concurrencyPipelineRun(name string, createdAfter int, started bool, ops ...tb.PipelineRunOp) *v1beta1.PipelineRun {
+	ops = append([]tb.PipelineRunOp{
+		tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccountName("test-sa")),
+	}, ops...)
+	if started {
+		ops = append(ops, tb.PipelineRunStatus(
+			tb.PipelineRunStartTime(concurrencyTestStart),
+			tb.PipelineRunStatusCondition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionUnknown,
+				Reason: v1beta1.PipelineRunReasonRunning.String(),
+			}),
+		))
+	}
+	pr := tb.PipelineRun(name, ops...)
+	pr.CreationTimestamp = metav1.NewTime(concurrencyTestStart.Add(time.Duration(createdAfter) * time.Minute))
+	return pr
+}
+
+func queued(pr *v1beta1.PipelineRun) *v1beta1.PipelineRun {
+	pr.Status.SetCondition(&apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown,
+		Reason: v1beta1.PipelineRunReasonQueued.String(),
+	})
+	return pr
+}
+
+func maxConcurrentPipelineRuns(limit string) []*corev1.ConfigMap {
+	return []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.GetNamespace()},
+		Data: map[string]string{
+			"default-max-concurrent-pipelineruns": limit,
+		},
+	}}
+}
+
+func createdTaskRuns(clients test.Clients) int {
+	count := 0
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+			count++
+		}
+	}
+	return count
+}
+
+func TestConcurrencyGroup(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		pr   *v1beta1.PipelineRun
+		want string
+	}{{
+		name: "pipeline name",
+		pr:   tb.PipelineRun("pr", tb.PipelineRunSpec("test-pipeline")),
+		want: "test-pipeline",
+	}, {
+		name: "group annotation",
+		pr: tb.PipelineRun("pr", tb.PipelineRunSpec("test-pipeline"),
+			tb.PipelineRunAnnotation(ConcurrencyGroupAnnotationKey, "pull-request-42")),
+		want: "pull-request-42",
+	}, {
+		name: "embedded pipeline spec",
+		pr: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "pr"},
+			Spec:       v1beta1.PipelineRunSpec{PipelineSpec: &v1beta1.PipelineSpec{}},
+		},
+		want: "",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := concurrencyGroup(tc.pr); got != tc.want {
+				t.Errorf("concurrencyGroup() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCreatedBefore(t *testing.T) {
+	a := concurrencyPipelineRun("a", 0, false)
+	b := concurrencyPipelineRun("b", 0, false)
+	c := concurrencyPipelineRun("c", 1, false)
+	if !createdBefore(a, c) || createdBefore(c, a) {
+		t.Errorf("Expected PipelineRuns to be ordered by creation time")
+	}
+	if !createdBefore(a, b) || createdBefore(b, a) {
+		t.Errorf("Expected PipelineRuns created at the same time to be ordered by name")
+	}
+}
+
+func TestReconcile_QueuedOverNamespaceLimit(t *testing.T) {
+	prs := []*v1beta1.PipelineRun{
+		concurrencyPipelineRun("test-pipeline-run-running", 0, true),
+		concurrencyPipelineRun("test-pipeline-run-new", 1, false),
+	}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines: []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+			tb.PipelineTask("hello-world-1", "hello-world"),
+		))},
+		Tasks:      []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))},
+		ConfigMaps: maxConcurrentPipelineRuns("1"),
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal PipelineRunQueued PipelineRun \"test-pipeline-run-new\" is queued: the limit of 1 concurrent PipelineRuns in namespace \"foo\" is reached",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-new", wantEvents, false)
+
+	if !reconciledRun.IsQueued() {
+		t.Errorf("Expected PipelineRun to be queued, but condition is %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+	if reconciledRun.HasStarted() {
+		t.Errorf("Expected a queued PipelineRun not to be started")
+	}
+	if n := createdTaskRuns(clients); n != 0 {
+		t.Errorf("Expected no TaskRun to be created for a queued PipelineRun, got %d", n)
+	}
+}
+
+func TestReconcile_StartedUnderNamespaceLimit(t *testing.T) {
+	prs := []*v1beta1.PipelineRun{
+		concurrencyPipelineRun("test-pipeline-run-running", 0, true),
+		// PipelineRuns created later and pending PipelineRuns don't take a slot ahead of it
+		concurrencyPipelineRun("test-pipeline-run-pending", 0, false, tb.PipelineRunSpec("test-pipeline", tb.PipelineRunPending)),
+		queued(concurrencyPipelineRun("test-pipeline-run-newer", 2, false)),
+		queued(concurrencyPipelineRun("test-pipeline-run-new", 1, false)),
+	}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines: []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+			tb.PipelineTask("hello-world-1", "hello-world"),
+		))},
+		Tasks:      []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))},
+		ConfigMaps: maxConcurrentPipelineRuns("2"),
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 0 \\(Failed: 0, Cancelled 0\\), Incomplete: 1, Skipped: 0",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-new", wantEvents, false)
+
+	if !reconciledRun.HasStarted() || reconciledRun.IsQueued() {
+		t.Errorf("Expected the queued PipelineRun to be started, but condition is %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+	if n := createdTaskRuns(clients); n != 1 {
+		t.Errorf("Expected 1 TaskRun to be created, got %d", n)
+	}
+}
+
+func TestReconcile_QueuedInFIFOOrderOverGroupLimit(t *testing.T) {
+	prs := []*v1beta1.PipelineRun{
+		concurrencyPipelineRun("test-pipeline-run-running", 0, true),
+		// Started PipelineRuns of other Pipelines don't count towards the group limit
+		concurrencyPipelineRun("other-pipeline-run-running", 0, true, tb.PipelineRunSpec("other-pipeline")),
+		queued(concurrencyPipelineRun("test-pipeline-run-first", 1, false)),
+		concurrencyPipelineRun("test-pipeline-run-second", 2, false),
+	}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines: []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"),
+			tb.PipelineAnnotation(MaxConcurrentRunsAnnotationKey, "2"),
+			tb.PipelineSpec(tb.PipelineTask("hello-world-1", "hello-world")),
+		)},
+		Tasks: []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))},
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal PipelineRunQueued PipelineRun \"test-pipeline-run-second\" is queued: the limit of 2 concurrent PipelineRuns for group \"test-pipeline\" is reached",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-second", wantEvents, false)
+
+	if !reconciledRun.IsQueued() {
+		t.Errorf("Expected PipelineRun to be queued behind the older queued PipelineRun, but condition is %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+	if n := createdTaskRuns(clients); n != 0 {
+		t.Errorf("Expected no TaskRun to be created for a queued PipelineRun, got %d", n)
+	}
+}
+
+func TestReconcile_CancelOlderPipelineRuns(t *testing.T) {
+	group := tb.PipelineRunAnnotation(ConcurrencyGroupAnnotationKey, "pull-request-42")
+	prs := []*v1beta1.PipelineRun{
+		concurrencyPipelineRun("test-pipeline-run-older", 0, true, group),
+		concurrencyPipelineRun("test-pipeline-run-other-group", 0, true),
+		concurrencyPipelineRun("test-pipeline-run-new", 1, false, group),
+	}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines: []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"),
+			tb.PipelineAnnotation(ConcurrencyStrategyAnnotationKey, ConcurrencyStrategyCancelOlder),
+			tb.PipelineSpec(tb.PipelineTask("hello-world-1", "hello-world")),
+		)},
+		Tasks: []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))},
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-new", []string{}, false)
+
+	if !reconciledRun.HasStarted() {
+		t.Errorf("Expected the newest PipelineRun of the group to be started")
+	}
+	var patched []string
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "patch" && a.GetResource().Resource == "pipelineruns" {
+			patched = append(patched, a.(interface{ GetName() string }).GetName())
+		}
+	}
+	if d := cmp.Diff([]string{"test-pipeline-run-older"}, patched); d != "" {
+		t.Errorf("Unexpected cancelled PipelineRuns %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcile_QueuedOverSpecConcurrency(t *testing.T) {
+	prs := []*v1beta1.PipelineRun{
+		concurrencyPipelineRun("test-pipeline-run-running", 0, true),
+		concurrencyPipelineRun("test-pipeline-run-new", 1, false),
+	}
+	p := tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+	))
+	p.Spec.Concurrency = &v1beta1.PipelineConcurrency{MaxRuns: 1}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    []*v1beta1.Pipeline{p},
+		Tasks:        []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))},
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal PipelineRunQueued PipelineRun \"test-pipeline-run-new\" is queued: the limit of 1 concurrent PipelineRuns for group \"test-pipeline\" is reached",
+	}
+	reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run-new", wantEvents, false)
+
+	if !reconciledRun.IsQueued() {
+		t.Errorf("Expected PipelineRun to be queued, but condition is %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+}
+
+func TestGetConcurrencyPolicy_AnnotationsOverrideSpec(t *testing.T) {
+	p := tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"),
+		tb.PipelineAnnotation(MaxConcurrentRunsAnnotationKey, "3"),
+		tb.PipelineSpec(tb.PipelineTask("hello-world-1", "hello-world")),
+	)
+	p.Spec.Concurrency = &v1beta1.PipelineConcurrency{MaxRuns: 1, Strategy: ConcurrencyStrategyCancelOlder}
+	pr := concurrencyPipelineRun("test-pipeline-run", 0, false,
+		tb.PipelineRunAnnotation(ConcurrencyStrategyAnnotationKey, ConcurrencyStrategyQueue))
+	prt := NewPipelineRunTest(test.Data{Pipelines: []*v1beta1.Pipeline{p}}, t)
+	defer prt.Cancel()
+
+	r := &Reconciler{pipelineLister: prt.TestAssets.Informers.Pipeline.Lister()}
+	got := r.getConcurrencyPolicy(context.Background(), pr)
+	want := concurrencyPolicy{group: "test-pipeline", groupLimit: 3}
+	if d := cmp.Diff(want, got, cmp.AllowUnexported(concurrencyPolicy{})); d != "" {
+		t.Errorf("Unexpected concurrency policy %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcile_ChildPipelineRunNotQueued(t *testing.T) {
+	parent := concurrencyPipelineRun("test-pipeline-run-parent", 0, true)
+	child := concurrencyPipelineRun("test-pipeline-run-parent-child", 1, false)
+	child.OwnerReferences = []metav1.OwnerReference{parent.GetOwnerReference()}
+	d := test.Data{
+		PipelineRuns: []*v1beta1.PipelineRun{parent, child},
+		Pipelines: []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+			tb.PipelineTask("hello-world-1", "hello-world"),
+		))},
+		Tasks:      []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))},
+		ConfigMaps: maxConcurrentPipelineRuns("1"),
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	// The parent takes the only slot, queuing its child would never let it complete
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 0 \\(Failed: 0, Cancelled 0\\), Incomplete: 1, Skipped: 0",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-parent-child", wantEvents, false)
+
+	if !reconciledRun.HasStarted() || reconciledRun.IsQueued() {
+		t.Errorf("Expected the child PipelineRun to be started, but condition is %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+	if n := createdTaskRuns(clients); n != 1 {
+		t.Errorf("Expected 1 TaskRun to be created, got %d", n)
+	}
+}
+
+func TestReconcile_ChildPipelineRunTakesNoSlot(t *testing.T) {
+	parent := concurrencyPipelineRun("test-pipeline-run-parent", 0, true)
+	child := concurrencyPipelineRun("test-pipeline-run-parent-child", 1, true)
+	child.OwnerReferences = []metav1.OwnerReference{parent.GetOwnerReference()}
+	d := test.Data{
+		PipelineRuns: []*v1beta1.PipelineRun{parent, child, concurrencyPipelineRun("test-pipeline-run-new", 2, false)},
+		Pipelines: []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+			tb.PipelineTask("hello-world-1", "hello-world"),
+		))},
+		Tasks:      []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))},
+		ConfigMaps: maxConcurrentPipelineRuns("2"),
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 0 \\(Failed: 0, Cancelled 0\\), Incomplete: 1, Skipped: 0",
+	}
+	reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run-new", wantEvents, false)
+
+	if !reconciledRun.HasStarted() || reconciledRun.IsQueued() {
+		t.Errorf("Expected the PipelineRun to be started, but condition is %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+}
+
+func TestEnqueueQueuedPipelineRuns(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	informer := fakepipelineruninformer.Get(ctx)
+	done := concurrencyPipelineRun("test-pipeline-run-done", 0, true)
+	done.Status.MarkSucceeded("Succeeded", "done")
+	for _, pr := range []*v1beta1.PipelineRun{
+		done,
+		concurrencyPipelineRun("test-pipeline-run-running", 0, true),
+		queued(concurrencyPipelineRun("test-pipeline-run-queued", 1, false)),
+	} {
+		if err := informer.Informer().GetIndexer().Add(pr); err != nil {
+			t.Fatalf("Adding PipelineRun to informer: %v", err)
+		}
+	}
+
+	var enqueued []types.NamespacedName
+	handler := enqueueQueuedPipelineRuns(informer.Lister(), func(key types.NamespacedName) {
+		enqueued = append(enqueued, key)
+	})
+
+	running := concurrencyPipelineRun("test-pipeline-run-running", 0, true)
+	handler.OnUpdate(running, running)
+	if len(enqueued) != 0 {
+		t.Errorf("Expected no PipelineRun to be enqueued on the update of a running PipelineRun, got %v", enqueued)
+	}
+
+	// Updates of a PipelineRun which was already done, e.g. its labels, don't free a slot
+	handler.OnUpdate(done, done)
+	if len(enqueued) != 0 {
+		t.Errorf("Expected no PipelineRun to be enqueued on the update of a done PipelineRun, got %v", enqueued)
+	}
+
+	handler.OnUpdate(concurrencyPipelineRun("test-pipeline-run-done", 0, true), done)
+	want := []types.NamespacedName{{Namespace: "foo", Name: "test-pipeline-run-queued"}}
+	if d := cmp.Diff(want, enqueued); d != "" {
+		t.Errorf("Unexpected enqueued PipelineRuns on completion %s", diff.PrintWantGot(d))
+	}
+
+	enqueued = nil
+	handler.OnDelete(done)
+	if d := cmp.Diff(want, enqueued); d != "" {
+		t.Errorf("Unexpected enqueued PipelineRuns on deletion %s", diff.PrintWantGot(d))
+	}
+}
@@ -22,8 +22,10 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
 	conditioninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/condition"
+	runinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/run"
 	clustertaskinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/clustertask"
 	pipelineinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipeline"
 	pipelineruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipelinerun"
@@ -49,6 +51,7 @@ func NewController(namespace string, images pipeline.Images) func(context.Contex
 		kubeclientset := kubeclient.Get(ctx)
 		pipelineclientset := pipelineclient.Get(ctx)
 		taskRunInformer := taskruninformer.Get(ctx)
+		runInformer := runinformer.Get(ctx)
 		taskInformer := taskinformer.Get(ctx)
 		clusterTaskInformer := clustertaskinformer.Get(ctx)
 		pipelineRunInformer := pipelineruninformer.Get(ctx)
@@ -62,20 +65,22 @@ func NewController(namespace string, images pipeline.Images) func(context.Contex
 		}
 
 		c := &Reconciler{
-			KubeClientSet:     kubeclientset,
-			PipelineClientSet: pipelineclientset,
-			Images:            images,
-			pipelineRunLister: pipelineRunInformer.Lister(),
-			pipelineLister:    pipelineInformer.Lister(),
-			taskLister:        taskInformer.Lister(),
-			clusterTaskLister: clusterTaskInformer.Lister(),
-			taskRunLister:     taskRunInformer.Lister(),
-			resourceLister:    resourceInformer.Lister(),
-			conditionLister:   conditionInformer.Lister(),
-			timeoutHandler:    timeoutHandler,
-			cloudEventClient:  cloudeventclient.Get(ctx),
-			metrics:           metrics,
-			pvcHandler:        volumeclaim.NewPVCHandler(kubeclientset, logger),
+			KubeClientSet:        kubeclientset,
+			PipelineClientSet:    pipelineclientset,
+			Images:               images,
+			pipelineRunLister:    pipelineRunInformer.Lister(),
+			pipelineLister:       pipelineInformer.Lister(),
+			taskLister:           taskInformer.Lister(),
+			clusterTaskLister:    clusterTaskInformer.Lister(),
+			taskRunLister:        taskRunInformer.Lister(),
+			runLister:            runInformer.Lister(),
+			resourceLister:       resourceInformer.Lister(),
+			conditionLister:      conditionInformer.Lister(),
+			timeoutHandler:       timeoutHandler,
+			cloudEventClient:     cloudeventclient.Get(ctx),
+			cloudEventDispatcher: cloudeventclient.GetDispatcher(ctx),
+			metrics:              metrics,
+			pvcHandler:           volumeclaim.NewPVCHandler(kubeclientset, logger),
 		}
 		impl := pipelinerunreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
 			configStore := config.NewStore(logger.Named("config-store"))
@@ -87,6 +92,13 @@ func NewController(namespace string, images pipeline.Images) func(context.Contex
 		})
 
 		timeoutHandler.SetCallbackFunc(impl.EnqueueKey)
+		if c.cloudEventDispatcher != nil {
+			// The PipelineRun is reconciled once its cloud events are done, to record their delivery
+			c.cloudEventDispatcher.SetCallbackFunc(pipeline.PipelineRunControllerName, impl.EnqueueKey)
+			pipelineRunInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+				DeleteFunc: c.cloudEventDispatcher.Forget,
+			})
+		}
 		timeoutHandler.CheckTimeouts(ctx, namespace, kubeclientset, pipelineclientset)
 
 		logger.Info("Setting up event handlers")
@@ -100,6 +112,19 @@ func NewController(namespace string, images pipeline.Images) func(context.Contex
 		taskRunInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 			UpdateFunc: controller.PassNew(impl.EnqueueControllerOf),
 		})
+		runInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: controller.PassNew(impl.EnqueueControllerOf),
+		})
+		// Child PipelineRuns enqueue the PipelineRun that created them
+		pipelineRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: controller.FilterControllerGVK(v1beta1.SchemeGroupVersion.WithKind("PipelineRun")),
+			Handler: cache.ResourceEventHandlerFuncs{
+				UpdateFunc: controller.PassNew(impl.EnqueueControllerOf),
+			},
+		})
+
+		// Queued PipelineRuns are enqueued when a slot frees up in their namespace
+		pipelineRunInformer.Informer().AddEventHandler(enqueueQueuedPipelineRuns(pipelineRunInformer.Lister(), impl.EnqueueKey))
 
 		go metrics.ReportRunningPipelineRuns(ctx, pipelineRunInformer.Lister())
 
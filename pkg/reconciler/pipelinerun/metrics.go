@@ -47,6 +47,18 @@ var (
 	runningPRsCount = stats.Float64("running_pipelineruns_count",
 		"Number of pipelineruns executing currently",
 		stats.UnitDimensionless)
+
+	pendingPRsCount = stats.Float64("pending_pipelineruns_count",
+		"Number of pipelineruns pending currently",
+		stats.UnitDimensionless)
+
+	pausedPRsCount = stats.Float64("paused_pipelineruns_count",
+		"Number of pipelineruns paused currently",
+		stats.UnitDimensionless)
+
+	queuedPRsCount = stats.Float64("queued_pipelineruns_count",
+		"Number of pipelineruns queued because of concurrency limits",
+		stats.UnitDimensionless)
 )
 
 // Recorder holds keys for Tekton metrics
@@ -113,6 +125,21 @@ func NewRecorder() (*Recorder, error) {
 			Measure:     runningPRsCount,
 			Aggregation: view.LastValue(),
 		},
+		&view.View{
+			Description: pendingPRsCount.Description(),
+			Measure:     pendingPRsCount,
+			Aggregation: view.LastValue(),
+		},
+		&view.View{
+			Description: pausedPRsCount.Description(),
+			Measure:     pausedPRsCount,
+			Aggregation: view.LastValue(),
+		},
+		&view.View{
+			Description: queuedPRsCount.Description(),
+			Measure:     queuedPRsCount,
+			Aggregation: view.LastValue(),
+		},
 	)
 
 	if err != nil {
@@ -163,7 +190,8 @@ func (r *Recorder) DurationAndCount(pr *v1beta1.PipelineRun) error {
 	return nil
 }
 
-// RunningPipelineRuns logs the number of PipelineRuns running right now
+// RunningPipelineRuns logs the number of PipelineRuns running right now,
+// as well as the number of PipelineRuns which are pending, paused or queued
 // returns an error if its failed to log the metrics
 func (r *Recorder) RunningPipelineRuns(lister listers.PipelineRunLister) error {
 	if !r.initialized {
@@ -175,9 +203,19 @@ func (r *Recorder) RunningPipelineRuns(lister listers.PipelineRunLister) error {
 		return fmt.Errorf("failed to list pipelineruns while generating metrics : %v", err)
 	}
 
-	var runningPRs int
+	var runningPRs, pendingPRs, pausedPRs, queuedPRs int
 	for _, pr := range prs {
-		if !pr.IsDone() {
+		if pr.IsDone() {
+			continue
+		}
+		switch {
+		case pr.IsPending() && !pr.HasStarted():
+			pendingPRs++
+		case pr.IsQueued():
+			queuedPRs++
+		case pr.IsPaused():
+			pausedPRs++
+		default:
 			runningPRs++
 		}
 	}
@@ -187,6 +225,9 @@ func (r *Recorder) RunningPipelineRuns(lister listers.PipelineRunLister) error {
 		return err
 	}
 	metrics.Record(ctx, runningPRsCount.M(float64(runningPRs)))
+	metrics.Record(ctx, pendingPRsCount.M(float64(pendingPRs)))
+	metrics.Record(ctx, pausedPRsCount.M(float64(pausedPRs)))
+	metrics.Record(ctx, queuedPRsCount.M(float64(queuedPRs)))
 
 	return nil
 }
@@ -179,6 +179,60 @@ func TestRecordRunningPipelineRunsCount(t *testing.T) {
 
 }
 
+func TestRecordPendingPausedAndQueuedPipelineRunsCount(t *testing.T) {
+	unregisterMetrics()
+
+	newPipelineRun := func(specStatus v1beta1.PipelineRunSpecStatus, started bool) *v1beta1.PipelineRun {
+		pr := &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: names.SimpleNameGenerator.RestrictLengthWithRandomSuffix("pipelinerun-")},
+			Spec:       v1beta1.PipelineRunSpec{Status: specStatus},
+			Status: v1beta1.PipelineRunStatus{
+				Status: duckv1beta1.Status{
+					Conditions: duckv1beta1.Conditions{{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionUnknown,
+					}},
+				},
+			},
+		}
+		if started {
+			pr.Status.StartTime = &metav1.Time{Time: time.Now()}
+		}
+		return pr
+	}
+
+	queued := newPipelineRun("", false)
+	queued.Status.Conditions[0].Reason = v1beta1.PipelineRunReasonQueued.String()
+
+	ctx, _ := rtesting.SetupFakeContext(t)
+	informer := fakepipelineruninformer.Get(ctx)
+	for _, pr := range []*v1beta1.PipelineRun{
+		newPipelineRun("", true),
+		newPipelineRun(v1beta1.PipelineRunSpecStatusPending, false),
+		newPipelineRun(v1beta1.PipelineRunSpecStatusPending, false),
+		newPipelineRun(v1beta1.PipelineRunSpecStatusPaused, true),
+		queued,
+	} {
+		if err := informer.Informer().GetIndexer().Add(pr); err != nil {
+			t.Fatalf("Adding PipelineRun to informer: %v", err)
+		}
+	}
+
+	metrics, err := NewRecorder()
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	if err := metrics.RunningPipelineRuns(informer.Lister()); err != nil {
+		t.Errorf("RunningPipelineRuns: %v", err)
+	}
+	metricstest.CheckLastValueData(t, "running_pipelineruns_count", map[string]string{}, 1)
+	metricstest.CheckLastValueData(t, "pending_pipelineruns_count", map[string]string{}, 2)
+	metricstest.CheckLastValueData(t, "paused_pipelineruns_count", map[string]string{}, 1)
+	metricstest.CheckLastValueData(t, "queued_pipelineruns_count", map[string]string{}, 1)
+}
+
 func unregisterMetrics() {
-	metricstest.Unregister("pipelinerun_duration_seconds", "pipelinerun_count", "running_pipelineruns_count")
+	metricstest.Unregister("pipelinerun_duration_seconds", "pipelinerun_count", "running_pipelineruns_count",
+		"pending_pipelineruns_count", "paused_pipelineruns_count", "queued_pipelineruns_count")
 }
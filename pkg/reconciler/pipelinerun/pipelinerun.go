@@ -18,6 +18,7 @@ package pipelinerun
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"reflect"
@@ -26,11 +27,11 @@ import (
 	"time"
 
 	"github.com/hashicorp/go-multierror"
-	"github.com/tektoncd/pipeline/pkg/apis/config"
 	apisconfig "github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/artifacts"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	pipelinerunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1beta1/pipelinerun"
@@ -38,17 +39,20 @@ import (
 	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
 	resourcelisters "github.com/tektoncd/pipeline/pkg/client/resource/listers/resource/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/contexts"
+	podconvert "github.com/tektoncd/pipeline/pkg/pod"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun"
+	tresources "github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	"github.com/tektoncd/pipeline/pkg/timeout"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	"github.com/tektoncd/pipeline/pkg/workspace"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
@@ -75,6 +79,10 @@ const (
 	// parameter(s) declared in the PipelineRun do not have the some declared type as the
 	// parameters(s) declared in the Pipeline that they are supposed to override.
 	ReasonParameterTypeMismatch = "ParameterTypeMismatch"
+	// ReasonInvalidParamValue indicates that the reason for the failure status is that the
+	// value of a parameter isn't allowed by the enum or pattern declared by the Pipeline, or by
+	// the Task it is given to.
+	ReasonInvalidParamValue = "InvalidParamValue"
 	// ReasonCouldntGetTask indicates that the reason for the failure status is that the
 	// associated Pipeline's Tasks couldn't all be retrieved
 	ReasonCouldntGetTask = "CouldntGetTask"
@@ -98,6 +106,13 @@ const (
 	// ReasonCouldntCancel indicates that a PipelineRun was cancelled but attempting to update
 	// all of the running TaskRuns as cancelled failed.
 	ReasonCouldntCancel = "PipelineRunCouldntCancel"
+	// ReasonMaxNestingDepthExceeded indicates that the PipelineRun would create a child
+	// PipelineRun nested deeper than allowed, for instance because its Pipeline runs itself
+	ReasonMaxNestingDepthExceeded = "MaxNestingDepthExceeded"
+
+	// NestingDepthAnnotationKey is the annotation holding the number of PipelineRuns a child
+	// PipelineRun is nested in
+	NestingDepthAnnotationKey = pipeline.GroupName + "/nesting-depth"
 )
 
 // Reconciler implements controller.Reconciler for Configuration resources.
@@ -107,18 +122,20 @@ type Reconciler struct {
 	Images            pipeline.Images
 
 	// listers index properties about resources
-	pipelineRunLister listers.PipelineRunLister
-	pipelineLister    listers.PipelineLister
-	taskRunLister     listers.TaskRunLister
-	taskLister        listers.TaskLister
-	clusterTaskLister listers.ClusterTaskLister
-	resourceLister    resourcelisters.PipelineResourceLister
-	conditionLister   listersv1alpha1.ConditionLister
-	cloudEventClient  cloudevent.CEClient
-	tracker           tracker.Interface
-	timeoutHandler    *timeout.Handler
-	metrics           *Recorder
-	pvcHandler        volumeclaim.PvcHandler
+	pipelineRunLister    listers.PipelineRunLister
+	pipelineLister       listers.PipelineLister
+	taskRunLister        listers.TaskRunLister
+	runLister            listersv1alpha1.RunLister
+	taskLister           listers.TaskLister
+	clusterTaskLister    listers.ClusterTaskLister
+	resourceLister       resourcelisters.PipelineResourceLister
+	conditionLister      listersv1alpha1.ConditionLister
+	cloudEventClient     cloudevent.CEClient
+	cloudEventDispatcher *cloudevent.Dispatcher
+	tracker              tracker.Interface
+	timeoutHandler       *timeout.Handler
+	metrics              *Recorder
+	pvcHandler           volumeclaim.PvcHandler
 }
 
 var (
@@ -132,10 +149,44 @@ var (
 func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun) pkgreconciler.Event {
 	logger := logging.FromContext(ctx)
 	ctx = cloudevent.ToContext(ctx, c.cloudEventClient)
+	ctx = cloudevent.WithDispatcher(ctx, c.cloudEventDispatcher)
 
 	// Read the initial condition
 	before := pr.Status.GetCondition(apis.ConditionSucceeded)
 
+	if pr.IsPending() && !pr.HasStarted() {
+		// A pending PipelineRun has not started yet: no TaskRuns are created and the start
+		// time is not set, so that the time spent pending does not count towards its timeout.
+		if before == nil {
+			// Report the transition to pending with its own reason, the "Started" event is
+			// sent once the PipelineRun is actually started.
+			before = &apis.Condition{}
+		}
+		pr.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  v1beta1.PipelineRunReasonPending.String(),
+			Message: fmt.Sprintf("PipelineRun %q is pending", pr.Name),
+		})
+		return c.finishReconcileUpdateEmitEvents(ctx, pr, before, nil)
+	}
+
+	if !pr.HasStarted() && !pr.IsDone() && !pr.IsCancelled() {
+		// PipelineRuns in excess of the concurrency limits are queued, without being started,
+		// until older PipelineRuns complete.
+		queued, err := c.enforceConcurrencyPolicy(ctx, pr)
+		if err != nil {
+			logger.Errorf("Failed to enforce the concurrency policy of PipelineRun %s: %v", pr.Name, err)
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+		}
+		if queued {
+			if before == nil {
+				before = &apis.Condition{}
+			}
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, nil)
+		}
+	}
+
 	if !pr.HasStarted() {
 		pr.Status.InitializeConditions()
 		// In case node time was not synchronized, when controller has been scheduled to other nodes.
@@ -145,7 +196,7 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 		}
 
 		// start goroutine to track pipelinerun timeout only startTime is not set
-		go c.timeoutHandler.Wait(pr.GetNamespacedName(), *pr.Status.StartTime, getPipelineRunTimeout(ctx, pr))
+		go c.timeoutHandler.WaitPipelineRun(pr.GetNamespacedName(), *pr.Status.StartTime, getPipelineRunTimeout(ctx, pr), pr.TasksTimeout())
 		// Emit events. During the first reconcile the status of the PipelineRun may change twice
 		// from not Started to Started and then to Running, so we need to sent the event here
 		// and at the end of 'Reconcile' again.
@@ -177,6 +228,14 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 			logger.Errorf("Failed to update TaskRun status for PipelineRun %s: %v", pr.Name, err)
 			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
 		}
+		if err := c.updateRunsStatusDirectly(pr); err != nil {
+			logger.Errorf("Failed to update Run status for PipelineRun %s: %v", pr.Name, err)
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+		}
+		if err := c.updateChildPipelineRunsStatusDirectly(pr); err != nil {
+			logger.Errorf("Failed to update child PipelineRun status for PipelineRun %s: %v", pr.Name, err)
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+		}
 		go func(metrics *Recorder) {
 			err := metrics.DurationAndCount(pr)
 			if err != nil {
@@ -219,6 +278,8 @@ func (c *Reconciler) finishReconcileUpdateEmitEvents(ctx context.Context, pr *v1
 
 	afterCondition := pr.Status.GetCondition(apis.ConditionSucceeded)
 	events.Emit(ctx, beforeCondition, afterCondition, pr)
+	events.EmitPipelineRunProgress(ctx, pr)
+	pr.Status.CloudEventSinks = c.cloudEventDispatcher.Deliveries(pr, pr.Status.CloudEventSinks)
 	_, err := c.updateLabelsAndAnnotations(pr)
 	if err != nil {
 		logger.Warn("Failed to update PipelineRun labels/annotations", zap.Error(err))
@@ -235,12 +296,14 @@ func (c *Reconciler) finishReconcileUpdateEmitEvents(ctx context.Context, pr *v1
 func (c *Reconciler) updatePipelineResults(ctx context.Context, pr *v1beta1.PipelineRun) {
 	logger := logging.FromContext(ctx)
 
-	// TODO: Use factory func instead of hard-coding this once OCI images are supported.
-	resolver := &resources.LocalPipelineRefResolver{
-		Namespace:    pr.Namespace,
-		Tektonclient: c.PipelineClientSet,
+	getPipelineFunc, err := resources.GetPipelineFunc(ctx, c.KubeClientSet, c.PipelineClientSet, pr.Spec.PipelineRef, pr.Namespace, pr.Spec.ServiceAccountName)
+	if err != nil {
+		logger.Errorf("Failed to fetch pipeline func for pipelinerun %s: %v", pr.Name, err)
+		pr.Status.MarkFailed(ReasonCouldntGetPipeline, "Error retrieving pipeline for pipelinerun %s/%s: %s",
+			pr.Namespace, pr.Name, err)
+		return
 	}
-	_, pipelineSpec, err := resources.GetPipelineData(ctx, pr, resolver.GetPipeline)
+	_, pipelineSpec, err := resources.GetPipelineData(ctx, pr, getPipelineFunc)
 	if err != nil {
 		logger.Errorf("Failed to determine Pipeline spec to use for pipelinerun %s: %v", pr.Name, err)
 		pr.Status.MarkFailed(ReasonCouldntGetPipeline,
@@ -258,12 +321,21 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun) err
 	// and may not have had all of the assumed default specified.
 	pr.SetDefaults(contexts.WithUpgradeViaDefaulting(ctx))
 
-	// TODO: Use factory func instead of hard-coding this once OCI images are supported.
-	resolver := &resources.LocalPipelineRefResolver{
-		Namespace:    pr.Namespace,
-		Tektonclient: c.PipelineClientSet,
+	getPipelineFunc, err := resources.GetPipelineFunc(ctx, c.KubeClientSet, c.PipelineClientSet, pr.Spec.PipelineRef, pr.Namespace, pr.Spec.ServiceAccountName)
+	if err != nil {
+		logger.Errorf("Failed to fetch pipeline func for pipelinerun %s: %v", pr.Name, err)
+		pr.Status.MarkFailed(ReasonCouldntGetPipeline, "Error retrieving pipeline for pipelinerun %s/%s: %s",
+			pr.Namespace, pr.Name, err)
+		return controller.NewPermanentError(err)
+	}
+	pipelineMeta, pipelineSpec, err := resources.GetPipelineData(ctx, pr, getPipelineFunc)
+	if errors.Is(err, trustedresources.ErrResourceVerificationFailed) {
+		logger.Errorf("Failed to verify Pipeline for pipelinerun %s: %v", pr.Name, err)
+		pr.Status.MarkFailed(podconvert.ReasonResourceVerificationFailed,
+			"Pipeline for pipelinerun %s/%s failed verification: %s",
+			pr.Namespace, pr.Name, err)
+		return controller.NewPermanentError(err)
 	}
-	pipelineMeta, pipelineSpec, err := resources.GetPipelineData(ctx, pr, resolver.GetPipeline)
 	if err != nil {
 		logger.Errorf("Failed to determine Pipeline spec to use for pipelinerun %s: %v", pr.Name, err)
 		pr.Status.MarkFailed(ReasonCouldntGetPipeline,
@@ -307,7 +379,8 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun) err
 	// if a task in PipelineRunState is final task or not
 	// the finally section is optional and might not exist
 	// dfinally holds an empty Graph in the absence of finally clause
-	dfinally, err := dag.Build(v1beta1.PipelineTaskList(pipelineSpec.Finally))
+	// final tasks run in parallel, the results of DAG tasks they consume don't link them
+	dfinally, err := dag.BuildWithoutLinks(v1beta1.PipelineTaskList(pipelineSpec.Finally))
 	if err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
 		pr.Status.MarkFailed(ReasonInvalidGraph,
@@ -359,6 +432,14 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun) err
 		return controller.NewPermanentError(err)
 	}
 
+	// Ensure that the values of the parameters from the PipelineRun are allowed by the Pipeline.
+	if err := resources.ValidateParamValues(pipelineSpec, pr); err != nil {
+		pr.Status.MarkFailed(ReasonInvalidParamValue,
+			"PipelineRun %s/%s parameters have values not allowed by Pipeline %s/%s's parameters: %s",
+			pr.Namespace, pr.Name, pr.Namespace, pipelineMeta.Name, err)
+		return controller.NewPermanentError(err)
+	}
+
 	// Ensure that the workspaces expected by the Pipeline are provided by the PipelineRun.
 	if err := resources.ValidateWorkspaceBindings(pipelineSpec, pr); err != nil {
 		pr.Status.MarkFailed(ReasonInvalidWorkspaceBinding,
@@ -387,27 +468,20 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun) err
 	// Apply parameter substitution from the PipelineRun
 	pipelineSpec = resources.ApplyParameters(pipelineSpec, pr)
 	pipelineSpec = resources.ApplyContexts(pipelineSpec, pipelineMeta.Name, pr)
+	pipelineSpec = resources.ApplyWorkspaces(pipelineSpec, pr)
 
 	// pipelineRunState holds a list of pipeline tasks after resolving conditions and pipeline resources
 	// pipelineRunState also holds a taskRun for each pipeline task after the taskRun is created
 	// pipelineRunState is instantiated and updated on every reconcile cycle
-	pipelineRunState, err := resources.ResolvePipelineRun(ctx,
-		*pr,
-		func(name string) (v1beta1.TaskInterface, error) {
-			return c.taskLister.Tasks(pr.Namespace).Get(name)
-		},
-		func(name string) (*v1beta1.TaskRun, error) {
-			return c.taskRunLister.TaskRuns(pr.Namespace).Get(name)
-		},
-		func(name string) (v1beta1.TaskInterface, error) {
-			return c.clusterTaskLister.Get(name)
-		},
-		func(name string) (*v1alpha1.Condition, error) {
-			return c.conditionLister.Conditions(pr.Namespace).Get(name)
-		},
-		append(pipelineSpec.Tasks, pipelineSpec.Finally...), providedResources,
-	)
+	pipelineRunState, err := c.resolvePipelineState(ctx, append(pipelineSpec.Tasks, pipelineSpec.Finally...), pr, providedResources)
 
+	if errors.Is(err, trustedresources.ErrResourceVerificationFailed) {
+		logger.Errorf("Failed to verify the Tasks of pipelinerun %s: %v", pr.Name, err)
+		pr.Status.MarkFailed(podconvert.ReasonResourceVerificationFailed,
+			"Pipeline %s/%s can't be Run; it contains Tasks that failed verification: %s",
+			pipelineMeta.Namespace, pipelineMeta.Name, err)
+		return controller.NewPermanentError(err)
+	}
 	if err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
 		switch err := err.(type) {
@@ -428,7 +502,17 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun) err
 	}
 
 	for _, rprt := range pipelineRunState {
-		err := taskrun.ValidateResolvedTaskResources(rprt.PipelineTask.Params, rprt.ResolvedTaskResources)
+		if rprt.IsCustomTask() || rprt.IsChildPipeline() {
+			// Custom Tasks are resolved and validated by their own controllers,
+			// and child Pipelines when their PipelineRun is reconciled
+			continue
+		}
+		params := rprt.PipelineTask.Params
+		if rprt.IsMatrixed() {
+			// All the combinations of the Matrix provide the same parameters
+			params = append(append([]v1beta1.Param{}, params...), rprt.PipelineTask.MatrixCombinations()[0]...)
+		}
+		err := taskrun.ValidateResolvedTaskResources(params, rprt.ResolvedTaskResources)
 		if err != nil {
 			logger.Errorf("Failed to validate pipelinerun %q with error %v", pr.Name, err)
 			pr.Status.MarkFailed(ReasonFailedValidation, err.Error())
@@ -466,6 +550,22 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun) err
 		return controller.NewPermanentError(err)
 	}
 
+	if pr.IsGracefullyCancelled() {
+		// Cancel the DAG tasks still running, the finally tasks are scheduled once they are done
+		if err := gracefullyCancelPipelineRun(logger, pr, dfinally, pipelineRunState, c.PipelineClientSet); err != nil {
+			logger.Errorf("Failed to cancel the running tasks of PipelineRun %s: %v", pr.Name, err)
+			return err
+		}
+	}
+	if pr.HaveTasksTimedOut() || pr.HaveFinallyTimedOut() {
+		// No new task of a timed out section is scheduled, and its Runs and child PipelineRuns
+		// still running are cancelled
+		if err := cancelTimedOutPipelineTasks(logger, pr, dfinally, pipelineRunState, c.PipelineClientSet); err != nil {
+			logger.Errorf("Failed to cancel the timed out tasks of PipelineRun %s: %v", pr.Name, err)
+			return err
+		}
+	}
+
 	if err := c.runNextSchedulableTask(ctx, pr, d, dfinally, pipelineRunState, as); err != nil {
 		return err
 	}
@@ -477,16 +577,94 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun) err
 	case corev1.ConditionFalse:
 		pr.Status.MarkFailed(after.Reason, after.Message)
 	case corev1.ConditionUnknown:
-		pr.Status.MarkRunning(after.Reason, after.Message)
+		if pr.IsPaused() {
+			pr.Status.MarkRunning(v1beta1.PipelineRunReasonPaused.String(),
+				"PipelineRun %q is paused, no new Tasks will be scheduled until it is resumed: %s", pr.Name, after.Message)
+		} else {
+			pr.Status.MarkRunning(after.Reason, after.Message)
+		}
 	}
 	// Read the condition the way it was set by the Mark* helpers
 	after = pr.Status.GetCondition(apis.ConditionSucceeded)
 	pr.Status.TaskRuns = pipelineRunState.GetTaskRunsStatus(pr)
+	pr.Status.Runs = pipelineRunState.GetRunsStatus(pr)
+	pr.Status.ChildPipelineRuns = pipelineRunState.GetChildPipelineRunsStatus(pr)
 	pr.Status.SkippedTasks = pipelineRunState.GetSkippedTasks(pr, d)
 	logger.Infof("PipelineRun %s status is being set to %s", pr.Name, after)
 	return nil
 }
 
+// resolvePipelineState resolves every PipelineTask of the PipelineRun, fetching the Tasks
+// they reference from the cluster listers, or from a Tekton Bundle when the
+// TaskRef specifies one.
+func (c *Reconciler) resolvePipelineState(
+	ctx context.Context,
+	tasks []v1beta1.PipelineTask,
+	pr *v1beta1.PipelineRun,
+	providedResources map[string]*resourcev1alpha1.PipelineResource) (resources.PipelineRunState, error) {
+	pst := resources.PipelineRunState{}
+	for i := range tasks {
+		getTask := func(name string) (v1beta1.TaskInterface, error) {
+			return c.taskLister.Tasks(pr.Namespace).Get(name)
+		}
+		getClusterTask := func(name string) (v1beta1.TaskInterface, error) {
+			return c.clusterTaskLister.Get(name)
+		}
+		if tasks[i].TaskRef != nil && tasks[i].TaskRef.Bundle != "" {
+			serviceAccountName, _ := pr.GetTaskRunSpecs(tasks[i].Name)
+			fn, err := tresources.GetTaskFunc(ctx, c.KubeClientSet, c.PipelineClientSet, tasks[i].TaskRef, pr.Namespace, serviceAccountName)
+			if err != nil {
+				return nil, &resources.TaskNotFoundError{
+					Name: tasks[i].TaskRef.Name,
+					Msg:  err.Error(),
+				}
+			}
+			getTask, getClusterTask = fn, fn
+		}
+		// The signature of each referenced Task is verified as it is resolved. The error of a
+		// Task which fails verification is returned as is, rather than as a missing Task.
+		var verifyErr error
+		verified := func(get func(string) (v1beta1.TaskInterface, error)) func(string) (v1beta1.TaskInterface, error) {
+			return func(name string) (v1beta1.TaskInterface, error) {
+				t, err := get(name)
+				if err != nil {
+					return nil, err
+				}
+				if verifyErr = trustedresources.VerifyTask(ctx, t); verifyErr != nil {
+					return nil, verifyErr
+				}
+				return t, nil
+			}
+		}
+		rprt, err := resources.ResolvePipelineRunTask(ctx,
+			*pr,
+			verified(getTask),
+			func(name string) (*v1beta1.TaskRun, error) {
+				return c.taskRunLister.TaskRuns(pr.Namespace).Get(name)
+			},
+			verified(getClusterTask),
+			func(name string) (*v1alpha1.Run, error) {
+				return c.runLister.Runs(pr.Namespace).Get(name)
+			},
+			func(name string) (*v1beta1.PipelineRun, error) {
+				return c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(name)
+			},
+			func(name string) (*v1alpha1.Condition, error) {
+				return c.conditionLister.Conditions(pr.Namespace).Get(name)
+			},
+			tasks[i], providedResources,
+		)
+		if verifyErr != nil {
+			return nil, verifyErr
+		}
+		if err != nil {
+			return nil, err
+		}
+		pst = append(pst, rprt)
+	}
+	return pst, nil
+}
+
 // runNextSchedulableTask gets the next schedulable Tasks from the dag based on the current
 // pipeline run state, and starts them
 // after all DAG tasks are done, it's responsible for scheduling final tasks and start executing them
@@ -497,9 +675,9 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1beta1.Pip
 
 	var nextRprts []*resources.ResolvedPipelineRunTask
 
-	// when pipeline run is stopping, do not schedule any new task and only
+	// when pipeline run is stopping or paused, do not schedule any new task and only
 	// wait for all running tasks to complete and report their status
-	if !pipelineRunState.IsStopping(d) {
+	if !pipelineRunState.IsStopping(d) && !pr.IsPaused() {
 		// candidateTasks is initialized to DAG root nodes to start pipeline execution
 		// candidateTasks is derived based on successfully finished tasks and/or skipped tasks
 		candidateTasks, err := dag.GetSchedulable(d, pipelineRunState.SuccessfulOrSkippedDAGTasks(d)...)
@@ -521,14 +699,69 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1beta1.Pip
 	resources.ApplyTaskResults(nextRprts, resolvedResultRefs)
 
 	// GetFinalTasks only returns tasks when a DAG is complete
-	nextRprts = append(nextRprts, pipelineRunState.GetFinalTasks(d, dfinally)...)
+	if !pr.IsPaused() {
+		finalRprts := pipelineRunState.GetFinalTasks(d, dfinally)
+		if len(finalRprts) > 0 && pr.Status.FinallyStartTime == nil {
+			// The timeout of the finally tasks counts from their start
+			pr.Status.FinallyStartTime = &metav1.Time{Time: time.Now()}
+			c.timeoutHandler.WaitFinally(pr.GetNamespacedName(), *pr.Status.FinallyStartTime, pr.FinallyTimeout())
+		}
+		// The final tasks consume the execution status and the results of the DAG tasks
+		resources.ApplyPipelineTaskStateContext(finalRprts, pipelineRunState.GetPipelineTaskStatus(d))
+		for _, rprt := range finalRprts {
+			// The results of DAG tasks which did not succeed can't be resolved,
+			// the final tasks consuming them are skipped instead of failing the PipelineRun
+			if resolvedResultRefs, err := resources.ResolveResultRefs(pipelineRunState, resources.PipelineRunState{rprt}); err == nil {
+				resources.ApplyTaskResults(resources.PipelineRunState{rprt}, resolvedResultRefs)
+			}
+		}
+		nextRprts = append(nextRprts, finalRprts...)
+	}
+
+	// Check the values given to the params of the tasks, which may come from the results of
+	// previous tasks, before creating any of them
+	for _, rprt := range nextRprts {
+		if rprt == nil || rprt.Skip(pipelineRunState, d) {
+			continue
+		}
+		if err := resources.ValidateTaskParamValues(rprt); err != nil {
+			logger.Infof("Invalid task param values for %q: %v", pr.Name, err)
+			pr.Status.MarkFailed(ReasonInvalidParamValue, err.Error())
+			return controller.NewPermanentError(err)
+		}
+		if rprt.IsChildPipeline() {
+			if max := maxPipelineNestingDepth(ctx); nestingDepth(pr) >= max {
+				err := fmt.Errorf("PipelineTask %s can't run a Pipeline: PipelineRun %s is already nested in %d PipelineRuns, the maximum nesting depth is %d",
+					rprt.PipelineTask.Name, pr.Name, nestingDepth(pr), max)
+				logger.Infof("Failed to create child PipelineRun for %q: %v", pr.Name, err)
+				pr.Status.MarkFailed(ReasonMaxNestingDepthExceeded, err.Error())
+				return controller.NewPermanentError(err)
+			}
+		}
+	}
 
 	for _, rprt := range nextRprts {
 		if rprt == nil || rprt.Skip(pipelineRunState, d) {
 			continue
 		}
-		if rprt.ResolvedConditionChecks == nil || rprt.ResolvedConditionChecks.IsSuccess() {
-			rprt.TaskRun, err = c.createTaskRun(ctx, rprt, pr, as.StorageBasePath(pr))
+		if rprt.IsCustomTask() {
+			rprt.Run, err = c.createRun(ctx, rprt, pr)
+			if err != nil {
+				recorder.Eventf(pr, corev1.EventTypeWarning, "RunCreationFailed", "Failed to create Run %q: %v", rprt.RunName, err)
+				return fmt.Errorf("error creating Run called %s for PipelineTask %s from PipelineRun %s: %w", rprt.RunName, rprt.PipelineTask.Name, pr.Name, err)
+			}
+		} else if rprt.IsChildPipeline() {
+			rprt.ChildPipelineRun, err = c.createChildPipelineRun(ctx, rprt, pr)
+			if err != nil {
+				recorder.Eventf(pr, corev1.EventTypeWarning, "PipelineRunCreationFailed", "Failed to create PipelineRun %q: %v", rprt.ChildPipelineRunName, err)
+				return fmt.Errorf("error creating PipelineRun called %s for PipelineTask %s from PipelineRun %s: %w", rprt.ChildPipelineRunName, rprt.PipelineTask.Name, pr.Name, err)
+			}
+		} else if rprt.IsMatrixed() {
+			if err := c.createMatrixTaskRuns(ctx, rprt, pr, as.StorageBasePath(pr)); err != nil {
+				return err
+			}
+		} else if rprt.ResolvedConditionChecks == nil || rprt.ResolvedConditionChecks.IsSuccess() {
+			rprt.TaskRun, err = c.createTaskRun(ctx, rprt.TaskRunName, rprt.PipelineTask.Params, rprt, pr, as.StorageBasePath(pr))
 			if err != nil {
 				recorder.Eventf(pr, corev1.EventTypeWarning, "TaskRunCreationFailed", "Failed to create TaskRun %q: %v", rprt.TaskRunName, err)
 				return fmt.Errorf("error creating TaskRun called %s for PipelineTask %s from PipelineRun %s: %w", rprt.TaskRunName, rprt.PipelineTask.Name, pr.Name, err)
@@ -552,6 +785,9 @@ func getPipelineRunResults(pipelineSpec *v1beta1.PipelineSpec, resolvedResultRef
 
 	for _, resolvedResultRef := range resolvedResultRefs {
 		replaceTarget := fmt.Sprintf("%s.%s.%s.%s", v1beta1.ResultTaskPart, resolvedResultRef.ResultReference.PipelineTask, v1beta1.ResultResultPart, resolvedResultRef.ResultReference.Result)
+		if resolvedResultRef.ResultReference.Property != "" {
+			replaceTarget = fmt.Sprintf("%s.%s", replaceTarget, resolvedResultRef.ResultReference.Property)
+		}
 		stringReplacements[replaceTarget] = resolvedResultRef.Value.StringVal
 	}
 	for _, result := range pipelineSpec.Results {
@@ -574,7 +810,7 @@ func (c *Reconciler) updateTaskRunsStatusDirectly(pr *v1beta1.PipelineRun) error
 		tr, err := c.taskRunLister.TaskRuns(pr.Namespace).Get(taskRunName)
 		if err != nil {
 			// If the TaskRun isn't found, it just means it won't be run
-			if !errors.IsNotFound(err) {
+			if !apierrors.IsNotFound(err) {
 				return fmt.Errorf("error retrieving TaskRun %s: %w", taskRunName, err)
 			}
 		} else {
@@ -584,10 +820,61 @@ func (c *Reconciler) updateTaskRunsStatusDirectly(pr *v1beta1.PipelineRun) error
 	return nil
 }
 
-func (c *Reconciler) createTaskRun(ctx context.Context, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, storageBasePath string) (*v1beta1.TaskRun, error) {
+func (c *Reconciler) updateRunsStatusDirectly(pr *v1beta1.PipelineRun) error {
+	for runName := range pr.Status.Runs {
+		prRunStatus := pr.Status.Runs[runName]
+		run, err := c.runLister.Runs(pr.Namespace).Get(runName)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("error retrieving Run %s: %w", runName, err)
+			}
+		} else {
+			prRunStatus.Status = &run.Status
+		}
+	}
+	return nil
+}
+
+func (c *Reconciler) updateChildPipelineRunsStatusDirectly(pr *v1beta1.PipelineRun) error {
+	for childName := range pr.Status.ChildPipelineRuns {
+		prcs := pr.Status.ChildPipelineRuns[childName]
+		child, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(childName)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("error retrieving PipelineRun %s: %w", childName, err)
+			}
+		} else {
+			prcs.Status = &child.Status
+		}
+	}
+	return nil
+}
+
+// createMatrixTaskRuns creates, or retries, the TaskRuns of the combinations of the Matrix of the
+// PipelineTask which need to run. Each TaskRun gets the params of the PipelineTask along with the
+// values of its combination.
+func (c *Reconciler) createMatrixTaskRuns(ctx context.Context, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, storageBasePath string) error {
+	recorder := controller.GetEventRecorder(ctx)
+	combinations := rprt.PipelineTask.MatrixCombinations()
+	if len(combinations) != len(rprt.TaskRunNames) {
+		return fmt.Errorf("matrix of PipelineTask %s from PipelineRun %s fans out to %d combinations, expected %d", rprt.PipelineTask.Name, pr.Name, len(combinations), len(rprt.TaskRunNames))
+	}
+	for _, i := range rprt.SchedulableMatrixCombinations() {
+		params := append(append([]v1beta1.Param{}, rprt.PipelineTask.Params...), combinations[i]...)
+		tr, err := c.createTaskRun(ctx, rprt.TaskRunNames[i], params, rprt, pr, storageBasePath)
+		if err != nil {
+			recorder.Eventf(pr, corev1.EventTypeWarning, "TaskRunCreationFailed", "Failed to create TaskRun %q: %v", rprt.TaskRunNames[i], err)
+			return fmt.Errorf("error creating TaskRun called %s for PipelineTask %s from PipelineRun %s: %w", rprt.TaskRunNames[i], rprt.PipelineTask.Name, pr.Name, err)
+		}
+		rprt.TaskRuns[i] = tr
+	}
+	return nil
+}
+
+func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, params []v1beta1.Param, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, storageBasePath string) (*v1beta1.TaskRun, error) {
 	logger := logging.FromContext(ctx)
 
-	tr, _ := c.taskRunLister.TaskRuns(pr.Namespace).Get(rprt.TaskRunName)
+	tr, _ := c.taskRunLister.TaskRuns(pr.Namespace).Get(taskRunName)
 	if tr != nil {
 		//is a retry
 		addRetryHistory(tr)
@@ -602,14 +889,14 @@ func (c *Reconciler) createTaskRun(ctx context.Context, rprt *resources.Resolved
 	serviceAccountName, podTemplate := pr.GetTaskRunSpecs(rprt.PipelineTask.Name)
 	tr = &v1beta1.TaskRun{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:            rprt.TaskRunName,
+			Name:            taskRunName,
 			Namespace:       pr.Namespace,
 			OwnerReferences: []metav1.OwnerReference{pr.GetOwnerReference()},
 			Labels:          combineTaskRunAndTaskSpecLabels(pr, rprt.PipelineTask),
 			Annotations:     combineTaskRunAndTaskSpecAnnotations(pr, rprt.PipelineTask),
 		},
 		Spec: v1beta1.TaskRunSpec{
-			Params:             rprt.PipelineTask.Params,
+			Params:             params,
 			ServiceAccountName: serviceAccountName,
 			Timeout:            getTaskRunTimeout(ctx, pr, rprt),
 			PodTemplate:        podTemplate,
@@ -620,6 +907,9 @@ func (c *Reconciler) createTaskRun(ctx context.Context, rprt *resources.Resolved
 			Name: rprt.ResolvedTaskResources.TaskName,
 			Kind: rprt.ResolvedTaskResources.Kind,
 		}
+		if rprt.PipelineTask.TaskRef != nil {
+			tr.Spec.TaskRef.Bundle = rprt.PipelineTask.TaskRef.Bundle
+		}
 	} else if rprt.ResolvedTaskResources.TaskSpec != nil {
 		tr.Spec.TaskSpec = rprt.ResolvedTaskResources.TaskSpec
 	}
@@ -636,7 +926,7 @@ func (c *Reconciler) createTaskRun(ctx context.Context, rprt *resources.Resolved
 				pipelinePVCWorkspaceName = pipelineWorkspaceName
 			}
 			tr.Spec.Workspaces = append(tr.Spec.Workspaces, taskWorkspaceByWorkspaceVolumeSource(b, taskWorkspaceName, pipelineTaskSubPath, pr.GetOwnerReference()))
-		} else {
+		} else if !isOptionalWorkspace(pr, pipelineWorkspaceName) {
 			return nil, fmt.Errorf("expected workspace %q to be provided by pipelinerun for pipeline task %q", pipelineWorkspaceName, rprt.PipelineTask.Name)
 		}
 	}
@@ -646,10 +936,110 @@ func (c *Reconciler) createTaskRun(ctx context.Context, rprt *resources.Resolved
 	}
 
 	resources.WrapSteps(&tr.Spec, rprt.PipelineTask, rprt.ResolvedTaskResources.Inputs, rprt.ResolvedTaskResources.Outputs, storageBasePath)
-	logger.Infof("Creating a new TaskRun object %s", rprt.TaskRunName)
+	logger.Infof("Creating a new TaskRun object %s", taskRunName)
 	return c.PipelineClientSet.TektonV1beta1().TaskRuns(pr.Namespace).Create(tr)
 }
 
+// createRun creates the Run executing the Custom Task referenced by the PipelineTask.
+// Runs are not retried, so an existing Run is never updated here.
+func (c *Reconciler) createRun(ctx context.Context, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun) (*v1alpha1.Run, error) {
+	logger := logging.FromContext(ctx)
+	r := &v1alpha1.Run{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            rprt.RunName,
+			Namespace:       pr.Namespace,
+			OwnerReferences: []metav1.OwnerReference{pr.GetOwnerReference()},
+			Labels:          combineTaskRunAndTaskSpecLabels(pr, rprt.PipelineTask),
+			Annotations:     combineTaskRunAndTaskSpecAnnotations(pr, rprt.PipelineTask),
+		},
+		Spec: v1alpha1.RunSpec{
+			Ref:     rprt.PipelineTask.TaskRef,
+			Params:  rprt.PipelineTask.Params,
+			Timeout: getTaskRunTimeout(ctx, pr, rprt),
+		},
+	}
+	logger.Infof("Creating a new Run object %s", rprt.RunName)
+	return c.PipelineClientSet.TektonV1alpha1().Runs(pr.Namespace).Create(r)
+}
+
+// createChildPipelineRun creates the PipelineRun executing the Pipeline referenced or embedded
+// by the PipelineTask. The params of the PipelineTask are passed to the child PipelineRun, and
+// the workspaces it binds are passed through from the PipelineRun.
+func (c *Reconciler) createChildPipelineRun(ctx context.Context, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun) (*v1beta1.PipelineRun, error) {
+	logger := logging.FromContext(ctx)
+	serviceAccountName, podTemplate := pr.GetTaskRunSpecs(rprt.PipelineTask.Name)
+	child := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            rprt.ChildPipelineRunName,
+			Namespace:       pr.Namespace,
+			OwnerReferences: []metav1.OwnerReference{pr.GetOwnerReference()},
+			Labels:          combineTaskRunAndTaskSpecLabels(pr, rprt.PipelineTask),
+			Annotations:     combineTaskRunAndTaskSpecAnnotations(pr, rprt.PipelineTask),
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef:        rprt.PipelineTask.PipelineRef,
+			PipelineSpec:       rprt.PipelineTask.PipelineSpec,
+			Params:             rprt.PipelineTask.Params,
+			ServiceAccountName: serviceAccountName,
+			Timeout:            getTaskRunTimeout(ctx, pr, rprt),
+			PodTemplate:        podTemplate,
+		},
+	}
+
+	pipelineRunWorkspaces := make(map[string]v1beta1.WorkspaceBinding)
+	for _, binding := range pr.Spec.Workspaces {
+		pipelineRunWorkspaces[binding.Name] = binding
+	}
+	for _, ws := range rprt.PipelineTask.Workspaces {
+		b, hasBinding := pipelineRunWorkspaces[ws.Workspace]
+		if !hasBinding {
+			if isOptionalWorkspace(pr, ws.Workspace) {
+				continue
+			}
+			return nil, fmt.Errorf("expected workspace %q to be provided by pipelinerun for pipeline task %q", ws.Workspace, rprt.PipelineTask.Name)
+		}
+		child.Spec.Workspaces = append(child.Spec.Workspaces, taskWorkspaceByWorkspaceVolumeSource(b, ws.Name, ws.SubPath, pr.GetOwnerReference()))
+	}
+
+	child.Annotations[NestingDepthAnnotationKey] = strconv.Itoa(nestingDepth(pr) + 1)
+
+	logger.Infof("Creating a new child PipelineRun object %s", rprt.ChildPipelineRunName)
+	return c.PipelineClientSet.TektonV1beta1().PipelineRuns(pr.Namespace).Create(child)
+}
+
+// nestingDepth returns the number of PipelineRuns the PipelineRun is nested in, 0 unless it is
+// a child PipelineRun.
+func nestingDepth(pr *v1beta1.PipelineRun) int {
+	depth, err := strconv.Atoi(pr.ObjectMeta.Annotations[NestingDepthAnnotationKey])
+	if err != nil || depth < 0 {
+		return 0
+	}
+	return depth
+}
+
+// maxPipelineNestingDepth returns the maximum number of PipelineRuns a child PipelineRun is
+// allowed to be nested in.
+func maxPipelineNestingDepth(ctx context.Context) int {
+	if defaults := apisconfig.FromContextOrDefaults(ctx).Defaults; defaults != nil {
+		return defaults.DefaultMaxPipelineNestingDepth
+	}
+	return apisconfig.DefaultMaxPipelineNestingDepth
+}
+
+// isOptionalWorkspace returns true if the Pipeline of the PipelineRun declares the workspace as optional,
+// in which case PipelineTasks are run without it when the PipelineRun does not bind it.
+func isOptionalWorkspace(pr *v1beta1.PipelineRun, name string) bool {
+	if pr.Status.PipelineSpec == nil {
+		return false
+	}
+	for _, ws := range pr.Status.PipelineSpec.Workspaces {
+		if ws.Name == name {
+			return ws.Optional
+		}
+	}
+	return false
+}
+
 // taskWorkspaceByWorkspaceVolumeSource is returning the WorkspaceBinding with the TaskRun specified name.
 // If the volume source is a volumeClaimTemplate, the template is applied and passed to TaskRun as a persistentVolumeClaim
 func taskWorkspaceByWorkspaceVolumeSource(wb v1beta1.WorkspaceBinding, taskWorkspaceName string, pipelineTaskSubPath string, owner metav1.OwnerReference) v1beta1.WorkspaceBinding {
@@ -700,6 +1090,8 @@ func getTaskrunAnnotations(pr *v1beta1.PipelineRun) map[string]string {
 	for key, val := range pr.ObjectMeta.Annotations {
 		annotations[key] = val
 	}
+	// The record of the events about the progress of the PipelineRun is its own.
+	delete(annotations, pipeline.GroupName+pipeline.ProgressEventsAnnotationKey)
 	return annotations
 }
 
@@ -761,23 +1153,17 @@ func combineTaskRunAndTaskSpecAnnotations(pr *v1beta1.PipelineRun, pipelineTask
 }
 
 func getPipelineRunTimeout(ctx context.Context, pr *v1beta1.PipelineRun) metav1.Duration {
-	if pr.Spec.Timeout == nil {
-		defaultTimeout := time.Duration(config.FromContextOrDefaults(ctx).Defaults.DefaultTimeoutMinutes)
-		return metav1.Duration{Duration: defaultTimeout * time.Minute}
-	}
-	return *pr.Spec.Timeout
+	return metav1.Duration{Duration: pr.PipelineTimeout(ctx)}
 }
 
 func getTaskRunTimeout(ctx context.Context, pr *v1beta1.PipelineRun, rprt *resources.ResolvedPipelineRunTask) *metav1.Duration {
+	if pr.Spec.Timeouts != nil {
+		return getSectionTaskRunTimeout(ctx, pr, rprt)
+	}
+
 	var taskRunTimeout = &metav1.Duration{Duration: apisconfig.NoTimeoutDuration}
 
-	var timeout time.Duration
-	if pr.Spec.Timeout == nil {
-		defaultTimeout := time.Duration(config.FromContextOrDefaults(ctx).Defaults.DefaultTimeoutMinutes)
-		timeout = defaultTimeout * time.Minute
-	} else {
-		timeout = pr.Spec.Timeout.Duration
-	}
+	timeout := pr.PipelineTimeout(ctx)
 
 	// If the value of the timeout is 0 for any resource, there is no timeout.
 	// It is impossible for pr.Spec.Timeout to be nil, since SetDefault always assigns it with a value.
@@ -808,6 +1194,57 @@ func getTaskRunTimeout(ctx context.Context, pr *v1beta1.PipelineRun, rprt *resou
 	return taskRunTimeout
 }
 
+// getSectionTaskRunTimeout returns the timeout of a TaskRun of a PipelineRun with spec.timeouts:
+// what remains of the timeout of its section, the tasks or the finally tasks, and of the
+// timeout of the whole PipelineRun. The timeout of its PipelineTask applies if it is shorter.
+func getSectionTaskRunTimeout(ctx context.Context, pr *v1beta1.PipelineRun, rprt *resources.ResolvedPipelineRunTask) *metav1.Duration {
+	var deadlines []time.Time
+	if timeout := pr.PipelineTimeout(ctx); timeout != apisconfig.NoTimeoutDuration {
+		deadlines = append(deadlines, pr.Status.StartTime.Add(timeout))
+	}
+	if isFinallyTask(pr, rprt.PipelineTask.Name) {
+		if timeout := pr.FinallyTimeout(); timeout != nil && timeout.Duration != apisconfig.NoTimeoutDuration {
+			finallyStartTime := time.Now()
+			if pr.Status.FinallyStartTime != nil {
+				finallyStartTime = pr.Status.FinallyStartTime.Time
+			}
+			deadlines = append(deadlines, finallyStartTime.Add(timeout.Duration))
+		}
+	} else if timeout := pr.TasksTimeout(); timeout != nil && timeout.Duration != apisconfig.NoTimeoutDuration {
+		deadlines = append(deadlines, pr.Status.StartTime.Add(timeout.Duration))
+	}
+
+	taskRunTimeout := &metav1.Duration{Duration: apisconfig.NoTimeoutDuration}
+	if rprt.PipelineTask.Timeout != nil {
+		taskRunTimeout.Duration = rprt.PipelineTask.Timeout.Duration
+	}
+	for _, deadline := range deadlines {
+		remaining := time.Until(deadline)
+		// Just in case something goes awry and we're creating the TaskRun after its section
+		// should have already timed out, set the timeout to 1 second.
+		if remaining < time.Second {
+			remaining = time.Second
+		}
+		if taskRunTimeout.Duration == apisconfig.NoTimeoutDuration || remaining < taskRunTimeout.Duration {
+			taskRunTimeout.Duration = remaining
+		}
+	}
+	return taskRunTimeout
+}
+
+// isFinallyTask returns true if the PipelineTask is one of the finally tasks of the Pipeline of pr
+func isFinallyTask(pr *v1beta1.PipelineRun, pipelineTaskName string) bool {
+	if pr.Status.PipelineSpec == nil {
+		return false
+	}
+	for _, t := range pr.Status.PipelineSpec.Finally {
+		if t.Name == pipelineTaskName {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Reconciler) updateLabelsAndAnnotations(pr *v1beta1.PipelineRun) (*v1beta1.PipelineRun, error) {
 	newPr, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(pr.Name)
 	if err != nil {
@@ -888,9 +1325,63 @@ func (c *Reconciler) updatePipelineRunStatusFromInformer(ctx context.Context, pr
 		return err
 	}
 	pr.Status = updatePipelineRunStatusFromTaskRuns(logger, pr.Name, pr.Status, taskRuns)
+
+	runs, err := c.runLister.Runs(pr.Namespace).List(labels.SelectorFromSet(pipelineRunLabels))
+	if err != nil {
+		logger.Errorf("could not list Runs %#v", err)
+		return err
+	}
+	pr.Status = updatePipelineRunStatusFromRuns(pr.Status, runs)
+
+	children, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).List(labels.SelectorFromSet(pipelineRunLabels))
+	if err != nil {
+		logger.Errorf("could not list PipelineRuns %#v", err)
+		return err
+	}
+	pr.Status = updatePipelineRunStatusFromChildPipelineRuns(pr.Status, children)
 	return nil
 }
 
+func updatePipelineRunStatusFromChildPipelineRuns(prStatus v1beta1.PipelineRunStatus, children []*v1beta1.PipelineRun) v1beta1.PipelineRunStatus {
+	// If no child PipelineRun was found, nothing to be done. We never remove them from the status
+	if len(children) == 0 {
+		return prStatus
+	}
+	if prStatus.ChildPipelineRuns == nil {
+		prStatus.ChildPipelineRuns = make(map[string]*v1beta1.PipelineRunChildStatus)
+	}
+	for _, child := range children {
+		if _, ok := prStatus.ChildPipelineRuns[child.Name]; !ok {
+			// This child PipelineRun was missing from the status.
+			prStatus.ChildPipelineRuns[child.Name] = &v1beta1.PipelineRunChildStatus{
+				PipelineTaskName: child.GetLabels()[pipeline.GroupName+pipeline.PipelineTaskLabelKey],
+				Status:           &child.Status,
+			}
+		}
+	}
+	return prStatus
+}
+
+func updatePipelineRunStatusFromRuns(prStatus v1beta1.PipelineRunStatus, runs []*v1alpha1.Run) v1beta1.PipelineRunStatus {
+	// If no Run was found, nothing to be done. We never remove runs from the status
+	if len(runs) == 0 {
+		return prStatus
+	}
+	if prStatus.Runs == nil {
+		prStatus.Runs = make(map[string]*v1beta1.PipelineRunRunStatus)
+	}
+	for _, run := range runs {
+		if _, ok := prStatus.Runs[run.Name]; !ok {
+			// This run was missing from the status.
+			prStatus.Runs[run.Name] = &v1beta1.PipelineRunRunStatus{
+				PipelineTaskName: run.GetLabels()[pipeline.GroupName+pipeline.PipelineTaskLabelKey],
+				Status:           &run.Status,
+			}
+		}
+	}
+	return prStatus
+}
+
 func updatePipelineRunStatusFromTaskRuns(logger *zap.SugaredLogger, prName string, prStatus v1beta1.PipelineRunStatus, trs []*v1beta1.TaskRun) v1beta1.PipelineRunStatus {
 	// If no TaskRun was found, nothing to be done. We never remove taskruns from the status
 	if trs == nil || len(trs) == 0 {
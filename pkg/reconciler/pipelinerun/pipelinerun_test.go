@@ -18,7 +18,14 @@ package pipelinerun
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"net/http/httptest"
+	"net/url"
 	"regexp"
 	"strings"
 	"testing"
@@ -26,6 +33,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-containerregistry/pkg/registry"
 	tbv1alpha1 "github.com/tektoncd/pipeline/internal/builder/v1alpha1"
 	tb "github.com/tektoncd/pipeline/internal/builder/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
@@ -33,11 +41,13 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	podconvert "github.com/tektoncd/pipeline/pkg/pod"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
 	taskrunresources "github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"github.com/tektoncd/pipeline/pkg/system"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	"github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
 	"github.com/tektoncd/pipeline/test/names"
@@ -49,9 +59,11 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	ktesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/record"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
@@ -86,7 +98,7 @@ type PipelineRunTest struct {
 }
 
 func ensureConfigurationConfigMapsExist(d *test.Data) {
-	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists bool
+	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists, provenanceExists, trustedResourcesExists, prunerExists, logArchiveExists bool
 	for _, cm := range d.ConfigMaps {
 		if cm.Name == config.GetDefaultsConfigName() {
 			defaultsExists = true
@@ -100,6 +112,18 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 		if cm.Name == config.GetArtifactPVCConfigName() {
 			artifactPVCExists = true
 		}
+		if cm.Name == config.GetProvenanceConfigName() {
+			provenanceExists = true
+		}
+		if cm.Name == config.GetTrustedResourcesConfigName() {
+			trustedResourcesExists = true
+		}
+		if cm.Name == config.GetPrunerConfigName() {
+			prunerExists = true
+		}
+		if cm.Name == config.GetLogArchiveConfigName() {
+			logArchiveExists = true
+		}
 	}
 	if !defaultsExists {
 		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
@@ -125,6 +149,30 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 			Data:       map[string]string{},
 		})
 	}
+	if !provenanceExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetProvenanceConfigName(), Namespace: system.GetNamespace()},
+			Data:       map[string]string{},
+		})
+	}
+	if !trustedResourcesExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetTrustedResourcesConfigName(), Namespace: system.GetNamespace()},
+			Data:       map[string]string{},
+		})
+	}
+	if !prunerExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetPrunerConfigName(), Namespace: system.GetNamespace()},
+			Data:       map[string]string{},
+		})
+	}
+	if !logArchiveExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetLogArchiveConfigName(), Namespace: system.GetNamespace()},
+			Data:       map[string]string{},
+		})
+	}
 }
 
 // getPipelineRunController returns an instance of the PipelineRun controller/reconciler that has been seeded with
@@ -132,6 +180,10 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 func getPipelineRunController(t *testing.T, d test.Data) (test.Assets, func()) {
 	//unregisterMetrics()
 	ctx, _ := ttesting.SetupFakeContext(t)
+	return getPipelineRunControllerWithContext(t, ctx, d)
+}
+
+func getPipelineRunControllerWithContext(t *testing.T, ctx context.Context, d test.Data) (test.Assets, func()) {
 	ctx, cancel := context.WithCancel(ctx)
 	ensureConfigurationConfigMapsExist(&d)
 	c, informers := test.SeedTestData(t, ctx, d)
@@ -1107,21 +1159,19 @@ func TestReconcileOnCancelledPipelineRun(t *testing.T) {
 	}
 }
 
-func TestReconcileWithTimeout(t *testing.T) {
-	// TestReconcileWithTimeout runs "Reconcile" on a PipelineRun that has timed out.
-	// It verifies that reconcile is successful, the pipeline status updated and events generated.
-	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
-		tb.PipelineTask("hello-world-1", "hello-world"),
-	))}
-	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-timeout",
+func TestReconcileOnPendingPipelineRun(t *testing.T) {
+	// TestReconcileOnPendingPipelineRun runs "Reconcile" on a PipelineRun that is pending.
+	// It verifies that reconcile is successful, that no TaskRun is created and that
+	// the PipelineRun is not started.
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-pending",
 		tb.PipelineRunNamespace("foo"),
-		tb.PipelineRunSpec("test-pipeline",
-			tb.PipelineRunServiceAccountName("test-sa"),
-			tb.PipelineRunTimeout(12*time.Hour),
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccountName("test-sa"),
+			tb.PipelineRunPending,
 		),
-		tb.PipelineRunStatus(
-			tb.PipelineRunStartTime(time.Now().AddDate(0, 0, -1))),
 	)}
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+	))}
 	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
 
 	d := test.Data{
@@ -1133,47 +1183,42 @@ func TestReconcileWithTimeout(t *testing.T) {
 	defer prt.Cancel()
 
 	wantEvents := []string{
-		"Warning Failed PipelineRun \"test-pipeline-run-with-timeout\" failed to finish within \"12h0m0s\"",
-	}
-	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-with-timeout", wantEvents, false)
-
-	if reconciledRun.Status.CompletionTime == nil {
-		t.Errorf("Expected a CompletionTime on invalid PipelineRun but was nil")
-	}
-
-	// The PipelineRun should be timed out.
-	if reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Reason != "PipelineRunTimeout" {
-		t.Errorf("Expected PipelineRun to be timed out, but condition reason is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+		"Normal PipelineRunPending PipelineRun \"test-pipeline-run-pending\" is pending",
 	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-pending", wantEvents, false)
 
-	actions := clients.Pipeline.Actions()
-	if len(actions) < 2 {
-		t.Fatalf("Expected client to have at least two action implementation but it has %d", len(actions))
+	if reconciledRun.HasStarted() {
+		t.Errorf("Expected a pending PipelineRun not to be started, but it has StartTime %v", reconciledRun.Status.StartTime)
 	}
 
-	// Check that the expected TaskRun was created
-	actual := actions[1].(ktesting.CreateAction).GetObject().(*v1beta1.TaskRun)
-	if actual == nil {
-		t.Fatalf("Expected a TaskRun to be created, but it wasn't.")
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsUnknown() || condition.Reason != v1beta1.PipelineRunReasonPending.String() {
+		t.Errorf("Expected PipelineRun condition to be unknown with reason %s, but was %v", v1beta1.PipelineRunReasonPending, condition)
 	}
 
-	// The TaskRun timeout should be less than or equal to the PipelineRun timeout.
-	if actual.Spec.Timeout.Duration > prs[0].Spec.Timeout.Duration {
-		t.Errorf("TaskRun timeout %s should be less than or equal to PipelineRun timeout %s", actual.Spec.Timeout.Duration.String(), prs[0].Spec.Timeout.Duration.String())
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+			t.Errorf("Expected no TaskRun to be created for a pending PipelineRun, got %v", a)
+		}
 	}
 }
 
-func TestReconcileWithoutPVC(t *testing.T) {
-	// TestReconcileWithoutPVC runs "Reconcile" on a PipelineRun that has two unrelated tasks.
-	// It verifies that reconcile is successful and that no PVC is created
+func TestReconcileOnPendingPipelineRunStarted(t *testing.T) {
+	// TestReconcileOnPendingPipelineRunStarted runs "Reconcile" on a PipelineRun that was pending
+	// and which had its pending status cleared. It verifies that the PipelineRun is started then.
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-was-pending",
+		tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccountName("test-sa")),
+		tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  v1beta1.PipelineRunReasonPending.String(),
+			Message: "PipelineRun \"test-pipeline-run-was-pending\" is pending",
+		})),
+	)}
 	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
 		tb.PipelineTask("hello-world-1", "hello-world"),
-		tb.PipelineTask("hello-world-2", "hello-world"),
 	))}
-
-	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run", tb.PipelineRunNamespace("foo"),
-		tb.PipelineRunSpec("test-pipeline")),
-	}
 	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
 
 	d := test.Data{
@@ -1184,110 +1229,237 @@ func TestReconcileWithoutPVC(t *testing.T) {
 	prt := NewPipelineRunTest(d, t)
 	defer prt.Cancel()
 
-	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", []string{}, false)
-	actions := clients.Pipeline.Actions()
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 0 \\(Failed: 0, Cancelled 0\\), Incomplete: 1, Skipped: 0",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-was-pending", wantEvents, false)
 
-	// Check that the expected TaskRun was created
-	for _, a := range actions {
-		if ca, ok := a.(ktesting.CreateAction); ok {
-			obj := ca.GetObject()
-			if pvc, ok := obj.(*corev1.PersistentVolumeClaim); ok {
-				t.Errorf("Did not expect to see a PVC created when no resources are linked. %s was created", pvc)
-			}
-		}
+	if !reconciledRun.HasStarted() {
+		t.Errorf("Expected the PipelineRun to be started once it is no longer pending")
 	}
 
-	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
-		t.Errorf("Expected PipelineRun to be running, but condition status is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	created := false
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+			created = true
+		}
+	}
+	if !created {
+		t.Errorf("Expected a TaskRun to be created once the PipelineRun is no longer pending")
 	}
 }
 
-func TestReconcileCancelledFailsTaskRunCancellation(t *testing.T) {
-	// TestReconcileCancelledFailsTaskRunCancellation runs "Reconcile" on a PipelineRun with a single TaskRun.
-	// The TaskRun cannot be cancelled. Check that the pipelinerun cancel fails, that reconcile fails and
-	// an event is generated
-	names.TestingSeed()
-	ptName := "hello-world-1"
-	prName := "test-pipeline-fails-to-cancel"
-	prs := []*v1beta1.PipelineRun{tb.PipelineRun(prName, tb.PipelineRunNamespace("foo"),
-		tb.PipelineRunSpec("test-pipeline",
-			tb.PipelineRunCancelled,
+func TestReconcileOnPausedPipelineRun(t *testing.T) {
+	// TestReconcileOnPausedPipelineRun runs "Reconcile" on a PipelineRun that has been paused
+	// while one of its TaskRuns is running. It verifies that no new TaskRun is created and
+	// that the status of the PipelineRun reflects the paused state.
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-paused",
+		tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccountName("test-sa"),
+			tb.PipelineRunPaused,
 		),
-		// The reconciler uses the presence of this TaskRun in the status to determine that a TaskRun
-		// is already running. The TaskRun will not be retrieved at all so we do not need to seed one.
-		tb.PipelineRunStatus(
+		tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now()),
 			tb.PipelineRunStatusCondition(apis.Condition{
 				Type:    apis.ConditionSucceeded,
 				Status:  corev1.ConditionUnknown,
 				Reason:  v1beta1.PipelineRunReasonRunning.String(),
-				Message: "running...",
+				Message: "Tasks Completed: 0 (Failed: 0, Cancelled 0), Incomplete: 2, Skipped: 0",
 			}),
-			tb.PipelineRunTaskRunsStatus(prName+ptName, &v1beta1.PipelineRunTaskRunStatus{
-				PipelineTaskName: ptName,
+			tb.PipelineRunTaskRunsStatus("test-pipeline-run-paused-hello-world-1", &v1beta1.PipelineRunTaskRunStatus{
+				PipelineTaskName: "hello-world-1",
 				Status:           &v1beta1.TaskRunStatus{},
 			}),
-			tb.PipelineRunStartTime(time.Now()),
 		),
 	)}
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+		tb.PipelineTask("hello-world-2", "hello-world"),
+	))}
+	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
+	trs := []*v1beta1.TaskRun{
+		tb.TaskRun("test-pipeline-run-paused-hello-world-1",
+			tb.TaskRunNamespace("foo"),
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-paused"),
+			tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineLabelKey, "test-pipeline"),
+			tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineRunLabelKey, "test-pipeline-run-paused"),
+			tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineTaskLabelKey, "hello-world-1"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world"),
+				tb.TaskRunServiceAccountName("test-sa"),
+			),
+			tb.TaskRunStatus(tb.StatusCondition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionUnknown,
+				Reason: v1beta1.TaskRunReasonRunning.String(),
+			})),
+		),
+	}
 
 	d := test.Data{
 		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
 	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
 
-	testAssets, cancel := getPipelineRunController(t, d)
-	defer cancel()
-	c := testAssets.Controller
-	clients := testAssets.Clients
-
-	// Make the patch call fail, i.e. make it so that the controller fails to cancel the TaskRun
-	clients.Pipeline.PrependReactor("patch", "taskruns", func(action ktesting.Action) (bool, runtime.Object, error) {
-		return true, nil, fmt.Errorf("i'm sorry Dave, i'm afraid i can't do that")
-	})
-
-	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-fails-to-cancel")
-	if err == nil {
-		t.Errorf("Expected to see error returned from reconcile after failing to cancel TaskRun but saw none!")
+	wantEvents := []string{
+		"Normal PipelineRunPaused PipelineRun \"test-pipeline-run-paused\" is paused, no new Tasks will be scheduled until it is resumed: " +
+			"Tasks Completed: 0 \\(Failed: 0, Cancelled 0\\), Incomplete: 2, Skipped: 0",
 	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-paused", wantEvents, false)
 
-	// Check that the PipelineRun is still running with correct error message
-	reconciledRun, err := clients.Pipeline.TektonV1beta1().PipelineRuns("foo").Get("test-pipeline-fails-to-cancel", metav1.GetOptions{})
-	if err != nil {
-		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsUnknown() || condition.Reason != v1beta1.PipelineRunReasonPaused.String() {
+		t.Errorf("Expected PipelineRun condition to be unknown with reason %s, but was %v", v1beta1.PipelineRunReasonPaused, condition)
 	}
 
-	// The PipelineRun should not be cancelled b/c we couldn't cancel the TaskRun
-	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
-	if !condition.IsUnknown() {
-		t.Errorf("Expected PipelineRun to still be running since the TaskRun could not be cancelled but succeeded condition is %v", condition.Status)
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+			t.Errorf("Expected no TaskRun to be created for a paused PipelineRun, got %v", a)
+		}
 	}
-	if condition.Reason != ReasonCouldntCancel {
-		t.Errorf("Expected PipelineRun condition to indicate the cancellation failed but reason was %s", condition.Reason)
+
+	if _, ok := reconciledRun.Status.TaskRuns["test-pipeline-run-paused-hello-world-1"]; !ok {
+		t.Errorf("Expected the running TaskRun to still be reported in the PipelineRun status, got %v", reconciledRun.Status.TaskRuns)
 	}
-	// The event here is "Normal" because in case we fail to cancel we leave the condition to unknown
-	// Further reconcile might converge then the status of the pipeline.
-	// See https://github.com/tektoncd/pipeline/issues/2647 for further details.
-	wantEvents := []string{
-		"Normal PipelineRunCouldntCancel PipelineRun \"test-pipeline-fails-to-cancel\" was cancelled but had errors trying to cancel TaskRuns",
-		"Warning InternalError 1 error occurred",
+}
+
+func TestReconcileOnGracefullyCancelledPipelineRun(t *testing.T) {
+	// TestReconcileOnGracefullyCancelledPipelineRun runs "Reconcile" on a PipelineRun cancelled
+	// with CancelledRunFinally as its DAG task runs, once the DAG task is cancelled and once its
+	// finally task completes. It verifies that the running DAG task is cancelled, that no other
+	// DAG task is scheduled, and that the finally task runs before the PipelineRun is cancelled.
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+		tb.PipelineTask("hello-world-2", "hello-world", tb.RunAfter("hello-world-1")),
+		tb.FinalPipelineTask("final-task-1", "hello-world"),
+	))}
+	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
+	taskRun := func(name, pipelineTask string, c apis.Condition) *v1beta1.TaskRun {
+		return tb.TaskRun(name,
+			tb.TaskRunNamespace("foo"),
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-cancelled-run-finally"),
+			tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineLabelKey, "test-pipeline"),
+			tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineRunLabelKey, "test-pipeline-run-cancelled-run-finally"),
+			tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineTaskLabelKey, pipelineTask),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world"), tb.TaskRunServiceAccountName("test-sa")),
+			tb.TaskRunStatus(tb.StatusCondition(c)),
+		)
 	}
-	err = checkEvents(t, testAssets.Recorder, prName, wantEvents)
-	if !(err == nil) {
-		t.Errorf(err.Error())
+	running := apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown, Reason: v1beta1.TaskRunReasonRunning.String()}
+	cancelled := apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse, Reason: v1beta1.TaskRunReasonCancelled.String()}
+	succeeded := apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue, Reason: v1beta1.TaskRunReasonSuccessful.String()}
+
+	for _, tc := range []struct {
+		name            string
+		taskRuns        []*v1beta1.TaskRun
+		wantEvents      []string
+		wantStatus      corev1.ConditionStatus
+		wantReason      string
+		wantCancelled   []string
+		wantCreatedTask string
+	}{{
+		name:     "dag task running",
+		taskRuns: []*v1beta1.TaskRun{taskRun("test-pipeline-run-cancelled-run-finally-hello-world-1", "hello-world-1", running)},
+		wantEvents: []string{
+			"Normal CancelledRunningFinally Tasks Completed: 0 \\(Failed: 0, Cancelled 0\\), Incomplete: 2, Skipped: 1",
+		},
+		wantStatus:    corev1.ConditionUnknown,
+		wantReason:    v1beta1.PipelineRunReasonCancelledRunningFinally.String(),
+		wantCancelled: []string{"test-pipeline-run-cancelled-run-finally-hello-world-1"},
+	}, {
+		name:     "dag task cancelled",
+		taskRuns: []*v1beta1.TaskRun{taskRun("test-pipeline-run-cancelled-run-finally-hello-world-1", "hello-world-1", cancelled)},
+		wantEvents: []string{
+			"Normal CancelledRunningFinally Tasks Completed: 1 \\(Failed: 0, Cancelled 1\\), Incomplete: 1, Skipped: 1",
+		},
+		wantStatus:      corev1.ConditionUnknown,
+		wantReason:      v1beta1.PipelineRunReasonCancelledRunningFinally.String(),
+		wantCreatedTask: "final-task-1",
+	}, {
+		name: "finally task done",
+		taskRuns: []*v1beta1.TaskRun{
+			taskRun("test-pipeline-run-cancelled-run-finally-hello-world-1", "hello-world-1", cancelled),
+			taskRun("test-pipeline-run-cancelled-run-finally-final-task-1", "final-task-1", succeeded),
+		},
+		wantEvents: []string{
+			"Warning Failed Tasks Completed: 2 \\(Failed: 0, Cancelled 1\\), Skipped: 1",
+		},
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1beta1.PipelineRunReasonCancelled.String(),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prStatus := tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now()),
+				tb.PipelineRunStatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionUnknown,
+					Reason: v1beta1.PipelineRunReasonRunning.String(),
+				}),
+			)
+			prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-cancelled-run-finally",
+				tb.PipelineRunNamespace("foo"),
+				tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccountName("test-sa"),
+					tb.PipelineRunCancelledRunFinally,
+				),
+				prStatus,
+			)}
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				TaskRuns:     tc.taskRuns,
+			}
+			prt := NewPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-cancelled-run-finally", tc.wantEvents, false)
+
+			condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+			if condition.Status != tc.wantStatus || condition.Reason != tc.wantReason {
+				t.Errorf("Expected PipelineRun condition to be %s with reason %s, but was %v", tc.wantStatus, tc.wantReason, condition)
+			}
+
+			var cancelledTaskRuns []string
+			var createdTasks []string
+			for _, a := range clients.Pipeline.Actions() {
+				switch {
+				case a.GetVerb() == "patch" && a.GetResource().Resource == "taskruns":
+					cancelledTaskRuns = append(cancelledTaskRuns, a.(ktesting.PatchAction).GetName())
+				case a.GetVerb() == "create" && a.GetResource().Resource == "taskruns":
+					tr := a.(ktesting.CreateAction).GetObject().(*v1beta1.TaskRun)
+					createdTasks = append(createdTasks, tr.Labels[pipeline.GroupName+pipeline.PipelineTaskLabelKey])
+				}
+			}
+			if d := cmp.Diff(tc.wantCancelled, cancelledTaskRuns); d != "" {
+				t.Errorf("Unexpected cancelled TaskRuns %s", diff.PrintWantGot(d))
+			}
+			var wantCreated []string
+			if tc.wantCreatedTask != "" {
+				wantCreated = []string{tc.wantCreatedTask}
+			}
+			if d := cmp.Diff(wantCreated, createdTasks); d != "" {
+				t.Errorf("Unexpected created TaskRuns %s", diff.PrintWantGot(d))
+			}
+		})
 	}
 }
 
-func TestReconcileCancelledPipelineRun(t *testing.T) {
-	// TestReconcileCancelledPipelineRun runs "Reconcile" on a PipelineRun that has been cancelled.
-	// The PipelineRun had no TaskRun associated yet, and no TaskRun should have been created.
+func TestReconcileWithTimeout(t *testing.T) {
+	// TestReconcileWithTimeout runs "Reconcile" on a PipelineRun that has timed out.
 	// It verifies that reconcile is successful, the pipeline status updated and events generated.
 	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
-		tb.PipelineTask("hello-world-1", "hello-world", tb.Retries(1)),
+		tb.PipelineTask("hello-world-1", "hello-world"),
 	))}
-	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-cancelled", tb.PipelineRunNamespace("foo"),
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-timeout",
+		tb.PipelineRunNamespace("foo"),
 		tb.PipelineRunSpec("test-pipeline",
-			tb.PipelineRunCancelled,
+			tb.PipelineRunServiceAccountName("test-sa"),
+			tb.PipelineRunTimeout(12*time.Hour),
 		),
-		tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now())),
+		tb.PipelineRunStatus(
+			tb.PipelineRunStartTime(time.Now().AddDate(0, 0, -1))),
 	)}
 	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
 
@@ -1300,256 +1472,221 @@ func TestReconcileCancelledPipelineRun(t *testing.T) {
 	defer prt.Cancel()
 
 	wantEvents := []string{
-		"Warning Failed PipelineRun \"test-pipeline-run-cancelled\" was cancelled",
+		"Warning Failed PipelineRun \"test-pipeline-run-with-timeout\" failed to finish within \"12h0m0s\"",
 	}
-	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-cancelled", wantEvents, false)
-	actions := clients.Pipeline.Actions()
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-with-timeout", wantEvents, false)
 
-	// The PipelineRun should be still cancelled.
-	if reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Reason != ReasonCancelled {
-		t.Errorf("Expected PipelineRun to be cancelled, but condition reason is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	if reconciledRun.Status.CompletionTime == nil {
+		t.Errorf("Expected a CompletionTime on invalid PipelineRun but was nil")
 	}
 
-	// Check that no TaskRun is created or run
-	for _, action := range actions {
-		actionType := fmt.Sprintf("%T", action)
-		if !(actionType == "testing.UpdateActionImpl" || actionType == "testing.GetActionImpl") {
-			t.Errorf("Expected a TaskRun to be get/updated, but it was %s", actionType)
-		}
+	// The PipelineRun should be timed out.
+	if reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Reason != "PipelineRunTimeout" {
+		t.Errorf("Expected PipelineRun to be timed out, but condition reason is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
 	}
-}
 
-func TestReconcilePropagateLabels(t *testing.T) {
-	names.TestingSeed()
-	taskName := "hello-world-1"
+	actions := clients.Pipeline.Actions()
+	if len(actions) < 2 {
+		t.Fatalf("Expected client to have at least two action implementation but it has %d", len(actions))
+	}
 
+	// Check that the expected TaskRun was created
+	actual := actions[1].(ktesting.CreateAction).GetObject().(*v1beta1.TaskRun)
+	if actual == nil {
+		t.Fatalf("Expected a TaskRun to be created, but it wasn't.")
+	}
+
+	// The TaskRun timeout should be less than or equal to the PipelineRun timeout.
+	if actual.Spec.Timeout.Duration > prs[0].Spec.Timeout.Duration {
+		t.Errorf("TaskRun timeout %s should be less than or equal to PipelineRun timeout %s", actual.Spec.Timeout.Duration.String(), prs[0].Spec.Timeout.Duration.String())
+	}
+}
+
+func TestReconcileWithTasksTimeout(t *testing.T) {
+	// TestReconcileWithTasksTimeout runs "Reconcile" on a PipelineRun whose tasks exceeded
+	// spec.timeouts.tasks. It verifies that no new task of the DAG is scheduled, and that the
+	// finally task is started with what remains of spec.timeouts.finally.
 	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
-		tb.PipelineTask(taskName, "hello-world"),
+		tb.PipelineTask("hello-world-1", "hello-world"),
+		tb.PipelineTask("hello-world-2", "hello-world", tb.RunAfter("hello-world-1")),
+		tb.FinalPipelineTask("final-task-1", "hello-world"),
 	))}
-	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-labels", tb.PipelineRunNamespace("foo"),
-		tb.PipelineRunLabel("PipelineRunLabel", "PipelineRunValue"),
-		tb.PipelineRunLabel("tekton.dev/pipeline", "WillNotBeUsed"),
-		tb.PipelineRunSpec("test-pipeline",
-			tb.PipelineRunServiceAccountName("test-sa"),
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-timeouts",
+		tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccountName("test-sa"), tb.PipelineRunNilTimeout),
+		tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now().Add(-20*time.Minute)),
+			tb.PipelineRunStatusCondition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionUnknown,
+				Reason: v1beta1.PipelineRunReasonRunning.String(),
+			}),
 		),
 	)}
+	prs[0].Spec.Timeouts = &v1beta1.TimeoutFields{
+		Pipeline: &metav1.Duration{Duration: time.Hour},
+		Tasks:    &metav1.Duration{Duration: 10 * time.Minute},
+		Finally:  &metav1.Duration{Duration: 15 * time.Minute},
+	}
 	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
-
-	expected := tb.TaskRun("test-pipeline-run-with-labels-hello-world-1-9l9zj",
-		tb.TaskRunNamespace("foo"),
-		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-with-labels",
-			tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
-			tb.Controller, tb.BlockOwnerDeletion,
-		),
-		tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
-		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineTaskLabelKey, "hello-world-1"),
-		tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-with-labels"),
-		tb.TaskRunLabel("PipelineRunLabel", "PipelineRunValue"),
-		tb.TaskRunSpec(
-			tb.TaskRunTaskRef("hello-world"),
-			tb.TaskRunServiceAccountName("test-sa"),
+	trs := []*v1beta1.TaskRun{
+		tb.TaskRun("test-pipeline-run-with-timeouts-hello-world-1",
+			tb.TaskRunNamespace("foo"),
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-with-timeouts"),
+			tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineLabelKey, "test-pipeline"),
+			tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineRunLabelKey, "test-pipeline-run-with-timeouts"),
+			tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineTaskLabelKey, "hello-world-1"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world"), tb.TaskRunServiceAccountName("test-sa")),
+			tb.TaskRunStatus(tb.StatusCondition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+				Reason: v1beta1.TaskRunReasonSuccessful.String(),
+			})),
 		),
-	)
+	}
 
 	d := test.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
+		TaskRuns:     trs,
 	}
 	prt := NewPipelineRunTest(d, t)
 	defer prt.Cancel()
 
-	_, clients := prt.reconcileRun("foo", "test-pipeline-run-with-labels", []string{}, false)
-	actions := clients.Pipeline.Actions()
-	if len(actions) < 2 {
-		t.Fatalf("Expected client to have at least two action implementation but it has %d", len(actions))
-	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-with-timeouts", []string{}, false)
 
-	// Check that the expected TaskRun was created
-	actual := actions[1].(ktesting.CreateAction).GetObject().(*v1beta1.TaskRun)
-	if actual == nil {
-		t.Errorf("Expected a TaskRun to be created, but it wasn't.")
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to be running its finally task, but condition is %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+	if reconciledRun.Status.FinallyStartTime == nil {
+		t.Error("Expected the start time of the finally tasks to be set")
+	}
+	if d := cmp.Diff([]v1beta1.SkippedTask{{Name: "hello-world-2"}}, reconciledRun.Status.SkippedTasks); d != "" {
+		t.Errorf("Expected the task scheduled after the tasks timeout to be skipped %s", diff.PrintWantGot(d))
 	}
 
-	if d := cmp.Diff(actual, expected); d != "" {
-		t.Errorf("expected to see TaskRun %v created. Diff %s", expected, diff.PrintWantGot(d))
+	var created []*v1beta1.TaskRun
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+			created = append(created, a.(ktesting.CreateAction).GetObject().(*v1beta1.TaskRun))
+		}
+	}
+	if len(created) != 1 || created[0].Labels[pipeline.GroupName+pipeline.PipelineTaskLabelKey] != "final-task-1" {
+		t.Fatalf("Expected only the TaskRun of the finally task to be created, got %v", created)
+	}
+	if timeout := created[0].Spec.Timeout.Duration; timeout > 15*time.Minute || timeout < 15*time.Minute-time.Second {
+		t.Errorf("Expected the finally TaskRun to get the finally timeout of 15m, got %s", timeout)
 	}
 }
 
-func TestReconcileWithDifferentServiceAccounts(t *testing.T) {
-	names.TestingSeed()
-
+func TestReconcileWithFinallyConsumingResultsAndStatus(t *testing.T) {
+	// TestReconcileWithFinallyConsumingResultsAndStatus runs "Reconcile" on a PipelineRun whose DAG
+	// tasks are done, one of them failed. It verifies that the finally tasks get the results and the
+	// execution status of the DAG tasks, and that the ones consuming the results of the failed task,
+	// or with when expressions evaluating to false, are skipped.
 	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
-		tb.PipelineTask("hello-world-0", "hello-world-task"),
-		tb.PipelineTask("hello-world-1", "hello-world-task"),
+		tb.PipelineTask("task-succeeded", "hello-world"),
+		tb.PipelineTask("task-failed", "hello-world"),
+		tb.FinalPipelineTask("final-consumes-results", "hello-world",
+			tb.PipelineTaskParam("commit", "$(tasks.task-succeeded.results.commit)"),
+			tb.PipelineTaskParam("status", "$(tasks.task-failed.status)"),
+		),
+		tb.FinalPipelineTask("final-consumes-failed-results", "hello-world",
+			tb.PipelineTaskParam("commit", "$(tasks.task-failed.results.commit)"),
+		),
+		tb.FinalPipelineTask("final-on-failure", "hello-world",
+			tb.PipelineTaskParam("status", "$(tasks.status)"),
+			tb.PipelineTaskWhenExpression("$(tasks.status)", selection.In, []string{"Failed"}),
+		),
+		tb.FinalPipelineTask("final-on-success", "hello-world",
+			tb.PipelineTaskWhenExpression("$(tasks.status)", selection.In, []string{"Succeeded"}),
+		),
 	))}
-	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-different-service-accs", tb.PipelineRunNamespace("foo"),
-		tb.PipelineRunSpec("test-pipeline",
-			tb.PipelineRunServiceAccountName("test-sa-0"),
-			tb.PipelineRunServiceAccountNameTask("hello-world-1", "test-sa-1"),
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-finally-results",
+		tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccountName("test-sa")),
+		tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now()),
+			tb.PipelineRunStatusCondition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionUnknown,
+				Reason: v1beta1.PipelineRunReasonRunning.String(),
+			}),
 		),
 	)}
-	ts := []*v1beta1.Task{
-		tb.Task("hello-world-task", tb.TaskNamespace("foo")),
+	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"), tb.TaskSpec(
+		tb.TaskParam("commit", v1beta1.ParamTypeString, tb.ParamSpecDefault("")),
+		tb.TaskParam("status", v1beta1.ParamTypeString, tb.ParamSpecDefault("")),
+	))}
+	taskRun := func(pipelineTaskName string, status corev1.ConditionStatus) *v1beta1.TaskRun {
+		return tb.TaskRun("test-pipeline-run-finally-results-"+pipelineTaskName,
+			tb.TaskRunNamespace("foo"),
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-finally-results"),
+			tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineLabelKey, "test-pipeline"),
+			tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineRunLabelKey, "test-pipeline-run-finally-results"),
+			tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineTaskLabelKey, pipelineTaskName),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world"), tb.TaskRunServiceAccountName("test-sa")),
+			tb.TaskRunStatus(tb.StatusCondition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: status,
+			}), tb.TaskRunResult("commit", "abc123")),
+		)
+	}
+	trs := []*v1beta1.TaskRun{
+		taskRun("task-succeeded", corev1.ConditionTrue),
+		taskRun("task-failed", corev1.ConditionFalse),
 	}
 
 	d := test.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
+		TaskRuns:     trs,
 	}
 	prt := NewPipelineRunTest(d, t)
 	defer prt.Cancel()
 
-	_, clients := prt.reconcileRun("foo", "test-pipeline-run-different-service-accs", []string{}, false)
-
-	taskRunNames := []string{"test-pipeline-run-different-service-accs-hello-world-0-9l9zj", "test-pipeline-run-different-service-accs-hello-world-1-mz4c7"}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-finally-results", []string{}, false)
 
-	expectedTaskRuns := []*v1beta1.TaskRun{
-		tb.TaskRun(taskRunNames[0],
-			tb.TaskRunNamespace("foo"),
-			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-different-service-accs",
-				tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
-				tb.Controller, tb.BlockOwnerDeletion,
-			),
-			tb.TaskRunSpec(
-				tb.TaskRunTaskRef("hello-world-task"),
-				tb.TaskRunServiceAccountName("test-sa-0"),
-			),
-			tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
-			tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-different-service-accs"),
-			tb.TaskRunLabel("tekton.dev/pipelineTask", "hello-world-0"),
-		),
-		tb.TaskRun(taskRunNames[1],
-			tb.TaskRunNamespace("foo"),
-			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-different-service-accs",
-				tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
-				tb.Controller, tb.BlockOwnerDeletion,
-			),
-			tb.TaskRunSpec(
-				tb.TaskRunTaskRef("hello-world-task"),
-				tb.TaskRunServiceAccountName("test-sa-1"),
-			),
-			tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
-			tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-different-service-accs"),
-			tb.TaskRunLabel("tekton.dev/pipelineTask", "hello-world-1"),
-		),
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to be running its finally tasks, but condition is %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
 	}
-	for i := range ps[0].Spec.Tasks {
-		// Check that the expected TaskRun was created
-		actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(taskRunNames[i], metav1.GetOptions{})
-		if err != nil {
-			t.Fatalf("Expected a TaskRun to be created, but it wasn't: %s", err)
-		}
-		if d := cmp.Diff(actual, expectedTaskRuns[i], ignoreResourceVersion); d != "" {
-			t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRuns[i], diff.PrintWantGot(d))
-		}
-
+	expectedSkippedTasks := []v1beta1.SkippedTask{{Name: "final-consumes-failed-results"}, {Name: "final-on-success"}}
+	if d := cmp.Diff(expectedSkippedTasks, reconciledRun.Status.SkippedTasks); d != "" {
+		t.Errorf("Expected the finally tasks without their results or with false when expressions to be skipped %s", diff.PrintWantGot(d))
 	}
-}
 
-// TestReconcileWithTimeoutAndRetry runs "Reconcile" against pipelines with
-// retries and timeout settings, and status that represents different number of
-// retries already performed.  It verifies the reconciled status and events
-// generated
-func TestReconcileWithTimeoutAndRetry(t *testing.T) {
-	for _, tc := range []struct {
-		name               string
-		retries            int
-		conditionSucceeded corev1.ConditionStatus
-		wantEvents         []string
-	}{{
-		name:               "One try has to be done",
-		retries:            1,
-		conditionSucceeded: corev1.ConditionFalse,
-		wantEvents: []string{
-			"Warning Failed PipelineRun \"test-pipeline-retry-run-with-timeout\" failed to finish within",
+	created := map[string][]v1beta1.Param{}
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+			tr := a.(ktesting.CreateAction).GetObject().(*v1beta1.TaskRun)
+			created[tr.Labels[pipeline.GroupName+pipeline.PipelineTaskLabelKey]] = tr.Spec.Params
+		}
+	}
+	expectedParams := map[string][]v1beta1.Param{
+		"final-consumes-results": {
+			{Name: "commit", Value: *v1beta1.NewArrayOrString("abc123")},
+			{Name: "status", Value: *v1beta1.NewArrayOrString("Failed")},
 		},
-	}, {
-		name:               "No more retries are needed",
-		retries:            2,
-		conditionSucceeded: corev1.ConditionUnknown,
-		wantEvents: []string{
-			"Warning Failed PipelineRun \"test-pipeline-retry-run-with-timeout\" failed to finish within",
+		"final-on-failure": {
+			{Name: "status", Value: *v1beta1.NewArrayOrString("Failed")},
 		},
-	}} {
-		t.Run(tc.name, func(t *testing.T) {
-			ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline-retry", tb.PipelineNamespace("foo"), tb.PipelineSpec(
-				tb.PipelineTask("hello-world-1", "hello-world", tb.Retries(tc.retries)),
-			))}
-			prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-retry-run-with-timeout", tb.PipelineRunNamespace("foo"),
-				tb.PipelineRunSpec("test-pipeline-retry",
-					tb.PipelineRunServiceAccountName("test-sa"),
-					tb.PipelineRunTimeout(12*time.Hour),
-				),
-				tb.PipelineRunStatus(
-					tb.PipelineRunStartTime(time.Now().AddDate(0, 0, -1))),
-			)}
-
-			ts := []*v1beta1.Task{
-				tb.Task("hello-world", tb.TaskNamespace("foo")),
-			}
-			trs := []*v1beta1.TaskRun{
-				tb.TaskRun("hello-world-1",
-					tb.TaskRunNamespace("foo"),
-					tb.TaskRunStatus(
-						tb.PodName("my-pod-name"),
-						tb.StatusCondition(apis.Condition{
-							Type:   apis.ConditionSucceeded,
-							Status: corev1.ConditionFalse,
-						}),
-						tb.Retry(v1beta1.TaskRunStatus{
-							Status: duckv1beta1.Status{
-								Conditions: []apis.Condition{{
-									Type:   apis.ConditionSucceeded,
-									Status: corev1.ConditionFalse,
-								}},
-							},
-						}),
-					)),
-			}
-
-			prtrs := &v1beta1.PipelineRunTaskRunStatus{
-				PipelineTaskName: "hello-world-1",
-				Status:           &trs[0].Status,
-			}
-			prs[0].Status.TaskRuns = make(map[string]*v1beta1.PipelineRunTaskRunStatus)
-			prs[0].Status.TaskRuns["hello-world-1"] = prtrs
-
-			d := test.Data{
-				PipelineRuns: prs,
-				Pipelines:    ps,
-				Tasks:        ts,
-				TaskRuns:     trs,
-			}
-			prt := NewPipelineRunTest(d, t)
-			defer prt.Cancel()
-
-			reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-retry-run-with-timeout", []string{}, false)
-
-			if len(reconciledRun.Status.TaskRuns["hello-world-1"].Status.RetriesStatus) != tc.retries {
-				t.Fatalf(" %d retry expected but %d ", tc.retries, len(reconciledRun.Status.TaskRuns["hello-world-1"].Status.RetriesStatus))
-			}
-
-			if status := reconciledRun.Status.TaskRuns["hello-world-1"].Status.GetCondition(apis.ConditionSucceeded).Status; status != tc.conditionSucceeded {
-				t.Fatalf("Succeeded expected to be %s but is %s", tc.conditionSucceeded, status)
-			}
-		})
+	}
+	if d := cmp.Diff(expectedParams, created); d != "" {
+		t.Errorf("Expected the TaskRuns of the finally tasks to get the results and status of the DAG tasks %s", diff.PrintWantGot(d))
 	}
 }
 
-func TestReconcilePropagateAnnotations(t *testing.T) {
-	names.TestingSeed()
-
+func TestReconcileWithoutPVC(t *testing.T) {
+	// TestReconcileWithoutPVC runs "Reconcile" on a PipelineRun that has two unrelated tasks.
+	// It verifies that reconcile is successful and that no PVC is created
 	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
 		tb.PipelineTask("hello-world-1", "hello-world"),
+		tb.PipelineTask("hello-world-2", "hello-world"),
 	))}
-	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-annotations", tb.PipelineRunNamespace("foo"),
-		tb.PipelineRunAnnotation("PipelineRunAnnotation", "PipelineRunValue"),
-		tb.PipelineRunSpec("test-pipeline",
-			tb.PipelineRunServiceAccountName("test-sa"),
-		),
-	)}
+
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run", tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline")),
+	}
 	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
 
 	d := test.Data{
@@ -1560,153 +1697,110 @@ func TestReconcilePropagateAnnotations(t *testing.T) {
 	prt := NewPipelineRunTest(d, t)
 	defer prt.Cancel()
 
-	_, clients := prt.reconcileRun("foo", "test-pipeline-run-with-annotations", []string{}, false)
-
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", []string{}, false)
 	actions := clients.Pipeline.Actions()
-	if len(actions) < 2 {
-		t.Fatalf("Expected client to have at least two action implementation but it has %d", len(actions))
-	}
 
 	// Check that the expected TaskRun was created
-	actual := actions[1].(ktesting.CreateAction).GetObject().(*v1beta1.TaskRun)
-	if actual == nil {
-		t.Errorf("Expected a TaskRun to be created, but it wasn't.")
-	}
-	expectedTaskRun := tb.TaskRun("test-pipeline-run-with-annotations-hello-world-1-9l9zj",
-		tb.TaskRunNamespace("foo"),
-		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-with-annotations",
-			tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
-			tb.Controller, tb.BlockOwnerDeletion,
-		),
-		tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
-		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineTaskLabelKey, "hello-world-1"),
-		tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-with-annotations"),
-		tb.TaskRunAnnotation("PipelineRunAnnotation", "PipelineRunValue"),
-		tb.TaskRunSpec(
-			tb.TaskRunTaskRef("hello-world"),
-			tb.TaskRunServiceAccountName("test-sa"),
-		),
-	)
+	for _, a := range actions {
+		if ca, ok := a.(ktesting.CreateAction); ok {
+			obj := ca.GetObject()
+			if pvc, ok := obj.(*corev1.PersistentVolumeClaim); ok {
+				t.Errorf("Did not expect to see a PVC created when no resources are linked. %s was created", pvc)
+			}
+		}
+	}
 
-	if d := cmp.Diff(actual, expectedTaskRun); d != "" {
-		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRun, diff.PrintWantGot(d))
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to be running, but condition status is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
 	}
 }
 
-func TestGetTaskRunTimeout(t *testing.T) {
-	prName := "pipelinerun-timeouts"
-	ns := "foo"
-	p := "pipeline"
-
-	tcs := []struct {
-		name     string
-		pr       *v1beta1.PipelineRun
-		rprt     *resources.ResolvedPipelineRunTask
-		expected *metav1.Duration
-	}{{
-		name: "nil timeout duration",
-		pr: tb.PipelineRun(prName, tb.PipelineRunNamespace(ns),
-			tb.PipelineRunSpec(p, tb.PipelineRunNilTimeout),
-			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now())),
-		),
-		rprt: &resources.ResolvedPipelineRunTask{
-			PipelineTask: &v1beta1.PipelineTask{
-				Timeout: nil,
-			},
-		},
-		expected: &metav1.Duration{Duration: 60 * time.Minute},
-	}, {
-		name: "timeout specified in pr",
-		pr: tb.PipelineRun(prName, tb.PipelineRunNamespace(ns),
-			tb.PipelineRunSpec(p, tb.PipelineRunTimeout(20*time.Minute)),
-			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now())),
-		),
-		rprt: &resources.ResolvedPipelineRunTask{
-			PipelineTask: &v1beta1.PipelineTask{
-				Timeout: nil,
-			},
-		},
-		expected: &metav1.Duration{Duration: 20 * time.Minute},
-	}, {
-		name: "0 timeout duration",
-		pr: tb.PipelineRun(prName, tb.PipelineRunNamespace(ns),
-			tb.PipelineRunSpec(p, tb.PipelineRunTimeout(0*time.Minute)),
-			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now())),
-		),
-		rprt: &resources.ResolvedPipelineRunTask{
-			PipelineTask: &v1beta1.PipelineTask{
-				Timeout: nil,
-			},
-		},
-		expected: &metav1.Duration{Duration: 0 * time.Minute},
-	}, {
-		name: "taskrun being created after timeout expired",
-		pr: tb.PipelineRun(prName, tb.PipelineRunNamespace(ns),
-			tb.PipelineRunSpec(p, tb.PipelineRunTimeout(1*time.Minute)),
-			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now().Add(-2*time.Minute)))),
-		rprt: &resources.ResolvedPipelineRunTask{
-			PipelineTask: &v1beta1.PipelineTask{
-				Timeout: nil,
-			},
-		},
-		expected: &metav1.Duration{Duration: 1 * time.Second},
-	}, {
-		name: "taskrun being created with timeout for PipelineTask",
-		pr: tb.PipelineRun(prName, tb.PipelineRunNamespace(ns),
-			tb.PipelineRunSpec(p, tb.PipelineRunTimeout(20*time.Minute)),
-			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now())),
+func TestReconcileCancelledFailsTaskRunCancellation(t *testing.T) {
+	// TestReconcileCancelledFailsTaskRunCancellation runs "Reconcile" on a PipelineRun with a single TaskRun.
+	// The TaskRun cannot be cancelled. Check that the pipelinerun cancel fails, that reconcile fails and
+	// an event is generated
+	names.TestingSeed()
+	ptName := "hello-world-1"
+	prName := "test-pipeline-fails-to-cancel"
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun(prName, tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunCancelled,
 		),
-		rprt: &resources.ResolvedPipelineRunTask{
-			PipelineTask: &v1beta1.PipelineTask{
-				Timeout: &metav1.Duration{Duration: 2 * time.Minute},
-			},
-		},
-		expected: &metav1.Duration{Duration: 2 * time.Minute},
-	}, {
-		name: "0 timeout duration for PipelineRun, PipelineTask timeout still applied",
-		pr: tb.PipelineRun(prName, tb.PipelineRunNamespace(ns),
-			tb.PipelineRunSpec(p, tb.PipelineRunTimeout(0*time.Minute)),
-			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now())),
+		// The reconciler uses the presence of this TaskRun in the status to determine that a TaskRun
+		// is already running. The TaskRun will not be retrieved at all so we do not need to seed one.
+		tb.PipelineRunStatus(
+			tb.PipelineRunStatusCondition(apis.Condition{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionUnknown,
+				Reason:  v1beta1.PipelineRunReasonRunning.String(),
+				Message: "running...",
+			}),
+			tb.PipelineRunTaskRunsStatus(prName+ptName, &v1beta1.PipelineRunTaskRunStatus{
+				PipelineTaskName: ptName,
+				Status:           &v1beta1.TaskRunStatus{},
+			}),
+			tb.PipelineRunStartTime(time.Now()),
 		),
-		rprt: &resources.ResolvedPipelineRunTask{
-			PipelineTask: &v1beta1.PipelineTask{
-				Timeout: &metav1.Duration{Duration: 2 * time.Minute},
-			},
-		},
-		expected: &metav1.Duration{Duration: 2 * time.Minute},
-	}}
+	)}
 
-	for _, tc := range tcs {
-		t.Run(tc.name, func(t *testing.T) {
-			if d := cmp.Diff(getTaskRunTimeout(context.TODO(), tc.pr, tc.rprt), tc.expected); d != "" {
-				t.Errorf("Unexpected task run timeout. Diff %s", diff.PrintWantGot(d))
-			}
-		})
+	d := test.Data{
+		PipelineRuns: prs,
+	}
+
+	testAssets, cancel := getPipelineRunController(t, d)
+	defer cancel()
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	// Make the patch call fail, i.e. make it so that the controller fails to cancel the TaskRun
+	clients.Pipeline.PrependReactor("patch", "taskruns", func(action ktesting.Action) (bool, runtime.Object, error) {
+		return true, nil, fmt.Errorf("i'm sorry Dave, i'm afraid i can't do that")
+	})
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-fails-to-cancel")
+	if err == nil {
+		t.Errorf("Expected to see error returned from reconcile after failing to cancel TaskRun but saw none!")
+	}
+
+	// Check that the PipelineRun is still running with correct error message
+	reconciledRun, err := clients.Pipeline.TektonV1beta1().PipelineRuns("foo").Get("test-pipeline-fails-to-cancel", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+
+	// The PipelineRun should not be cancelled b/c we couldn't cancel the TaskRun
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsUnknown() {
+		t.Errorf("Expected PipelineRun to still be running since the TaskRun could not be cancelled but succeeded condition is %v", condition.Status)
+	}
+	if condition.Reason != ReasonCouldntCancel {
+		t.Errorf("Expected PipelineRun condition to indicate the cancellation failed but reason was %s", condition.Reason)
+	}
+	// The event here is "Normal" because in case we fail to cancel we leave the condition to unknown
+	// Further reconcile might converge then the status of the pipeline.
+	// See https://github.com/tektoncd/pipeline/issues/2647 for further details.
+	wantEvents := []string{
+		"Normal PipelineRunCouldntCancel PipelineRun \"test-pipeline-fails-to-cancel\" was cancelled but had errors trying to cancel TaskRuns",
+		"Warning InternalError 1 error occurred",
+	}
+	err = checkEvents(t, testAssets.Recorder, prName, wantEvents)
+	if !(err == nil) {
+		t.Errorf(err.Error())
 	}
 }
 
-// TestReconcileAndPropagateCustomPipelineTaskRunSpec tests that custom PipelineTaskRunSpec declared
-// in PipelineRun is propagated to created TaskRuns
-func TestReconcileAndPropagateCustomPipelineTaskRunSpec(t *testing.T) {
-	names.TestingSeed()
-	prName := "test-pipeline-run"
+func TestReconcileCancelledPipelineRun(t *testing.T) {
+	// TestReconcileCancelledPipelineRun runs "Reconcile" on a PipelineRun that has been cancelled.
+	// The PipelineRun had no TaskRun associated yet, and no TaskRun should have been created.
+	// It verifies that reconcile is successful, the pipeline status updated and events generated.
 	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
-		tb.PipelineTask("hello-world-1", "hello-world"),
+		tb.PipelineTask("hello-world-1", "hello-world", tb.Retries(1)),
 	))}
-	prs := []*v1beta1.PipelineRun{tb.PipelineRun(prName, tb.PipelineRunNamespace("foo"),
-		tb.PipelineRunAnnotation("PipelineRunAnnotation", "PipelineRunValue"),
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-cancelled", tb.PipelineRunNamespace("foo"),
 		tb.PipelineRunSpec("test-pipeline",
-			tb.PipelineRunServiceAccountName("test-sa"),
-			tb.PipelineTaskRunSpecs([]v1beta1.PipelineTaskRunSpec{{
-				PipelineTaskName:       "hello-world-1",
-				TaskServiceAccountName: "custom-sa",
-				TaskPodTemplate: &v1beta1.PodTemplate{
-					NodeSelector: map[string]string{
-						"workloadtype": "tekton",
-					},
-				},
-			}}),
+			tb.PipelineRunCancelled,
 		),
+		tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now())),
 	)}
 	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
 
@@ -1718,316 +1812,258 @@ func TestReconcileAndPropagateCustomPipelineTaskRunSpec(t *testing.T) {
 	prt := NewPipelineRunTest(d, t)
 	defer prt.Cancel()
 
-	_, clients := prt.reconcileRun("foo", prName, []string{}, false)
+	wantEvents := []string{
+		"Warning Failed PipelineRun \"test-pipeline-run-cancelled\" was cancelled",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-cancelled", wantEvents, false)
 	actions := clients.Pipeline.Actions()
 
-	if len(actions) < 2 {
-		t.Fatalf("Expected client to have at least two action implementation but it has %d", len(actions))
+	// The PipelineRun should be still cancelled.
+	if reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Reason != ReasonCancelled {
+		t.Errorf("Expected PipelineRun to be cancelled, but condition reason is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
 	}
 
-	// Check that the expected TaskRun was created
-	actual := actions[1].(ktesting.CreateAction).GetObject().(*v1beta1.TaskRun)
-	if actual == nil {
-		t.Errorf("Expected a TaskRun to be created, but it wasn't.")
+	// Check that no TaskRun is created or run
+	for _, action := range actions {
+		actionType := fmt.Sprintf("%T", action)
+		if !(actionType == "testing.UpdateActionImpl" || actionType == "testing.GetActionImpl") {
+			t.Errorf("Expected a TaskRun to be get/updated, but it was %s", actionType)
+		}
 	}
-	expectedTaskRun := tb.TaskRun("test-pipeline-run-hello-world-1-9l9zj",
+}
+
+func TestReconcilePropagateLabels(t *testing.T) {
+	names.TestingSeed()
+	taskName := "hello-world-1"
+
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask(taskName, "hello-world"),
+	))}
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-labels", tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunLabel("PipelineRunLabel", "PipelineRunValue"),
+		tb.PipelineRunLabel("tekton.dev/pipeline", "WillNotBeUsed"),
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunServiceAccountName("test-sa"),
+		),
+	)}
+	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
+
+	expected := tb.TaskRun("test-pipeline-run-with-labels-hello-world-1-9l9zj",
 		tb.TaskRunNamespace("foo"),
-		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run",
+		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-with-labels",
 			tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
 			tb.Controller, tb.BlockOwnerDeletion,
 		),
-		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineLabelKey, "test-pipeline"),
+		tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
 		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineTaskLabelKey, "hello-world-1"),
-		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineRunLabelKey, "test-pipeline-run"),
-		tb.TaskRunAnnotation("PipelineRunAnnotation", "PipelineRunValue"),
+		tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-with-labels"),
+		tb.TaskRunLabel("PipelineRunLabel", "PipelineRunValue"),
 		tb.TaskRunSpec(
 			tb.TaskRunTaskRef("hello-world"),
-			tb.TaskRunServiceAccountName("custom-sa"),
-			tb.TaskRunPodTemplate(&v1beta1.PodTemplate{
-				NodeSelector: map[string]string{
-					"workloadtype": "tekton",
-				},
-			}),
+			tb.TaskRunServiceAccountName("test-sa"),
 		),
 	)
 
-	if d := cmp.Diff(actual, expectedTaskRun); d != "" {
-		t.Errorf("expected to see propagated custom ServiceAccountName and PodTemplate in TaskRun %v created. Diff %s", expectedTaskRun, diff.PrintWantGot(d))
-	}
-}
-
-func TestReconcileWithConditionChecks(t *testing.T) {
-	// TestReconcileWithConditionChecks runs "Reconcile" on a PipelineRun that has a task with
-	// multiple conditions. It verifies that reconcile is successful, taskruns are created and
-	// the status is updated. It checks that the correct events are sent.
-	names.TestingSeed()
-	prName := "test-pipeline-run"
-	conditions := []*v1alpha1.Condition{
-		tbv1alpha1.Condition("cond-1",
-			tbv1alpha1.ConditionNamespace("foo"),
-			tbv1alpha1.ConditionLabels(
-				map[string]string{
-					"label-1": "value-1",
-					"label-2": "value-2",
-				}),
-			tbv1alpha1.ConditionAnnotations(
-				map[string]string{
-					"annotation-1": "value-1",
-				}),
-			tbv1alpha1.ConditionSpec(
-				tbv1alpha1.ConditionSpecCheck("", "foo", tb.Args("bar")),
-			)),
-		tbv1alpha1.Condition("cond-2",
-			tbv1alpha1.ConditionNamespace("foo"),
-			tbv1alpha1.ConditionLabels(
-				map[string]string{
-					"label-3": "value-3",
-					"label-4": "value-4",
-				}),
-			tbv1alpha1.ConditionSpec(
-				tbv1alpha1.ConditionSpecCheck("", "foo", tb.Args("bar")),
-			)),
-	}
-	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
-		tb.PipelineTask("hello-world-1", "hello-world",
-			tb.PipelineTaskCondition("cond-1"),
-			tb.PipelineTaskCondition("cond-2")),
-	))}
-	prs := []*v1beta1.PipelineRun{tb.PipelineRun(prName, tb.PipelineRunNamespace("foo"),
-		tb.PipelineRunAnnotation("PipelineRunAnnotation", "PipelineRunValue"),
-		tb.PipelineRunSpec("test-pipeline",
-			tb.PipelineRunServiceAccountName("test-sa"),
-		),
-	)}
-	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
-
 	d := test.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
-		Conditions:   conditions,
 	}
 	prt := NewPipelineRunTest(d, t)
 	defer prt.Cancel()
 
-	wantEvents := []string{
-		"Normal Started",
-		"Normal Running Tasks Completed: 0 \\(Failed: 0, Cancelled 0\\), Incomplete: 1, Skipped: 0",
-	}
-	_, clients := prt.reconcileRun("foo", prName, wantEvents, false)
-
-	ccNameBase := prName + "-hello-world-1-9l9zj"
-	ccNames := map[string]string{
-		"cond-1": ccNameBase + "-cond-1-0-mz4c7",
-		"cond-2": ccNameBase + "-cond-2-1-mssqb",
-	}
-	expectedConditionChecks := make([]*v1beta1.TaskRun, len(conditions))
-	for index, condition := range conditions {
-		expectedConditionChecks[index] = makeExpectedTr(condition.Name, ccNames[condition.Name], condition.Labels, condition.Annotations)
-	}
-
+	_, clients := prt.reconcileRun("foo", "test-pipeline-run-with-labels", []string{}, false)
 	actions := clients.Pipeline.Actions()
-	if len(actions) < 3 {
-		t.Fatalf("Expected client to have at least three action implementation but it has %d", len(actions))
+	if len(actions) < 2 {
+		t.Fatalf("Expected client to have at least two action implementation but it has %d", len(actions))
 	}
 
 	// Check that the expected TaskRun was created
-	condCheck0 := actions[1].(ktesting.CreateAction).GetObject().(*v1beta1.TaskRun)
-	condCheck1 := actions[2].(ktesting.CreateAction).GetObject().(*v1beta1.TaskRun)
-	if condCheck0 == nil || condCheck1 == nil {
-		t.Errorf("Expected two ConditionCheck TaskRuns to be created, but it wasn't.")
+	actual := actions[1].(ktesting.CreateAction).GetObject().(*v1beta1.TaskRun)
+	if actual == nil {
+		t.Errorf("Expected a TaskRun to be created, but it wasn't.")
 	}
 
-	actual := []*v1beta1.TaskRun{condCheck0, condCheck1}
-	if d := cmp.Diff(actual, expectedConditionChecks); d != "" {
-		t.Errorf("expected to see 2 ConditionCheck TaskRuns created. Diff %s", diff.PrintWantGot(d))
+	if d := cmp.Diff(actual, expected); d != "" {
+		t.Errorf("expected to see TaskRun %v created. Diff %s", expected, diff.PrintWantGot(d))
 	}
 }
 
-func TestReconcileWithFailingConditionChecks(t *testing.T) {
-	// TestReconcileWithFailingConditionChecks runs "Reconcile" on a PipelineRun that has a task with
-	// multiple conditions, some that fails. It verifies that reconcile is successful, taskruns are
-	// created and the status is updated. It checks that the correct events are sent.
+func TestReconcileWithDifferentServiceAccounts(t *testing.T) {
 	names.TestingSeed()
-	conditions := []*v1alpha1.Condition{
-		tbv1alpha1.Condition("always-false", tbv1alpha1.ConditionNamespace("foo"), tbv1alpha1.ConditionSpec(
-			tbv1alpha1.ConditionSpecCheck("", "foo", tb.Args("bar")),
-		)),
-	}
-	pipelineRunName := "test-pipeline-run-with-conditions"
-	prccs := make(map[string]*v1beta1.PipelineRunConditionCheckStatus)
 
-	conditionCheckName := pipelineRunName + "task-2-always-false-xxxyyy"
-	prccs[conditionCheckName] = &v1beta1.PipelineRunConditionCheckStatus{
-		ConditionName: "always-false-0",
-		Status:        &v1beta1.ConditionCheckStatus{},
-	}
 	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
-		tb.PipelineTask("task-1", "hello-world"),
-		tb.PipelineTask("task-2", "hello-world", tb.PipelineTaskCondition("always-false")),
-		tb.PipelineTask("task-3", "hello-world", tb.RunAfter("task-1")),
+		tb.PipelineTask("hello-world-0", "hello-world-task"),
+		tb.PipelineTask("hello-world-1", "hello-world-task"),
 	))}
-
-	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-conditions", tb.PipelineRunNamespace("foo"),
-		tb.PipelineRunAnnotation("PipelineRunAnnotation", "PipelineRunValue"),
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-different-service-accs", tb.PipelineRunNamespace("foo"),
 		tb.PipelineRunSpec("test-pipeline",
-			tb.PipelineRunServiceAccountName("test-sa"),
+			tb.PipelineRunServiceAccountName("test-sa-0"),
+			tb.PipelineRunServiceAccountNameTask("hello-world-1", "test-sa-1"),
 		),
-		tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
-			Type:    apis.ConditionSucceeded,
-			Status:  corev1.ConditionUnknown,
-			Reason:  v1beta1.PipelineRunReasonRunning.String(),
-			Message: "Not all Tasks in the Pipeline have finished executing",
-		}), tb.PipelineRunTaskRunsStatus(pipelineRunName+"task-1", &v1beta1.PipelineRunTaskRunStatus{
-			PipelineTaskName: "task-1",
-			Status:           &v1beta1.TaskRunStatus{},
-		}), tb.PipelineRunTaskRunsStatus(pipelineRunName+"task-2", &v1beta1.PipelineRunTaskRunStatus{
-			PipelineTaskName: "task-2",
-			Status:           &v1beta1.TaskRunStatus{},
-			ConditionChecks:  prccs,
-		})),
 	)}
-
-	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
-	trs := []*v1beta1.TaskRun{
-		tb.TaskRun(pipelineRunName+"task-1",
-			tb.TaskRunNamespace("foo"),
-			tb.TaskRunOwnerReference("kind", "name"),
-			tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineLabelKey, "test-pipeline-run-with-conditions"),
-			tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineRunLabelKey, "test-pipeline"),
-			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
-			tb.TaskRunStatus(tb.StatusCondition(apis.Condition{
-				Type:   apis.ConditionSucceeded,
-				Status: corev1.ConditionTrue,
-			}),
-			),
-		),
-		tb.TaskRun(conditionCheckName,
-			tb.TaskRunNamespace("foo"),
-			tb.TaskRunOwnerReference("kind", "name"),
-			tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineLabelKey, "test-pipeline-run-with-conditions"),
-			tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineRunLabelKey, "test-pipeline"),
-			tb.TaskRunLabel(pipeline.GroupName+pipeline.ConditionCheckKey, conditionCheckName),
-			tb.TaskRunLabel(pipeline.GroupName+pipeline.ConditionNameKey, "always-false"),
-			tb.TaskRunSpec(tb.TaskRunTaskSpec()),
-			tb.TaskRunStatus(tb.StatusCondition(apis.Condition{
-				Type:   apis.ConditionSucceeded,
-				Status: corev1.ConditionFalse,
-			}),
-			),
-		),
+	ts := []*v1beta1.Task{
+		tb.Task("hello-world-task", tb.TaskNamespace("foo")),
 	}
 
 	d := test.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
-		TaskRuns:     trs,
-		Conditions:   conditions,
 	}
 	prt := NewPipelineRunTest(d, t)
 	defer prt.Cancel()
 
-	wantEvents := []string{
-		"Normal Started",
-		"Normal Running Tasks Completed: 1 \\(Failed: 0, Cancelled 0\\), Incomplete: 1, Skipped: 1",
-	}
-	_, clients := prt.reconcileRun("foo", pipelineRunName, wantEvents, false)
+	_, clients := prt.reconcileRun("foo", "test-pipeline-run-different-service-accs", []string{}, false)
 
-	actions := clients.Pipeline.Actions()
-	if len(actions) < 2 {
-		t.Fatalf("Expected client to have at least two action implementation but it has %d", len(actions))
-	}
+	taskRunNames := []string{"test-pipeline-run-different-service-accs-hello-world-0-9l9zj", "test-pipeline-run-different-service-accs-hello-world-1-mz4c7"}
 
-	// Check that the expected TaskRun was created
-	actual := actions[1].(ktesting.CreateAction).GetObject().(*v1beta1.TaskRun)
-	if actual == nil {
-		t.Errorf("Expected a ConditionCheck TaskRun to be created, but it wasn't.")
-	}
-	expectedTaskRun := tb.TaskRun("test-pipeline-run-with-conditions-task-3-9l9zj",
-		tb.TaskRunNamespace("foo"),
-		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-with-conditions",
-			tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
-			tb.Controller, tb.BlockOwnerDeletion,
+	expectedTaskRuns := []*v1beta1.TaskRun{
+		tb.TaskRun(taskRunNames[0],
+			tb.TaskRunNamespace("foo"),
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-different-service-accs",
+				tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
+				tb.Controller, tb.BlockOwnerDeletion,
+			),
+			tb.TaskRunSpec(
+				tb.TaskRunTaskRef("hello-world-task"),
+				tb.TaskRunServiceAccountName("test-sa-0"),
+			),
+			tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
+			tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-different-service-accs"),
+			tb.TaskRunLabel("tekton.dev/pipelineTask", "hello-world-0"),
 		),
-		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineLabelKey, "test-pipeline"),
-		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineTaskLabelKey, "task-3"),
-		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineRunLabelKey, "test-pipeline-run-with-conditions"),
-		tb.TaskRunAnnotation("PipelineRunAnnotation", "PipelineRunValue"),
-		tb.TaskRunSpec(
-			tb.TaskRunTaskRef("hello-world"),
-			tb.TaskRunServiceAccountName("test-sa"),
+		tb.TaskRun(taskRunNames[1],
+			tb.TaskRunNamespace("foo"),
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-different-service-accs",
+				tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
+				tb.Controller, tb.BlockOwnerDeletion,
+			),
+			tb.TaskRunSpec(
+				tb.TaskRunTaskRef("hello-world-task"),
+				tb.TaskRunServiceAccountName("test-sa-1"),
+			),
+			tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
+			tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-different-service-accs"),
+			tb.TaskRunLabel("tekton.dev/pipelineTask", "hello-world-1"),
 		),
-	)
-
-	if d := cmp.Diff(actual, expectedTaskRun); d != "" {
-		t.Errorf("expected to see ConditionCheck TaskRun %v created. Diff %s", expectedTaskRun, diff.PrintWantGot(d))
 	}
-}
+	for i := range ps[0].Spec.Tasks {
+		// Check that the expected TaskRun was created
+		actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(taskRunNames[i], metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Expected a TaskRun to be created, but it wasn't: %s", err)
+		}
+		if d := cmp.Diff(actual, expectedTaskRuns[i], ignoreResourceVersion); d != "" {
+			t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRuns[i], diff.PrintWantGot(d))
+		}
 
-func makeExpectedTr(condName, ccName string, labels, annotations map[string]string) *v1beta1.TaskRun {
-	return tb.TaskRun(ccName,
-		tb.TaskRunNamespace("foo"),
-		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run",
-			tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
-			tb.Controller, tb.BlockOwnerDeletion,
-		),
-		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineLabelKey, "test-pipeline"),
-		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineTaskLabelKey, "hello-world-1"),
-		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineRunLabelKey, "test-pipeline-run"),
-		tb.TaskRunLabel(pipeline.GroupName+pipeline.ConditionCheckKey, ccName),
-		tb.TaskRunLabel(pipeline.GroupName+pipeline.ConditionNameKey, condName),
-		tb.TaskRunLabels(labels),
-		tb.TaskRunAnnotation("PipelineRunAnnotation", "PipelineRunValue"),
-		tb.TaskRunAnnotations(annotations),
-		tb.TaskRunSpec(
-			tb.TaskRunTaskSpec(
-				tb.Step("foo", tb.StepName("condition-check-"+condName), tb.StepArgs("bar")),
-			),
-			tb.TaskRunServiceAccountName("test-sa"),
-		),
-	)
+	}
 }
 
-func ensurePVCCreated(t *testing.T, clients test.Clients, name, namespace string) {
-	t.Helper()
-	_, err := clients.Kube.CoreV1().PersistentVolumeClaims(namespace).Get(name, metav1.GetOptions{})
-	if err != nil {
-		t.Errorf("Expected PVC %s to be created for VolumeResource but did not exist", name)
-	}
-	pvcCreated := false
-	for _, a := range clients.Kube.Actions() {
-		if a.GetVerb() == "create" && a.GetResource().Resource == "persistentvolumeclaims" {
-			pvcCreated = true
-		}
-	}
-	if !pvcCreated {
-		t.Errorf("Expected to see volume resource PVC created but didn't")
+// TestReconcileWithTimeoutAndRetry runs "Reconcile" against pipelines with
+// retries and timeout settings, and status that represents different number of
+// retries already performed.  It verifies the reconciled status and events
+// generated
+func TestReconcileWithTimeoutAndRetry(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		retries            int
+		conditionSucceeded corev1.ConditionStatus
+		wantEvents         []string
+	}{{
+		name:               "One try has to be done",
+		retries:            1,
+		conditionSucceeded: corev1.ConditionFalse,
+		wantEvents: []string{
+			"Warning Failed PipelineRun \"test-pipeline-retry-run-with-timeout\" failed to finish within",
+		},
+	}, {
+		name:               "No more retries are needed",
+		retries:            2,
+		conditionSucceeded: corev1.ConditionUnknown,
+		wantEvents: []string{
+			"Warning Failed PipelineRun \"test-pipeline-retry-run-with-timeout\" failed to finish within",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline-retry", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+				tb.PipelineTask("hello-world-1", "hello-world", tb.Retries(tc.retries)),
+			))}
+			prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-retry-run-with-timeout", tb.PipelineRunNamespace("foo"),
+				tb.PipelineRunSpec("test-pipeline-retry",
+					tb.PipelineRunServiceAccountName("test-sa"),
+					tb.PipelineRunTimeout(12*time.Hour),
+				),
+				tb.PipelineRunStatus(
+					tb.PipelineRunStartTime(time.Now().AddDate(0, 0, -1))),
+			)}
+
+			ts := []*v1beta1.Task{
+				tb.Task("hello-world", tb.TaskNamespace("foo")),
+			}
+			trs := []*v1beta1.TaskRun{
+				tb.TaskRun("hello-world-1",
+					tb.TaskRunNamespace("foo"),
+					tb.TaskRunStatus(
+						tb.PodName("my-pod-name"),
+						tb.StatusCondition(apis.Condition{
+							Type:   apis.ConditionSucceeded,
+							Status: corev1.ConditionFalse,
+						}),
+						tb.Retry(v1beta1.TaskRunStatus{
+							Status: duckv1beta1.Status{
+								Conditions: []apis.Condition{{
+									Type:   apis.ConditionSucceeded,
+									Status: corev1.ConditionFalse,
+								}},
+							},
+						}),
+					)),
+			}
+
+			prtrs := &v1beta1.PipelineRunTaskRunStatus{
+				PipelineTaskName: "hello-world-1",
+				Status:           &trs[0].Status,
+			}
+			prs[0].Status.TaskRuns = make(map[string]*v1beta1.PipelineRunTaskRunStatus)
+			prs[0].Status.TaskRuns["hello-world-1"] = prtrs
+
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				TaskRuns:     trs,
+			}
+			prt := NewPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-retry-run-with-timeout", []string{}, false)
+
+			if len(reconciledRun.Status.TaskRuns["hello-world-1"].Status.RetriesStatus) != tc.retries {
+				t.Fatalf(" %d retry expected but %d ", tc.retries, len(reconciledRun.Status.TaskRuns["hello-world-1"].Status.RetriesStatus))
+			}
+
+			if status := reconciledRun.Status.TaskRuns["hello-world-1"].Status.GetCondition(apis.ConditionSucceeded).Status; status != tc.conditionSucceeded {
+				t.Fatalf("Succeeded expected to be %s but is %s", tc.conditionSucceeded, status)
+			}
+		})
 	}
 }
 
-func TestReconcileWithWhenExpressionsWithParameters(t *testing.T) {
+func TestReconcilePropagateAnnotations(t *testing.T) {
 	names.TestingSeed()
-	prName := "test-pipeline-run"
+
 	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
-		tb.PipelineParamSpec("run", v1beta1.ParamTypeString),
-		tb.PipelineTask("hello-world-1", "hello-world-1",
-			tb.PipelineTaskWhenExpression("foo", selection.NotIn, []string{"bar"}),
-			tb.PipelineTaskWhenExpression("$(params.run)", selection.In, []string{"yes"})),
-		tb.PipelineTask("hello-world-2", "hello-world-2",
-			tb.PipelineTaskWhenExpression("$(params.run)", selection.NotIn, []string{"yes"})),
+		tb.PipelineTask("hello-world-1", "hello-world"),
 	))}
-	prs := []*v1beta1.PipelineRun{tb.PipelineRun(prName, tb.PipelineRunNamespace("foo"),
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-annotations", tb.PipelineRunNamespace("foo"),
 		tb.PipelineRunAnnotation("PipelineRunAnnotation", "PipelineRunValue"),
 		tb.PipelineRunSpec("test-pipeline",
 			tb.PipelineRunServiceAccountName("test-sa"),
-			tb.PipelineRunParam("run", "yes"),
 		),
 	)}
-	ts := []*v1beta1.Task{
-		tb.Task("hello-world-1", tb.TaskNamespace("foo")),
-		tb.Task("hello-world-2", tb.TaskNamespace("foo")),
-	}
+	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
 
 	d := test.Data{
 		PipelineRuns: prs,
@@ -2037,80 +2073,1098 @@ func TestReconcileWithWhenExpressionsWithParameters(t *testing.T) {
 	prt := NewPipelineRunTest(d, t)
 	defer prt.Cancel()
 
-	wantEvents := []string{
-		"Normal Started",
-		"Normal Running Tasks Completed: 0 \\(Failed: 0, Cancelled 0\\), Incomplete: 1, Skipped: 1",
+	_, clients := prt.reconcileRun("foo", "test-pipeline-run-with-annotations", []string{}, false)
+
+	actions := clients.Pipeline.Actions()
+	if len(actions) < 2 {
+		t.Fatalf("Expected client to have at least two action implementation but it has %d", len(actions))
 	}
-	pipelineRun, clients := prt.reconcileRun("foo", prName, wantEvents, false)
 
 	// Check that the expected TaskRun was created
-	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(metav1.ListOptions{
-		LabelSelector: "tekton.dev/pipelineTask=hello-world-1,tekton.dev/pipelineRun=test-pipeline-run",
-		Limit:         1,
-	})
-	if err != nil {
-		t.Fatalf("Failure to list TaskRun's %s", err)
-	}
-	if len(actual.Items) != 1 {
-		t.Fatalf("Expected 1 TaskRun got %d", len(actual.Items))
+	actual := actions[1].(ktesting.CreateAction).GetObject().(*v1beta1.TaskRun)
+	if actual == nil {
+		t.Errorf("Expected a TaskRun to be created, but it wasn't.")
 	}
-	expectedTaskRunName := "test-pipeline-run-hello-world-1-9l9zj"
-	expectedTaskRun := tb.TaskRun(expectedTaskRunName,
+	expectedTaskRun := tb.TaskRun("test-pipeline-run-with-annotations-hello-world-1-9l9zj",
 		tb.TaskRunNamespace("foo"),
-		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run",
+		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-with-annotations",
 			tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
 			tb.Controller, tb.BlockOwnerDeletion,
 		),
-		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineLabelKey, "test-pipeline"),
+		tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
 		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineTaskLabelKey, "hello-world-1"),
-		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineRunLabelKey, "test-pipeline-run"),
+		tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-with-annotations"),
 		tb.TaskRunAnnotation("PipelineRunAnnotation", "PipelineRunValue"),
 		tb.TaskRunSpec(
-			tb.TaskRunTaskRef("hello-world-1"),
+			tb.TaskRunTaskRef("hello-world"),
 			tb.TaskRunServiceAccountName("test-sa"),
 		),
 	)
-	actualTaskRun := actual.Items[0]
-	if d := cmp.Diff(&actualTaskRun, expectedTaskRun, ignoreResourceVersion); d != "" {
-		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRunName, diff.PrintWantGot(d))
+
+	if d := cmp.Diff(actual, expectedTaskRun); d != "" {
+		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRun, diff.PrintWantGot(d))
 	}
+}
 
-	actualSkippedTasks := pipelineRun.Status.SkippedTasks
-	expectedSkippedTasks := []v1beta1.SkippedTask{{
-		Name: "hello-world-2",
+func TestGetTaskRunTimeout(t *testing.T) {
+	prName := "pipelinerun-timeouts"
+	ns := "foo"
+	p := "pipeline"
+
+	tcs := []struct {
+		name     string
+		pr       *v1beta1.PipelineRun
+		rprt     *resources.ResolvedPipelineRunTask
+		expected *metav1.Duration
+	}{{
+		name: "nil timeout duration",
+		pr: tb.PipelineRun(prName, tb.PipelineRunNamespace(ns),
+			tb.PipelineRunSpec(p, tb.PipelineRunNilTimeout),
+			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now())),
+		),
+		rprt: &resources.ResolvedPipelineRunTask{
+			PipelineTask: &v1beta1.PipelineTask{
+				Timeout: nil,
+			},
+		},
+		expected: &metav1.Duration{Duration: 60 * time.Minute},
+	}, {
+		name: "timeout specified in pr",
+		pr: tb.PipelineRun(prName, tb.PipelineRunNamespace(ns),
+			tb.PipelineRunSpec(p, tb.PipelineRunTimeout(20*time.Minute)),
+			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now())),
+		),
+		rprt: &resources.ResolvedPipelineRunTask{
+			PipelineTask: &v1beta1.PipelineTask{
+				Timeout: nil,
+			},
+		},
+		expected: &metav1.Duration{Duration: 20 * time.Minute},
+	}, {
+		name: "0 timeout duration",
+		pr: tb.PipelineRun(prName, tb.PipelineRunNamespace(ns),
+			tb.PipelineRunSpec(p, tb.PipelineRunTimeout(0*time.Minute)),
+			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now())),
+		),
+		rprt: &resources.ResolvedPipelineRunTask{
+			PipelineTask: &v1beta1.PipelineTask{
+				Timeout: nil,
+			},
+		},
+		expected: &metav1.Duration{Duration: 0 * time.Minute},
+	}, {
+		name: "taskrun being created after timeout expired",
+		pr: tb.PipelineRun(prName, tb.PipelineRunNamespace(ns),
+			tb.PipelineRunSpec(p, tb.PipelineRunTimeout(1*time.Minute)),
+			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now().Add(-2*time.Minute)))),
+		rprt: &resources.ResolvedPipelineRunTask{
+			PipelineTask: &v1beta1.PipelineTask{
+				Timeout: nil,
+			},
+		},
+		expected: &metav1.Duration{Duration: 1 * time.Second},
+	}, {
+		name: "taskrun being created with timeout for PipelineTask",
+		pr: tb.PipelineRun(prName, tb.PipelineRunNamespace(ns),
+			tb.PipelineRunSpec(p, tb.PipelineRunTimeout(20*time.Minute)),
+			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now())),
+		),
+		rprt: &resources.ResolvedPipelineRunTask{
+			PipelineTask: &v1beta1.PipelineTask{
+				Timeout: &metav1.Duration{Duration: 2 * time.Minute},
+			},
+		},
+		expected: &metav1.Duration{Duration: 2 * time.Minute},
+	}, {
+		name: "0 timeout duration for PipelineRun, PipelineTask timeout still applied",
+		pr: tb.PipelineRun(prName, tb.PipelineRunNamespace(ns),
+			tb.PipelineRunSpec(p, tb.PipelineRunTimeout(0*time.Minute)),
+			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now())),
+		),
+		rprt: &resources.ResolvedPipelineRunTask{
+			PipelineTask: &v1beta1.PipelineTask{
+				Timeout: &metav1.Duration{Duration: 2 * time.Minute},
+			},
+		},
+		expected: &metav1.Duration{Duration: 2 * time.Minute},
 	}}
-	if d := cmp.Diff(actualSkippedTasks, expectedSkippedTasks); d != "" {
-		t.Errorf("expected to find Skipped Tasks %v. Diff %s", expectedSkippedTasks, diff.PrintWantGot(d))
-	}
 
-	skippedTasks := []string{"hello-world-2"}
-	for _, skippedTask := range skippedTasks {
-		labelSelector := fmt.Sprintf("tekton.dev/pipelineTask=%s,tekton.dev/pipelineRun=test-pipeline-run-different-service-accs", skippedTask)
-		actualSkippedTask, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(metav1.ListOptions{
-			LabelSelector: labelSelector,
-			Limit:         1,
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if d := cmp.Diff(getTaskRunTimeout(context.TODO(), tc.pr, tc.rprt), tc.expected); d != "" {
+				t.Errorf("Unexpected task run timeout. Diff %s", diff.PrintWantGot(d))
+			}
 		})
-		if err != nil {
+	}
+}
+
+func TestGetTaskRunTimeout_Timeouts(t *testing.T) {
+	// The TaskRuns of a PipelineRun with spec.timeouts get what remains of the timeout
+	// of their section, so the expected timeouts are approximate.
+	now := time.Now()
+	finallyStarted := metav1.NewTime(now.Add(-5 * time.Minute))
+	tcs := []struct {
+		name             string
+		timeouts         v1beta1.TimeoutFields
+		startTime        time.Time
+		finallyStartTime *metav1.Time
+		pipelineTask     v1beta1.PipelineTask
+		expected         time.Duration
+	}{{
+		name:         "remaining tasks timeout",
+		timeouts:     v1beta1.TimeoutFields{Pipeline: &metav1.Duration{Duration: time.Hour}, Tasks: &metav1.Duration{Duration: 40 * time.Minute}},
+		startTime:    now.Add(-10 * time.Minute),
+		pipelineTask: v1beta1.PipelineTask{Name: "task-1"},
+		expected:     30 * time.Minute,
+	}, {
+		name:         "remaining pipeline timeout",
+		timeouts:     v1beta1.TimeoutFields{Pipeline: &metav1.Duration{Duration: time.Hour}},
+		startTime:    now.Add(-10 * time.Minute),
+		pipelineTask: v1beta1.PipelineTask{Name: "task-1"},
+		expected:     50 * time.Minute,
+	}, {
+		name:         "shorter timeout of the PipelineTask",
+		timeouts:     v1beta1.TimeoutFields{Pipeline: &metav1.Duration{Duration: time.Hour}, Tasks: &metav1.Duration{Duration: 40 * time.Minute}},
+		startTime:    now.Add(-10 * time.Minute),
+		pipelineTask: v1beta1.PipelineTask{Name: "task-1", Timeout: &metav1.Duration{Duration: 5 * time.Minute}},
+		expected:     5 * time.Minute,
+	}, {
+		name:         "tasks timed out",
+		timeouts:     v1beta1.TimeoutFields{Pipeline: &metav1.Duration{Duration: time.Hour}, Tasks: &metav1.Duration{Duration: 10 * time.Minute}},
+		startTime:    now.Add(-20 * time.Minute),
+		pipelineTask: v1beta1.PipelineTask{Name: "task-1"},
+		expected:     time.Second,
+	}, {
+		name:         "finally task starting",
+		timeouts:     v1beta1.TimeoutFields{Pipeline: &metav1.Duration{Duration: time.Hour}, Tasks: &metav1.Duration{Duration: 40 * time.Minute}, Finally: &metav1.Duration{Duration: 15 * time.Minute}},
+		startTime:    now.Add(-30 * time.Minute),
+		pipelineTask: v1beta1.PipelineTask{Name: "final-task-1"},
+		expected:     15 * time.Minute,
+	}, {
+		name:             "remaining finally timeout",
+		timeouts:         v1beta1.TimeoutFields{Pipeline: &metav1.Duration{Duration: time.Hour}, Tasks: &metav1.Duration{Duration: 40 * time.Minute}, Finally: &metav1.Duration{Duration: 15 * time.Minute}},
+		startTime:        now.Add(-30 * time.Minute),
+		finallyStartTime: &finallyStarted,
+		pipelineTask:     v1beta1.PipelineTask{Name: "final-task-1"},
+		expected:         10 * time.Minute,
+	}, {
+		name:         "finally task bounded by the pipeline timeout",
+		timeouts:     v1beta1.TimeoutFields{Pipeline: &metav1.Duration{Duration: time.Hour}, Finally: &metav1.Duration{Duration: 15 * time.Minute}},
+		startTime:    now.Add(-55 * time.Minute),
+		pipelineTask: v1beta1.PipelineTask{Name: "final-task-1"},
+		expected:     5 * time.Minute,
+	}, {
+		name:         "no timeout",
+		timeouts:     v1beta1.TimeoutFields{Pipeline: &metav1.Duration{Duration: 0}},
+		startTime:    now.Add(-10 * time.Minute),
+		pipelineTask: v1beta1.PipelineTask{Name: "task-1"},
+		expected:     0,
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			timeouts := tc.timeouts
+			pr := &v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-timeouts", Namespace: "foo"},
+				Spec:       v1beta1.PipelineRunSpec{Timeouts: &timeouts},
+				Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+					StartTime:        &metav1.Time{Time: tc.startTime},
+					FinallyStartTime: tc.finallyStartTime,
+					PipelineSpec: &v1beta1.PipelineSpec{
+						Tasks:   []v1beta1.PipelineTask{{Name: "task-1"}},
+						Finally: []v1beta1.PipelineTask{{Name: "final-task-1"}},
+					},
+				}},
+			}
+			pipelineTask := tc.pipelineTask
+			got := getTaskRunTimeout(context.Background(), pr, &resources.ResolvedPipelineRunTask{PipelineTask: &pipelineTask})
+			if got.Duration > tc.expected || got.Duration < tc.expected-time.Second {
+				t.Errorf("Expected a TaskRun timeout of about %s, got %s", tc.expected, got.Duration)
+			}
+		})
+	}
+}
+
+// TestReconcileAndPropagateCustomPipelineTaskRunSpec tests that custom PipelineTaskRunSpec declared
+// in PipelineRun is propagated to created TaskRuns
+func TestReconcileAndPropagateCustomPipelineTaskRunSpec(t *testing.T) {
+	names.TestingSeed()
+	prName := "test-pipeline-run"
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+	))}
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun(prName, tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunAnnotation("PipelineRunAnnotation", "PipelineRunValue"),
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunServiceAccountName("test-sa"),
+			tb.PipelineTaskRunSpecs([]v1beta1.PipelineTaskRunSpec{{
+				PipelineTaskName:       "hello-world-1",
+				TaskServiceAccountName: "custom-sa",
+				TaskPodTemplate: &v1beta1.PodTemplate{
+					NodeSelector: map[string]string{
+						"workloadtype": "tekton",
+					},
+				},
+			}}),
+		),
+	)}
+	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	_, clients := prt.reconcileRun("foo", prName, []string{}, false)
+	actions := clients.Pipeline.Actions()
+
+	if len(actions) < 2 {
+		t.Fatalf("Expected client to have at least two action implementation but it has %d", len(actions))
+	}
+
+	// Check that the expected TaskRun was created
+	actual := actions[1].(ktesting.CreateAction).GetObject().(*v1beta1.TaskRun)
+	if actual == nil {
+		t.Errorf("Expected a TaskRun to be created, but it wasn't.")
+	}
+	expectedTaskRun := tb.TaskRun("test-pipeline-run-hello-world-1-9l9zj",
+		tb.TaskRunNamespace("foo"),
+		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run",
+			tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
+			tb.Controller, tb.BlockOwnerDeletion,
+		),
+		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineLabelKey, "test-pipeline"),
+		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineTaskLabelKey, "hello-world-1"),
+		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineRunLabelKey, "test-pipeline-run"),
+		tb.TaskRunAnnotation("PipelineRunAnnotation", "PipelineRunValue"),
+		tb.TaskRunSpec(
+			tb.TaskRunTaskRef("hello-world"),
+			tb.TaskRunServiceAccountName("custom-sa"),
+			tb.TaskRunPodTemplate(&v1beta1.PodTemplate{
+				NodeSelector: map[string]string{
+					"workloadtype": "tekton",
+				},
+			}),
+		),
+	)
+
+	if d := cmp.Diff(actual, expectedTaskRun); d != "" {
+		t.Errorf("expected to see propagated custom ServiceAccountName and PodTemplate in TaskRun %v created. Diff %s", expectedTaskRun, diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcileWithConditionChecks(t *testing.T) {
+	// TestReconcileWithConditionChecks runs "Reconcile" on a PipelineRun that has a task with
+	// multiple conditions. It verifies that reconcile is successful, taskruns are created and
+	// the status is updated. It checks that the correct events are sent.
+	names.TestingSeed()
+	prName := "test-pipeline-run"
+	conditions := []*v1alpha1.Condition{
+		tbv1alpha1.Condition("cond-1",
+			tbv1alpha1.ConditionNamespace("foo"),
+			tbv1alpha1.ConditionLabels(
+				map[string]string{
+					"label-1": "value-1",
+					"label-2": "value-2",
+				}),
+			tbv1alpha1.ConditionAnnotations(
+				map[string]string{
+					"annotation-1": "value-1",
+				}),
+			tbv1alpha1.ConditionSpec(
+				tbv1alpha1.ConditionSpecCheck("", "foo", tb.Args("bar")),
+			)),
+		tbv1alpha1.Condition("cond-2",
+			tbv1alpha1.ConditionNamespace("foo"),
+			tbv1alpha1.ConditionLabels(
+				map[string]string{
+					"label-3": "value-3",
+					"label-4": "value-4",
+				}),
+			tbv1alpha1.ConditionSpec(
+				tbv1alpha1.ConditionSpecCheck("", "foo", tb.Args("bar")),
+			)),
+	}
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world",
+			tb.PipelineTaskCondition("cond-1"),
+			tb.PipelineTaskCondition("cond-2")),
+	))}
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun(prName, tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunAnnotation("PipelineRunAnnotation", "PipelineRunValue"),
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunServiceAccountName("test-sa"),
+		),
+	)}
+	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		Conditions:   conditions,
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 0 \\(Failed: 0, Cancelled 0\\), Incomplete: 1, Skipped: 0",
+	}
+	_, clients := prt.reconcileRun("foo", prName, wantEvents, false)
+
+	ccNameBase := prName + "-hello-world-1-9l9zj"
+	ccNames := map[string]string{
+		"cond-1": ccNameBase + "-cond-1-0-mz4c7",
+		"cond-2": ccNameBase + "-cond-2-1-mssqb",
+	}
+	expectedConditionChecks := make([]*v1beta1.TaskRun, len(conditions))
+	for index, condition := range conditions {
+		expectedConditionChecks[index] = makeExpectedTr(condition.Name, ccNames[condition.Name], condition.Labels, condition.Annotations)
+	}
+
+	actions := clients.Pipeline.Actions()
+	if len(actions) < 3 {
+		t.Fatalf("Expected client to have at least three action implementation but it has %d", len(actions))
+	}
+
+	// Check that the expected TaskRun was created
+	condCheck0 := actions[1].(ktesting.CreateAction).GetObject().(*v1beta1.TaskRun)
+	condCheck1 := actions[2].(ktesting.CreateAction).GetObject().(*v1beta1.TaskRun)
+	if condCheck0 == nil || condCheck1 == nil {
+		t.Errorf("Expected two ConditionCheck TaskRuns to be created, but it wasn't.")
+	}
+
+	actual := []*v1beta1.TaskRun{condCheck0, condCheck1}
+	if d := cmp.Diff(actual, expectedConditionChecks); d != "" {
+		t.Errorf("expected to see 2 ConditionCheck TaskRuns created. Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcileWithFailingConditionChecks(t *testing.T) {
+	// TestReconcileWithFailingConditionChecks runs "Reconcile" on a PipelineRun that has a task with
+	// multiple conditions, some that fails. It verifies that reconcile is successful, taskruns are
+	// created and the status is updated. It checks that the correct events are sent.
+	names.TestingSeed()
+	conditions := []*v1alpha1.Condition{
+		tbv1alpha1.Condition("always-false", tbv1alpha1.ConditionNamespace("foo"), tbv1alpha1.ConditionSpec(
+			tbv1alpha1.ConditionSpecCheck("", "foo", tb.Args("bar")),
+		)),
+	}
+	pipelineRunName := "test-pipeline-run-with-conditions"
+	prccs := make(map[string]*v1beta1.PipelineRunConditionCheckStatus)
+
+	conditionCheckName := pipelineRunName + "task-2-always-false-xxxyyy"
+	prccs[conditionCheckName] = &v1beta1.PipelineRunConditionCheckStatus{
+		ConditionName: "always-false-0",
+		Status:        &v1beta1.ConditionCheckStatus{},
+	}
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("task-1", "hello-world"),
+		tb.PipelineTask("task-2", "hello-world", tb.PipelineTaskCondition("always-false")),
+		tb.PipelineTask("task-3", "hello-world", tb.RunAfter("task-1")),
+	))}
+
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-conditions", tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunAnnotation("PipelineRunAnnotation", "PipelineRunValue"),
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunServiceAccountName("test-sa"),
+		),
+		tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  v1beta1.PipelineRunReasonRunning.String(),
+			Message: "Not all Tasks in the Pipeline have finished executing",
+		}), tb.PipelineRunTaskRunsStatus(pipelineRunName+"task-1", &v1beta1.PipelineRunTaskRunStatus{
+			PipelineTaskName: "task-1",
+			Status:           &v1beta1.TaskRunStatus{},
+		}), tb.PipelineRunTaskRunsStatus(pipelineRunName+"task-2", &v1beta1.PipelineRunTaskRunStatus{
+			PipelineTaskName: "task-2",
+			Status:           &v1beta1.TaskRunStatus{},
+			ConditionChecks:  prccs,
+		})),
+	)}
+
+	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
+	trs := []*v1beta1.TaskRun{
+		tb.TaskRun(pipelineRunName+"task-1",
+			tb.TaskRunNamespace("foo"),
+			tb.TaskRunOwnerReference("kind", "name"),
+			tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineLabelKey, "test-pipeline-run-with-conditions"),
+			tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineRunLabelKey, "test-pipeline"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+			tb.TaskRunStatus(tb.StatusCondition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			}),
+			),
+		),
+		tb.TaskRun(conditionCheckName,
+			tb.TaskRunNamespace("foo"),
+			tb.TaskRunOwnerReference("kind", "name"),
+			tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineLabelKey, "test-pipeline-run-with-conditions"),
+			tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineRunLabelKey, "test-pipeline"),
+			tb.TaskRunLabel(pipeline.GroupName+pipeline.ConditionCheckKey, conditionCheckName),
+			tb.TaskRunLabel(pipeline.GroupName+pipeline.ConditionNameKey, "always-false"),
+			tb.TaskRunSpec(tb.TaskRunTaskSpec()),
+			tb.TaskRunStatus(tb.StatusCondition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+			}),
+			),
+		),
+	}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+		Conditions:   conditions,
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 1 \\(Failed: 0, Cancelled 0\\), Incomplete: 1, Skipped: 1",
+	}
+	_, clients := prt.reconcileRun("foo", pipelineRunName, wantEvents, false)
+
+	actions := clients.Pipeline.Actions()
+	if len(actions) < 2 {
+		t.Fatalf("Expected client to have at least two action implementation but it has %d", len(actions))
+	}
+
+	// Check that the expected TaskRun was created
+	actual := actions[1].(ktesting.CreateAction).GetObject().(*v1beta1.TaskRun)
+	if actual == nil {
+		t.Errorf("Expected a ConditionCheck TaskRun to be created, but it wasn't.")
+	}
+	expectedTaskRun := tb.TaskRun("test-pipeline-run-with-conditions-task-3-9l9zj",
+		tb.TaskRunNamespace("foo"),
+		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-with-conditions",
+			tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
+			tb.Controller, tb.BlockOwnerDeletion,
+		),
+		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineLabelKey, "test-pipeline"),
+		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineTaskLabelKey, "task-3"),
+		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineRunLabelKey, "test-pipeline-run-with-conditions"),
+		tb.TaskRunAnnotation("PipelineRunAnnotation", "PipelineRunValue"),
+		tb.TaskRunSpec(
+			tb.TaskRunTaskRef("hello-world"),
+			tb.TaskRunServiceAccountName("test-sa"),
+		),
+	)
+
+	if d := cmp.Diff(actual, expectedTaskRun); d != "" {
+		t.Errorf("expected to see ConditionCheck TaskRun %v created. Diff %s", expectedTaskRun, diff.PrintWantGot(d))
+	}
+}
+
+func makeExpectedTr(condName, ccName string, labels, annotations map[string]string) *v1beta1.TaskRun {
+	return tb.TaskRun(ccName,
+		tb.TaskRunNamespace("foo"),
+		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run",
+			tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
+			tb.Controller, tb.BlockOwnerDeletion,
+		),
+		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineLabelKey, "test-pipeline"),
+		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineTaskLabelKey, "hello-world-1"),
+		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineRunLabelKey, "test-pipeline-run"),
+		tb.TaskRunLabel(pipeline.GroupName+pipeline.ConditionCheckKey, ccName),
+		tb.TaskRunLabel(pipeline.GroupName+pipeline.ConditionNameKey, condName),
+		tb.TaskRunLabels(labels),
+		tb.TaskRunAnnotation("PipelineRunAnnotation", "PipelineRunValue"),
+		tb.TaskRunAnnotations(annotations),
+		tb.TaskRunSpec(
+			tb.TaskRunTaskSpec(
+				tb.Step("foo", tb.StepName("condition-check-"+condName), tb.StepArgs("bar")),
+			),
+			tb.TaskRunServiceAccountName("test-sa"),
+		),
+	)
+}
+
+func ensurePVCCreated(t *testing.T, clients test.Clients, name, namespace string) {
+	t.Helper()
+	_, err := clients.Kube.CoreV1().PersistentVolumeClaims(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		t.Errorf("Expected PVC %s to be created for VolumeResource but did not exist", name)
+	}
+	pvcCreated := false
+	for _, a := range clients.Kube.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "persistentvolumeclaims" {
+			pvcCreated = true
+		}
+	}
+	if !pvcCreated {
+		t.Errorf("Expected to see volume resource PVC created but didn't")
+	}
+}
+
+func TestReconcileWithWhenExpressionsWithParameters(t *testing.T) {
+	names.TestingSeed()
+	prName := "test-pipeline-run"
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineParamSpec("run", v1beta1.ParamTypeString),
+		tb.PipelineTask("hello-world-1", "hello-world-1",
+			tb.PipelineTaskWhenExpression("foo", selection.NotIn, []string{"bar"}),
+			tb.PipelineTaskWhenExpression("$(params.run)", selection.In, []string{"yes"})),
+		tb.PipelineTask("hello-world-2", "hello-world-2",
+			tb.PipelineTaskWhenExpression("$(params.run)", selection.NotIn, []string{"yes"})),
+	))}
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun(prName, tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunAnnotation("PipelineRunAnnotation", "PipelineRunValue"),
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunServiceAccountName("test-sa"),
+			tb.PipelineRunParam("run", "yes"),
+		),
+	)}
+	ts := []*v1beta1.Task{
+		tb.Task("hello-world-1", tb.TaskNamespace("foo")),
+		tb.Task("hello-world-2", tb.TaskNamespace("foo")),
+	}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 0 \\(Failed: 0, Cancelled 0\\), Incomplete: 1, Skipped: 1",
+	}
+	pipelineRun, clients := prt.reconcileRun("foo", prName, wantEvents, false)
+
+	// Check that the expected TaskRun was created
+	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(metav1.ListOptions{
+		LabelSelector: "tekton.dev/pipelineTask=hello-world-1,tekton.dev/pipelineRun=test-pipeline-run",
+		Limit:         1,
+	})
+	if err != nil {
+		t.Fatalf("Failure to list TaskRun's %s", err)
+	}
+	if len(actual.Items) != 1 {
+		t.Fatalf("Expected 1 TaskRun got %d", len(actual.Items))
+	}
+	expectedTaskRunName := "test-pipeline-run-hello-world-1-9l9zj"
+	expectedTaskRun := tb.TaskRun(expectedTaskRunName,
+		tb.TaskRunNamespace("foo"),
+		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run",
+			tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
+			tb.Controller, tb.BlockOwnerDeletion,
+		),
+		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineLabelKey, "test-pipeline"),
+		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineTaskLabelKey, "hello-world-1"),
+		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineRunLabelKey, "test-pipeline-run"),
+		tb.TaskRunAnnotation("PipelineRunAnnotation", "PipelineRunValue"),
+		tb.TaskRunSpec(
+			tb.TaskRunTaskRef("hello-world-1"),
+			tb.TaskRunServiceAccountName("test-sa"),
+		),
+	)
+	actualTaskRun := actual.Items[0]
+	if d := cmp.Diff(&actualTaskRun, expectedTaskRun, ignoreResourceVersion); d != "" {
+		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRunName, diff.PrintWantGot(d))
+	}
+
+	actualSkippedTasks := pipelineRun.Status.SkippedTasks
+	expectedSkippedTasks := []v1beta1.SkippedTask{{
+		Name: "hello-world-2",
+	}}
+	if d := cmp.Diff(actualSkippedTasks, expectedSkippedTasks); d != "" {
+		t.Errorf("expected to find Skipped Tasks %v. Diff %s", expectedSkippedTasks, diff.PrintWantGot(d))
+	}
+
+	skippedTasks := []string{"hello-world-2"}
+	for _, skippedTask := range skippedTasks {
+		labelSelector := fmt.Sprintf("tekton.dev/pipelineTask=%s,tekton.dev/pipelineRun=test-pipeline-run-different-service-accs", skippedTask)
+		actualSkippedTask, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(metav1.ListOptions{
+			LabelSelector: labelSelector,
+			Limit:         1,
+		})
+		if err != nil {
+			t.Fatalf("Failure to list TaskRun's %s", err)
+		}
+		if len(actualSkippedTask.Items) != 0 {
+			t.Fatalf("Expected 0 TaskRuns got %d", len(actualSkippedTask.Items))
+		}
+	}
+}
+
+func TestReconcileWithWhenExpressionsWithTaskResults(t *testing.T) {
+	names.TestingSeed()
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("a-task", "a-task"),
+		tb.PipelineTask("b-task", "b-task",
+			tb.PipelineTaskWhenExpression("$(tasks.a-task.results.aResult)", selection.In, []string{"aResultValue"}),
+			tb.PipelineTaskWhenExpression("aResultValue", selection.In, []string{"$(tasks.a-task.results.aResult)"}),
+		),
+		tb.PipelineTask("c-task", "c-task",
+			tb.PipelineTaskWhenExpression("$(tasks.a-task.results.aResult)", selection.In, []string{"missing"}),
+		),
+		tb.PipelineTask("d-task", "d-task", tb.RunAfter("c-task")),
+	))}
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-different-service-accs", tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunServiceAccountName("test-sa-0"),
+		),
+	)}
+	ts := []*v1beta1.Task{
+		tb.Task("a-task", tb.TaskNamespace("foo")),
+		tb.Task("b-task", tb.TaskNamespace("foo")),
+		tb.Task("c-task", tb.TaskNamespace("foo")),
+		tb.Task("d-task", tb.TaskNamespace("foo")),
+	}
+	trs := []*v1beta1.TaskRun{
+		tb.TaskRun("test-pipeline-run-different-service-accs-a-task-xxyyy",
+			tb.TaskRunNamespace("foo"),
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-different-service-accs",
+				tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
+				tb.Controller, tb.BlockOwnerDeletion,
+			),
+			tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
+			tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-different-service-accs"),
+			tb.TaskRunLabel("tekton.dev/pipelineTask", "a-task"),
+			tb.TaskRunSpec(
+				tb.TaskRunTaskRef("hello-world"),
+				tb.TaskRunServiceAccountName("test-sa"),
+			),
+			tb.TaskRunStatus(
+				tb.StatusCondition(
+					apis.Condition{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionTrue,
+					},
+				),
+				tb.TaskRunResult("aResult", "aResultValue"),
+			),
+		),
+	}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 1 \\(Failed: 0, Cancelled 0\\), Incomplete: 1, Skipped: 2",
+	}
+	pipelineRun, clients := prt.reconcileRun("foo", "test-pipeline-run-different-service-accs", wantEvents, false)
+
+	expectedTaskRunName := "test-pipeline-run-different-service-accs-b-task-9l9zj"
+	expectedTaskRun := tb.TaskRun(expectedTaskRunName,
+		tb.TaskRunNamespace("foo"),
+		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-different-service-accs",
+			tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
+			tb.Controller, tb.BlockOwnerDeletion,
+		),
+		tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
+		tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-different-service-accs"),
+		tb.TaskRunLabel("tekton.dev/pipelineTask", "b-task"),
+		tb.TaskRunSpec(
+			tb.TaskRunTaskRef("b-task"),
+			tb.TaskRunServiceAccountName("test-sa-0"),
+		),
+	)
+	// Check that the expected TaskRun was created
+	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(metav1.ListOptions{
+		LabelSelector: "tekton.dev/pipelineTask=b-task,tekton.dev/pipelineRun=test-pipeline-run-different-service-accs",
+		Limit:         1,
+	})
+
+	if err != nil {
+		t.Fatalf("Failure to list TaskRun's %s", err)
+	}
+	if len(actual.Items) != 1 {
+		t.Fatalf("Expected 1 TaskRuns got %d", len(actual.Items))
+	}
+	actualTaskRun := actual.Items[0]
+	if d := cmp.Diff(&actualTaskRun, expectedTaskRun, ignoreResourceVersion); d != "" {
+		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRunName, diff.PrintWantGot(d))
+	}
+
+	actualSkippedTasks := pipelineRun.Status.SkippedTasks
+	expectedSkippedTasks := []v1beta1.SkippedTask{{
+		Name: "c-task",
+	}, {
+		Name: "d-task",
+	}}
+	if d := cmp.Diff(actualSkippedTasks, expectedSkippedTasks); d != "" {
+		t.Errorf("expected to find Skipped Tasks %v. Diff %s", expectedSkippedTasks, diff.PrintWantGot(d))
+	}
+
+	skippedTasks := []string{"c-task", "d-task"}
+	for _, skippedTask := range skippedTasks {
+		labelSelector := fmt.Sprintf("tekton.dev/pipelineTask=%s,tekton.dev/pipelineRun=test-pipeline-run-different-service-accs", skippedTask)
+		actualSkippedTask, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(metav1.ListOptions{
+			LabelSelector: labelSelector,
+			Limit:         1,
+		})
+		if err != nil {
 			t.Fatalf("Failure to list TaskRun's %s", err)
 		}
-		if len(actualSkippedTask.Items) != 0 {
-			t.Fatalf("Expected 0 TaskRuns got %d", len(actualSkippedTask.Items))
+		if len(actualSkippedTask.Items) != 0 {
+			t.Fatalf("Expected 0 TaskRuns got %d", len(actualSkippedTask.Items))
+		}
+	}
+}
+
+// TestReconcileWithAffinityAssistantStatefulSet tests that given a pipelineRun with workspaces,
+// an Affinity Assistant StatefulSet is created for each PVC workspace and
+// that the Affinity Assistant names is propagated to TaskRuns.
+func TestReconcileWithAffinityAssistantStatefulSet(t *testing.T) {
+	workspaceName := "ws1"
+	workspaceName2 := "ws2"
+	emptyDirWorkspace := "emptyDirWorkspace"
+	pipelineRunName := "test-pipeline-run"
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world", tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", workspaceName, "")),
+		tb.PipelineTask("hello-world-2", "hello-world", tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", workspaceName2, "")),
+		tb.PipelineTask("hello-world-3", "hello-world", tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", emptyDirWorkspace, "")),
+		tb.PipelineWorkspaceDeclaration(workspaceName, workspaceName2, emptyDirWorkspace),
+	))}
+
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun(pipelineRunName, tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunWorkspaceBindingVolumeClaimTemplate(workspaceName, "myclaim", ""),
+			tb.PipelineRunWorkspaceBindingVolumeClaimTemplate(workspaceName2, "myclaim2", ""),
+			tb.PipelineRunWorkspaceBindingEmptyDir(emptyDirWorkspace),
+		)),
+	}
+	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", pipelineRunName, []string{}, false)
+
+	// Check that the expected StatefulSet was created
+	stsNames := make([]string, 0)
+	for _, a := range clients.Kube.Actions() {
+		if ca, ok := a.(ktesting.CreateAction); ok {
+			obj := ca.GetObject()
+			if sts, ok := obj.(*appsv1.StatefulSet); ok {
+				stsNames = append(stsNames, sts.Name)
+			}
+		}
+	}
+
+	if len(stsNames) != 2 {
+		t.Fatalf("expected one StatefulSet created. %d was created", len(stsNames))
+	}
+
+	expectedAffinityAssistantName1 := getAffinityAssistantName(workspaceName, pipelineRunName)
+	expectedAffinityAssistantName2 := getAffinityAssistantName(workspaceName2, pipelineRunName)
+	expectedAffinityAssistantStsNames := make(map[string]bool)
+	expectedAffinityAssistantStsNames[expectedAffinityAssistantName1] = true
+	expectedAffinityAssistantStsNames[expectedAffinityAssistantName2] = true
+	for _, stsName := range stsNames {
+		_, found := expectedAffinityAssistantStsNames[stsName]
+		if !found {
+			t.Errorf("unexpected StatefulSet created, named %s", stsName)
+		}
+	}
+
+	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+	}
+
+	if len(taskRuns.Items) != 3 {
+		t.Errorf("expected two TaskRuns created. %d was created", len(taskRuns.Items))
+	}
+
+	taskRunsWithPropagatedAffinityAssistantName := 0
+	for _, tr := range taskRuns.Items {
+		for _, ws := range tr.Spec.Workspaces {
+			propagatedAffinityAssistantName := tr.Annotations["pipeline.tekton.dev/affinity-assistant"]
+			if ws.PersistentVolumeClaim != nil {
+
+				if propagatedAffinityAssistantName != expectedAffinityAssistantName1 && propagatedAffinityAssistantName != expectedAffinityAssistantName2 {
+					t.Fatalf("found taskRun with PVC workspace, but with unexpected AffinityAssistantAnnotation value; expected %s or %s, got %s", expectedAffinityAssistantName1, expectedAffinityAssistantName2, propagatedAffinityAssistantName)
+				}
+				taskRunsWithPropagatedAffinityAssistantName++
+			}
+
+			if ws.PersistentVolumeClaim == nil {
+				if propagatedAffinityAssistantName != "" {
+					t.Fatalf("found taskRun workspace that is not PVC workspace, but with unexpected AffinityAssistantAnnotation; expected NO AffinityAssistantAnnotation, got %s", propagatedAffinityAssistantName)
+				}
+			}
+		}
+	}
+
+	if taskRunsWithPropagatedAffinityAssistantName != 2 {
+		t.Errorf("expected only one of two TaskRuns to have Affinity Assistant affinity. %d was detected", taskRunsWithPropagatedAffinityAssistantName)
+	}
+
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to be running, but condition status is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+}
+
+// TestReconcileWithVolumeClaimTemplateWorkspace tests that given a pipeline with volumeClaimTemplate workspace,
+// a PVC is created and that the workspace appears as a PersistentVolumeClaim workspace for TaskRuns.
+func TestReconcileWithVolumeClaimTemplateWorkspace(t *testing.T) {
+	workspaceName := "ws1"
+	claimName := "myclaim"
+	pipelineRunName := "test-pipeline-run"
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world", tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", workspaceName, "")),
+		tb.PipelineTask("hello-world-2", "hello-world"),
+		tb.PipelineWorkspaceDeclaration(workspaceName),
+	))}
+
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun(pipelineRunName, tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunWorkspaceBindingVolumeClaimTemplate(workspaceName, claimName, ""))),
+	}
+	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", pipelineRunName, []string{}, false)
+
+	// Check that the expected PVC was created
+	pvcNames := make([]string, 0)
+	for _, a := range clients.Kube.Actions() {
+		if ca, ok := a.(ktesting.CreateAction); ok {
+			obj := ca.GetObject()
+			if pvc, ok := obj.(*corev1.PersistentVolumeClaim); ok {
+				pvcNames = append(pvcNames, pvc.Name)
+			}
+		}
+	}
+
+	if len(pvcNames) != 1 {
+		t.Errorf("expected one PVC created. %d was created", len(pvcNames))
+	}
+
+	expectedPVCName := fmt.Sprintf("%s-%s", claimName, "cab465d09a")
+	if pvcNames[0] != expectedPVCName {
+		t.Errorf("expected the created PVC to be named %s. It was named %s", expectedPVCName, pvcNames[0])
+	}
+
+	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+	}
+
+	for _, tr := range taskRuns.Items {
+		for _, ws := range tr.Spec.Workspaces {
+			if ws.VolumeClaimTemplate != nil {
+				t.Fatalf("found volumeClaimTemplate workspace. Did not expect to find any taskruns with volumeClaimTemplate workspaces")
+			}
+
+			if ws.PersistentVolumeClaim == nil {
+				t.Fatalf("found taskRun workspace that is not PersistentVolumeClaim workspace. Did only expect PersistentVolumeClaims workspaces")
+			}
+		}
+	}
+
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to be running, but condition status is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+}
+
+// TestReconcileWithVolumeClaimTemplateWorkspaceUsingSubPaths tests that given a pipeline with volumeClaimTemplate workspace and
+// multiple instances of the same task, but using different subPaths in the volume - is seen as taskRuns with expected subPaths.
+func TestReconcileWithVolumeClaimTemplateWorkspaceUsingSubPaths(t *testing.T) {
+	workspaceName := "ws1"
+	workspaceNameWithSubPath := "ws2"
+	subPath1 := "customdirectory"
+	subPath2 := "otherdirecory"
+	pipelineRunWsSubPath := "mypath"
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world", tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", workspaceName, subPath1)),
+		tb.PipelineTask("hello-world-2", "hello-world", tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", workspaceName, subPath2)),
+		tb.PipelineTask("hello-world-3", "hello-world", tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", workspaceName, "")),
+		tb.PipelineTask("hello-world-4", "hello-world", tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", workspaceNameWithSubPath, "")),
+		tb.PipelineTask("hello-world-5", "hello-world", tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", workspaceNameWithSubPath, subPath1)),
+		tb.PipelineWorkspaceDeclaration(workspaceName, workspaceNameWithSubPath),
+	))}
+
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run", tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunWorkspaceBindingVolumeClaimTemplate(workspaceName, "myclaim", ""),
+			tb.PipelineRunWorkspaceBindingVolumeClaimTemplate(workspaceNameWithSubPath, "myclaim", pipelineRunWsSubPath))),
+	}
+	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", []string{}, false)
+
+	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+	}
+
+	if len(taskRuns.Items) != 5 {
+		t.Fatalf("unexpected number of taskRuns found, expected 2, but found %d", len(taskRuns.Items))
+	}
+
+	hasSeenWorkspaceWithPipelineTaskSubPath1 := false
+	hasSeenWorkspaceWithPipelineTaskSubPath2 := false
+	hasSeenWorkspaceWithEmptyPipelineTaskSubPath := false
+	hasSeenWorkspaceWithRunSubPathAndEmptyPipelineTaskSubPath := false
+	hasSeenWorkspaceWithRunSubPathAndPipelineTaskSubPath1 := false
+	for _, tr := range taskRuns.Items {
+		for _, ws := range tr.Spec.Workspaces {
+
+			if ws.PersistentVolumeClaim == nil {
+				t.Fatalf("found taskRun workspace that is not PersistentVolumeClaim workspace. Did only expect PersistentVolumeClaims workspaces")
+			}
+
+			if ws.SubPath == subPath1 {
+				hasSeenWorkspaceWithPipelineTaskSubPath1 = true
+			}
+
+			if ws.SubPath == subPath2 {
+				hasSeenWorkspaceWithPipelineTaskSubPath2 = true
+			}
+
+			if ws.SubPath == "" {
+				hasSeenWorkspaceWithEmptyPipelineTaskSubPath = true
+			}
+
+			if ws.SubPath == pipelineRunWsSubPath {
+				hasSeenWorkspaceWithRunSubPathAndEmptyPipelineTaskSubPath = true
+			}
+
+			if ws.SubPath == fmt.Sprintf("%s/%s", pipelineRunWsSubPath, subPath1) {
+				hasSeenWorkspaceWithRunSubPathAndPipelineTaskSubPath1 = true
+			}
 		}
 	}
+
+	if !hasSeenWorkspaceWithPipelineTaskSubPath1 {
+		t.Fatalf("did not see a taskRun with a workspace using pipelineTask subPath1")
+	}
+
+	if !hasSeenWorkspaceWithPipelineTaskSubPath2 {
+		t.Fatalf("did not see a taskRun with a workspace using pipelineTask subPath2")
+	}
+
+	if !hasSeenWorkspaceWithEmptyPipelineTaskSubPath {
+		t.Fatalf("did not see a taskRun with a workspace using empty pipelineTask subPath")
+	}
+
+	if !hasSeenWorkspaceWithRunSubPathAndEmptyPipelineTaskSubPath {
+		t.Fatalf("did not see a taskRun with workspace using empty pipelineTask subPath and a subPath from pipelineRun")
+	}
+
+	if !hasSeenWorkspaceWithRunSubPathAndPipelineTaskSubPath1 {
+		t.Fatalf("did not see a taskRun with workspace using pipelineTaks subPath1 and a subPath from pipelineRun")
+	}
+
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to be running, but condition status is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+}
+
+func TestReconcileWithPipelineBundle(t *testing.T) {
+	names.TestingSeed()
+
+	// Set up a fake registry to push an image to.
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ref := u.Host + "/pipelines/test-pipeline:latest"
+	ps := tb.Pipeline("test-pipeline", tb.PipelineType(), tb.PipelineSpec(
+		tb.PipelineTask("unit-test-1", "unit-test-task", func(pt *v1beta1.PipelineTask) {
+			pt.TaskRef.Bundle = ref
+		}),
+	))
+	ts := tb.Task("unit-test-task", tb.TaskType(), tb.TaskSpec(
+		tb.Step("foo", tb.StepName("simple-step"), tb.StepCommand("/mycmd")),
+	))
+	if _, err := test.CreateImage(ref, ps, ts); err != nil {
+		t.Fatalf("failed to upload test image: %v", err)
+	}
+
+	prs := []*v1beta1.PipelineRun{{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run-bundle", Namespace: "foo"},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "test-pipeline", Bundle: ref},
+		},
+	}}
+	d := test.Data{
+		PipelineRuns: prs,
+		ServiceAccounts: []*corev1.ServiceAccount{{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+		}},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.GetNamespace()},
+			Data: map[string]string{
+				"enable-tekton-oci-bundles": "true",
+			},
+		}},
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-bundle", []string{}, false)
+
+	if d := cmp.Diff(&ps.Spec, reconciledRun.Status.PipelineSpec); d != "" {
+		t.Errorf("PipelineSpec stored on the PipelineRun doesn't match the bundle %s", diff.PrintWantGot(d))
+	}
+
+	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(metav1.ListOptions{
+		LabelSelector: "tekton.dev/pipelineTask=unit-test-1,tekton.dev/pipelineRun=test-pipeline-run-bundle",
+		Limit:         1,
+	})
+	if err != nil {
+		t.Fatalf("Failure to list TaskRun's %s", err)
+	}
+	if len(actual.Items) != 1 {
+		t.Fatalf("Expected 1 TaskRun got %d", len(actual.Items))
+	}
+	if got := actual.Items[0].Spec.TaskRef; got == nil || got.Name != "unit-test-task" || got.Bundle != ref {
+		t.Errorf("Expected TaskRun to reference unit-test-task in bundle %s, got %v", ref, got)
+	}
 }
 
-func TestReconcileWithWhenExpressionsWithTaskResults(t *testing.T) {
+func TestReconcileWithTaskResults(t *testing.T) {
 	names.TestingSeed()
 	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
 		tb.PipelineTask("a-task", "a-task"),
 		tb.PipelineTask("b-task", "b-task",
-			tb.PipelineTaskWhenExpression("$(tasks.a-task.results.aResult)", selection.In, []string{"aResultValue"}),
-			tb.PipelineTaskWhenExpression("aResultValue", selection.In, []string{"$(tasks.a-task.results.aResult)"}),
-		),
-		tb.PipelineTask("c-task", "c-task",
-			tb.PipelineTaskWhenExpression("$(tasks.a-task.results.aResult)", selection.In, []string{"missing"}),
+			tb.PipelineTaskParam("bParam", "$(tasks.a-task.results.aResult)"),
 		),
-		tb.PipelineTask("d-task", "d-task", tb.RunAfter("c-task")),
 	))}
 	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-different-service-accs", tb.PipelineRunNamespace("foo"),
 		tb.PipelineRunSpec("test-pipeline",
@@ -2119,9 +3173,11 @@ func TestReconcileWithWhenExpressionsWithTaskResults(t *testing.T) {
 	)}
 	ts := []*v1beta1.Task{
 		tb.Task("a-task", tb.TaskNamespace("foo")),
-		tb.Task("b-task", tb.TaskNamespace("foo")),
-		tb.Task("c-task", tb.TaskNamespace("foo")),
-		tb.Task("d-task", tb.TaskNamespace("foo")),
+		tb.Task("b-task", tb.TaskNamespace("foo"),
+			tb.TaskSpec(
+				tb.TaskParam("bParam", v1beta1.ParamTypeString),
+			),
+		),
 	}
 	trs := []*v1beta1.TaskRun{
 		tb.TaskRun("test-pipeline-run-different-service-accs-a-task-xxyyy",
@@ -2158,11 +3214,7 @@ func TestReconcileWithWhenExpressionsWithTaskResults(t *testing.T) {
 	prt := NewPipelineRunTest(d, t)
 	defer prt.Cancel()
 
-	wantEvents := []string{
-		"Normal Started",
-		"Normal Running Tasks Completed: 1 \\(Failed: 0, Cancelled 0\\), Incomplete: 1, Skipped: 2",
-	}
-	pipelineRun, clients := prt.reconcileRun("foo", "test-pipeline-run-different-service-accs", wantEvents, false)
+	_, clients := prt.reconcileRun("foo", "test-pipeline-run-different-service-accs", []string{}, false)
 
 	expectedTaskRunName := "test-pipeline-run-different-service-accs-b-task-9l9zj"
 	expectedTaskRun := tb.TaskRun(expectedTaskRunName,
@@ -2177,6 +3229,7 @@ func TestReconcileWithWhenExpressionsWithTaskResults(t *testing.T) {
 		tb.TaskRunSpec(
 			tb.TaskRunTaskRef("b-task"),
 			tb.TaskRunServiceAccountName("test-sa-0"),
+			tb.TaskRunParam("bParam", "aResultValue"),
 		),
 	)
 	// Check that the expected TaskRun was created
@@ -2195,383 +3248,696 @@ func TestReconcileWithWhenExpressionsWithTaskResults(t *testing.T) {
 	if d := cmp.Diff(&actualTaskRun, expectedTaskRun, ignoreResourceVersion); d != "" {
 		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRunName, diff.PrintWantGot(d))
 	}
+}
 
-	actualSkippedTasks := pipelineRun.Status.SkippedTasks
-	expectedSkippedTasks := []v1beta1.SkippedTask{{
-		Name: "c-task",
-	}, {
-		Name: "d-task",
+func TestReconcileWithTaskResultNotAllowedByTask(t *testing.T) {
+	names.TestingSeed()
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("a-task", "a-task"),
+		tb.PipelineTask("b-task", "b-task",
+			tb.PipelineTaskParam("env", "$(tasks.a-task.results.env)"),
+		),
+	))}
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run", tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline"),
+	)}
+	bTask := tb.Task("b-task", tb.TaskNamespace("foo"))
+	bTask.Spec.Params = []v1beta1.ParamSpec{{
+		Name: "env",
+		Type: v1beta1.ParamTypeString,
+		Enum: []string{"dev", "prod"},
 	}}
-	if d := cmp.Diff(actualSkippedTasks, expectedSkippedTasks); d != "" {
-		t.Errorf("expected to find Skipped Tasks %v. Diff %s", expectedSkippedTasks, diff.PrintWantGot(d))
+	ts := []*v1beta1.Task{tb.Task("a-task", tb.TaskNamespace("foo")), bTask}
+	trs := []*v1beta1.TaskRun{
+		tb.TaskRun("test-pipeline-run-a-task",
+			tb.TaskRunNamespace("foo"),
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run",
+				tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
+				tb.Controller, tb.BlockOwnerDeletion,
+			),
+			tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
+			tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run"),
+			tb.TaskRunLabel("tekton.dev/pipelineTask", "a-task"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("a-task")),
+			tb.TaskRunStatus(
+				tb.StatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}),
+				tb.TaskRunResult("env", "prdo"),
+			),
+		),
 	}
 
-	skippedTasks := []string{"c-task", "d-task"}
-	for _, skippedTask := range skippedTasks {
-		labelSelector := fmt.Sprintf("tekton.dev/pipelineTask=%s,tekton.dev/pipelineRun=test-pipeline-run-different-service-accs", skippedTask)
-		actualSkippedTask, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(metav1.ListOptions{
-			LabelSelector: labelSelector,
-			Limit:         1,
-		})
-		if err != nil {
-			t.Fatalf("Failure to list TaskRun's %s", err)
-		}
-		if len(actualSkippedTask.Items) != 0 {
-			t.Fatalf("Expected 0 TaskRuns got %d", len(actualSkippedTask.Items))
-		}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
 	}
-}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
 
-// TestReconcileWithAffinityAssistantStatefulSet tests that given a pipelineRun with workspaces,
-// an Affinity Assistant StatefulSet is created for each PVC workspace and
-// that the Affinity Assistant names is propagated to TaskRuns.
-func TestReconcileWithAffinityAssistantStatefulSet(t *testing.T) {
-	workspaceName := "ws1"
-	workspaceName2 := "ws2"
-	emptyDirWorkspace := "emptyDirWorkspace"
-	pipelineRunName := "test-pipeline-run"
-	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
-		tb.PipelineTask("hello-world-1", "hello-world", tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", workspaceName, "")),
-		tb.PipelineTask("hello-world-2", "hello-world", tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", workspaceName2, "")),
-		tb.PipelineTask("hello-world-3", "hello-world", tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", emptyDirWorkspace, "")),
-		tb.PipelineWorkspaceDeclaration(workspaceName, workspaceName2, emptyDirWorkspace),
-	))}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", []string{}, true)
 
-	prs := []*v1beta1.PipelineRun{tb.PipelineRun(pipelineRunName, tb.PipelineRunNamespace("foo"),
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Status != corev1.ConditionFalse {
+		t.Errorf("Expected PipelineRun status to be failed, but was %v", condition)
+	}
+	if condition != nil && condition.Reason != ReasonInvalidParamValue {
+		t.Errorf("Expected failure to be because of reason %q but was %s", ReasonInvalidParamValue, condition.Reason)
+	}
+	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(metav1.ListOptions{
+		LabelSelector: "tekton.dev/pipelineTask=b-task,tekton.dev/pipelineRun=test-pipeline-run",
+	})
+	if err != nil {
+		t.Fatalf("Failure to list TaskRun's %s", err)
+	}
+	if len(actual.Items) != 0 {
+		t.Errorf("Expected no TaskRun to be created for b-task but got %d", len(actual.Items))
+	}
+}
+
+func TestReconcileWithTaskResultsEmbeddedNoneStarted(t *testing.T) {
+	names.TestingSeed()
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-different-service-accs", tb.PipelineRunNamespace("foo"),
 		tb.PipelineRunSpec("test-pipeline",
-			tb.PipelineRunWorkspaceBindingVolumeClaimTemplate(workspaceName, "myclaim", ""),
-			tb.PipelineRunWorkspaceBindingVolumeClaimTemplate(workspaceName2, "myclaim2", ""),
-			tb.PipelineRunWorkspaceBindingEmptyDir(emptyDirWorkspace),
-		)),
+			tb.PipelineRunPipelineSpec(
+				tb.PipelineParamSpec("foo", v1beta1.ParamTypeString),
+				tb.PipelineTask("a-task", "a-task"),
+				tb.PipelineTask("b-task", "b-task",
+					tb.PipelineTaskParam("bParam", "$(params.foo)/baz@$(tasks.a-task.results.A_RESULT)"),
+				),
+			),
+			tb.PipelineRunParam("foo", "bar"),
+			tb.PipelineRunServiceAccountName("test-sa-0"),
+		),
+	)}
+	ts := []*v1beta1.Task{
+		tb.Task("a-task", tb.TaskNamespace("foo"),
+			tb.TaskSpec(
+				tb.TaskResults("A_RESULT", ""),
+			),
+		),
+		tb.Task("b-task", tb.TaskNamespace("foo"),
+			tb.TaskSpec(
+				tb.TaskParam("bParam", v1beta1.ParamTypeString),
+			),
+		),
 	}
-	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
 
 	d := test.Data{
 		PipelineRuns: prs,
-		Pipelines:    ps,
 		Tasks:        ts,
 	}
 	prt := NewPipelineRunTest(d, t)
 	defer prt.Cancel()
 
-	reconciledRun, clients := prt.reconcileRun("foo", pipelineRunName, []string{}, false)
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-different-service-accs", []string{}, false)
 
-	// Check that the expected StatefulSet was created
-	stsNames := make([]string, 0)
-	for _, a := range clients.Kube.Actions() {
-		if ca, ok := a.(ktesting.CreateAction); ok {
-			obj := ca.GetObject()
-			if sts, ok := obj.(*appsv1.StatefulSet); ok {
-				stsNames = append(stsNames, sts.Name)
-			}
-		}
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to be running, but condition status is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
 	}
 
-	if len(stsNames) != 2 {
-		t.Fatalf("expected one StatefulSet created. %d was created", len(stsNames))
+	// Since b-task is dependent on a-task, via the results, only a-task should run
+	expectedTaskRunName := "test-pipeline-run-different-service-accs-a-task-9l9zj"
+	expectedTaskRun := tb.TaskRun(expectedTaskRunName,
+		tb.TaskRunNamespace("foo"),
+		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-different-service-accs",
+			tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
+			tb.Controller, tb.BlockOwnerDeletion,
+		),
+		tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline-run-different-service-accs"),
+		tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-different-service-accs"),
+		tb.TaskRunLabel("tekton.dev/pipelineTask", "a-task"),
+		tb.TaskRunSpec(
+			tb.TaskRunTaskRef("a-task", tb.TaskRefKind(v1beta1.NamespacedTaskKind)),
+			tb.TaskRunServiceAccountName("test-sa-0"),
+		),
+	)
+	// Check that the expected TaskRun was created (only)
+	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failure to list TaskRun's %s", err)
+	}
+	if len(actual.Items) != 1 {
+		t.Fatalf("Expected 1 TaskRuns got %d", len(actual.Items))
+	}
+	actualTaskRun := actual.Items[0]
+	if d := cmp.Diff(expectedTaskRun, &actualTaskRun, ignoreResourceVersion); d != "" {
+		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRun, diff.PrintWantGot(d))
 	}
+}
 
-	expectedAffinityAssistantName1 := getAffinityAssistantName(workspaceName, pipelineRunName)
-	expectedAffinityAssistantName2 := getAffinityAssistantName(workspaceName2, pipelineRunName)
-	expectedAffinityAssistantStsNames := make(map[string]bool)
-	expectedAffinityAssistantStsNames[expectedAffinityAssistantName1] = true
-	expectedAffinityAssistantStsNames[expectedAffinityAssistantName2] = true
-	for _, stsName := range stsNames {
-		_, found := expectedAffinityAssistantStsNames[stsName]
-		if !found {
-			t.Errorf("unexpected StatefulSet created, named %s", stsName)
-		}
+// customTasksEnabled returns a feature flags ConfigMap with Custom Tasks enabled.
+func customTasksEnabled() []*corev1.ConfigMap {
+	return []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.GetNamespace()},
+		Data:       map[string]string{"enable-custom-tasks": "true"},
+	}}
+}
+
+func TestReconcile_CustomTask(t *testing.T) {
+	names.TestingSeed()
+	prName := "test-pipelinerun"
+	prs := []*v1beta1.PipelineRun{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      prName,
+			Namespace: "foo",
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Tasks: []v1beta1.PipelineTask{{
+					Name: "custom-task",
+					Params: []v1beta1.Param{{
+						Name:  "param1",
+						Value: *v1beta1.NewArrayOrString("value1"),
+					}},
+					TaskRef: &v1beta1.TaskRef{
+						APIVersion: "example.dev/v0",
+						Kind:       "Example",
+					},
+				}},
+			},
+		},
+	}}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		ConfigMaps:   customTasksEnabled(),
 	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
 
-	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(metav1.ListOptions{})
-	if err != nil {
-		t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 0",
 	}
+	reconciledRun, clients := prt.reconcileRun("foo", prName, wantEvents, false)
 
-	if len(taskRuns.Items) != 3 {
-		t.Errorf("expected two TaskRuns created. %d was created", len(taskRuns.Items))
+	actions := clients.Pipeline.Actions()
+	if len(actions) < 2 {
+		t.Fatalf("Expected client to have at least two action implementation but it has %d", len(actions))
 	}
 
-	taskRunsWithPropagatedAffinityAssistantName := 0
-	for _, tr := range taskRuns.Items {
-		for _, ws := range tr.Spec.Workspaces {
-			propagatedAffinityAssistantName := tr.Annotations["pipeline.tekton.dev/affinity-assistant"]
-			if ws.PersistentVolumeClaim != nil {
+	// Check that the expected Run was created.
+	actual := actions[0].(ktesting.CreateAction).GetObject()
+	wantRunName := "test-pipelinerun-custom-task-9l9zj"
+	trueb := true
+	expectedRun := &v1alpha1.Run{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      wantRunName,
+			Namespace: "foo",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         "tekton.dev/v1beta1",
+				Kind:               "PipelineRun",
+				Name:               prName,
+				Controller:         &trueb,
+				BlockOwnerDeletion: &trueb,
+			}},
+			Labels: map[string]string{
+				"tekton.dev/pipeline":     prName,
+				"tekton.dev/pipelineRun":  prName,
+				"tekton.dev/pipelineTask": "custom-task",
+			},
+			Annotations: map[string]string{},
+		},
+		Spec: v1alpha1.RunSpec{
+			Params: []v1beta1.Param{{
+				Name:  "param1",
+				Value: *v1beta1.NewArrayOrString("value1"),
+			}},
+			Ref: &v1beta1.TaskRef{
+				APIVersion: "example.dev/v0",
+				Kind:       "Example",
+			},
+			Timeout: &metav1.Duration{Duration: config.DefaultTimeoutMinutes * time.Minute},
+		},
+	}
+	if d := cmp.Diff(expectedRun, actual); d != "" {
+		t.Errorf("expected to see Run created: %s", diff.PrintWantGot(d))
+	}
 
-				if propagatedAffinityAssistantName != expectedAffinityAssistantName1 && propagatedAffinityAssistantName != expectedAffinityAssistantName2 {
-					t.Fatalf("found taskRun with PVC workspace, but with unexpected AffinityAssistantAnnotation value; expected %s or %s, got %s", expectedAffinityAssistantName1, expectedAffinityAssistantName2, propagatedAffinityAssistantName)
-				}
-				taskRunsWithPropagatedAffinityAssistantName++
-			}
+	// This PipelineRun is in progress now and the status should reflect that
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Status != corev1.ConditionUnknown {
+		t.Errorf("Expected PipelineRun status to be in progress, but was %v", condition)
+	}
+	if condition != nil && condition.Reason != v1beta1.PipelineRunReasonRunning.String() {
+		t.Errorf("Expected reason %q but was %s", v1beta1.PipelineRunReasonRunning.String(), condition.Reason)
+	}
 
-			if ws.PersistentVolumeClaim == nil {
-				if propagatedAffinityAssistantName != "" {
-					t.Fatalf("found taskRun workspace that is not PVC workspace, but with unexpected AffinityAssistantAnnotation; expected NO AffinityAssistantAnnotation, got %s", propagatedAffinityAssistantName)
-				}
-			}
-		}
+	if len(reconciledRun.Status.Runs) != 1 {
+		t.Errorf("Expected PipelineRun status to include one Run status, got %d", len(reconciledRun.Status.Runs))
+	}
+	if _, exists := reconciledRun.Status.Runs[wantRunName]; !exists {
+		t.Errorf("Expected PipelineRun status to include Run status but was %v", reconciledRun.Status.Runs)
 	}
+}
+
+func TestReconcile_CustomTaskDisabled(t *testing.T) {
+	prName := "test-pipelinerun"
+	prs := []*v1beta1.PipelineRun{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      prName,
+			Namespace: "foo",
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Tasks: []v1beta1.PipelineTask{{
+					Name: "custom-task",
+					TaskRef: &v1beta1.TaskRef{
+						APIVersion: "example.dev/v0",
+						Kind:       "Example",
+					},
+				}},
+			},
+		},
+	}}
 
-	if taskRunsWithPropagatedAffinityAssistantName != 2 {
-		t.Errorf("expected only one of two TaskRuns to have Affinity Assistant affinity. %d was detected", taskRunsWithPropagatedAffinityAssistantName)
+	d := test.Data{
+		PipelineRuns: prs,
 	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
 
-	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
-		t.Errorf("Expected PipelineRun to be running, but condition status is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	reconciledRun, clients := prt.reconcileRun("foo", prName, []string{}, true)
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != ReasonFailedValidation {
+		t.Errorf("Expected PipelineRun to fail validation, but condition was %v", condition)
+	}
+	runs, err := clients.Pipeline.TektonV1alpha1().Runs("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs.Items) != 0 {
+		t.Errorf("Expected no Run to be created, got %d", len(runs.Items))
 	}
 }
 
-// TestReconcileWithVolumeClaimTemplateWorkspace tests that given a pipeline with volumeClaimTemplate workspace,
-// a PVC is created and that the workspace appears as a PersistentVolumeClaim workspace for TaskRuns.
-func TestReconcileWithVolumeClaimTemplateWorkspace(t *testing.T) {
-	workspaceName := "ws1"
-	claimName := "myclaim"
-	pipelineRunName := "test-pipeline-run"
-	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
-		tb.PipelineTask("hello-world-1", "hello-world", tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", workspaceName, "")),
-		tb.PipelineTask("hello-world-2", "hello-world"),
-		tb.PipelineWorkspaceDeclaration(workspaceName),
-	))}
-
-	prs := []*v1beta1.PipelineRun{tb.PipelineRun(pipelineRunName, tb.PipelineRunNamespace("foo"),
-		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunWorkspaceBindingVolumeClaimTemplate(workspaceName, claimName, ""))),
+func TestReconcileWithRunResults(t *testing.T) {
+	names.TestingSeed()
+	prName := "test-pipelinerun"
+	ps := []*v1beta1.Pipeline{{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline", Namespace: "foo"},
+		Spec: v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{
+				Name: "a-task",
+				TaskRef: &v1beta1.TaskRef{
+					APIVersion: "example.dev/v0",
+					Kind:       "Example",
+				},
+			}, {
+				Name:    "b-task",
+				TaskRef: &v1beta1.TaskRef{Name: "b-task"},
+				Params: []v1beta1.Param{{
+					Name:  "bParam",
+					Value: *v1beta1.NewArrayOrString("$(tasks.a-task.results.aResult)"),
+				}},
+			}},
+		},
+	}}
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun(prName, tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccountName("test-sa-0")),
+	)}
+	ts := []*v1beta1.Task{
+		tb.Task("b-task", tb.TaskNamespace("foo"),
+			tb.TaskSpec(tb.TaskParam("bParam", v1beta1.ParamTypeString)),
+		),
 	}
-	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
+	runs := []*v1alpha1.Run{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pipelinerun-a-task-xxyyy",
+			Namespace: "foo",
+			Labels: map[string]string{
+				"tekton.dev/pipeline":     "test-pipeline",
+				"tekton.dev/pipelineRun":  prName,
+				"tekton.dev/pipelineTask": "a-task",
+			},
+		},
+		Spec: v1alpha1.RunSpec{
+			Ref: &v1beta1.TaskRef{
+				APIVersion: "example.dev/v0",
+				Kind:       "Example",
+			},
+		},
+		Status: v1alpha1.RunStatus{
+			Status: duckv1.Status{
+				Conditions: []apis.Condition{{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}},
+			},
+			RunStatusFields: v1alpha1.RunStatusFields{
+				Results: []v1alpha1.RunResult{{Name: "aResult", Value: "aResultValue"}},
+			},
+		},
+	}}
 
 	d := test.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
+		Runs:         runs,
+		ConfigMaps:   customTasksEnabled(),
 	}
 	prt := NewPipelineRunTest(d, t)
 	defer prt.Cancel()
 
-	reconciledRun, clients := prt.reconcileRun("foo", pipelineRunName, []string{}, false)
-
-	// Check that the expected PVC was created
-	pvcNames := make([]string, 0)
-	for _, a := range clients.Kube.Actions() {
-		if ca, ok := a.(ktesting.CreateAction); ok {
-			obj := ca.GetObject()
-			if pvc, ok := obj.(*corev1.PersistentVolumeClaim); ok {
-				pvcNames = append(pvcNames, pvc.Name)
-			}
-		}
-	}
-
-	if len(pvcNames) != 1 {
-		t.Errorf("expected one PVC created. %d was created", len(pvcNames))
-	}
-
-	expectedPVCName := fmt.Sprintf("%s-%s", claimName, "cab465d09a")
-	if pvcNames[0] != expectedPVCName {
-		t.Errorf("expected the created PVC to be named %s. It was named %s", expectedPVCName, pvcNames[0])
-	}
+	reconciledRun, clients := prt.reconcileRun("foo", prName, []string{}, false)
 
-	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(metav1.ListOptions{})
+	expectedTaskRunName := "test-pipelinerun-b-task-9l9zj"
+	expectedTaskRun := tb.TaskRun(expectedTaskRunName,
+		tb.TaskRunNamespace("foo"),
+		tb.TaskRunOwnerReference("PipelineRun", prName,
+			tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
+			tb.Controller, tb.BlockOwnerDeletion,
+		),
+		tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
+		tb.TaskRunLabel("tekton.dev/pipelineRun", prName),
+		tb.TaskRunLabel("tekton.dev/pipelineTask", "b-task"),
+		tb.TaskRunSpec(
+			tb.TaskRunTaskRef("b-task"),
+			tb.TaskRunServiceAccountName("test-sa-0"),
+			tb.TaskRunParam("bParam", "aResultValue"),
+		),
+	)
+	// Check that the expected TaskRun was created
+	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(metav1.ListOptions{
+		LabelSelector: "tekton.dev/pipelineTask=b-task,tekton.dev/pipelineRun=" + prName,
+		Limit:         1,
+	})
 	if err != nil {
-		t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+		t.Fatalf("Failure to list TaskRun's %s", err)
 	}
-
-	for _, tr := range taskRuns.Items {
-		for _, ws := range tr.Spec.Workspaces {
-			if ws.VolumeClaimTemplate != nil {
-				t.Fatalf("found volumeClaimTemplate workspace. Did not expect to find any taskruns with volumeClaimTemplate workspaces")
-			}
-
-			if ws.PersistentVolumeClaim == nil {
-				t.Fatalf("found taskRun workspace that is not PersistentVolumeClaim workspace. Did only expect PersistentVolumeClaims workspaces")
-			}
-		}
+	if len(actual.Items) != 1 {
+		t.Fatalf("Expected 1 TaskRuns got %d", len(actual.Items))
+	}
+	actualTaskRun := actual.Items[0]
+	if d := cmp.Diff(&actualTaskRun, expectedTaskRun, ignoreResourceVersion); d != "" {
+		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRunName, diff.PrintWantGot(d))
 	}
 
-	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
-		t.Errorf("Expected PipelineRun to be running, but condition status is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	// The Run recovered from the informer should be tracked in the status
+	if prrs, ok := reconciledRun.Status.Runs["test-pipelinerun-a-task-xxyyy"]; !ok || prrs.PipelineTaskName != "a-task" {
+		t.Errorf("Expected PipelineRun status to include Run for a-task but was %v", reconciledRun.Status.Runs)
 	}
 }
 
-// TestReconcileWithVolumeClaimTemplateWorkspaceUsingSubPaths tests that given a pipeline with volumeClaimTemplate workspace and
-// multiple instances of the same task, but using different subPaths in the volume - is seen as taskRuns with expected subPaths.
-func TestReconcileWithVolumeClaimTemplateWorkspaceUsingSubPaths(t *testing.T) {
-	workspaceName := "ws1"
-	workspaceNameWithSubPath := "ws2"
-	subPath1 := "customdirectory"
-	subPath2 := "otherdirecory"
-	pipelineRunWsSubPath := "mypath"
-	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
-		tb.PipelineTask("hello-world-1", "hello-world", tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", workspaceName, subPath1)),
-		tb.PipelineTask("hello-world-2", "hello-world", tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", workspaceName, subPath2)),
-		tb.PipelineTask("hello-world-3", "hello-world", tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", workspaceName, "")),
-		tb.PipelineTask("hello-world-4", "hello-world", tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", workspaceNameWithSubPath, "")),
-		tb.PipelineTask("hello-world-5", "hello-world", tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", workspaceNameWithSubPath, subPath1)),
-		tb.PipelineWorkspaceDeclaration(workspaceName, workspaceNameWithSubPath),
-	))}
+// pipelinesInPipelinesEnabled returns a feature flags ConfigMap with Pipelines in Pipelines enabled.
+func pipelinesInPipelinesEnabled() []*corev1.ConfigMap {
+	return []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.GetNamespace()},
+		Data:       map[string]string{"enable-pipelines-in-pipelines": "true"},
+	}}
+}
 
-	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run", tb.PipelineRunNamespace("foo"),
-		tb.PipelineRunSpec("test-pipeline",
-			tb.PipelineRunWorkspaceBindingVolumeClaimTemplate(workspaceName, "myclaim", ""),
-			tb.PipelineRunWorkspaceBindingVolumeClaimTemplate(workspaceNameWithSubPath, "myclaim", pipelineRunWsSubPath))),
+func TestReconcile_ChildPipeline(t *testing.T) {
+	names.TestingSeed()
+	prName := "test-pipelinerun"
+	childSpec := &v1beta1.PipelineSpec{
+		Params:     []v1beta1.ParamSpec{{Name: "version", Type: v1beta1.ParamTypeString}},
+		Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{Name: "src"}},
+		Tasks: []v1beta1.PipelineTask{{
+			Name: "compile",
+			TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: &v1beta1.TaskSpec{
+				Workspaces: []v1beta1.WorkspaceDeclaration{{Name: "src"}},
+				Steps: []v1beta1.Step{{Container: corev1.Container{
+					Name:  "compile",
+					Image: "busybox",
+				}}},
+			}},
+			Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{Name: "src", Workspace: "src"}},
+		}},
 	}
-	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
+	prs := []*v1beta1.PipelineRun{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      prName,
+			Namespace: "foo",
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			ServiceAccountName: "test-sa",
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{Name: "source"}},
+				Tasks: []v1beta1.PipelineTask{{
+					Name:         "build",
+					PipelineSpec: childSpec,
+					Params: []v1beta1.Param{{
+						Name:  "version",
+						Value: *v1beta1.NewArrayOrString("v1"),
+					}},
+					Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{Name: "src", Workspace: "source"}},
+				}},
+			},
+			Workspaces: []v1beta1.WorkspaceBinding{{
+				Name:     "source",
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			}},
+		},
+	}}
 
 	d := test.Data{
 		PipelineRuns: prs,
-		Pipelines:    ps,
-		Tasks:        ts,
+		ConfigMaps:   pipelinesInPipelinesEnabled(),
 	}
 	prt := NewPipelineRunTest(d, t)
 	defer prt.Cancel()
 
-	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", []string{}, false)
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 0",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", prName, wantEvents, false)
 
-	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(metav1.ListOptions{})
+	// Check that the expected child PipelineRun was created.
+	wantChildName := "test-pipelinerun-build-9l9zj"
+	actual, err := clients.Pipeline.TektonV1beta1().PipelineRuns("foo").Get(wantChildName, metav1.GetOptions{})
 	if err != nil {
-		t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+		t.Fatalf("Expected child PipelineRun %s to be created: %v", wantChildName, err)
+	}
+	trueb := true
+	expectedChild := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      wantChildName,
+			Namespace: "foo",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         "tekton.dev/v1beta1",
+				Kind:               "PipelineRun",
+				Name:               prName,
+				Controller:         &trueb,
+				BlockOwnerDeletion: &trueb,
+			}},
+			Labels: map[string]string{
+				"tekton.dev/pipeline":     prName,
+				"tekton.dev/pipelineRun":  prName,
+				"tekton.dev/pipelineTask": "build",
+			},
+			Annotations: map[string]string{NestingDepthAnnotationKey: "1"},
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineSpec: childSpec,
+			Params: []v1beta1.Param{{
+				Name:  "version",
+				Value: *v1beta1.NewArrayOrString("v1"),
+			}},
+			ServiceAccountName: "test-sa",
+			Timeout:            &metav1.Duration{Duration: config.DefaultTimeoutMinutes * time.Minute},
+			Workspaces: []v1beta1.WorkspaceBinding{{
+				Name:     "src",
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			}},
+		},
 	}
-
-	if len(taskRuns.Items) != 5 {
-		t.Fatalf("unexpected number of taskRuns found, expected 2, but found %d", len(taskRuns.Items))
+	if d := cmp.Diff(expectedChild, actual, ignoreResourceVersion); d != "" {
+		t.Errorf("expected to see child PipelineRun created: %s", diff.PrintWantGot(d))
 	}
 
-	hasSeenWorkspaceWithPipelineTaskSubPath1 := false
-	hasSeenWorkspaceWithPipelineTaskSubPath2 := false
-	hasSeenWorkspaceWithEmptyPipelineTaskSubPath := false
-	hasSeenWorkspaceWithRunSubPathAndEmptyPipelineTaskSubPath := false
-	hasSeenWorkspaceWithRunSubPathAndPipelineTaskSubPath1 := false
-	for _, tr := range taskRuns.Items {
-		for _, ws := range tr.Spec.Workspaces {
-
-			if ws.PersistentVolumeClaim == nil {
-				t.Fatalf("found taskRun workspace that is not PersistentVolumeClaim workspace. Did only expect PersistentVolumeClaims workspaces")
-			}
-
-			if ws.SubPath == subPath1 {
-				hasSeenWorkspaceWithPipelineTaskSubPath1 = true
-			}
-
-			if ws.SubPath == subPath2 {
-				hasSeenWorkspaceWithPipelineTaskSubPath2 = true
-			}
-
-			if ws.SubPath == "" {
-				hasSeenWorkspaceWithEmptyPipelineTaskSubPath = true
-			}
+	// This PipelineRun is in progress now and the status should reflect that
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Status != corev1.ConditionUnknown {
+		t.Errorf("Expected PipelineRun status to be in progress, but was %v", condition)
+	}
+	if prcs, exists := reconciledRun.Status.ChildPipelineRuns[wantChildName]; !exists || prcs.PipelineTaskName != "build" {
+		t.Errorf("Expected PipelineRun status to include child PipelineRun status but was %v", reconciledRun.Status.ChildPipelineRuns)
+	}
+}
 
-			if ws.SubPath == pipelineRunWsSubPath {
-				hasSeenWorkspaceWithRunSubPathAndEmptyPipelineTaskSubPath = true
-			}
+func TestReconcile_ChildPipelineMaxNestingDepth(t *testing.T) {
+	prName := "test-pipelinerun"
+	// A Pipeline running itself would otherwise create child PipelineRuns forever
+	ps := []*v1beta1.Pipeline{{
+		ObjectMeta: metav1.ObjectMeta{Name: "recursive-pipeline", Namespace: "foo"},
+		Spec: v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{
+				Name:        "recurse",
+				PipelineRef: &v1beta1.PipelineRef{Name: "recursive-pipeline"},
+			}},
+		},
+	}}
+	prs := []*v1beta1.PipelineRun{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        prName,
+			Namespace:   "foo",
+			Annotations: map[string]string{NestingDepthAnnotationKey: "2"},
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "recursive-pipeline"},
+		},
+	}}
+	cms := append(pipelinesInPipelinesEnabled(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.GetNamespace()},
+		Data:       map[string]string{"default-max-pipeline-nesting-depth": "2"},
+	})
 
-			if ws.SubPath == fmt.Sprintf("%s/%s", pipelineRunWsSubPath, subPath1) {
-				hasSeenWorkspaceWithRunSubPathAndPipelineTaskSubPath1 = true
-			}
-		}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		ConfigMaps:   cms,
 	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
 
-	if !hasSeenWorkspaceWithPipelineTaskSubPath1 {
-		t.Fatalf("did not see a taskRun with a workspace using pipelineTask subPath1")
+	wantEvents := []string{
+		"Normal Started",
+		"Warning Failed PipelineTask recurse can't run a Pipeline: PipelineRun test-pipelinerun is already nested in 2 PipelineRuns, the maximum nesting depth is 2",
+		"Warning InternalError 1 error occurred",
 	}
-
-	if !hasSeenWorkspaceWithPipelineTaskSubPath2 {
-		t.Fatalf("did not see a taskRun with a workspace using pipelineTask subPath2")
+	reconciledRun, clients := prt.reconcileRun("foo", prName, wantEvents, true)
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != ReasonMaxNestingDepthExceeded {
+		t.Errorf("Expected PipelineRun to fail with reason %s, but condition was %v", ReasonMaxNestingDepthExceeded, condition)
 	}
-
-	if !hasSeenWorkspaceWithEmptyPipelineTaskSubPath {
-		t.Fatalf("did not see a taskRun with a workspace using empty pipelineTask subPath")
+	children, err := clients.Pipeline.TektonV1beta1().PipelineRuns("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
 	}
-
-	if !hasSeenWorkspaceWithRunSubPathAndEmptyPipelineTaskSubPath {
-		t.Fatalf("did not see a taskRun with workspace using empty pipelineTask subPath and a subPath from pipelineRun")
+	if len(children.Items) != 1 {
+		t.Errorf("Expected no child PipelineRun to be created, got %d PipelineRuns", len(children.Items))
 	}
+}
 
-	if !hasSeenWorkspaceWithRunSubPathAndPipelineTaskSubPath1 {
-		t.Fatalf("did not see a taskRun with workspace using pipelineTaks subPath1 and a subPath from pipelineRun")
+func TestReconcile_ChildPipelineDisabled(t *testing.T) {
+	prName := "test-pipelinerun"
+	prs := []*v1beta1.PipelineRun{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      prName,
+			Namespace: "foo",
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Tasks: []v1beta1.PipelineTask{{
+					Name:        "build",
+					PipelineRef: &v1beta1.PipelineRef{Name: "build-pipeline"},
+				}},
+			},
+		},
+	}}
+
+	d := test.Data{
+		PipelineRuns: prs,
 	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
 
-	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
-		t.Errorf("Expected PipelineRun to be running, but condition status is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	reconciledRun, clients := prt.reconcileRun("foo", prName, []string{}, true)
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != ReasonFailedValidation {
+		t.Errorf("Expected PipelineRun to fail validation, but condition was %v", condition)
+	}
+	children, err := clients.Pipeline.TektonV1beta1().PipelineRuns("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(children.Items) != 1 {
+		t.Errorf("Expected no child PipelineRun to be created, got %d PipelineRuns", len(children.Items))
 	}
 }
 
-func TestReconcileWithTaskResults(t *testing.T) {
+func TestReconcileWithChildPipelineResults(t *testing.T) {
 	names.TestingSeed()
-	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
-		tb.PipelineTask("a-task", "a-task"),
-		tb.PipelineTask("b-task", "b-task",
-			tb.PipelineTaskParam("bParam", "$(tasks.a-task.results.aResult)"),
-		),
-	))}
-	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-different-service-accs", tb.PipelineRunNamespace("foo"),
-		tb.PipelineRunSpec("test-pipeline",
-			tb.PipelineRunServiceAccountName("test-sa-0"),
-		),
+	prName := "test-pipelinerun"
+	ps := []*v1beta1.Pipeline{{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline", Namespace: "foo"},
+		Spec: v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{
+				Name:        "build",
+				PipelineRef: &v1beta1.PipelineRef{Name: "build-pipeline"},
+			}, {
+				Name:    "deploy",
+				TaskRef: &v1beta1.TaskRef{Name: "deploy"},
+				Params: []v1beta1.Param{{
+					Name:  "image",
+					Value: *v1beta1.NewArrayOrString("$(tasks.build.results.image)"),
+				}},
+			}},
+		},
+	}}
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun(prName, tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccountName("test-sa-0")),
 	)}
 	ts := []*v1beta1.Task{
-		tb.Task("a-task", tb.TaskNamespace("foo")),
-		tb.Task("b-task", tb.TaskNamespace("foo"),
-			tb.TaskSpec(
-				tb.TaskParam("bParam", v1beta1.ParamTypeString),
-			),
-		),
-	}
-	trs := []*v1beta1.TaskRun{
-		tb.TaskRun("test-pipeline-run-different-service-accs-a-task-xxyyy",
-			tb.TaskRunNamespace("foo"),
-			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-different-service-accs",
-				tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
-				tb.Controller, tb.BlockOwnerDeletion,
-			),
-			tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
-			tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-different-service-accs"),
-			tb.TaskRunLabel("tekton.dev/pipelineTask", "a-task"),
-			tb.TaskRunSpec(
-				tb.TaskRunTaskRef("hello-world"),
-				tb.TaskRunServiceAccountName("test-sa"),
-			),
-			tb.TaskRunStatus(
-				tb.StatusCondition(
-					apis.Condition{
-						Type:   apis.ConditionSucceeded,
-						Status: corev1.ConditionTrue,
-					},
-				),
-				tb.TaskRunResult("aResult", "aResultValue"),
-			),
+		tb.Task("deploy", tb.TaskNamespace("foo"),
+			tb.TaskSpec(tb.TaskParam("image", v1beta1.ParamTypeString)),
 		),
 	}
+	children := []*v1beta1.PipelineRun{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pipelinerun-build-xxyyy",
+			Namespace: "foo",
+			Labels: map[string]string{
+				"tekton.dev/pipeline":     "test-pipeline",
+				"tekton.dev/pipelineRun":  prName,
+				"tekton.dev/pipelineTask": "build",
+			},
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "build-pipeline"},
+		},
+		Status: v1beta1.PipelineRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}},
+			},
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				PipelineResults: []v1beta1.PipelineRunResult{{Name: "image", Value: "registry/app@sha256:abc"}},
+			},
+		},
+	}}
 
 	d := test.Data{
-		PipelineRuns: prs,
+		PipelineRuns: append(prs, children...),
 		Pipelines:    ps,
 		Tasks:        ts,
-		TaskRuns:     trs,
+		ConfigMaps:   pipelinesInPipelinesEnabled(),
 	}
 	prt := NewPipelineRunTest(d, t)
 	defer prt.Cancel()
 
-	_, clients := prt.reconcileRun("foo", "test-pipeline-run-different-service-accs", []string{}, false)
+	reconciledRun, clients := prt.reconcileRun("foo", prName, []string{}, false)
 
-	expectedTaskRunName := "test-pipeline-run-different-service-accs-b-task-9l9zj"
+	expectedTaskRunName := "test-pipelinerun-deploy-9l9zj"
 	expectedTaskRun := tb.TaskRun(expectedTaskRunName,
 		tb.TaskRunNamespace("foo"),
-		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-different-service-accs",
+		tb.TaskRunOwnerReference("PipelineRun", prName,
 			tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
 			tb.Controller, tb.BlockOwnerDeletion,
 		),
 		tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
-		tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-different-service-accs"),
-		tb.TaskRunLabel("tekton.dev/pipelineTask", "b-task"),
+		tb.TaskRunLabel("tekton.dev/pipelineRun", prName),
+		tb.TaskRunLabel("tekton.dev/pipelineTask", "deploy"),
 		tb.TaskRunSpec(
-			tb.TaskRunTaskRef("b-task"),
+			tb.TaskRunTaskRef("deploy"),
 			tb.TaskRunServiceAccountName("test-sa-0"),
-			tb.TaskRunParam("bParam", "aResultValue"),
+			tb.TaskRunParam("image", "registry/app@sha256:abc"),
 		),
 	)
 	// Check that the expected TaskRun was created
 	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(metav1.ListOptions{
-		LabelSelector: "tekton.dev/pipelineTask=b-task,tekton.dev/pipelineRun=test-pipeline-run-different-service-accs",
+		LabelSelector: "tekton.dev/pipelineTask=deploy,tekton.dev/pipelineRun=" + prName,
 		Limit:         1,
 	})
-
 	if err != nil {
 		t.Fatalf("Failure to list TaskRun's %s", err)
 	}
@@ -2582,76 +3948,198 @@ func TestReconcileWithTaskResults(t *testing.T) {
 	if d := cmp.Diff(&actualTaskRun, expectedTaskRun, ignoreResourceVersion); d != "" {
 		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRunName, diff.PrintWantGot(d))
 	}
+
+	// The child PipelineRun recovered from the informer should be tracked in the status
+	if prcs, ok := reconciledRun.Status.ChildPipelineRuns["test-pipelinerun-build-xxyyy"]; !ok || prcs.PipelineTaskName != "build" {
+		t.Errorf("Expected PipelineRun status to include child PipelineRun for build but was %v", reconciledRun.Status.ChildPipelineRuns)
+	}
 }
 
-func TestReconcileWithTaskResultsEmbeddedNoneStarted(t *testing.T) {
+func TestReconcile_Matrix(t *testing.T) {
 	names.TestingSeed()
-	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-different-service-accs", tb.PipelineRunNamespace("foo"),
-		tb.PipelineRunSpec("test-pipeline",
-			tb.PipelineRunPipelineSpec(
-				tb.PipelineParamSpec("foo", v1beta1.ParamTypeString),
-				tb.PipelineTask("a-task", "a-task"),
-				tb.PipelineTask("b-task", "b-task",
-					tb.PipelineTaskParam("bParam", "$(params.foo)/baz@$(tasks.a-task.results.A_RESULT)"),
-				),
+	prName := "test-pipelinerun"
+	ps := []*v1beta1.Pipeline{{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline", Namespace: "foo"},
+		Spec: v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{
+				Name:    "build",
+				TaskRef: &v1beta1.TaskRef{Name: "build"},
+				Params: []v1beta1.Param{{
+					Name:  "version",
+					Value: *v1beta1.NewArrayOrString("v1"),
+				}},
+				Matrix: []v1beta1.Param{{
+					Name:  "platform",
+					Value: *v1beta1.NewArrayOrString("linux", "mac"),
+				}},
+			}},
+		},
+	}}
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun(prName, tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccountName("test-sa-0")),
+	)}
+	ts := []*v1beta1.Task{
+		tb.Task("build", tb.TaskNamespace("foo"), tb.TaskSpec(
+			tb.TaskParam("version", v1beta1.ParamTypeString),
+			tb.TaskParam("platform", v1beta1.ParamTypeString),
+		)),
+	}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 0",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", prName, wantEvents, false)
+
+	// Check that one TaskRun was created per combination of the matrix
+	for i, platform := range []string{"linux", "mac"} {
+		expectedTaskRunName := fmt.Sprintf("test-pipelinerun-build-%d", i)
+		expectedTaskRun := tb.TaskRun(expectedTaskRunName,
+			tb.TaskRunNamespace("foo"),
+			tb.TaskRunOwnerReference("PipelineRun", prName,
+				tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
+				tb.Controller, tb.BlockOwnerDeletion,
 			),
-			tb.PipelineRunParam("foo", "bar"),
-			tb.PipelineRunServiceAccountName("test-sa-0"),
-		),
+			tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
+			tb.TaskRunLabel("tekton.dev/pipelineRun", prName),
+			tb.TaskRunLabel("tekton.dev/pipelineTask", "build"),
+			tb.TaskRunSpec(
+				tb.TaskRunTaskRef("build"),
+				tb.TaskRunServiceAccountName("test-sa-0"),
+				tb.TaskRunParam("version", "v1"),
+				tb.TaskRunParam("platform", platform),
+			),
+		)
+		actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(expectedTaskRunName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Expected TaskRun %s to be created: %v", expectedTaskRunName, err)
+		}
+		if d := cmp.Diff(expectedTaskRun, actual, ignoreResourceVersion); d != "" {
+			t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRunName, diff.PrintWantGot(d))
+		}
+		if prtrs, ok := reconciledRun.Status.TaskRuns[expectedTaskRunName]; !ok || prtrs.PipelineTaskName != "build" {
+			t.Errorf("Expected PipelineRun status to include TaskRun %s for build but was %v", expectedTaskRunName, reconciledRun.Status.TaskRuns)
+		}
+	}
+	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failure to list TaskRun's %s", err)
+	}
+	if len(actual.Items) != 2 {
+		t.Errorf("Expected 2 TaskRuns got %d", len(actual.Items))
+	}
+}
+
+func TestReconcileWithMatrixResults(t *testing.T) {
+	names.TestingSeed()
+	prName := "test-pipelinerun"
+	ps := []*v1beta1.Pipeline{{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline", Namespace: "foo"},
+		Spec: v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{
+				Name:    "build",
+				TaskRef: &v1beta1.TaskRef{Name: "build"},
+				Matrix: []v1beta1.Param{{
+					Name:  "platform",
+					Value: *v1beta1.NewArrayOrString("linux", "mac"),
+				}},
+			}, {
+				Name:    "publish",
+				TaskRef: &v1beta1.TaskRef{Name: "publish"},
+				Params: []v1beta1.Param{{
+					Name: "digests",
+					Value: v1beta1.ArrayOrString{
+						Type:     v1beta1.ParamTypeArray,
+						ArrayVal: []string{"$(tasks.build.results.digest)"},
+					},
+				}},
+			}},
+		},
+	}}
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun(prName, tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccountName("test-sa-0")),
 	)}
 	ts := []*v1beta1.Task{
-		tb.Task("a-task", tb.TaskNamespace("foo"),
-			tb.TaskSpec(
-				tb.TaskResults("A_RESULT", ""),
+		tb.Task("build", tb.TaskNamespace("foo"), tb.TaskSpec(
+			tb.TaskParam("platform", v1beta1.ParamTypeString),
+		)),
+		tb.Task("publish", tb.TaskNamespace("foo"), tb.TaskSpec(
+			tb.TaskParam("digests", v1beta1.ParamTypeArray),
+		)),
+	}
+	var trs []*v1beta1.TaskRun
+	for i, platform := range []string{"linux", "mac"} {
+		trs = append(trs, tb.TaskRun(fmt.Sprintf("test-pipelinerun-build-%d", i),
+			tb.TaskRunNamespace("foo"),
+			tb.TaskRunOwnerReference("PipelineRun", prName,
+				tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
+				tb.Controller, tb.BlockOwnerDeletion,
 			),
-		),
-		tb.Task("b-task", tb.TaskNamespace("foo"),
-			tb.TaskSpec(
-				tb.TaskParam("bParam", v1beta1.ParamTypeString),
+			tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
+			tb.TaskRunLabel("tekton.dev/pipelineRun", prName),
+			tb.TaskRunLabel("tekton.dev/pipelineTask", "build"),
+			tb.TaskRunSpec(
+				tb.TaskRunTaskRef("build"),
+				tb.TaskRunServiceAccountName("test-sa-0"),
+				tb.TaskRunParam("platform", platform),
 			),
-		),
+			tb.TaskRunStatus(
+				tb.StatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}),
+				tb.TaskRunResult("digest", "sha256:"+platform),
+			),
+		))
 	}
 
 	d := test.Data{
 		PipelineRuns: prs,
+		Pipelines:    ps,
 		Tasks:        ts,
+		TaskRuns:     trs,
 	}
 	prt := NewPipelineRunTest(d, t)
 	defer prt.Cancel()
 
-	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-different-service-accs", []string{}, false)
-
-	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
-		t.Errorf("Expected PipelineRun to be running, but condition status is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
-	}
+	reconciledRun, clients := prt.reconcileRun("foo", prName, []string{}, false)
 
-	// Since b-task is dependent on a-task, via the results, only a-task should run
-	expectedTaskRunName := "test-pipeline-run-different-service-accs-a-task-9l9zj"
+	expectedTaskRunName := "test-pipelinerun-publish-9l9zj"
 	expectedTaskRun := tb.TaskRun(expectedTaskRunName,
 		tb.TaskRunNamespace("foo"),
-		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-different-service-accs",
+		tb.TaskRunOwnerReference("PipelineRun", prName,
 			tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
 			tb.Controller, tb.BlockOwnerDeletion,
 		),
-		tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline-run-different-service-accs"),
-		tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-different-service-accs"),
-		tb.TaskRunLabel("tekton.dev/pipelineTask", "a-task"),
+		tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
+		tb.TaskRunLabel("tekton.dev/pipelineRun", prName),
+		tb.TaskRunLabel("tekton.dev/pipelineTask", "publish"),
 		tb.TaskRunSpec(
-			tb.TaskRunTaskRef("a-task", tb.TaskRefKind(v1beta1.NamespacedTaskKind)),
+			tb.TaskRunTaskRef("publish"),
 			tb.TaskRunServiceAccountName("test-sa-0"),
+			tb.TaskRunParam("digests", "sha256:linux", "sha256:mac"),
 		),
 	)
-	// Check that the expected TaskRun was created (only)
-	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(metav1.ListOptions{})
+	// Check that the results of the matrix were aggregated into the array param
+	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(expectedTaskRunName, metav1.GetOptions{})
 	if err != nil {
-		t.Fatalf("Failure to list TaskRun's %s", err)
+		t.Fatalf("Expected TaskRun %s to be created: %v", expectedTaskRunName, err)
 	}
-	if len(actual.Items) != 1 {
-		t.Fatalf("Expected 1 TaskRuns got %d", len(actual.Items))
+	if d := cmp.Diff(expectedTaskRun, actual, ignoreResourceVersion); d != "" {
+		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRunName, diff.PrintWantGot(d))
 	}
-	actualTaskRun := actual.Items[0]
-	if d := cmp.Diff(expectedTaskRun, &actualTaskRun, ignoreResourceVersion); d != "" {
-		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRun, diff.PrintWantGot(d))
+	for _, name := range []string{"test-pipelinerun-build-0", "test-pipelinerun-build-1"} {
+		if prtrs, ok := reconciledRun.Status.TaskRuns[name]; !ok || prtrs.PipelineTaskName != "build" {
+			t.Errorf("Expected PipelineRun status to include TaskRun %s for build but was %v", name, reconciledRun.Status.TaskRuns)
+		}
 	}
 }
 
@@ -3827,6 +5315,7 @@ func TestReconcile_CloudEvents(t *testing.T) {
 	wantCloudEvents := []string{
 		`(?s)dev.tekton.event.pipelinerun.started.v1.*test-pipelinerun`,
 		`(?s)dev.tekton.event.pipelinerun.running.v1.*test-pipelinerun`,
+		`(?s)dev.tekton.event.pipelinerun.task.started.v1.*test-pipelinerun.*"pipelineTaskName": "test-1"`,
 	}
 	ceClient := clients.CloudEvents.(cloudevent.FakeClient)
 	err := checkCloudEvents(t, &ceClient, "reconcile-cloud-events", wantCloudEvents)
@@ -3835,6 +5324,81 @@ func TestReconcile_CloudEvents(t *testing.T) {
 	}
 }
 
+func TestReconcile_CloudEventsDelivery(t *testing.T) {
+	names.TestingSeed()
+
+	prs := []*v1beta1.PipelineRun{
+		tb.PipelineRun("test-pipelinerun",
+			tb.PipelineRunNamespace("foo"),
+			tb.PipelineRunSelfLink("/pipeline/1234"),
+			tb.PipelineRunSpec("test-pipeline"),
+		),
+	}
+	ps := []*v1beta1.Pipeline{
+		tb.Pipeline("test-pipeline",
+			tb.PipelineNamespace("foo"),
+			tb.PipelineSpec(tb.PipelineTask("test-1", "test-task")),
+		),
+	}
+	ts := []*v1beta1.Task{
+		tb.Task("test-task", tb.TaskNamespace("foo"),
+			tb.TaskSpec(tb.Step("foo", tb.StepName("simple-step"), tb.StepCommand("/mycmd"))),
+		),
+	}
+	cms := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.GetNamespace()},
+		Data: map[string]string{
+			"default-cloud-events-sink": "http://synk:8080",
+		},
+	}}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		ConfigMaps:   cms,
+	}
+
+	ctx, _ := ttesting.SetupFakeContext(t)
+	ctx, stopDispatcher := context.WithCancel(ctx)
+	defer stopDispatcher()
+	dispatcher := cloudevent.NewDispatcher(cloudevent.Get(ctx), logging.FromContext(ctx), cloudevent.DefaultDispatcherOptions)
+	testAssets, cancel := getPipelineRunControllerWithContext(t, cloudevent.WithDispatcher(ctx, dispatcher), d)
+	defer cancel()
+	dispatcher.Start(ctx.Done())
+	prt := PipelineRunTest{Data: d, Test: t, TestAssets: testAssets, Cancel: cancel}
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipelinerun", []string{"Normal Started", "Normal Running Tasks Completed: 0"}, false)
+	if len(reconciledRun.Status.CloudEventSinks) != 1 || reconciledRun.Status.CloudEventSinks[0].Target != "http://synk:8080" {
+		t.Fatalf("Expected the delivery of the cloud events to the sink to be recorded, got %v", reconciledRun.Status.CloudEventSinks)
+	}
+
+	wantCloudEvents := []string{
+		`(?s)dev.tekton.event.pipelinerun.started.v1.*test-pipelinerun`,
+		`(?s)dev.tekton.event.pipelinerun.running.v1.*test-pipelinerun`,
+		`(?s)dev.tekton.event.pipelinerun.task.started.v1.*test-pipelinerun`,
+	}
+	ceClient := clients.CloudEvents.(cloudevent.FakeClient)
+	if err := checkCloudEvents(t, &ceClient, "reconcile-cloud-events-delivery", wantCloudEvents); err != nil {
+		t.Fatal(err)
+	}
+	if err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		deliveries := dispatcher.Deliveries(reconciledRun, nil)
+		return len(deliveries) == 1 && deliveries[0].Status.RetryCount == 3, nil
+	}); err != nil {
+		t.Fatalf("Timed out waiting for the delivery of the cloud events: %v", err)
+	}
+
+	// Once delivered, the state of the cloud events is recorded by the next reconcile.
+	reconciledRun, _ = prt.reconcileRun("foo", "test-pipelinerun", []string{}, false)
+	want := []v1beta1.CloudEventDelivery{{
+		Target: "http://synk:8080",
+		Status: v1beta1.CloudEventDeliveryState{Condition: v1beta1.CloudEventConditionSent, RetryCount: 3},
+	}}
+	if d := cmp.Diff(want, reconciledRun.Status.CloudEventSinks, cmpopts.IgnoreFields(v1beta1.CloudEventDeliveryState{}, "SentAt")); d != "" {
+		t.Errorf("Unexpected delivery of the cloud events %s", diff.PrintWantGot(d))
+	}
+}
+
 // this test validates taskSpec metadata is embedded into task run
 func TestReconcilePipeline_TaskSpecMetadata(t *testing.T) {
 	names.TestingSeed()
@@ -3924,6 +5488,104 @@ func TestReconcilePipeline_TaskSpecMetadata(t *testing.T) {
 	}
 }
 
+func TestReconcile_PipelineResourceVerificationFailed(t *testing.T) {
+	ps := []*v1beta1.Pipeline{tb.Pipeline("unsigned-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("unit-test-1", "unit-test-task"),
+	))}
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-unsigned", tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("unsigned-pipeline"),
+	)}
+	ts := []*v1beta1.Task{tb.Task("unit-test-task", tb.TaskNamespace("foo"))}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetTrustedResourcesConfigName(), Namespace: system.GetNamespace()},
+			Data: map[string]string{
+				"verification-mode": "enforce",
+				"public-keys": `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEWe7GDGWlsQZvXhAdip/DYYR6P/NA
+XTYCDs35fNb0j9xAVG64fm7xlHd/ICxdGhxA3cvRVlEW5/eFJTAWtwCdrw==
+-----END PUBLIC KEY-----
+`,
+			},
+		}},
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Warning Failed Pipeline for pipelinerun foo/test-pipeline-run-unsigned failed verification",
+		"Warning InternalError 1 error occurred",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-unsigned", wantEvents, true)
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != podconvert.ReasonResourceVerificationFailed {
+		t.Errorf("Expected PipelineRun to fail with reason %q, but had %v", podconvert.ReasonResourceVerificationFailed, condition)
+	}
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			t.Errorf("Expected no TaskRun to be created, got action %v", a)
+		}
+	}
+}
+
+func TestReconcile_TaskResourceVerificationFailed(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Couldn't generate key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatalf("Couldn't encode the public key: %v", err)
+	}
+	// The Pipeline is signed, but not the Task it references.
+	p := tb.Pipeline("signed-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("unit-test-1", "unit-test-task"),
+	))
+	sig, err := trustedresources.SignPipeline(context.Background(), p, key)
+	if err != nil {
+		t.Fatalf("Couldn't sign the Pipeline: %v", err)
+	}
+	p.Annotations = map[string]string{trustedresources.SignatureAnnotation: sig}
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-unsigned-task", tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("signed-pipeline"),
+	)}
+	ts := []*v1beta1.Task{tb.Task("unit-test-task", tb.TaskNamespace("foo"))}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    []*v1beta1.Pipeline{p},
+		Tasks:        ts,
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetTrustedResourcesConfigName(), Namespace: system.GetNamespace()},
+			Data: map[string]string{
+				"verification-mode": "enforce",
+				"public-keys":       string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+			},
+		}},
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Warning Failed Pipeline foo/signed-pipeline can't be Run; it contains Tasks that failed verification",
+		"Warning InternalError 1 error occurred",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-unsigned-task", wantEvents, true)
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != podconvert.ReasonResourceVerificationFailed {
+		t.Errorf("Expected PipelineRun to fail with reason %q, but had %v", podconvert.ReasonResourceVerificationFailed, condition)
+	}
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			t.Errorf("Expected no TaskRun to be created, got action %v", a)
+		}
+	}
+}
+
 // NewPipelineRunTest returns PipelineRunTest with a new PipelineRun controller created with specified state through data
 // This PipelineRunTest can be reused for multiple PipelineRuns by calling reconcileRun for each pipelineRun
 func NewPipelineRunTest(data test.Data, t *testing.T) *PipelineRunTest {
@@ -18,6 +18,7 @@ package resources
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 )
@@ -27,30 +28,51 @@ func ApplyParameters(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun) *v1beta1.
 	// This assumes that the PipelineRun inputs have been validated against what the Pipeline requests.
 
 	// stringReplacements is used for standard single-string stringReplacements, while arrayReplacements contains arrays
-	// that need to be further processed.
+	// and objectReplacements contains objects that need to be further processed.
 	stringReplacements := map[string]string{}
 	arrayReplacements := map[string][]string{}
+	objectReplacements := map[string]map[string]string{}
 
 	// Set all the default stringReplacements
 	for _, p := range p.Params {
 		if p.Default != nil {
-			if p.Default.Type == v1beta1.ParamTypeString {
+			switch p.Default.Type {
+			case v1beta1.ParamTypeString:
 				stringReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Default.StringVal
-			} else {
+			case v1beta1.ParamTypeObject:
+				objectReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Default.ObjectVal
+			default:
 				arrayReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Default.ArrayVal
 			}
 		}
 	}
 	// Set and overwrite params with the ones from the PipelineRun
 	for _, p := range pr.Spec.Params {
-		if p.Value.Type == v1beta1.ParamTypeString {
+		switch p.Value.Type {
+		case v1beta1.ParamTypeString:
 			stringReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Value.StringVal
-		} else {
+		case v1beta1.ParamTypeObject:
+			// Keys missing from the PipelineRun keep the value of the default
+			object := map[string]string{}
+			for k, v := range objectReplacements[fmt.Sprintf("params.%s", p.Name)] {
+				object[k] = v
+			}
+			for k, v := range p.Value.ObjectVal {
+				object[k] = v
+			}
+			objectReplacements[fmt.Sprintf("params.%s", p.Name)] = object
+		default:
 			arrayReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Value.ArrayVal
 		}
 	}
+	// The keys of an object are replaced one by one, e.g. $(params.foo.key)
+	for name, object := range objectReplacements {
+		for k, v := range object {
+			stringReplacements[fmt.Sprintf("%s.%s", name, k)] = v
+		}
+	}
 
-	return ApplyReplacements(p, stringReplacements, arrayReplacements)
+	return ApplyReplacements(p, stringReplacements, arrayReplacements, objectReplacements)
 }
 
 // ApplyContexts applies the substitution from $(context.(pipelineRun|pipeline).*) with the specified values.
@@ -62,51 +84,83 @@ func ApplyContexts(spec *v1beta1.PipelineSpec, pipelineName string, pr *v1beta1.
 		"context.pipelineRun.namespace": pr.Namespace,
 		"context.pipelineRun.uid":       string(pr.ObjectMeta.UID),
 	}
-	return ApplyReplacements(spec, replacements, map[string][]string{})
+	return ApplyReplacements(spec, replacements, map[string][]string{}, map[string]map[string]string{})
+}
+
+// ApplyWorkspaces applies the substitution from $(workspaces.<name>.bound) with "true" for the
+// workspaces of the Pipeline bound by the PipelineRun, and "false" for its unbound optional workspaces.
+func ApplyWorkspaces(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun) *v1beta1.PipelineSpec {
+	boundWorkspaces := map[string]bool{}
+	for _, ws := range pr.Spec.Workspaces {
+		boundWorkspaces[ws.Name] = true
+	}
+	replacements := map[string]string{}
+	for _, ws := range p.Workspaces {
+		replacements[fmt.Sprintf("workspaces.%s.bound", ws.Name)] = strconv.FormatBool(boundWorkspaces[ws.Name])
+	}
+	return ApplyReplacements(p, replacements, map[string][]string{}, map[string]map[string]string{})
 }
 
-// ApplyTaskResults applies the ResolvedResultRef to each PipelineTask.Params and Pipeline.WhenExpressions in targets
+// ApplyTaskResults applies the ResolvedResultRef to each PipelineTask.Params, PipelineTask.Matrix and
+// Pipeline.WhenExpressions in targets
 func ApplyTaskResults(targets PipelineRunState, resolvedResultRefs ResolvedResultRefs) {
 	stringReplacements := resolvedResultRefs.getStringReplacements()
+	arrayReplacements := resolvedResultRefs.getArrayReplacements()
 	for _, resolvedPipelineRunTask := range targets {
 		// also make substitution for resolved condition checks
 		for _, resolvedConditionCheck := range resolvedPipelineRunTask.ResolvedConditionChecks {
 			pipelineTaskCondition := resolvedConditionCheck.PipelineTaskCondition.DeepCopy()
-			pipelineTaskCondition.Params = replaceParamValues(pipelineTaskCondition.Params, stringReplacements, nil)
+			pipelineTaskCondition.Params = replaceParamValues(pipelineTaskCondition.Params, stringReplacements, nil, nil)
 			resolvedConditionCheck.PipelineTaskCondition = pipelineTaskCondition
 		}
 		if resolvedPipelineRunTask.PipelineTask != nil {
 			pipelineTask := resolvedPipelineRunTask.PipelineTask.DeepCopy()
-			pipelineTask.Params = replaceParamValues(pipelineTask.Params, stringReplacements, nil)
+			pipelineTask.Params = replaceParamValues(pipelineTask.Params, stringReplacements, arrayReplacements, nil)
+			pipelineTask.Matrix = replaceParamValues(pipelineTask.Matrix, stringReplacements, nil, nil)
 			pipelineTask.WhenExpressions = pipelineTask.WhenExpressions.ReplaceWhenExpressionsVariables(stringReplacements)
 			resolvedPipelineRunTask.PipelineTask = pipelineTask
 		}
 	}
 }
 
+// ApplyPipelineTaskStateContext replaces the context variables referring to the execution status
+// of the tasks of the DAG in the Params and WhenExpressions of each PipelineTask in targets
+func ApplyPipelineTaskStateContext(targets PipelineRunState, replacements map[string]string) {
+	for _, resolvedPipelineRunTask := range targets {
+		if resolvedPipelineRunTask.PipelineTask != nil {
+			pipelineTask := resolvedPipelineRunTask.PipelineTask.DeepCopy()
+			pipelineTask.Params = replaceParamValues(pipelineTask.Params, replacements, nil, nil)
+			pipelineTask.WhenExpressions = pipelineTask.WhenExpressions.ReplaceWhenExpressionsVariables(replacements)
+			resolvedPipelineRunTask.PipelineTask = pipelineTask
+		}
+	}
+}
+
 // ApplyReplacements replaces placeholders for declared parameters with the specified replacements.
-func ApplyReplacements(p *v1beta1.PipelineSpec, replacements map[string]string, arrayReplacements map[string][]string) *v1beta1.PipelineSpec {
+func ApplyReplacements(p *v1beta1.PipelineSpec, replacements map[string]string, arrayReplacements map[string][]string, objectReplacements map[string]map[string]string) *v1beta1.PipelineSpec {
 	p = p.DeepCopy()
 
 	for i := range p.Tasks {
-		p.Tasks[i].Params = replaceParamValues(p.Tasks[i].Params, replacements, arrayReplacements)
+		p.Tasks[i].Params = replaceParamValues(p.Tasks[i].Params, replacements, arrayReplacements, objectReplacements)
+		p.Tasks[i].Matrix = replaceParamValues(p.Tasks[i].Matrix, replacements, arrayReplacements, objectReplacements)
 		for j := range p.Tasks[i].Conditions {
 			c := p.Tasks[i].Conditions[j]
-			c.Params = replaceParamValues(c.Params, replacements, arrayReplacements)
+			c.Params = replaceParamValues(c.Params, replacements, arrayReplacements, objectReplacements)
 		}
 		p.Tasks[i].WhenExpressions = p.Tasks[i].WhenExpressions.ReplaceWhenExpressionsVariables(replacements)
 	}
 
 	for i := range p.Finally {
-		p.Finally[i].Params = replaceParamValues(p.Finally[i].Params, replacements, arrayReplacements)
+		p.Finally[i].Params = replaceParamValues(p.Finally[i].Params, replacements, arrayReplacements, objectReplacements)
+		p.Finally[i].WhenExpressions = p.Finally[i].WhenExpressions.ReplaceWhenExpressionsVariables(replacements)
 	}
 
 	return p
 }
 
-func replaceParamValues(params []v1beta1.Param, stringReplacements map[string]string, arrayReplacements map[string][]string) []v1beta1.Param {
+func replaceParamValues(params []v1beta1.Param, stringReplacements map[string]string, arrayReplacements map[string][]string, objectReplacements map[string]map[string]string) []v1beta1.Param {
 	for i := range params {
-		params[i].Value.ApplyReplacements(stringReplacements, arrayReplacements)
+		params[i].Value.ApplyReplacements(stringReplacements, arrayReplacements, objectReplacements)
 	}
 	return params
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
 	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/selection"
 )
@@ -93,6 +94,32 @@ func TestApplyParameters(t *testing.T) {
 				}},
 			}},
 		},
+	}, {
+		name: "single parameter with when expression in finally",
+		original: v1beta1.PipelineSpec{
+			Params: []v1beta1.ParamSpec{
+				{Name: "first-param", Type: v1beta1.ParamTypeString, Default: v1beta1.NewArrayOrString("default-value")},
+			},
+			Finally: []v1beta1.PipelineTask{{
+				WhenExpressions: []v1beta1.WhenExpression{{
+					Input:    "$(params.first-param)",
+					Operator: selection.In,
+					Values:   []string{"$(tasks.status)"},
+				}},
+			}},
+		},
+		expected: v1beta1.PipelineSpec{
+			Params: []v1beta1.ParamSpec{
+				{Name: "first-param", Type: v1beta1.ParamTypeString, Default: v1beta1.NewArrayOrString("default-value")},
+			},
+			Finally: []v1beta1.PipelineTask{{
+				WhenExpressions: []v1beta1.WhenExpression{{
+					Input:    "default-value",
+					Operator: selection.In,
+					Values:   []string{"$(tasks.status)"},
+				}},
+			}},
+		},
 	}, {
 		name: "pipeline parameter nested inside task parameter",
 		original: v1beta1.PipelineSpec{
@@ -246,6 +273,41 @@ func TestApplyParameters(t *testing.T) {
 				},
 			}},
 		},
+	}, {
+		name: "object parameter",
+		original: v1beta1.PipelineSpec{
+			Params: []v1beta1.ParamSpec{{
+				Name:       "repo",
+				Type:       v1beta1.ParamTypeObject,
+				Properties: map[string]v1beta1.PropertySpec{"url": {}, "commit": {}},
+				Default:    v1beta1.NewObject(map[string]string{"commit": "main"}),
+			}},
+			Tasks: []v1beta1.PipelineTask{{
+				Params: []v1beta1.Param{
+					{Name: "url", Value: *v1beta1.NewArrayOrString("$(params.repo.url)")},
+					{Name: "revision", Value: *v1beta1.NewArrayOrString("refs/heads/$(params.repo.commit)")},
+					{Name: "entire-repo", Value: *v1beta1.NewArrayOrString("$(params.repo[*])")},
+					{Name: "new-repo", Value: *v1beta1.NewObject(map[string]string{"url": "$(params.repo.url)", "commit": "v0.17.0"})},
+				},
+			}},
+		},
+		params: []v1beta1.Param{{Name: "repo", Value: *v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline"})}},
+		expected: v1beta1.PipelineSpec{
+			Params: []v1beta1.ParamSpec{{
+				Name:       "repo",
+				Type:       v1beta1.ParamTypeObject,
+				Properties: map[string]v1beta1.PropertySpec{"url": {}, "commit": {}},
+				Default:    v1beta1.NewObject(map[string]string{"commit": "main"}),
+			}},
+			Tasks: []v1beta1.PipelineTask{{
+				Params: []v1beta1.Param{
+					{Name: "url", Value: *v1beta1.NewArrayOrString("https://github.com/tektoncd/pipeline")},
+					{Name: "revision", Value: *v1beta1.NewArrayOrString("refs/heads/main")},
+					{Name: "entire-repo", Value: *v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline", "commit": "main"})},
+					{Name: "new-repo", Value: *v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline", "commit": "v0.17.0"})},
+				},
+			}},
+		},
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
@@ -486,6 +548,53 @@ func TestApplyTaskResults_Conditions(t *testing.T) {
 	}
 }
 
+func TestApplyPipelineTaskStateContext(t *testing.T) {
+	replacements := map[string]string{
+		"tasks.task1.status": "Failed",
+		"tasks.status":       "Failed",
+	}
+	targets := PipelineRunState{{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "final-task",
+			TaskRef: &v1beta1.TaskRef{Name: "final-task"},
+			Params: []v1beta1.Param{{
+				Name:  "task1-status",
+				Value: *v1beta1.NewArrayOrString("$(tasks.task1.status)"),
+			}, {
+				Name:  "commit",
+				Value: *v1beta1.NewArrayOrString("$(tasks.task1.results.commit)"),
+			}},
+			WhenExpressions: v1beta1.WhenExpressions{{
+				Input:    "$(tasks.status)",
+				Operator: selection.In,
+				Values:   []string{"Failed"},
+			}},
+		},
+	}}
+	want := PipelineRunState{{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "final-task",
+			TaskRef: &v1beta1.TaskRef{Name: "final-task"},
+			Params: []v1beta1.Param{{
+				Name:  "task1-status",
+				Value: *v1beta1.NewArrayOrString("Failed"),
+			}, {
+				Name:  "commit",
+				Value: *v1beta1.NewArrayOrString("$(tasks.task1.results.commit)"),
+			}},
+			WhenExpressions: v1beta1.WhenExpressions{{
+				Input:    "Failed",
+				Operator: selection.In,
+				Values:   []string{"Failed"},
+			}},
+		},
+	}}
+	ApplyPipelineTaskStateContext(targets, replacements)
+	if d := cmp.Diff(want, targets); d != "" {
+		t.Fatalf("ApplyPipelineTaskStateContext() %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestContext(t *testing.T) {
 	for _, tc := range []struct {
 		description string
@@ -558,3 +667,65 @@ func TestContext(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyWorkspaces(t *testing.T) {
+	p := &v1beta1.PipelineSpec{
+		Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{
+			Name: "source",
+		}, {
+			Name:     "cache",
+			Optional: true,
+		}},
+		Tasks: []v1beta1.PipelineTask{{
+			Name:    "fetch-cache",
+			TaskRef: &v1beta1.TaskRef{Name: "fetch"},
+			WhenExpressions: v1beta1.WhenExpressions{{
+				Input:    "$(workspaces.cache.bound)",
+				Operator: selection.In,
+				Values:   []string{"true"},
+			}},
+		}, {
+			Name:    "build",
+			TaskRef: &v1beta1.TaskRef{Name: "build"},
+			Params: []v1beta1.Param{{
+				Name:  "use-source",
+				Value: *v1beta1.NewArrayOrString("$(workspaces.source.bound)"),
+			}},
+		}},
+	}
+	for _, tc := range []struct {
+		description   string
+		bindings      []v1beta1.WorkspaceBinding
+		expectedCache string
+	}{{
+		description: "optional workspace bound",
+		bindings: []v1beta1.WorkspaceBinding{{
+			Name:     "source",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		}, {
+			Name:     "cache",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		}},
+		expectedCache: "true",
+	}, {
+		description: "optional workspace unbound",
+		bindings: []v1beta1.WorkspaceBinding{{
+			Name:     "source",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		}},
+		expectedCache: "false",
+	}} {
+		t.Run(tc.description, func(t *testing.T) {
+			pr := &v1beta1.PipelineRun{
+				Spec: v1beta1.PipelineRunSpec{Workspaces: tc.bindings},
+			}
+			got := ApplyWorkspaces(p.DeepCopy(), pr)
+			if d := cmp.Diff(tc.expectedCache, got.Tasks[0].WhenExpressions[0].Input); d != "" {
+				t.Errorf("cache workspace bound variable: %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff("true", got.Tasks[1].Params[0].Value.StringVal); d != "" {
+				t.Errorf("source workspace bound variable: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -17,13 +17,64 @@ limitations under the License.
 package resources
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"github.com/tektoncd/pipeline/pkg/remote/oci"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 )
 
+// GetPipelineFunc is a factory function that will use the given PipelineRef as context to return a valid GetPipeline
+// function. It also requires a kubeclient, tektonclient, namespace, and service account in case it needs to find that
+// pipeline in cluster or authorize against an external repository. It will figure out whether it needs to look in the
+// cluster or in a remote image to fetch the reference.
+func GetPipelineFunc(ctx context.Context, k8s kubernetes.Interface, tekton clientset.Interface, pr *v1beta1.PipelineRef, namespace, saName string) (GetPipeline, error) {
+	cfg := config.FromContextOrDefaults(ctx)
+	if cfg.FeatureFlags.EnableTektonOCIBundles && pr != nil && pr.Bundle != "" {
+		// Return an inline function that implements GetPipeline by calling Resolver.Get with the pipeline kind and
+		// casting it to a PipelineInterface.
+		return func(name string) (v1beta1.PipelineInterface, error) {
+			// If there is a bundle url at all, construct an OCI resolver to fetch the pipeline, using the
+			// imagePullSecrets of the service account as credentials.
+			kc, err := k8schain.New(k8s, k8schain.Options{
+				Namespace:          namespace,
+				ServiceAccountName: saName,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get keychain: %w", err)
+			}
+			resolver := oci.NewResolver(pr.Bundle, kc)
+			obj, err := resolver.Get("pipeline", name)
+			if err != nil {
+				return nil, err
+			}
+			switch p := obj.(type) {
+			case *v1beta1.Pipeline:
+				return p, nil
+			case *v1alpha1.Pipeline:
+				converted := &v1beta1.Pipeline{}
+				if err := p.ConvertTo(ctx, converted); err != nil {
+					return nil, fmt.Errorf("failed to convert v1alpha1 Pipeline %s into v1beta1: %w", p.Name, err)
+				}
+				return converted, nil
+			}
+			return nil, fmt.Errorf("failed to convert %T into a Pipeline", obj)
+		}, nil
+	}
+
+	local := &LocalPipelineRefResolver{
+		Namespace:    namespace,
+		Tektonclient: tekton,
+	}
+	return local.GetPipeline, nil
+}
+
 // LocalPipelineRefResolver uses the current cluster to resolve a pipeline reference.
 type LocalPipelineRefResolver struct {
 	Namespace    string
@@ -17,15 +17,25 @@
 package resources_test
 
 import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/registry"
 	tb "github.com/tektoncd/pipeline/internal/builder/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	"github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
 )
 
 func TestPipelineRef(t *testing.T) {
@@ -81,3 +91,79 @@ func TestPipelineRef(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPipelineFunc(t *testing.T) {
+	// Set up a fake registry to push an image to.
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := config.ToContext(context.Background(), &config.Config{
+		FeatureFlags: &config.FeatureFlags{EnableTektonOCIBundles: true},
+	})
+	kubeclient := fakek8s.NewSimpleClientset(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"},
+	})
+
+	testcases := []struct {
+		name            string
+		localPipelines  []runtime.Object
+		remotePipelines []runtime.Object
+		ref             *v1beta1.PipelineRef
+		expected        runtime.Object
+	}{{
+		name: "remote-pipeline",
+		localPipelines: []runtime.Object{
+			tb.Pipeline("simple", tb.PipelineNamespace("default"), tb.PipelineType(), tb.PipelineSpec(tb.PipelineTask("something", "something"))),
+		},
+		remotePipelines: []runtime.Object{
+			tb.Pipeline("simple", tb.PipelineType()),
+			tb.Pipeline("dummy", tb.PipelineType()),
+		},
+		ref: &v1beta1.PipelineRef{
+			Name:   "simple",
+			Bundle: u.Host + "/remote-pipeline",
+		},
+		expected: tb.Pipeline("simple", tb.PipelineType()),
+	}, {
+		name: "local-pipeline",
+		localPipelines: []runtime.Object{
+			tb.Pipeline("simple", tb.PipelineNamespace("default"), tb.PipelineType()),
+		},
+		remotePipelines: []runtime.Object{
+			tb.Pipeline("simple", tb.PipelineType(), tb.PipelineSpec(tb.PipelineTask("something", "something"))),
+		},
+		ref: &v1beta1.PipelineRef{
+			Name: "simple",
+		},
+		expected: tb.Pipeline("simple", tb.PipelineNamespace("default"), tb.PipelineType()),
+	}}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tektonclient := fake.NewSimpleClientset(tc.localPipelines...)
+
+			_, err := test.CreateImage(fmt.Sprintf("%s/%s", u.Host, tc.name), tc.remotePipelines...)
+			if err != nil {
+				t.Fatalf("failed to upload test image: %s", err.Error())
+			}
+
+			fn, err := resources.GetPipelineFunc(ctx, kubeclient, tektonclient, tc.ref, "default", "default")
+			if err != nil {
+				t.Fatalf("failed to get pipeline fn: %s", err.Error())
+			}
+
+			pipeline, err := fn(tc.ref.Name)
+			if err != nil {
+				t.Fatalf("failed to call pipelinefn: %s", err.Error())
+			}
+
+			if d := cmp.Diff(pipeline, tc.expected); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
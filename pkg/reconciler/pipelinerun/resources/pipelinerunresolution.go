@@ -24,7 +24,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmeta"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/contexts"
@@ -62,76 +65,258 @@ func (e *ConditionNotFoundError) Error() string {
 
 // ResolvedPipelineRunTask contains a Task and its associated TaskRun, if it
 // exists. TaskRun can be nil to represent there being no TaskRun.
+// When the PipelineTask references a Custom Task, CustomTask is true and the
+// Task is executed through the Run named RunName instead of a TaskRun.
+// When the PipelineTask runs another Pipeline, ChildPipeline is true and the
+// Pipeline is executed through the PipelineRun named ChildPipelineRunName.
+// When the PipelineTask has a Matrix, the Task is executed through one TaskRun
+// per combination of the Matrix: TaskRunNames and TaskRuns hold them in the
+// order of the combinations, with a nil TaskRun for the ones not created yet.
 type ResolvedPipelineRunTask struct {
 	TaskRunName           string
 	TaskRun               *v1beta1.TaskRun
+	TaskRunNames          []string
+	TaskRuns              []*v1beta1.TaskRun
+	CustomTask            bool
+	RunName               string
+	Run                   *v1alpha1.Run
+	ChildPipeline         bool
+	ChildPipelineRunName  string
+	ChildPipelineRun      *v1beta1.PipelineRun
 	PipelineTask          *v1beta1.PipelineTask
 	ResolvedTaskResources *resources.ResolvedTaskResources
 	// ConditionChecks ~~TaskRuns but for evaling conditions
 	ResolvedConditionChecks TaskConditionCheckState // Could also be a TaskRun or maybe just a Pod?
+	// PipelineRunCancelled is true when the PipelineRun was cancelled gracefully, so that no
+	// new Tasks of its DAG are scheduled, only its finally Tasks
+	PipelineRunCancelled bool
+	// TasksTimedOut is true when the tasks of the PipelineRun exceeded their timeout, so that
+	// no new Tasks of its DAG are scheduled, only its finally Tasks
+	TasksTimedOut bool
 }
 
+// IsCustomTask returns true if the PipelineTask references a Custom Task.
+func (t ResolvedPipelineRunTask) IsCustomTask() bool {
+	return t.CustomTask
+}
+
+// IsChildPipeline returns true if the PipelineTask runs another Pipeline.
+func (t ResolvedPipelineRunTask) IsChildPipeline() bool {
+	return t.ChildPipeline
+}
+
+// IsMatrixed returns true if the PipelineTask fans out over a Matrix.
+func (t ResolvedPipelineRunTask) IsMatrixed() bool {
+	return t.PipelineTask != nil && t.PipelineTask.IsMatrixed()
+}
+
+// IsDone returns true when the TaskRun or Run of the PipelineTask has completed,
+// after all of its retries in the case of a TaskRun. A PipelineTask with a Matrix
+// is done when all of its TaskRuns have completed or have been cancelled.
 func (t ResolvedPipelineRunTask) IsDone() bool {
-	if t.TaskRun == nil || t.PipelineTask == nil {
+	if t.PipelineTask == nil {
 		return false
 	}
+	if t.IsCustomTask() {
+		return t.Run != nil && t.Run.IsDone()
+	}
+	if t.IsChildPipeline() {
+		return t.ChildPipelineRun != nil && t.ChildPipelineRun.IsDone()
+	}
+	if t.IsMatrixed() {
+		if len(t.TaskRuns) == 0 {
+			return false
+		}
+		for _, tr := range t.TaskRuns {
+			if !isTaskRunDone(tr, t.PipelineTask.Retries) && !isTaskRunCancelled(tr) {
+				return false
+			}
+		}
+		return true
+	}
+	return isTaskRunDone(t.TaskRun, t.PipelineTask.Retries)
+}
 
-	status := t.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
-	retriesDone := len(t.TaskRun.Status.RetriesStatus)
-	retries := t.PipelineTask.Retries
+// IsSuccessful returns true only if the taskrun itself has completed successfully,
+// or all of the TaskRuns of a PipelineTask with a Matrix have
+func (t ResolvedPipelineRunTask) IsSuccessful() bool {
+	if t.IsCustomTask() {
+		return t.Run != nil && t.Run.IsSuccessful()
+	}
+	if t.IsChildPipeline() {
+		return t.ChildPipelineRun != nil && t.ChildPipelineRun.Status.GetCondition(apis.ConditionSucceeded).IsTrue()
+	}
+	if t.IsMatrixed() {
+		if len(t.TaskRuns) == 0 {
+			return false
+		}
+		for _, tr := range t.TaskRuns {
+			if !isTaskRunSuccessful(tr) {
+				return false
+			}
+		}
+		return true
+	}
+	return isTaskRunSuccessful(t.TaskRun)
+}
+
+// IsFailure returns true only if the taskrun itself has failed. A PipelineTask
+// with a Matrix has failed once all of its TaskRuns are done and any of them failed.
+func (t ResolvedPipelineRunTask) IsFailure() bool {
+	if t.IsCustomTask() {
+		return t.Run != nil && t.Run.Status.GetCondition(apis.ConditionSucceeded).IsFalse()
+	}
+	if t.IsChildPipeline() {
+		return t.ChildPipelineRun != nil && t.ChildPipelineRun.Status.GetCondition(apis.ConditionSucceeded).IsFalse()
+	}
+	if t.IsMatrixed() {
+		if !t.IsDone() {
+			return false
+		}
+		for _, tr := range t.TaskRuns {
+			if isTaskRunFailure(tr, t.PipelineTask.Retries) {
+				return true
+			}
+		}
+		return false
+	}
+	return isTaskRunFailure(t.TaskRun, t.PipelineTask.Retries)
+}
+
+// IsCancelled returns true only if the taskrun itself has cancelled. A PipelineTask
+// with a Matrix is cancelled once all of its TaskRuns are done and any of them was cancelled.
+func (t ResolvedPipelineRunTask) IsCancelled() bool {
+	if t.IsCustomTask() {
+		if t.Run == nil {
+			return false
+		}
+		c := t.Run.Status.GetCondition(apis.ConditionSucceeded)
+		return c.IsFalse() && c.Reason == v1alpha1.RunReasonCancelled.String()
+	}
+	if t.IsChildPipeline() {
+		return t.ChildPipelineRun != nil && t.ChildPipelineRun.IsCancelled() &&
+			t.ChildPipelineRun.Status.GetCondition(apis.ConditionSucceeded).IsFalse()
+	}
+	if t.IsMatrixed() {
+		if !t.IsDone() {
+			return false
+		}
+		for _, tr := range t.TaskRuns {
+			if isTaskRunCancelled(tr) {
+				return true
+			}
+		}
+		return false
+	}
+	return isTaskRunCancelled(t.TaskRun)
+}
+
+// IsStarted returns true only if the PipelineRunTask itself has a TaskRun associated
+func (t ResolvedPipelineRunTask) IsStarted() bool {
+	if t.IsCustomTask() {
+		// A Run only gets a status once its custom task controller picks it up,
+		// so it is considered started as soon as it exists.
+		return t.Run != nil
+	}
+	if t.IsChildPipeline() {
+		return t.ChildPipelineRun != nil
+	}
+	if t.IsMatrixed() {
+		for _, tr := range t.TaskRuns {
+			if isTaskRunStarted(tr) {
+				return true
+			}
+		}
+		return false
+	}
+	return isTaskRunStarted(t.TaskRun)
+}
+
+// SchedulableMatrixCombinations returns the indexes of the combinations of the Matrix of the
+// PipelineTask whose TaskRun needs to be created, or retried after a failure.
+func (t ResolvedPipelineRunTask) SchedulableMatrixCombinations() []int {
+	var indexes []int
+	for i, tr := range t.TaskRuns {
+		if isTaskRunSchedulable(tr, t.PipelineTask.Retries) {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// isScheduled returns true if a TaskRun, Run or child PipelineRun has been created for the PipelineTask
+func (t ResolvedPipelineRunTask) isScheduled() bool {
+	if t.TaskRun != nil || t.Run != nil || t.ChildPipelineRun != nil {
+		return true
+	}
+	for _, tr := range t.TaskRuns {
+		if tr != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func isTaskRunDone(tr *v1beta1.TaskRun, retries int) bool {
+	if tr == nil {
+		return false
+	}
+	status := tr.Status.GetCondition(apis.ConditionSucceeded)
+	retriesDone := len(tr.Status.RetriesStatus)
 	return status.IsTrue() || status.IsFalse() && retriesDone >= retries
 }
 
-// IsSuccessful returns true only if the taskrun itself has completed successfully
-func (t ResolvedPipelineRunTask) IsSuccessful() bool {
-	if t.TaskRun == nil {
+func isTaskRunSuccessful(tr *v1beta1.TaskRun) bool {
+	if tr == nil {
 		return false
 	}
-	c := t.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
+	c := tr.Status.GetCondition(apis.ConditionSucceeded)
 	if c == nil {
 		return false
 	}
-
 	return c.Status == corev1.ConditionTrue
 }
 
-// IsFailure returns true only if the taskrun itself has failed
-func (t ResolvedPipelineRunTask) IsFailure() bool {
-	if t.TaskRun == nil {
+func isTaskRunFailure(tr *v1beta1.TaskRun, retries int) bool {
+	if tr == nil {
 		return false
 	}
-	c := t.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
-	retriesDone := len(t.TaskRun.Status.RetriesStatus)
-	retries := t.PipelineTask.Retries
+	c := tr.Status.GetCondition(apis.ConditionSucceeded)
+	retriesDone := len(tr.Status.RetriesStatus)
 	return c.IsFalse() && retriesDone >= retries
 }
 
-// IsCancelled returns true only if the taskrun itself has cancelled
-func (t ResolvedPipelineRunTask) IsCancelled() bool {
-	if t.TaskRun == nil {
+func isTaskRunCancelled(tr *v1beta1.TaskRun) bool {
+	if tr == nil {
 		return false
 	}
-
-	c := t.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
+	c := tr.Status.GetCondition(apis.ConditionSucceeded)
 	if c == nil {
 		return false
 	}
-
 	return c.IsFalse() && c.Reason == v1beta1.TaskRunReasonCancelled.String()
 }
 
-// IsStarted returns true only if the PipelineRunTask itself has a TaskRun associated
-func (t ResolvedPipelineRunTask) IsStarted() bool {
-	if t.TaskRun == nil {
+func isTaskRunStarted(tr *v1beta1.TaskRun) bool {
+	if tr == nil {
 		return false
 	}
+	return tr.Status.GetCondition(apis.ConditionSucceeded) != nil
+}
 
-	c := t.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
-	if c == nil {
-		return false
+// isTaskRunSchedulable returns true if tr doesn't exist yet, or if it failed and
+// can still be retried. Cancelled TaskRuns are never retried.
+func isTaskRunSchedulable(tr *v1beta1.TaskRun, retries int) bool {
+	if tr == nil {
+		return true
 	}
-
-	return true
+	status := tr.Status.GetCondition(apis.ConditionSucceeded)
+	if status != nil && status.IsFalse() {
+		if !(tr.IsCancelled() || status.Reason == v1beta1.TaskRunReasonCancelled.String() || status.Reason == ReasonConditionCheckFailed) {
+			return len(tr.Status.RetriesStatus) < retries
+		}
+	}
+	return false
 }
 
 func (t *ResolvedPipelineRunTask) checkParentsDone(state PipelineRunState, d *dag.Graph) bool {
@@ -150,11 +335,24 @@ func (t *ResolvedPipelineRunTask) checkParentsDone(state PipelineRunState, d *da
 	return true
 }
 
+// skipBecauseResultReferencesAreMissing returns true once all the tasks of the DAG d are done,
+// if the results referenced by the params or when expressions of the final task can't be resolved
+func (t *ResolvedPipelineRunTask) skipBecauseResultReferencesAreMissing(state PipelineRunState, d *dag.Graph) bool {
+	if !state.checkTasksDone(d) {
+		return false
+	}
+	if _, err := ResolveResultRefs(state, PipelineRunState{t}); err != nil {
+		return true
+	}
+	return false
+}
+
 // Skip returns true if a PipelineTask will not be run because
 // (1) its When Expressions evaluated to false
 // (2) its Condition Checks failed
 // (3) its parent task was skipped
 // (4) Pipeline is in stopping state (one of the PipelineTasks failed)
+// (5) it is a final task consuming results of tasks of the DAG which are not available
 // Note that this means Skip returns false if a conditionCheck is in progress
 func (t *ResolvedPipelineRunTask) Skip(state PipelineRunState, d *dag.Graph) bool {
 	// it already has TaskRun associated with it - PipelineTask not skipped
@@ -185,6 +383,12 @@ func (t *ResolvedPipelineRunTask) Skip(state PipelineRunState, d *dag.Graph) boo
 		return true
 	}
 
+	// Skip the final task if the results it consumes can't be resolved, because the tasks
+	// producing them did not succeed or did not produce them
+	if !isTaskInGraph(t.PipelineTask.Name, d) && t.skipBecauseResultReferencesAreMissing(state, d) {
+		return true
+	}
+
 	stateMap := state.ToMap()
 	// Recursively look at parent tasks to see if they have been skipped,
 	// if any of the parents have been skipped, skip as well
@@ -202,6 +406,12 @@ func (t *ResolvedPipelineRunTask) Skip(state PipelineRunState, d *dag.Graph) boo
 // GetTaskRun is a function that will retrieve the TaskRun name.
 type GetTaskRun func(name string) (*v1beta1.TaskRun, error)
 
+// GetRun is a function that will retrieve a Run by name.
+type GetRun func(name string) (*v1alpha1.Run, error)
+
+// GetPipelineRun is a function that will retrieve a PipelineRun by name.
+type GetPipelineRun func(name string) (*v1beta1.PipelineRun, error)
+
 // GetResourcesFromBindings will retrieve all Resources bound in PipelineRun pr and return a map
 // from the declared name of the PipelineResource (which is how the PipelineResource will
 // be referred to in the PipelineRun) to the PipelineResource, obtained via getResource.
@@ -255,6 +465,9 @@ func ValidateWorkspaceBindings(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun)
 	}
 
 	for _, ws := range p.Workspaces {
+		if ws.Optional {
+			continue
+		}
 		if _, ok := pipelineRunWorkspaces[ws.Name]; !ok {
 			return fmt.Errorf("pipeline expects workspace with name %q be provided by pipelinerun", ws.Name)
 		}
@@ -302,6 +515,8 @@ func ResolvePipelineRun(
 	getTask resources.GetTask,
 	getTaskRun resources.GetTaskRun,
 	getClusterTask resources.GetClusterTask,
+	getRun GetRun,
+	getPipelineRun GetPipelineRun,
 	getCondition GetCondition,
 	tasks []v1beta1.PipelineTask,
 	providedResources map[string]*resourcev1alpha1.PipelineResource,
@@ -309,71 +524,144 @@ func ResolvePipelineRun(
 
 	state := []*ResolvedPipelineRunTask{}
 	for i := range tasks {
-		pt := tasks[i]
+		rprt, err := ResolvePipelineRunTask(ctx, pipelineRun, getTask, getTaskRun, getClusterTask, getRun, getPipelineRun, getCondition, tasks[i], providedResources)
+		if err != nil {
+			return nil, err
+		}
+		// Add this task to the state of the PipelineRun
+		state = append(state, rprt)
+	}
+	return state, nil
+}
 
-		rprt := ResolvedPipelineRunTask{
-			PipelineTask: &pt,
-			TaskRunName:  GetTaskRunName(pipelineRun.Status.TaskRuns, pt.Name, pipelineRun.Name),
+// ResolvePipelineRunTask retrieves the Task instance referenced by a single PipelineTask,
+// getting it from getTask or getClusterTask. If it is unable to retrieve the referenced
+// Task, it will return an error, otherwise it returns the resolved PipelineTask.
+// It will retrieve the Resources needed for the TaskRun using the mapping of providedResources.
+// PipelineTasks referencing a Custom Task are not resolved against any Task; only
+// their Run, if it exists, is retrieved using getRun. Likewise, PipelineTasks running
+// another Pipeline only have their child PipelineRun retrieved using getPipelineRun.
+func ResolvePipelineRunTask(
+	ctx context.Context,
+	pipelineRun v1beta1.PipelineRun,
+	getTask resources.GetTask,
+	getTaskRun resources.GetTaskRun,
+	getClusterTask resources.GetClusterTask,
+	getRun GetRun,
+	getPipelineRun GetPipelineRun,
+	getCondition GetCondition,
+	pt v1beta1.PipelineTask,
+	providedResources map[string]*resourcev1alpha1.PipelineResource,
+) (*ResolvedPipelineRunTask, error) {
+	rprt := ResolvedPipelineRunTask{
+		PipelineTask:         &pt,
+		PipelineRunCancelled: pipelineRun.IsGracefullyCancelled(),
+		TasksTimedOut:        pipelineRun.HaveTasksTimedOut(),
+	}
+
+	if pt.IsCustomTask() {
+		rprt.CustomTask = true
+		rprt.RunName = GetRunName(pipelineRun.Status.Runs, pt.Name, pipelineRun.Name)
+		run, err := getRun(rprt.RunName)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("error retrieving Run %s: %w", rprt.RunName, err)
 		}
+		if run != nil {
+			rprt.Run = run
+		}
+		return &rprt, nil
+	}
 
-		// Find the Task that this PipelineTask is using
-		var (
-			t        v1beta1.TaskInterface
-			err      error
-			spec     v1beta1.TaskSpec
-			taskName string
-			kind     v1beta1.TaskKind
-		)
+	if pt.IsChildPipeline() {
+		rprt.ChildPipeline = true
+		rprt.ChildPipelineRunName = GetChildPipelineRunName(pipelineRun.Status.ChildPipelineRuns, pt.Name, pipelineRun.Name)
+		child, err := getPipelineRun(rprt.ChildPipelineRunName)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("error retrieving PipelineRun %s: %w", rprt.ChildPipelineRunName, err)
+		}
+		if child != nil {
+			rprt.ChildPipelineRun = child
+		}
+		return &rprt, nil
+	}
 
-		if pt.TaskRef != nil {
-			if pt.TaskRef.Kind == v1beta1.ClusterTaskKind {
-				t, err = getClusterTask(pt.TaskRef.Name)
-			} else {
-				t, err = getTask(pt.TaskRef.Name)
-			}
-			if err != nil {
-				return nil, &TaskNotFoundError{
-					Name: pt.TaskRef.Name,
-					Msg:  err.Error(),
-				}
-			}
-			spec = t.TaskSpec()
-			taskName = t.TaskMetadata().Name
-			kind = pt.TaskRef.Kind
+	// Find the Task that this PipelineTask is using
+	var (
+		t        v1beta1.TaskInterface
+		err      error
+		spec     v1beta1.TaskSpec
+		taskName string
+		kind     v1beta1.TaskKind
+	)
+
+	if pt.TaskRef != nil {
+		if pt.TaskRef.Kind == v1beta1.ClusterTaskKind {
+			t, err = getClusterTask(pt.TaskRef.Name)
 		} else {
-			spec = *pt.TaskSpec.TaskSpec
+			t, err = getTask(pt.TaskRef.Name)
 		}
-		spec.SetDefaults(contexts.WithUpgradeViaDefaulting(ctx))
-		rtr, err := ResolvePipelineTaskResources(pt, &spec, taskName, kind, providedResources)
 		if err != nil {
-			return nil, fmt.Errorf("couldn't match referenced resources with declared resources: %w", err)
+			return nil, &TaskNotFoundError{
+				Name: pt.TaskRef.Name,
+				Msg:  err.Error(),
+			}
 		}
+		spec = t.TaskSpec()
+		taskName = t.TaskMetadata().Name
+		kind = pt.TaskRef.Kind
+	} else {
+		spec = *pt.TaskSpec.TaskSpec
+	}
+	spec.SetDefaults(contexts.WithUpgradeViaDefaulting(ctx))
+	rtr, err := ResolvePipelineTaskResources(pt, &spec, taskName, kind, providedResources)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't match referenced resources with declared resources: %w", err)
+	}
 
-		rprt.ResolvedTaskResources = rtr
+	rprt.ResolvedTaskResources = rtr
 
-		taskRun, err := getTaskRun(rprt.TaskRunName)
-		if err != nil {
-			if !errors.IsNotFound(err) {
-				return nil, fmt.Errorf("error retrieving TaskRun %s: %w", rprt.TaskRunName, err)
-			}
+	if pt.IsMatrixed() {
+		count := pt.MatrixCombinationsCount()
+		if count == 0 {
+			return nil, fmt.Errorf("matrix of pipeline task %q has no combinations", pt.Name)
 		}
-		if taskRun != nil {
-			rprt.TaskRun = taskRun
+		if max := maxMatrixCombinationsCount(ctx); count > max {
+			return nil, fmt.Errorf("matrix of pipeline task %q fans out to %d combinations, more than the maximum of %d", pt.Name, count, max)
 		}
-
-		// Get all conditions that this pipelineTask will be using, if any
-		if len(pt.Conditions) > 0 {
-			rcc, err := resolveConditionChecks(&pt, pipelineRun.Status.TaskRuns, rprt.TaskRunName, getTaskRun, getCondition, providedResources)
-			if err != nil {
-				return nil, err
+		rprt.TaskRunNames = GetNamesOfTaskRuns(pt.Name, pipelineRun.Name, count)
+		rprt.TaskRuns = make([]*v1beta1.TaskRun, count)
+		for i, name := range rprt.TaskRunNames {
+			taskRun, err := getTaskRun(name)
+			if err != nil && !errors.IsNotFound(err) {
+				return nil, fmt.Errorf("error retrieving TaskRun %s: %w", name, err)
+			}
+			if taskRun != nil {
+				rprt.TaskRuns[i] = taskRun
 			}
-			rprt.ResolvedConditionChecks = rcc
 		}
+		return &rprt, nil
+	}
 
-		// Add this task to the state of the PipelineRun
-		state = append(state, &rprt)
+	rprt.TaskRunName = GetTaskRunName(pipelineRun.Status.TaskRuns, pt.Name, pipelineRun.Name)
+	taskRun, err := getTaskRun(rprt.TaskRunName)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("error retrieving TaskRun %s: %w", rprt.TaskRunName, err)
+		}
 	}
-	return state, nil
+	if taskRun != nil {
+		rprt.TaskRun = taskRun
+	}
+
+	// Get all conditions that this pipelineTask will be using, if any
+	if len(pt.Conditions) > 0 {
+		rcc, err := resolveConditionChecks(&pt, pipelineRun.Status.TaskRuns, rprt.TaskRunName, getTaskRun, getCondition, providedResources)
+		if err != nil {
+			return nil, err
+		}
+		rprt.ResolvedConditionChecks = rcc
+	}
+	return &rprt, nil
 }
 
 // getConditionCheckName should return a unique name for a `ConditionCheck` if one has not already been defined, and the existing one otherwise.
@@ -401,6 +689,48 @@ func GetTaskRunName(taskRunsStatus map[string]*v1beta1.PipelineRunTaskRunStatus,
 	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", prName, ptName))
 }
 
+// GetNamesOfTaskRuns returns the names of the TaskRuns a PipelineTask with a Matrix fans out to,
+// one per combination of the Matrix. Since the names need to match the combinations across
+// reconciles, they are derived from the index of the combination instead of a random suffix.
+func GetNamesOfTaskRuns(ptName, prName string, count int) []string {
+	taskRunNames := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		taskRunNames = append(taskRunNames, kmeta.ChildName(fmt.Sprintf("%s-%s", prName, ptName), fmt.Sprintf("-%d", i)))
+	}
+	return taskRunNames
+}
+
+// maxMatrixCombinationsCount returns the maximum number of TaskRuns a PipelineTask with a
+// Matrix is allowed to fan out to.
+func maxMatrixCombinationsCount(ctx context.Context) int {
+	if defaults := config.FromContextOrDefaults(ctx).Defaults; defaults != nil {
+		return defaults.DefaultMaxMatrixCombinationsCount
+	}
+	return config.DefaultMaxMatrixCombinationsCount
+}
+
+// GetRunName should return a unique name for a `Run` if one has not already been defined, and the existing one otherwise.
+func GetRunName(runsStatus map[string]*v1beta1.PipelineRunRunStatus, ptName, prName string) string {
+	for k, v := range runsStatus {
+		if v.PipelineTaskName == ptName {
+			return k
+		}
+	}
+
+	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", prName, ptName))
+}
+
+// GetChildPipelineRunName should return a unique name for a child `PipelineRun` if one has not already been defined, and the existing one otherwise.
+func GetChildPipelineRunName(childPipelineRunsStatus map[string]*v1beta1.PipelineRunChildStatus, ptName, prName string) string {
+	for k, v := range childPipelineRunsStatus {
+		if v.PipelineTaskName == ptName {
+			return k
+		}
+	}
+
+	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", prName, ptName))
+}
+
 func resolveConditionChecks(pt *v1beta1.PipelineTask, taskRunStatus map[string]*v1beta1.PipelineRunTaskRunStatus, taskRunName string, getTaskRun resources.GetTaskRun, getCondition GetCondition, providedResources map[string]*resourcev1alpha1.PipelineResource) ([]*ResolvedConditionCheck, error) {
 	rccs := []*ResolvedConditionCheck{}
 	for i := range pt.Conditions {
@@ -26,6 +26,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	tbv1alpha1 "github.com/tektoncd/pipeline/internal/builder/v1alpha1"
 	tb "github.com/tektoncd/pipeline/internal/builder/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
@@ -852,6 +853,78 @@ func TestIsSkipped(t *testing.T) {
 	}
 }
 
+func TestIsSkipped_FinalTasks(t *testing.T) {
+	finalTask := v1beta1.PipelineTask{
+		Name:    "final-task",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		Params: []v1beta1.Param{{
+			Name:  "commit",
+			Value: *v1beta1.NewArrayOrString("$(tasks.mytask1.results.commit)"),
+		}},
+	}
+	withCommitResult := func(tr *v1beta1.TaskRun) *v1beta1.TaskRun {
+		tr.Status.TaskRunResults = []v1beta1.TaskRunResult{{Name: "commit", Value: "abc123"}}
+		return tr
+	}
+
+	tcs := []struct {
+		name      string
+		taskRun   *v1beta1.TaskRun
+		finalTask v1beta1.PipelineTask
+		expected  bool
+	}{{
+		name:      "final task consuming the result of a successful task",
+		taskRun:   withCommitResult(makeSucceeded(trs[0])),
+		finalTask: finalTask,
+		expected:  false,
+	}, {
+		name:      "final task consuming the result of a failed task",
+		taskRun:   withCommitResult(makeFailed(trs[0])),
+		finalTask: finalTask,
+		expected:  true,
+	}, {
+		name:      "final task consuming a result the successful task did not produce",
+		taskRun:   makeSucceeded(trs[0]),
+		finalTask: finalTask,
+		expected:  true,
+	}, {
+		name:      "final task consuming the result of a running task",
+		taskRun:   makeStarted(trs[0]),
+		finalTask: finalTask,
+		expected:  false,
+	}, {
+		name:    "final task consuming no results after a failed task",
+		taskRun: makeFailed(trs[0]),
+		finalTask: v1beta1.PipelineTask{
+			Name:    "final-task",
+			TaskRef: &v1beta1.TaskRef{Name: "task"},
+		},
+		expected: false,
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			finalTask := tc.finalTask
+			rprt := &ResolvedPipelineRunTask{
+				PipelineTask: &finalTask,
+				TaskRunName:  "pipelinerun-final-task",
+			}
+			state := PipelineRunState{{
+				PipelineTask: &pts[0],
+				TaskRunName:  "pipelinerun-mytask1",
+				TaskRun:      tc.taskRun,
+			}, rprt}
+			d, err := dag.Build(v1beta1.PipelineTaskList([]v1beta1.PipelineTask{pts[0]}))
+			if err != nil {
+				t.Fatalf("Could not build the dag of the tasks: %v", err)
+			}
+			if d := cmp.Diff(tc.expected, rprt.Skip(state, d)); d != "" {
+				t.Errorf("Didn't get expected isSkipped %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func getExpectedMessage(status corev1.ConditionStatus, successful, incomplete, skipped, failed, cancelled int) string {
 	if status == corev1.ConditionFalse || status == corev1.ConditionTrue {
 		return fmt.Sprintf("Tasks Completed: %d (Failed: %d, Cancelled %d), Skipped: %d",
@@ -977,7 +1050,7 @@ func TestResolvePipelineRun(t *testing.T) {
 	getClusterTask := func(name string) (v1beta1.TaskInterface, error) { return nil, nil }
 	getCondition := func(name string) (*v1alpha1.Condition, error) { return nil, nil }
 
-	pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, getCondition, p.Spec.Tasks, providedResources)
+	pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, nopGetRun, nopGetPipelineRun, getCondition, p.Spec.Tasks, providedResources)
 	if err != nil {
 		t.Fatalf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
 	}
@@ -1034,6 +1107,294 @@ func TestResolvePipelineRun(t *testing.T) {
 	}
 }
 
+func TestResolvePipelineRun_CustomTask(t *testing.T) {
+	names.TestingSeed()
+	pts := []v1beta1.PipelineTask{{
+		Name:    "customtask",
+		TaskRef: &v1beta1.TaskRef{APIVersion: "example.dev/v0", Kind: "Example"},
+	}, {
+		Name:    "run-exists",
+		TaskRef: &v1beta1.TaskRef{APIVersion: "example.dev/v0", Kind: "Example"},
+	}}
+	pr := v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+		Status: v1beta1.PipelineRunStatus{
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				Runs: map[string]*v1beta1.PipelineRunRunStatus{
+					"pipelinerun-run-exists-xxyyy": {PipelineTaskName: "run-exists"},
+				},
+			},
+		},
+	}
+	run := &v1alpha1.Run{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-run-exists-xxyyy"}}
+	getTask := func(name string) (v1beta1.TaskInterface, error) { return nil, errors.New("should not get called") }
+	getTaskRun := func(name string) (*v1beta1.TaskRun, error) { return nil, errors.New("should not get called") }
+	getClusterTask := func(name string) (v1beta1.TaskInterface, error) { return nil, errors.New("should not get called") }
+	getCondition := func(name string) (*v1alpha1.Condition, error) { return nil, errors.New("should not get called") }
+	getRun := func(name string) (*v1alpha1.Run, error) {
+		if name == run.Name {
+			return run, nil
+		}
+		return nil, kerrors.NewNotFound(v1alpha1.Resource("run"), name)
+	}
+
+	pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, getRun, nopGetPipelineRun, getCondition, pts, nil)
+	if err != nil {
+		t.Fatalf("Error resolving custom tasks: %s", err)
+	}
+	expectedState := PipelineRunState{{
+		PipelineTask: &pts[0],
+		CustomTask:   true,
+		RunName:      "pipelinerun-customtask-9l9zj",
+	}, {
+		PipelineTask: &pts[1],
+		CustomTask:   true,
+		RunName:      "pipelinerun-run-exists-xxyyy",
+		Run:          run,
+	}}
+	if d := cmp.Diff(expectedState, pipelineState); d != "" {
+		t.Errorf("Unexpected pipeline state: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolvePipelineRun_ChildPipeline(t *testing.T) {
+	names.TestingSeed()
+	pts := []v1beta1.PipelineTask{{
+		Name:        "child",
+		PipelineRef: &v1beta1.PipelineRef{Name: "build"},
+	}, {
+		Name:         "child-exists",
+		PipelineSpec: &v1beta1.PipelineSpec{Tasks: []v1beta1.PipelineTask{{Name: "foo", TaskRef: &v1beta1.TaskRef{Name: "task"}}}},
+	}}
+	pr := v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+		Status: v1beta1.PipelineRunStatus{
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				ChildPipelineRuns: map[string]*v1beta1.PipelineRunChildStatus{
+					"pipelinerun-child-exists-xxyyy": {PipelineTaskName: "child-exists"},
+				},
+			},
+		},
+	}
+	child := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-child-exists-xxyyy"}}
+	getTask := func(name string) (v1beta1.TaskInterface, error) { return nil, errors.New("should not get called") }
+	getTaskRun := func(name string) (*v1beta1.TaskRun, error) { return nil, errors.New("should not get called") }
+	getClusterTask := func(name string) (v1beta1.TaskInterface, error) { return nil, errors.New("should not get called") }
+	getCondition := func(name string) (*v1alpha1.Condition, error) { return nil, errors.New("should not get called") }
+	getPipelineRun := func(name string) (*v1beta1.PipelineRun, error) {
+		if name == child.Name {
+			return child, nil
+		}
+		return nil, kerrors.NewNotFound(v1beta1.Resource("pipelinerun"), name)
+	}
+
+	pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, nopGetRun, getPipelineRun, getCondition, pts, nil)
+	if err != nil {
+		t.Fatalf("Error resolving child pipelines: %s", err)
+	}
+	expectedState := PipelineRunState{{
+		PipelineTask:         &pts[0],
+		ChildPipeline:        true,
+		ChildPipelineRunName: "pipelinerun-child-9l9zj",
+	}, {
+		PipelineTask:         &pts[1],
+		ChildPipeline:        true,
+		ChildPipelineRunName: "pipelinerun-child-exists-xxyyy",
+		ChildPipelineRun:     child,
+	}}
+	if d := cmp.Diff(expectedState, pipelineState); d != "" {
+		t.Errorf("Unexpected pipeline state: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolvePipelineRun_Matrix(t *testing.T) {
+	pt := v1beta1.PipelineTask{
+		Name:    "build",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		Matrix: []v1beta1.Param{{
+			Name:  "platform",
+			Value: *v1beta1.NewArrayOrString("linux", "mac", "windows"),
+		}},
+	}
+	pr := v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+	}
+	existing := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-build-1"}}
+	getTask := func(name string) (v1beta1.TaskInterface, error) { return task, nil }
+	getTaskRun := func(name string) (*v1beta1.TaskRun, error) {
+		if name == existing.Name {
+			return existing, nil
+		}
+		return nil, kerrors.NewNotFound(v1beta1.Resource("taskrun"), name)
+	}
+	getClusterTask := func(name string) (v1beta1.TaskInterface, error) { return nil, errors.New("should not get called") }
+	getCondition := func(name string) (*v1alpha1.Condition, error) { return nil, errors.New("should not get called") }
+
+	pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, nopGetRun, nopGetPipelineRun, getCondition, []v1beta1.PipelineTask{pt}, nil)
+	if err != nil {
+		t.Fatalf("Error resolving matrix: %s", err)
+	}
+	if len(pipelineState) != 1 {
+		t.Fatalf("Expected one resolved pipeline task but got %d", len(pipelineState))
+	}
+	rprt := pipelineState[0]
+	if rprt.TaskRunName != "" {
+		t.Errorf("Expected no TaskRunName for a matrix but got %q", rprt.TaskRunName)
+	}
+	wantNames := []string{"pipelinerun-build-0", "pipelinerun-build-1", "pipelinerun-build-2"}
+	if d := cmp.Diff(wantNames, rprt.TaskRunNames); d != "" {
+		t.Errorf("Unexpected TaskRun names %s", diff.PrintWantGot(d))
+	}
+	wantTaskRuns := []*v1beta1.TaskRun{nil, existing, nil}
+	if d := cmp.Diff(wantTaskRuns, rprt.TaskRuns); d != "" {
+		t.Errorf("Unexpected TaskRuns %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff([]int{0, 2}, rprt.SchedulableMatrixCombinations()); d != "" {
+		t.Errorf("Unexpected schedulable combinations %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolvePipelineRun_MatrixErrors(t *testing.T) {
+	getTask := func(name string) (v1beta1.TaskInterface, error) { return task, nil }
+	getTaskRun := func(name string) (*v1beta1.TaskRun, error) {
+		return nil, kerrors.NewNotFound(v1beta1.Resource("taskrun"), name)
+	}
+	getClusterTask := func(name string) (v1beta1.TaskInterface, error) { return nil, errors.New("should not get called") }
+	getCondition := func(name string) (*v1alpha1.Condition, error) { return nil, errors.New("should not get called") }
+	pr := v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"}}
+
+	for _, tc := range []struct {
+		name    string
+		ctx     context.Context
+		pt      v1beta1.PipelineTask
+		wantErr string
+	}{{
+		name: "no combinations",
+		ctx:  context.Background(),
+		pt: v1beta1.PipelineTask{
+			Name:    "build",
+			TaskRef: &v1beta1.TaskRef{Name: "task"},
+			Matrix: []v1beta1.Param{{
+				Name:  "platform",
+				Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{}},
+			}},
+		},
+		wantErr: `matrix of pipeline task "build" has no combinations`,
+	}, {
+		name: "too many combinations",
+		ctx: config.ToContext(context.Background(), &config.Config{
+			Defaults: &config.Defaults{DefaultMaxMatrixCombinationsCount: 3},
+		}),
+		pt: v1beta1.PipelineTask{
+			Name:    "build",
+			TaskRef: &v1beta1.TaskRef{Name: "task"},
+			Matrix: []v1beta1.Param{{
+				Name:  "platform",
+				Value: *v1beta1.NewArrayOrString("linux", "mac"),
+			}, {
+				Name:  "browser",
+				Value: *v1beta1.NewArrayOrString("chrome", "firefox"),
+			}},
+		},
+		wantErr: `matrix of pipeline task "build" fans out to 4 combinations, more than the maximum of 3`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ResolvePipelineRun(tc.ctx, pr, getTask, getTaskRun, getClusterTask, nopGetRun, nopGetPipelineRun, getCondition, []v1beta1.PipelineTask{tc.pt}, nil)
+			if err == nil {
+				t.Fatalf("Expected error %q but got none", tc.wantErr)
+			}
+			if d := cmp.Diff(tc.wantErr, err.Error()); d != "" {
+				t.Errorf("Unexpected error %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestResolvedPipelineRunTask_MatrixStatus(t *testing.T) {
+	pt := &v1beta1.PipelineTask{
+		Name:    "build",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		Retries: 1,
+		Matrix: []v1beta1.Param{{
+			Name:  "platform",
+			Value: *v1beta1.NewArrayOrString("linux", "mac"),
+		}},
+	}
+	for _, tc := range []struct {
+		name          string
+		taskRuns      []*v1beta1.TaskRun
+		wantDone      bool
+		wantSucceeded bool
+		wantFailed    bool
+		wantCancelled bool
+		wantStarted   bool
+	}{{
+		name:     "not started",
+		taskRuns: []*v1beta1.TaskRun{nil, nil},
+	}, {
+		name:        "partially created",
+		taskRuns:    []*v1beta1.TaskRun{makeSucceeded(trs[0]), nil},
+		wantStarted: true,
+	}, {
+		name:        "running",
+		taskRuns:    []*v1beta1.TaskRun{makeSucceeded(trs[0]), makeStarted(trs[1])},
+		wantStarted: true,
+	}, {
+		name:          "succeeded",
+		taskRuns:      []*v1beta1.TaskRun{makeSucceeded(trs[0]), makeSucceeded(trs[1])},
+		wantDone:      true,
+		wantSucceeded: true,
+		wantStarted:   true,
+	}, {
+		name:        "failed with retries left",
+		taskRuns:    []*v1beta1.TaskRun{makeSucceeded(trs[0]), makeFailed(trs[1])},
+		wantStarted: true,
+	}, {
+		name:        "failed after retries",
+		taskRuns:    []*v1beta1.TaskRun{makeSucceeded(trs[0]), withRetries(makeFailed(trs[1]))},
+		wantDone:    true,
+		wantFailed:  true,
+		wantStarted: true,
+	}, {
+		name:          "cancelled",
+		taskRuns:      []*v1beta1.TaskRun{withCancelled(makeFailed(trs[0])), makeSucceeded(trs[1])},
+		wantDone:      true,
+		wantCancelled: true,
+		wantStarted:   true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rprt := ResolvedPipelineRunTask{
+				PipelineTask: pt,
+				TaskRunNames: []string{"pipelinerun-build-0", "pipelinerun-build-1"},
+				TaskRuns:     tc.taskRuns,
+			}
+			if got := rprt.IsDone(); got != tc.wantDone {
+				t.Errorf("IsDone() = %t, want %t", got, tc.wantDone)
+			}
+			if got := rprt.IsSuccessful(); got != tc.wantSucceeded {
+				t.Errorf("IsSuccessful() = %t, want %t", got, tc.wantSucceeded)
+			}
+			if got := rprt.IsFailure(); got != tc.wantFailed {
+				t.Errorf("IsFailure() = %t, want %t", got, tc.wantFailed)
+			}
+			if got := rprt.IsCancelled(); got != tc.wantCancelled {
+				t.Errorf("IsCancelled() = %t, want %t", got, tc.wantCancelled)
+			}
+			if got := rprt.IsStarted(); got != tc.wantStarted {
+				t.Errorf("IsStarted() = %t, want %t", got, tc.wantStarted)
+			}
+		})
+	}
+}
+
+func nopGetRun(string) (*v1alpha1.Run, error) {
+	return nil, errors.New("GetRun should not be called")
+}
+
+func nopGetPipelineRun(string) (*v1beta1.PipelineRun, error) {
+	return nil, errors.New("GetPipelineRun should not be called")
+}
+
 func TestResolvePipelineRun_PipelineTaskHasNoResources(t *testing.T) {
 	pts := []v1beta1.PipelineTask{{
 		Name:    "mytask1",
@@ -1056,7 +1417,7 @@ func TestResolvePipelineRun_PipelineTaskHasNoResources(t *testing.T) {
 			Name: "pipelinerun",
 		},
 	}
-	pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, getCondition, pts, providedResources)
+	pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, nopGetRun, nopGetPipelineRun, getCondition, pts, providedResources)
 	if err != nil {
 		t.Fatalf("Did not expect error when resolving PipelineRun without Resources: %v", err)
 	}
@@ -1103,7 +1464,7 @@ func TestResolvePipelineRun_TaskDoesntExist(t *testing.T) {
 			Name: "pipelinerun",
 		},
 	}
-	_, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, getCondition, pts, providedResources)
+	_, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, nopGetRun, nopGetPipelineRun, getCondition, pts, providedResources)
 	switch err := err.(type) {
 	case nil:
 		t.Fatalf("Expected error getting non-existent Tasks for Pipeline %s but got none", p.Name)
@@ -1149,7 +1510,7 @@ func TestResolvePipelineRun_ResourceBindingsDontExist(t *testing.T) {
 					Name: "pipelinerun",
 				},
 			}
-			_, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, getCondition, tt.p.Spec.Tasks, providedResources)
+			_, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, nopGetRun, nopGetPipelineRun, getCondition, tt.p.Spec.Tasks, providedResources)
 			if err == nil {
 				t.Fatalf("Expected error when bindings are in incorrect state for Pipeline %s but got none", p.Name)
 			}
@@ -1199,7 +1560,7 @@ func TestResolvePipelineRun_withExistingTaskRuns(t *testing.T) {
 	getClusterTask := func(name string) (v1beta1.TaskInterface, error) { return nil, nil }
 	getTaskRun := func(name string) (*v1beta1.TaskRun, error) { return nil, nil }
 	getCondition := func(name string) (*v1alpha1.Condition, error) { return nil, nil }
-	pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, getCondition, p.Spec.Tasks, providedResources)
+	pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, nopGetRun, nopGetPipelineRun, getCondition, p.Spec.Tasks, providedResources)
 	if err != nil {
 		t.Fatalf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
 	}
@@ -1252,7 +1613,7 @@ func TestResolvedPipelineRun_PipelineTaskHasOptionalResources(t *testing.T) {
 	getClusterTask := func(name string) (v1beta1.TaskInterface, error) { return nil, nil }
 	getCondition := func(name string) (*v1alpha1.Condition, error) { return nil, nil }
 
-	pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, getCondition, p.Spec.Tasks, providedResources)
+	pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, nopGetRun, nopGetPipelineRun, getCondition, p.Spec.Tasks, providedResources)
 	if err != nil {
 		t.Fatalf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
 	}
@@ -1355,7 +1716,7 @@ func TestResolveConditionChecks(t *testing.T) {
 
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
-			pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, tc.getTaskRun, getClusterTask, getCondition, pts, providedResources)
+			pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, tc.getTaskRun, getClusterTask, nopGetRun, nopGetPipelineRun, getCondition, pts, providedResources)
 			if err != nil {
 				t.Fatalf("Did not expect error when resolving PipelineRun without Conditions: %v", err)
 			}
@@ -1456,7 +1817,7 @@ func TestResolveConditionChecks_MultipleConditions(t *testing.T) {
 
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
-			pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, tc.getTaskRun, getClusterTask, getCondition, pts, providedResources)
+			pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, tc.getTaskRun, getClusterTask, nopGetRun, nopGetPipelineRun, getCondition, pts, providedResources)
 			if err != nil {
 				t.Fatalf("Did not expect error when resolving PipelineRun without Conditions: %v", err)
 			}
@@ -1500,7 +1861,7 @@ func TestResolveConditionChecks_ConditionDoesNotExist(t *testing.T) {
 		},
 	}
 
-	_, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, getCondition, pts, providedResources)
+	_, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, nopGetRun, nopGetPipelineRun, getCondition, pts, providedResources)
 
 	switch err := err.(type) {
 	case nil:
@@ -1569,7 +1930,7 @@ func TestResolveConditionCheck_UseExistingConditionCheckName(t *testing.T) {
 		},
 	}
 
-	pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, getCondition, pts, providedResources)
+	pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, nopGetRun, nopGetPipelineRun, getCondition, pts, providedResources)
 	if err != nil {
 		t.Fatalf("Did not expect error when resolving PipelineRun without Conditions: %v", err)
 	}
@@ -1645,7 +2006,7 @@ func TestResolvedConditionCheck_WithResources(t *testing.T) {
 
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
-			pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, getCondition, pts, tc.providedResources)
+			pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, nopGetRun, nopGetPipelineRun, getCondition, pts, tc.providedResources)
 
 			if tc.wantErr {
 				if err == nil {
@@ -1723,6 +2084,23 @@ func TestValidateWorkspaceBindings(t *testing.T) {
 	}
 }
 
+func TestValidateWorkspaceBindings_OptionalWorkspace(t *testing.T) {
+	p := &v1beta1.PipelineSpec{
+		Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{
+			Name: "foo",
+		}, {
+			Name:     "cache",
+			Optional: true,
+		}},
+	}
+	pr := tb.PipelineRun("pipelinerun", tb.PipelineRunSpec("pipeline",
+		tb.PipelineRunWorkspaceBindingEmptyDir("foo"),
+	))
+	if err := ValidateWorkspaceBindings(p, pr); err != nil {
+		t.Fatalf("Expected no error when the optional `cache` workspace is not provided but got %v", err)
+	}
+}
+
 func TestValidateServiceaccountMapping(t *testing.T) {
 	p := tb.Pipeline("pipelines", tb.PipelineSpec(
 		tb.PipelineTask("mytask1", "task",
@@ -1780,7 +2158,7 @@ func TestResolvePipeline_WhenExpressions(t *testing.T) {
 	}
 
 	t.Run("When Expressions exist", func(t *testing.T) {
-		_, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, getCondition, pts, providedResources)
+		_, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getClusterTask, nopGetRun, nopGetPipelineRun, getCondition, pts, providedResources)
 		if err != nil {
 			t.Fatalf("Did not expect error when resolving PipelineRun: %v", err)
 		}
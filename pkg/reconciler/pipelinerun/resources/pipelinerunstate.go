@@ -17,6 +17,7 @@ limitations under the License.
 package resources
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 
@@ -52,10 +53,11 @@ func (state PipelineRunState) IsDone() bool {
 	return true
 }
 
-// IsBeforeFirstTaskRun returns true if the PipelineRun has not yet started its first TaskRun
+// IsBeforeFirstTaskRun returns true if the PipelineRun has not yet started its first TaskRun,
+// Run or child PipelineRun
 func (state PipelineRunState) IsBeforeFirstTaskRun() bool {
 	for _, t := range state {
-		if t.TaskRun != nil {
+		if t.isScheduled() {
 			return false
 		}
 	}
@@ -63,10 +65,14 @@ func (state PipelineRunState) IsBeforeFirstTaskRun() bool {
 }
 
 // IsStopping returns true if the PipelineRun won't be scheduling any new Task because
-// at least one task already failed or was cancelled in the specified dag
+// at least one task already failed or was cancelled in the specified dag, or because
+// the PipelineRun was cancelled gracefully or its tasks timed out
 func (state PipelineRunState) IsStopping(d *dag.Graph) bool {
 	for _, t := range state {
 		if isTaskInGraph(t.PipelineTask.Name, d) {
+			if t.PipelineRunCancelled || t.TasksTimedOut {
+				return true
+			}
 			if t.IsCancelled() {
 				return true
 			}
@@ -84,18 +90,29 @@ func (state PipelineRunState) IsStopping(d *dag.Graph) bool {
 func (state PipelineRunState) GetNextTasks(candidateTasks sets.String) []*ResolvedPipelineRunTask {
 	tasks := []*ResolvedPipelineRunTask{}
 	for _, t := range state {
-		if _, ok := candidateTasks[t.PipelineTask.Name]; ok && t.TaskRun == nil {
-			tasks = append(tasks, t)
+		if t.IsCustomTask() {
+			// Runs are not retried, they only need to be created once
+			if _, ok := candidateTasks[t.PipelineTask.Name]; ok && t.Run == nil {
+				tasks = append(tasks, t)
+			}
+			continue
 		}
-		if _, ok := candidateTasks[t.PipelineTask.Name]; ok && t.TaskRun != nil {
-			status := t.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
-			if status != nil && status.IsFalse() {
-				if !(t.TaskRun.IsCancelled() || status.Reason == v1beta1.TaskRunReasonCancelled.String() || status.Reason == ReasonConditionCheckFailed) {
-					if len(t.TaskRun.Status.RetriesStatus) < t.PipelineTask.Retries {
-						tasks = append(tasks, t)
-					}
-				}
+		if t.IsChildPipeline() {
+			// Child PipelineRuns are not retried either
+			if _, ok := candidateTasks[t.PipelineTask.Name]; ok && t.ChildPipelineRun == nil {
+				tasks = append(tasks, t)
 			}
+			continue
+		}
+		if t.IsMatrixed() {
+			// Each TaskRun of the Matrix is created, or retried, independently
+			if _, ok := candidateTasks[t.PipelineTask.Name]; ok && len(t.SchedulableMatrixCombinations()) > 0 {
+				tasks = append(tasks, t)
+			}
+			continue
+		}
+		if _, ok := candidateTasks[t.PipelineTask.Name]; ok && isTaskRunSchedulable(t.TaskRun, t.PipelineTask.Retries) {
+			tasks = append(tasks, t)
 		}
 	}
 	return tasks
@@ -120,7 +137,7 @@ func (state PipelineRunState) SuccessfulOrSkippedDAGTasks(d *dag.Graph) []string
 func (state PipelineRunState) checkTasksDone(d *dag.Graph) bool {
 	for _, t := range state {
 		if isTaskInGraph(t.PipelineTask.Name, d) {
-			if t.TaskRun == nil {
+			if !t.isScheduled() {
 				// this task might have skipped if taskRun is nil
 				// continue and ignore if this task was skipped
 				// skipped task is considered part of done
@@ -157,6 +174,42 @@ func (state PipelineRunState) GetFinalTasks(d *dag.Graph, dfinally *dag.Graph) [
 	return tasks
 }
 
+// GetPipelineTaskStatus returns the execution status of each task of the DAG d, keyed by its
+// context variable tasks.<pipelineTaskName>.status, along with their aggregate execution status,
+// keyed by tasks.status, to be consumed by the finally tasks
+func (state PipelineRunState) GetPipelineTaskStatus(d *dag.Graph) map[string]string {
+	tStatus := map[string]string{}
+	succeeded, failed, skipped := 0, 0, 0
+	for _, t := range state {
+		if !isTaskInGraph(t.PipelineTask.Name, d) {
+			continue
+		}
+		s := v1beta1.PipelineTaskStateNone
+		switch {
+		case t.IsSuccessful():
+			s = v1beta1.PipelineRunReasonSuccessful.String()
+			succeeded++
+		case t.IsFailure():
+			s = v1beta1.PipelineRunReasonFailed.String()
+			failed++
+		case t.Skip(state, d):
+			skipped++
+		}
+		tStatus[v1beta1.PipelineTaskStatusPrefix+t.PipelineTask.Name+v1beta1.PipelineTaskStatusSuffix] = s
+	}
+	aggregateStatus := v1beta1.PipelineTaskStateNone
+	switch {
+	case failed > 0:
+		aggregateStatus = v1beta1.PipelineRunReasonFailed.String()
+	case succeeded+skipped == len(d.Nodes) && skipped > 0:
+		aggregateStatus = v1beta1.PipelineRunReasonCompleted.String()
+	case succeeded == len(d.Nodes):
+		aggregateStatus = v1beta1.PipelineRunReasonSuccessful.String()
+	}
+	tStatus[v1beta1.PipelineTasksAggregateStatus] = aggregateStatus
+	return tStatus
+}
+
 // GetPipelineConditionStatus will return the Condition that the PipelineRun prName should be
 // updated with, based on the status of the TaskRuns in state.
 func (state PipelineRunState) GetPipelineConditionStatus(pr *v1beta1.PipelineRun, logger *zap.SugaredLogger, dag *dag.Graph, dfinally *dag.Graph) *apis.Condition {
@@ -170,7 +223,7 @@ func (state PipelineRunState) GetPipelineConditionStatus(pr *v1beta1.PipelineRun
 			Type:    apis.ConditionSucceeded,
 			Status:  corev1.ConditionFalse,
 			Reason:  v1beta1.PipelineRunReasonTimedOut.String(),
-			Message: fmt.Sprintf("PipelineRun %q failed to finish within %q", pr.Name, pr.Spec.Timeout.Duration.String()),
+			Message: fmt.Sprintf("PipelineRun %q failed to finish within %q", pr.Name, pr.PipelineTimeout(context.Background()).String()),
 		}
 	}
 
@@ -223,6 +276,12 @@ func (state PipelineRunState) GetPipelineConditionStatus(pr *v1beta1.PipelineRun
 		if failedTasks > 0 || cancelledTasks > 0 {
 			status = corev1.ConditionFalse
 		}
+		// A PipelineRun cancelled gracefully is cancelled once its finally tasks are done,
+		// even if none of its tasks had to be cancelled
+		if pr.IsGracefullyCancelled() && reason != v1beta1.PipelineRunReasonFailed.String() {
+			status = corev1.ConditionFalse
+			reason = v1beta1.PipelineRunReasonCancelled.String()
+		}
 		logger.Infof("All TaskRuns have finished for PipelineRun %s so it has finished", pr.Name)
 		return &apis.Condition{
 			Type:   apis.ConditionSucceeded,
@@ -237,9 +296,12 @@ func (state PipelineRunState) GetPipelineConditionStatus(pr *v1beta1.PipelineRun
 	// transition pipeline into stopping state when one of the tasks(dag/final) cancelled or one of the dag tasks failed
 	// for a pipeline with final tasks, single dag task failure does not transition to interim stopping state
 	// pipeline stays in running state until all final tasks are done before transitioning to failed state
-	if cancelledTasks > 0 || (failedTasks > 0 && state.checkTasksDone(dfinally)) {
+	switch {
+	case pr.IsGracefullyCancelled():
+		reason = v1beta1.PipelineRunReasonCancelledRunningFinally.String()
+	case cancelledTasks > 0 || (failedTasks > 0 && state.checkTasksDone(dfinally)):
 		reason = v1beta1.PipelineRunReasonStopping.String()
-	} else {
+	default:
 		reason = v1beta1.PipelineRunReasonRunning.String()
 	}
 	return &apis.Condition{
@@ -264,6 +326,22 @@ func (state PipelineRunState) GetSkippedTasks(pr *v1beta1.PipelineRun, d *dag.Gr
 func (state PipelineRunState) GetTaskRunsStatus(pr *v1beta1.PipelineRun) map[string]*v1beta1.PipelineRunTaskRunStatus {
 	status := make(map[string]*v1beta1.PipelineRunTaskRunStatus)
 	for _, rprt := range state {
+		if rprt.IsMatrixed() {
+			for _, tr := range rprt.TaskRuns {
+				if tr == nil {
+					continue
+				}
+				prtrs := pr.Status.TaskRuns[tr.Name]
+				if prtrs == nil {
+					prtrs = &v1beta1.PipelineRunTaskRunStatus{
+						PipelineTaskName: rprt.PipelineTask.Name,
+					}
+				}
+				prtrs.Status = &tr.Status
+				status[tr.Name] = prtrs
+			}
+			continue
+		}
 		if rprt.TaskRun == nil && rprt.ResolvedConditionChecks == nil {
 			continue
 		}
@@ -310,6 +388,54 @@ func (state PipelineRunState) GetTaskRunsStatus(pr *v1beta1.PipelineRun) map[str
 	return status
 }
 
+// GetRunsStatus returns the status of the Runs created for the Custom Tasks of the PipelineRun,
+// or nil if no Run has been created.
+func (state PipelineRunState) GetRunsStatus(pr *v1beta1.PipelineRun) map[string]*v1beta1.PipelineRunRunStatus {
+	var status map[string]*v1beta1.PipelineRunRunStatus
+	for _, rprt := range state {
+		if !rprt.IsCustomTask() || rprt.Run == nil {
+			continue
+		}
+		if status == nil {
+			status = make(map[string]*v1beta1.PipelineRunRunStatus)
+		}
+
+		prrs := pr.Status.Runs[rprt.RunName]
+		if prrs == nil {
+			prrs = &v1beta1.PipelineRunRunStatus{
+				PipelineTaskName: rprt.PipelineTask.Name,
+			}
+		}
+		prrs.Status = &rprt.Run.Status
+		status[rprt.RunName] = prrs
+	}
+	return status
+}
+
+// GetChildPipelineRunsStatus returns the status of the child PipelineRuns created for the
+// PipelineTasks running another Pipeline, or nil if no child PipelineRun has been created.
+func (state PipelineRunState) GetChildPipelineRunsStatus(pr *v1beta1.PipelineRun) map[string]*v1beta1.PipelineRunChildStatus {
+	var status map[string]*v1beta1.PipelineRunChildStatus
+	for _, rprt := range state {
+		if !rprt.IsChildPipeline() || rprt.ChildPipelineRun == nil {
+			continue
+		}
+		if status == nil {
+			status = make(map[string]*v1beta1.PipelineRunChildStatus)
+		}
+
+		prcs := pr.Status.ChildPipelineRuns[rprt.ChildPipelineRunName]
+		if prcs == nil {
+			prcs = &v1beta1.PipelineRunChildStatus{
+				PipelineTaskName: rprt.PipelineTask.Name,
+			}
+		}
+		prcs.Status = &rprt.ChildPipelineRun.Status
+		status[rprt.ChildPipelineRunName] = prcs
+	}
+	return status
+}
+
 // Check if a PipelineTask belongs to the specified Graph
 func isTaskInGraph(pipelineTaskName string, d *dag.Graph) bool {
 	if _, ok := d.Nodes[pipelineTaskName]; ok {
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	tb "github.com/tektoncd/pipeline/internal/builder/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
@@ -640,6 +641,68 @@ func TestPipelineRunState_GetFinalTasks(t *testing.T) {
 	}
 }
 
+func TestPipelineRunState_GetPipelineTaskStatus(t *testing.T) {
+	tcs := []struct {
+		name     string
+		state    PipelineRunState
+		dagTasks []v1beta1.PipelineTask
+		expected map[string]string
+	}{{
+		name:     "one task succeeded and one not started",
+		state:    oneFinishedState,
+		dagTasks: []v1beta1.PipelineTask{pts[0], pts[1]},
+		expected: map[string]string{
+			"tasks.mytask1.status": v1beta1.PipelineRunReasonSuccessful.String(),
+			"tasks.mytask2.status": v1beta1.PipelineTaskStateNone,
+			"tasks.status":         v1beta1.PipelineTaskStateNone,
+		},
+	}, {
+		name:     "all tasks succeeded",
+		state:    allFinishedState,
+		dagTasks: []v1beta1.PipelineTask{pts[0], pts[1]},
+		expected: map[string]string{
+			"tasks.mytask1.status": v1beta1.PipelineRunReasonSuccessful.String(),
+			"tasks.mytask2.status": v1beta1.PipelineRunReasonSuccessful.String(),
+			"tasks.status":         v1beta1.PipelineRunReasonSuccessful.String(),
+		},
+	}, {
+		name:     "one task failed, final tasks are ignored",
+		state:    oneFailedState,
+		dagTasks: []v1beta1.PipelineTask{pts[0]},
+		expected: map[string]string{
+			"tasks.mytask1.status": v1beta1.PipelineRunReasonFailed.String(),
+			"tasks.status":         v1beta1.PipelineRunReasonFailed.String(),
+		},
+	}, {
+		name: "one task succeeded and one skipped",
+		state: PipelineRunState{{
+			PipelineTask: &pts[0],
+			TaskRunName:  "pipelinerun-mytask1",
+			TaskRun:      makeSucceeded(trs[0]),
+		}, {
+			PipelineTask: &pts[10],
+			TaskRunName:  "pipelinerun-mytask11",
+		}},
+		dagTasks: []v1beta1.PipelineTask{pts[0], pts[10]},
+		expected: map[string]string{
+			"tasks.mytask1.status":  v1beta1.PipelineRunReasonSuccessful.String(),
+			"tasks.mytask11.status": v1beta1.PipelineTaskStateNone,
+			"tasks.status":          v1beta1.PipelineRunReasonCompleted.String(),
+		},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := dag.Build(v1beta1.PipelineTaskList(tc.dagTasks))
+			if err != nil {
+				t.Fatalf("Unexpected error while building DAG for pipelineTasks %v: %v", tc.dagTasks, err)
+			}
+			if d := cmp.Diff(tc.expected, tc.state.GetPipelineTaskStatus(d)); d != "" {
+				t.Errorf("Didn't get the expected execution status of the tasks %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestGetPipelineConditionStatus(t *testing.T) {
 
 	var taskRetriedState = PipelineRunState{{
@@ -1030,3 +1093,100 @@ func TestGetPipelineConditionStatus_PipelineTimeouts(t *testing.T) {
 		t.Fatalf("Expected to get status %s but got %s for state %v", corev1.ConditionFalse, c.Status, oneFinishedState)
 	}
 }
+
+func TestGetNextTasks_Matrix(t *testing.T) {
+	pt := &v1beta1.PipelineTask{
+		Name:    "build",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		Retries: 1,
+		Matrix: []v1beta1.Param{{
+			Name:  "platform",
+			Value: *v1beta1.NewArrayOrString("linux", "mac"),
+		}},
+	}
+	tcs := []struct {
+		name            string
+		taskRuns        []*v1beta1.TaskRun
+		wantNext        bool
+		wantSchedulable []int
+	}{{
+		name:            "not started",
+		taskRuns:        []*v1beta1.TaskRun{nil, nil},
+		wantNext:        true,
+		wantSchedulable: []int{0, 1},
+	}, {
+		name:            "partially created",
+		taskRuns:        []*v1beta1.TaskRun{makeStarted(trs[0]), nil},
+		wantNext:        true,
+		wantSchedulable: []int{1},
+	}, {
+		name:     "running",
+		taskRuns: []*v1beta1.TaskRun{makeStarted(trs[0]), makeSucceeded(trs[1])},
+	}, {
+		name:            "failed with retries left",
+		taskRuns:        []*v1beta1.TaskRun{makeFailed(trs[0]), makeSucceeded(trs[1])},
+		wantNext:        true,
+		wantSchedulable: []int{0},
+	}, {
+		name:     "failed after retries",
+		taskRuns: []*v1beta1.TaskRun{withRetries(makeFailed(trs[0])), makeSucceeded(trs[1])},
+	}, {
+		name:     "cancelled",
+		taskRuns: []*v1beta1.TaskRun{withCancelled(makeFailed(trs[0])), makeSucceeded(trs[1])},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			state := PipelineRunState{{
+				PipelineTask: pt,
+				TaskRunNames: []string{"pipelinerun-build-0", "pipelinerun-build-1"},
+				TaskRuns:     tc.taskRuns,
+				ResolvedTaskResources: &resources.ResolvedTaskResources{
+					TaskSpec: &task.Spec,
+				},
+			}}
+			var expectedNext []*ResolvedPipelineRunTask
+			if tc.wantNext {
+				expectedNext = []*ResolvedPipelineRunTask{state[0]}
+			}
+			next := state.GetNextTasks(sets.NewString("build"))
+			if d := cmp.Diff(expectedNext, next, cmpopts.EquateEmpty()); d != "" {
+				t.Errorf("Didn't get expected next Tasks %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantSchedulable, state[0].SchedulableMatrixCombinations()); d != "" {
+				t.Errorf("Didn't get expected schedulable combinations %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestGetTaskRunsStatus_Matrix(t *testing.T) {
+	pr := tb.PipelineRun("pipelinerun")
+	pr.Status.TaskRuns = map[string]*v1beta1.PipelineRunTaskRunStatus{
+		"pipelinerun-mytask1": {PipelineTaskName: "build"},
+	}
+	state := PipelineRunState{{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "build",
+			TaskRef: &v1beta1.TaskRef{Name: "task"},
+			Matrix: []v1beta1.Param{{
+				Name:  "platform",
+				Value: *v1beta1.NewArrayOrString("linux", "mac", "windows"),
+			}},
+		},
+		TaskRunNames: []string{"pipelinerun-mytask1", "pipelinerun-mytask2", "pipelinerun-mytask3"},
+		TaskRuns:     []*v1beta1.TaskRun{makeSucceeded(trs[0]), makeStarted(trs[1]), nil},
+	}}
+	want := map[string]*v1beta1.PipelineRunTaskRunStatus{
+		"pipelinerun-mytask1": {
+			PipelineTaskName: "build",
+			Status:           &state[0].TaskRuns[0].Status,
+		},
+		"pipelinerun-mytask2": {
+			PipelineTaskName: "build",
+			Status:           &state[0].TaskRuns[1].Status,
+		},
+	}
+	if d := cmp.Diff(want, state.GetTaskRunsStatus(pr)); d != "" {
+		t.Errorf("Unexpected TaskRuns status %s", diff.PrintWantGot(d))
+	}
+}
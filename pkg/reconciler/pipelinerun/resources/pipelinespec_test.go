@@ -18,10 +18,17 @@ package resources
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"testing"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -127,3 +134,57 @@ func TestGetPipelineSpec_Error(t *testing.T) {
 		t.Fatalf("Expected error when unable to find referenced Pipeline but got none")
 	}
 }
+
+func TestGetPipelineSpec_Verification(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Couldn't generate key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatalf("Couldn't encode the public key: %v", err)
+	}
+	ctx := config.ToContext(context.Background(), &config.Config{
+		TrustedResources: &config.TrustedResources{
+			VerificationMode: config.VerificationModeEnforce,
+			PublicKeys:       string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+		},
+	})
+
+	pipeline := &v1beta1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "orchestrate",
+		},
+		Spec: v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{
+				Name: "mytask",
+				TaskRef: &v1beta1.TaskRef{
+					Name: "mytask",
+				},
+			}},
+		},
+	}
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mypipelinerun",
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{
+				Name: "orchestrate",
+			},
+		},
+	}
+	gp := func(n string) (v1beta1.PipelineInterface, error) { return pipeline, nil }
+	if _, _, err := GetPipelineData(ctx, pr, gp); !errors.Is(err, trustedresources.ErrResourceVerificationFailed) {
+		t.Fatalf("Expected a verification error for an unsigned Pipeline but got %v", err)
+	}
+
+	sig, err := trustedresources.SignPipeline(context.Background(), pipeline, key)
+	if err != nil {
+		t.Fatalf("Unexpected error signing the Pipeline: %v", err)
+	}
+	pipeline.Annotations = map[string]string{trustedresources.SignatureAnnotation: sig}
+	if _, _, err := GetPipelineData(ctx, pr, gp); err != nil {
+		t.Fatalf("Did not expect error getting the signed Pipeline but got: %s", err)
+	}
+}
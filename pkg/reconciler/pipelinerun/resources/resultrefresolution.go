@@ -17,9 +17,12 @@ limitations under the License.
 package resources
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"knative.dev/pkg/apis"
 )
@@ -34,6 +37,8 @@ type ResolvedResultRef struct {
 	Value           v1beta1.ArrayOrString
 	ResultReference v1beta1.ResultRef
 	FromTaskRun     string
+	FromRun         string
+	FromPipelineRun string
 }
 
 // ResolveResultRefs resolves any ResultReference that are found in the target ResolvedPipelineRunTask
@@ -130,6 +135,9 @@ func removeDup(refs ResolvedResultRefs) ResolvedResultRefs {
 		if order[i].Result > order[j].Result {
 			return false
 		}
+		if order[i].Property > order[j].Property {
+			return false
+		}
 		return true
 	})
 
@@ -143,19 +151,27 @@ func removeDup(refs ResolvedResultRefs) ResolvedResultRefs {
 func convertToResultRefs(pipelineRunState PipelineRunState, target *ResolvedPipelineRunTask) (ResolvedResultRefs, error) {
 	var resolvedResultRefs ResolvedResultRefs
 	for _, condition := range target.PipelineTask.Conditions {
-		condRefs, err := convertParams(condition.Params, pipelineRunState, condition.ConditionRef)
+		condRefs, err := convertParams(condition.Params, pipelineRunState, condition.ConditionRef, false)
 		if err != nil {
 			return nil, err
 		}
 		resolvedResultRefs = append(resolvedResultRefs, condRefs...)
 	}
 
-	taskParamsRefs, err := convertParams(target.PipelineTask.Params, pipelineRunState, target.PipelineTask.Name)
+	taskParamsRefs, err := convertParams(target.PipelineTask.Params, pipelineRunState, target.PipelineTask.Name, true)
 	if err != nil {
 		return nil, err
 	}
 	resolvedResultRefs = append(resolvedResultRefs, taskParamsRefs...)
 
+	// The number of TaskRuns of a Matrix is known before its results are resolved,
+	// so results aggregated from another Matrix can't be expanded into it
+	taskMatrixRefs, err := convertParams(target.PipelineTask.Matrix, pipelineRunState, target.PipelineTask.Name, false)
+	if err != nil {
+		return nil, err
+	}
+	resolvedResultRefs = append(resolvedResultRefs, taskMatrixRefs...)
+
 	taskWhenExpressionsRefs, err := convertWhenExpressions(target.PipelineTask.WhenExpressions, pipelineRunState, target.PipelineTask.Name)
 	if err != nil {
 		return nil, err
@@ -165,13 +181,21 @@ func convertToResultRefs(pipelineRunState PipelineRunState, target *ResolvedPipe
 	return resolvedResultRefs, nil
 }
 
-func convertParams(params []v1beta1.Param, pipelineRunState PipelineRunState, name string) (ResolvedResultRefs, error) {
+// convertParams resolves the result references of params. When allowArrays is true, the results
+// aggregated from the TaskRuns of a Matrix can be used as entire values of array params.
+func convertParams(params []v1beta1.Param, pipelineRunState PipelineRunState, name string, allowArrays bool) (ResolvedResultRefs, error) {
 	var resolvedParams ResolvedResultRefs
 	for _, param := range params {
 		resolvedResultRefs, err := extractResultRefsForParam(pipelineRunState, param)
 		if err != nil {
 			return nil, fmt.Errorf("unable to find result referenced by param %q in %q: %w", param.Name, name, err)
 		}
+		for _, r := range resolvedResultRefs {
+			if r.Value.Type == v1beta1.ParamTypeArray && !(allowArrays && isIsolatedInArray(param.Value, r.getReplaceTarget())) {
+				return nil, fmt.Errorf("result %q of task %q referenced by param %q in %q is produced by a matrix, it can only be used as an entire value of an array param",
+					r.ResultReference.Result, r.ResultReference.PipelineTask, param.Name, name)
+			}
+		}
 		if resolvedResultRefs != nil {
 			resolvedParams = append(resolvedParams, resolvedResultRefs...)
 		}
@@ -188,6 +212,12 @@ func convertWhenExpressions(whenExpressions []v1beta1.WhenExpression, pipelineRu
 			if err != nil {
 				return nil, fmt.Errorf("unable to find result referenced by when expression with input %q in task %q: %w", whenExpression.Input, name, err)
 			}
+			for _, r := range resolvedResultRefs {
+				if r.Value.Type == v1beta1.ParamTypeArray {
+					return nil, fmt.Errorf("result %q of task %q referenced by when expression with input %q in task %q is produced by a matrix, it can't be used in when expressions",
+						r.ResultReference.Result, r.ResultReference.PipelineTask, whenExpression.Input, name)
+				}
+			}
 			if resolvedResultRefs != nil {
 				resolvedWhenExpressions = append(resolvedWhenExpressions, resolvedResultRefs...)
 			}
@@ -205,7 +235,32 @@ func convertPipelineResultToResultRefs(pipelineStatus v1beta1.PipelineRunStatus,
 	return resolvedResultRefs
 }
 
+// isIsolatedInArray returns true if value is an array in which the variable target is only used
+// as an entire element.
+func isIsolatedInArray(value v1beta1.ArrayOrString, target string) bool {
+	if value.Type != v1beta1.ParamTypeArray {
+		return false
+	}
+	variable := fmt.Sprintf("$(%s)", target)
+	for _, element := range value.ArrayVal {
+		if strings.Contains(element, variable) && element != variable {
+			return false
+		}
+	}
+	return true
+}
+
 func resolveResultRef(pipelineState PipelineRunState, resultRef *v1beta1.ResultRef) (*ResolvedResultRef, error) {
+	if referencedPipelineTask := pipelineState.ToMap()[resultRef.PipelineTask]; referencedPipelineTask != nil {
+		switch {
+		case referencedPipelineTask.IsCustomTask():
+			return resolveResultRefFromRun(referencedPipelineTask, resultRef)
+		case referencedPipelineTask.IsChildPipeline():
+			return resolveResultRefFromChildPipelineRun(referencedPipelineTask, resultRef)
+		case referencedPipelineTask.IsMatrixed():
+			return resolveResultRefFromMatrix(referencedPipelineTask, resultRef)
+		}
+	}
 	referencedTaskRun, err := getReferencedTaskRun(pipelineState, resultRef)
 	if err != nil {
 		return nil, err
@@ -214,14 +269,113 @@ func resolveResultRef(pipelineState PipelineRunState, resultRef *v1beta1.ResultR
 	if err != nil {
 		return nil, err
 	}
+	value, err := resultValue(result.Value, resultRef)
+	if err != nil {
+		return nil, err
+	}
 	return &ResolvedResultRef{
-		Value:           *v1beta1.NewArrayOrString(result.Value),
+		Value:           *v1beta1.NewArrayOrString(value),
 		FromTaskRun:     referencedTaskRun.Name,
 		ResultReference: *resultRef,
 	}, nil
 }
 
+func resolveResultRefFromRun(referencedPipelineTask *ResolvedPipelineRunTask, resultRef *v1beta1.ResultRef) (*ResolvedResultRef, error) {
+	if referencedPipelineTask.Run == nil || referencedPipelineTask.IsFailure() {
+		return nil, fmt.Errorf("could not find successful run for task %q", referencedPipelineTask.PipelineTask.Name)
+	}
+	result, err := findRunResult(referencedPipelineTask.Run.Status.Results, resultRef)
+	if err != nil {
+		return nil, err
+	}
+	value, err := resultValue(result.Value, resultRef)
+	if err != nil {
+		return nil, err
+	}
+	return &ResolvedResultRef{
+		Value:           *v1beta1.NewArrayOrString(value),
+		FromRun:         referencedPipelineTask.Run.Name,
+		ResultReference: *resultRef,
+	}, nil
+}
+
+// resolveResultRefFromChildPipelineRun resolves a result reference against the PipelineResults
+// of the child PipelineRun of referencedPipelineTask.
+func resolveResultRefFromChildPipelineRun(referencedPipelineTask *ResolvedPipelineRunTask, resultRef *v1beta1.ResultRef) (*ResolvedResultRef, error) {
+	if referencedPipelineTask.ChildPipelineRun == nil || referencedPipelineTask.IsFailure() {
+		return nil, fmt.Errorf("could not find successful pipelinerun for task %q", referencedPipelineTask.PipelineTask.Name)
+	}
+	result, err := findPipelineRunResult(referencedPipelineTask.ChildPipelineRun.Status.PipelineResults, resultRef)
+	if err != nil {
+		return nil, err
+	}
+	value, err := resultValue(result.Value, resultRef)
+	if err != nil {
+		return nil, err
+	}
+	return &ResolvedResultRef{
+		Value:           *v1beta1.NewArrayOrString(value),
+		FromPipelineRun: referencedPipelineTask.ChildPipelineRun.Name,
+		ResultReference: *resultRef,
+	}, nil
+}
+
+// resolveResultRefFromMatrix resolves a result reference against the TaskRuns the Matrix of
+// referencedPipelineTask fans out to. The results of the TaskRuns are aggregated into an array,
+// in the order of the combinations of the Matrix.
+func resolveResultRefFromMatrix(referencedPipelineTask *ResolvedPipelineRunTask, resultRef *v1beta1.ResultRef) (*ResolvedResultRef, error) {
+	if !referencedPipelineTask.IsSuccessful() {
+		return nil, fmt.Errorf("could not find successful taskruns for task %q", referencedPipelineTask.PipelineTask.Name)
+	}
+	values := make([]string, 0, len(referencedPipelineTask.TaskRuns))
+	for _, tr := range referencedPipelineTask.TaskRuns {
+		result, err := findTaskResultForParam(tr, resultRef)
+		if err != nil {
+			return nil, err
+		}
+		value, err := resultValue(result.Value, resultRef)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return &ResolvedResultRef{
+		Value:           v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: values},
+		ResultReference: *resultRef,
+	}, nil
+}
+
 func resolveResultRefForPipelineResult(pipelineStatus v1beta1.PipelineRunStatus, resultRef *v1beta1.ResultRef) (*ResolvedResultRef, error) {
+	if childStatus, childName, ok := getChildPipelineRunStatus(pipelineStatus, resultRef.PipelineTask); ok {
+		result, err := findPipelineRunResult(childStatus.PipelineResults, resultRef)
+		if err != nil {
+			return nil, err
+		}
+		value, err := resultValue(result.Value, resultRef)
+		if err != nil {
+			return nil, err
+		}
+		return &ResolvedResultRef{
+			Value:           *v1beta1.NewArrayOrString(value),
+			FromPipelineRun: childName,
+			ResultReference: *resultRef,
+		}, nil
+	}
+	if runStatus, runName, ok := getRunStatus(pipelineStatus, resultRef.PipelineTask); ok {
+		result, err := findRunResult(runStatus.Results, resultRef)
+		if err != nil {
+			return nil, err
+		}
+		value, err := resultValue(result.Value, resultRef)
+		if err != nil {
+			return nil, err
+		}
+		return &ResolvedResultRef{
+			Value:           *v1beta1.NewArrayOrString(value),
+			FromRun:         runName,
+			ResultReference: *resultRef,
+		}, nil
+	}
 	taskRunStatus, taskRunName, err := getTaskRunStatus(pipelineStatus, resultRef.PipelineTask)
 
 	if err != nil {
@@ -231,13 +385,34 @@ func resolveResultRefForPipelineResult(pipelineStatus v1beta1.PipelineRunStatus,
 	if err != nil {
 		return nil, err
 	}
+	value, err := resultValue(result.Value, resultRef)
+	if err != nil {
+		return nil, err
+	}
 	return &ResolvedResultRef{
-		Value:           *v1beta1.NewArrayOrString(result.Value),
+		Value:           *v1beta1.NewArrayOrString(value),
 		FromTaskRun:     taskRunName,
 		ResultReference: *resultRef,
 	}, nil
 }
 
+// resultValue returns the value of a result, or the value of one of its keys when the result
+// reference is to a key of an object result, e.g. $(tasks.foo.results.bar.key).
+func resultValue(value string, resultRef *v1beta1.ResultRef) (string, error) {
+	if resultRef.Property == "" {
+		return value, nil
+	}
+	object := map[string]string{}
+	if err := json.Unmarshal([]byte(value), &object); err != nil {
+		return "", fmt.Errorf("result %q of task %q is not an object: %w", resultRef.Result, resultRef.PipelineTask, err)
+	}
+	propertyValue, ok := object[resultRef.Property]
+	if !ok {
+		return "", fmt.Errorf("result %q of task %q has no key %q", resultRef.Result, resultRef.PipelineTask, resultRef.Property)
+	}
+	return propertyValue, nil
+}
+
 func getReferencedTaskRun(pipelineState PipelineRunState, reference *v1beta1.ResultRef) (*v1beta1.TaskRun, error) {
 	referencedPipelineTask := pipelineState.ToMap()[reference.PipelineTask]
 
@@ -264,6 +439,44 @@ func getTaskRunStatus(pipelineStatus v1beta1.PipelineRunStatus, pipelineTaskName
 	return nil, "", fmt.Errorf("could not find task run status for task %q referenced by result", pipelineTaskName)
 }
 
+// getRunStatus returns the status of the Run executing the custom task pipelineTaskName, if any.
+func getRunStatus(pipelineStatus v1beta1.PipelineRunStatus, pipelineTaskName string) (*v1alpha1.RunStatus, string, bool) {
+	for key, run := range pipelineStatus.PipelineRunStatusFields.Runs {
+		if run.PipelineTaskName == pipelineTaskName && run.Status != nil {
+			return run.Status, key, true
+		}
+	}
+	return nil, "", false
+}
+
+// getChildPipelineRunStatus returns the status of the child PipelineRun executing pipelineTaskName, if any.
+func getChildPipelineRunStatus(pipelineStatus v1beta1.PipelineRunStatus, pipelineTaskName string) (*v1beta1.PipelineRunStatus, string, bool) {
+	for key, child := range pipelineStatus.PipelineRunStatusFields.ChildPipelineRuns {
+		if child.PipelineTaskName == pipelineTaskName && child.Status != nil {
+			return child.Status, key, true
+		}
+	}
+	return nil, "", false
+}
+
+func findPipelineRunResult(results []v1beta1.PipelineRunResult, reference *v1beta1.ResultRef) (*v1beta1.PipelineRunResult, error) {
+	for _, result := range results {
+		if result.Name == reference.Result {
+			return &result, nil
+		}
+	}
+	return nil, fmt.Errorf("Could not find result with name %s for pipelinerun %s", reference.Result, reference.PipelineTask)
+}
+
+func findRunResult(results []v1alpha1.RunResult, reference *v1beta1.ResultRef) (*v1alpha1.RunResult, error) {
+	for _, result := range results {
+		if result.Name == reference.Result {
+			return &result, nil
+		}
+	}
+	return nil, fmt.Errorf("Could not find result with name %s for run %s", reference.Result, reference.PipelineTask)
+}
+
 func findTaskResultForPipelineResult(taskStatus *v1beta1.TaskRunStatus, reference *v1beta1.ResultRef) (*v1beta1.TaskRunResult, error) {
 	results := taskStatus.TaskRunStatusFields.TaskRunResults
 	for _, result := range results {
@@ -287,12 +500,31 @@ func findTaskResultForParam(taskRun *v1beta1.TaskRun, reference *v1beta1.ResultR
 func (rs ResolvedResultRefs) getStringReplacements() map[string]string {
 	replacements := map[string]string{}
 	for _, r := range rs {
+		if r.Value.Type == v1beta1.ParamTypeArray {
+			continue
+		}
 		replaceTarget := r.getReplaceTarget()
 		replacements[replaceTarget] = r.Value.StringVal
 	}
 	return replacements
 }
 
+// getArrayReplacements returns the replacements for the results aggregated from the TaskRuns of a Matrix
+func (rs ResolvedResultRefs) getArrayReplacements() map[string][]string {
+	replacements := map[string][]string{}
+	for _, r := range rs {
+		if r.Value.Type != v1beta1.ParamTypeArray {
+			continue
+		}
+		replacements[r.getReplaceTarget()] = r.Value.ArrayVal
+	}
+	return replacements
+}
+
 func (r *ResolvedResultRef) getReplaceTarget() string {
-	return fmt.Sprintf("%s.%s.%s.%s", v1beta1.ResultTaskPart, r.ResultReference.PipelineTask, v1beta1.ResultResultPart, r.ResultReference.Result)
+	target := fmt.Sprintf("%s.%s.%s.%s", v1beta1.ResultTaskPart, r.ResultReference.PipelineTask, v1beta1.ResultResultPart, r.ResultReference.Result)
+	if r.ResultReference.Property != "" {
+		target = fmt.Sprintf("%s.%s", target, r.ResultReference.Property)
+	}
+	return target
 }
@@ -8,11 +8,14 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	tb "github.com/tektoncd/pipeline/internal/builder/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/selection"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 )
 
@@ -155,6 +158,136 @@ func TestTaskParamResolver_ResolveResultRefs(t *testing.T) {
 		},
 		want:    nil,
 		wantErr: true,
+	}, {
+		name: "successful resolution: using result reference to a custom task",
+		pipelineRunState: PipelineRunState{{
+			CustomTask: true,
+			RunName:    "aRun",
+			Run: &v1alpha1.Run{
+				ObjectMeta: metav1.ObjectMeta{Name: "aRun"},
+				Status: v1alpha1.RunStatus{
+					RunStatusFields: v1alpha1.RunStatusFields{
+						Results: []v1alpha1.RunResult{{Name: "aResult", Value: "aResultValue"}},
+					},
+				},
+			},
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "aCustomPipelineTask",
+				TaskRef: &v1beta1.TaskRef{APIVersion: "example.dev/v0", Kind: "Example"},
+			},
+		}},
+		param: v1beta1.Param{
+			Name:  "targetParam",
+			Value: *v1beta1.NewArrayOrString("$(tasks.aCustomPipelineTask.results.aResult)"),
+		},
+		want: ResolvedResultRefs{{
+			Value: *v1beta1.NewArrayOrString("aResultValue"),
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "aCustomPipelineTask",
+				Result:       "aResult",
+			},
+			FromRun: "aRun",
+		}},
+		wantErr: false,
+	}, {
+		name: "successful resolution: using result reference to a child pipeline",
+		pipelineRunState: PipelineRunState{{
+			ChildPipeline:        true,
+			ChildPipelineRunName: "aChildPipelineRun",
+			ChildPipelineRun: &v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "aChildPipelineRun"},
+				Status: v1beta1.PipelineRunStatus{
+					Status: duckv1beta1.Status{
+						Conditions: []apis.Condition{{
+							Type:   apis.ConditionSucceeded,
+							Status: corev1.ConditionTrue,
+						}},
+					},
+					PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+						PipelineResults: []v1beta1.PipelineRunResult{{Name: "aResult", Value: "aResultValue"}},
+					},
+				},
+			},
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:        "aChildPipelineTask",
+				PipelineRef: &v1beta1.PipelineRef{Name: "aPipeline"},
+			},
+		}},
+		param: v1beta1.Param{
+			Name:  "targetParam",
+			Value: *v1beta1.NewArrayOrString("$(tasks.aChildPipelineTask.results.aResult)"),
+		},
+		want: ResolvedResultRefs{{
+			Value: *v1beta1.NewArrayOrString("aResultValue"),
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "aChildPipelineTask",
+				Result:       "aResult",
+			},
+			FromPipelineRun: "aChildPipelineRun",
+		}},
+		wantErr: false,
+	}, {
+		name: "unsuccessful resolution: child pipelinerun missing",
+		pipelineRunState: PipelineRunState{{
+			ChildPipeline:        true,
+			ChildPipelineRunName: "aChildPipelineRun",
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:        "aChildPipelineTask",
+				PipelineRef: &v1beta1.PipelineRef{Name: "aPipeline"},
+			},
+		}},
+		param: v1beta1.Param{
+			Name:  "targetParam",
+			Value: *v1beta1.NewArrayOrString("$(tasks.aChildPipelineTask.results.aResult)"),
+		},
+		want:    nil,
+		wantErr: true,
+	}, {
+		name: "unsuccessful resolution: run failed",
+		pipelineRunState: PipelineRunState{{
+			CustomTask: true,
+			RunName:    "aRun",
+			Run: &v1alpha1.Run{
+				ObjectMeta: metav1.ObjectMeta{Name: "aRun"},
+				Status: v1alpha1.RunStatus{
+					Status: duckv1.Status{
+						Conditions: []apis.Condition{{
+							Type:   apis.ConditionSucceeded,
+							Status: corev1.ConditionFalse,
+						}},
+					},
+					RunStatusFields: v1alpha1.RunStatusFields{
+						Results: []v1alpha1.RunResult{{Name: "aResult", Value: "aResultValue"}},
+					},
+				},
+			},
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "aCustomPipelineTask",
+				TaskRef: &v1beta1.TaskRef{APIVersion: "example.dev/v0", Kind: "Example"},
+			},
+		}},
+		param: v1beta1.Param{
+			Name:  "targetParam",
+			Value: *v1beta1.NewArrayOrString("$(tasks.aCustomPipelineTask.results.aResult)"),
+		},
+		want:    nil,
+		wantErr: true,
+	}, {
+		name: "unsuccessful resolution: run missing",
+		pipelineRunState: PipelineRunState{{
+			CustomTask: true,
+			RunName:    "aRun",
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "aCustomPipelineTask",
+				TaskRef: &v1beta1.TaskRef{APIVersion: "example.dev/v0", Kind: "Example"},
+			},
+		}},
+		param: v1beta1.Param{
+			Name:  "targetParam",
+			Value: *v1beta1.NewArrayOrString("$(tasks.aCustomPipelineTask.results.aResult)"),
+		},
+		want:    nil,
+		wantErr: true,
 	}, {
 		name: "unsuccessful resolution: task run missing",
 		pipelineRunState: PipelineRunState{{
@@ -169,6 +302,68 @@ func TestTaskParamResolver_ResolveResultRefs(t *testing.T) {
 		},
 		want:    nil,
 		wantErr: true,
+	}, {
+		name: "successful resolution: using the key of an object result",
+		pipelineRunState: PipelineRunState{{
+			TaskRunName: "aTaskRun",
+			TaskRun: tb.TaskRun("aTaskRun", tb.TaskRunStatus(
+				tb.TaskRunResult("repo", `{"url": "https://github.com/tektoncd/pipeline", "commit": "main"}`),
+			)),
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "aTask",
+				TaskRef: &v1beta1.TaskRef{Name: "aTask"},
+			},
+		}},
+		param: v1beta1.Param{
+			Name:  "targetParam",
+			Value: *v1beta1.NewArrayOrString("$(tasks.aTask.results.repo.commit)"),
+		},
+		want: ResolvedResultRefs{{
+			Value: *v1beta1.NewArrayOrString("main"),
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "aTask",
+				Result:       "repo",
+				Property:     "commit",
+			},
+			FromTaskRun: "aTaskRun",
+		}},
+		wantErr: false,
+	}, {
+		name: "failed resolution: using a missing key of an object result",
+		pipelineRunState: PipelineRunState{{
+			TaskRunName: "aTaskRun",
+			TaskRun: tb.TaskRun("aTaskRun", tb.TaskRunStatus(
+				tb.TaskRunResult("repo", `{"url": "https://github.com/tektoncd/pipeline"}`),
+			)),
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "aTask",
+				TaskRef: &v1beta1.TaskRef{Name: "aTask"},
+			},
+		}},
+		param: v1beta1.Param{
+			Name:  "targetParam",
+			Value: *v1beta1.NewArrayOrString("$(tasks.aTask.results.repo.commit)"),
+		},
+		want:    nil,
+		wantErr: true,
+	}, {
+		name: "failed resolution: using the key of a result which is not an object",
+		pipelineRunState: PipelineRunState{{
+			TaskRunName: "aTaskRun",
+			TaskRun: tb.TaskRun("aTaskRun", tb.TaskRunStatus(
+				tb.TaskRunResult("repo", "https://github.com/tektoncd/pipeline"),
+			)),
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "aTask",
+				TaskRef: &v1beta1.TaskRef{Name: "aTask"},
+			},
+		}},
+		param: v1beta1.Param{
+			Name:  "targetParam",
+			Value: *v1beta1.NewArrayOrString("$(tasks.aTask.results.repo.commit)"),
+		},
+		want:    nil,
+		wantErr: true,
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Logf("test name: %s\n", tt.name)
@@ -441,3 +636,204 @@ func TestResolvePipelineResultRefs(t *testing.T) {
 		})
 	}
 }
+
+func TestResolvePipelineResultRefs_Runs(t *testing.T) {
+	status := v1beta1.PipelineRunStatus{
+		PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+			Runs: map[string]*v1beta1.PipelineRunRunStatus{
+				"aRun": {
+					PipelineTaskName: "aCustomPipelineTask",
+					Status: &v1alpha1.RunStatus{
+						Status: duckv1.Status{
+							Conditions: []apis.Condition{{
+								Type:   apis.ConditionSucceeded,
+								Status: corev1.ConditionTrue,
+							}},
+						},
+						RunStatusFields: v1alpha1.RunStatusFields{
+							Results: []v1alpha1.RunResult{{Name: "aResult", Value: "aResultValue"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	pipelineResults := []v1beta1.PipelineResult{{
+		Name:  "from-custom-task",
+		Value: "$(tasks.aCustomPipelineTask.results.aResult)",
+	}, {
+		Name:  "missing-from-custom-task",
+		Value: "$(tasks.aCustomPipelineTask.results.missing)",
+	}}
+	want := ResolvedResultRefs{{
+		Value: *v1beta1.NewArrayOrString("aResultValue"),
+		ResultReference: v1beta1.ResultRef{
+			PipelineTask: "aCustomPipelineTask",
+			Result:       "aResult",
+		},
+		FromRun: "aRun",
+	}}
+	got := ResolvePipelineResultRefs(status, pipelineResults)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ResolvePipelineResultRefs %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolvePipelineResultRefs_ChildPipelineRuns(t *testing.T) {
+	status := v1beta1.PipelineRunStatus{
+		PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+			ChildPipelineRuns: map[string]*v1beta1.PipelineRunChildStatus{
+				"aChildPipelineRun": {
+					PipelineTaskName: "aChildPipelineTask",
+					Status: &v1beta1.PipelineRunStatus{
+						PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+							PipelineResults: []v1beta1.PipelineRunResult{{Name: "aResult", Value: "aResultValue"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	pipelineResults := []v1beta1.PipelineResult{{
+		Name:  "from-child-pipeline",
+		Value: "$(tasks.aChildPipelineTask.results.aResult)",
+	}}
+	want := ResolvedResultRefs{{
+		Value: *v1beta1.NewArrayOrString("aResultValue"),
+		ResultReference: v1beta1.ResultRef{
+			PipelineTask: "aChildPipelineTask",
+			Result:       "aResult",
+		},
+		FromPipelineRun: "aChildPipelineRun",
+	}}
+	got := ResolvePipelineResultRefs(status, pipelineResults)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ResolvePipelineResultRefs %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolveResultRefs_Matrix(t *testing.T) {
+	matrixTaskRun := func(name, result string) *v1beta1.TaskRun {
+		return &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: v1beta1.TaskRunStatus{
+				Status: duckv1beta1.Status{
+					Conditions: duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}},
+				},
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					TaskRunResults: []v1beta1.TaskRunResult{{Name: "digest", Value: result}},
+				},
+			},
+		}
+	}
+	matrixTask := &ResolvedPipelineRunTask{
+		TaskRunNames: []string{"pr-build-0", "pr-build-1"},
+		TaskRuns:     []*v1beta1.TaskRun{matrixTaskRun("pr-build-0", "sha-amd64"), matrixTaskRun("pr-build-1", "sha-arm64")},
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "build",
+			TaskRef: &v1beta1.TaskRef{Name: "build"},
+			Matrix: []v1beta1.Param{{
+				Name:  "platform",
+				Value: *v1beta1.NewArrayOrString("amd64", "arm64"),
+			}},
+		},
+	}
+	runningMatrixTask := &ResolvedPipelineRunTask{
+		TaskRunNames: matrixTask.TaskRunNames,
+		TaskRuns:     []*v1beta1.TaskRun{matrixTask.TaskRuns[0], nil},
+		PipelineTask: matrixTask.PipelineTask,
+	}
+	target := func(params []v1beta1.Param, matrix []v1beta1.Param, when []v1beta1.WhenExpression) *ResolvedPipelineRunTask {
+		return &ResolvedPipelineRunTask{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:            "publish",
+				TaskRef:         &v1beta1.TaskRef{Name: "publish"},
+				Params:          params,
+				Matrix:          matrix,
+				WhenExpressions: when,
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name       string
+		state      PipelineRunState
+		target     *ResolvedPipelineRunTask
+		want       ResolvedResultRefs
+		wantErrMsg string
+	}{{
+		name:  "aggregated result used as an entire value of an array param",
+		state: PipelineRunState{matrixTask},
+		target: target([]v1beta1.Param{{
+			Name:  "digests",
+			Value: *v1beta1.NewArrayOrString("first", "$(tasks.build.results.digest)"),
+		}}, nil, nil),
+		want: ResolvedResultRefs{{
+			Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{"sha-amd64", "sha-arm64"}},
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "build",
+				Result:       "digest",
+			},
+		}},
+	}, {
+		name:  "aggregated result used in a string param",
+		state: PipelineRunState{matrixTask},
+		target: target([]v1beta1.Param{{
+			Name:  "digest",
+			Value: *v1beta1.NewArrayOrString("$(tasks.build.results.digest)"),
+		}}, nil, nil),
+		wantErrMsg: `result "digest" of task "build" referenced by param "digest" in "publish" is produced by a matrix, it can only be used as an entire value of an array param`,
+	}, {
+		name:  "aggregated result used within an element of an array param",
+		state: PipelineRunState{matrixTask},
+		target: target([]v1beta1.Param{{
+			Name:  "digests",
+			Value: *v1beta1.NewArrayOrString("image@$(tasks.build.results.digest)"),
+		}}, nil, nil),
+		wantErrMsg: `result "digest" of task "build" referenced by param "digests" in "publish" is produced by a matrix, it can only be used as an entire value of an array param`,
+	}, {
+		name:  "aggregated result used in a matrix",
+		state: PipelineRunState{matrixTask},
+		target: target(nil, []v1beta1.Param{{
+			Name:  "digest",
+			Value: *v1beta1.NewArrayOrString("$(tasks.build.results.digest)", "other"),
+		}}, nil),
+		wantErrMsg: `result "digest" of task "build" referenced by param "digest" in "publish" is produced by a matrix, it can only be used as an entire value of an array param`,
+	}, {
+		name:  "aggregated result used in a when expression",
+		state: PipelineRunState{matrixTask},
+		target: target(nil, nil, []v1beta1.WhenExpression{{
+			Input:    "$(tasks.build.results.digest)",
+			Operator: selection.In,
+			Values:   []string{"sha"},
+		}}),
+		wantErrMsg: `result "digest" of task "build" referenced by when expression with input "$(tasks.build.results.digest)" in task "publish" is produced by a matrix, it can't be used in when expressions`,
+	}, {
+		name:  "matrix not done yet",
+		state: PipelineRunState{runningMatrixTask},
+		target: target([]v1beta1.Param{{
+			Name:  "digests",
+			Value: *v1beta1.NewArrayOrString("$(tasks.build.results.digest)"),
+		}}, nil, nil),
+		wantErrMsg: `unable to find result referenced by param "digests" in "publish": could not find successful taskruns for task "build"`,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveResultRefs(tt.state, PipelineRunState{tt.target})
+			if tt.wantErrMsg != "" {
+				if err == nil {
+					t.Fatalf("Expected error %q but got none", tt.wantErrMsg)
+				}
+				if d := cmp.Diff(tt.wantErrMsg, err.Error()); d != "" {
+					t.Errorf("Unexpected error %s", diff.PrintWantGot(d))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("ResolveResultRefs %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
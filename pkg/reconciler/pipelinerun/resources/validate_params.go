@@ -25,18 +25,24 @@ import (
 
 // ValidateParamTypesMatching validate that parameters in PipelineRun override corresponding parameters in Pipeline of the same type.
 func ValidateParamTypesMatching(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun) error {
-	// Build a map of parameter names/types declared in p.
-	paramTypes := make(map[string]v1beta1.ParamType)
+	// Build a map of parameter names/specs declared in p.
+	paramSpecs := make(map[string]v1beta1.ParamSpec)
 	for _, param := range p.Params {
-		paramTypes[param.Name] = param.Type
+		paramSpecs[param.Name] = param
 	}
 
-	// Build a list of parameter names from pr that have mismatching types with the map created above.
+	// Build a list of parameter names from pr that have mismatching types with the map created above,
+	// and of the keys missing from the object parameters.
 	var wrongTypeParamNames []string
+	var missingKeys []string
 	for _, param := range pr.Spec.Params {
-		if paramType, ok := paramTypes[param.Name]; ok {
-			if param.Value.Type != paramType {
+		if paramSpec, ok := paramSpecs[param.Name]; ok {
+			if param.Value.Type != paramSpec.Type {
 				wrongTypeParamNames = append(wrongTypeParamNames, param.Name)
+			} else if paramSpec.Type == v1beta1.ParamTypeObject {
+				for _, key := range paramSpec.MissingObjectKeys(param.Value) {
+					missingKeys = append(missingKeys, fmt.Sprintf("%s.%s", param.Name, key))
+				}
 			}
 		}
 	}
@@ -45,6 +51,36 @@ func ValidateParamTypesMatching(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun
 	if len(wrongTypeParamNames) != 0 {
 		return fmt.Errorf("parameters have inconsistent types : %s", wrongTypeParamNames)
 	}
+	if len(missingKeys) != 0 {
+		return fmt.Errorf("object parameters are missing keys which have no default values : %s", missingKeys)
+	}
+	return nil
+}
+
+// ValidateParamValues validates that the values of the parameters in PipelineRun are allowed by the
+// enum and pattern declared by the corresponding parameters in Pipeline.
+func ValidateParamValues(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun) error {
+	if err := v1beta1.ValidateParamValues(p.Params, pr.Spec.Params).ViaField("params"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ValidateTaskParamValues validates that the values given to the parameters of a PipelineTask,
+// including the values produced from the results of previous Tasks, are allowed by the enum and
+// pattern declared by its Task.
+func ValidateTaskParamValues(rprt *ResolvedPipelineRunTask) error {
+	if rprt.PipelineTask == nil || rprt.ResolvedTaskResources == nil || rprt.ResolvedTaskResources.TaskSpec == nil {
+		return nil
+	}
+	paramSpecs := rprt.ResolvedTaskResources.TaskSpec.Params
+	if err := v1beta1.ValidateParamValues(paramSpecs, rprt.PipelineTask.Params).ViaField("params"); err != nil {
+		return fmt.Errorf("invalid params for PipelineTask %s: %w", rprt.PipelineTask.Name, err)
+	}
+	// Each element of a matrix parameter is given to a separate TaskRun
+	if err := v1beta1.ValidateParamValues(paramSpecs, rprt.PipelineTask.Matrix).ViaField("matrix"); err != nil {
+		return fmt.Errorf("invalid matrix params for PipelineTask %s: %w", rprt.PipelineTask.Name, err)
+	}
 	return nil
 }
 
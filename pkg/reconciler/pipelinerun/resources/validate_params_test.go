@@ -21,6 +21,7 @@ import (
 
 	tb "github.com/tektoncd/pipeline/internal/builder/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 )
 
 func TestValidateParamTypesMatching_Valid(t *testing.T) {
@@ -70,6 +71,19 @@ func TestValidateParamTypesMatching_Valid(t *testing.T) {
 				tb.PipelineRunParam("mismatching-type", "astring"),
 				tb.PipelineRunParam("correct-type-2", "another", "array"))),
 		errorExpected: true,
+	}, {
+		name: "object param with keys from the default",
+		p: &v1beta1.Pipeline{Spec: v1beta1.PipelineSpec{Params: []v1beta1.ParamSpec{{
+			Name:       "repo",
+			Type:       v1beta1.ParamTypeObject,
+			Properties: map[string]v1beta1.PropertySpec{"url": {Type: v1beta1.ParamTypeString}, "commit": {Type: v1beta1.ParamTypeString}},
+			Default:    v1beta1.NewObject(map[string]string{"commit": "main"}),
+		}}}},
+		pr: &v1beta1.PipelineRun{Spec: v1beta1.PipelineRunSpec{Params: []v1beta1.Param{{
+			Name:  "repo",
+			Value: *v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline"}),
+		}}}},
+		errorExpected: false,
 	}}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -112,6 +126,27 @@ func TestValidateParamTypesMatching_Invalid(t *testing.T) {
 				tb.PipelineRunParam("correct-type-1", "somestring"),
 				tb.PipelineRunParam("mismatching-type", "astring"),
 				tb.PipelineRunParam("correct-type-2", "another", "array"))),
+	}, {
+		name: "object-string mismatch",
+		p: &v1beta1.Pipeline{Spec: v1beta1.PipelineSpec{Params: []v1beta1.ParamSpec{{
+			Name:       "repo",
+			Type:       v1beta1.ParamTypeObject,
+			Properties: map[string]v1beta1.PropertySpec{"url": {Type: v1beta1.ParamTypeString}},
+		}}}},
+		pr: tb.PipelineRun("a-pipelinerun",
+			tb.PipelineRunSpec("test-pipeline",
+				tb.PipelineRunParam("repo", "https://github.com/tektoncd/pipeline"))),
+	}, {
+		name: "object param missing keys",
+		p: &v1beta1.Pipeline{Spec: v1beta1.PipelineSpec{Params: []v1beta1.ParamSpec{{
+			Name:       "repo",
+			Type:       v1beta1.ParamTypeObject,
+			Properties: map[string]v1beta1.PropertySpec{"url": {Type: v1beta1.ParamTypeString}, "commit": {Type: v1beta1.ParamTypeString}},
+		}}}},
+		pr: &v1beta1.PipelineRun{Spec: v1beta1.PipelineRunSpec{Params: []v1beta1.Param{{
+			Name:  "repo",
+			Value: *v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline"}),
+		}}}},
 	}}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -222,3 +257,116 @@ func TestValidateRequiredParametersProvided_Invalid(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateParamValues(t *testing.T) {
+	p := &v1beta1.PipelineSpec{
+		Params: []v1beta1.ParamSpec{{
+			Name: "env",
+			Type: v1beta1.ParamTypeString,
+			Enum: []string{"dev", "prod"},
+		}, {
+			Name:    "version",
+			Type:    v1beta1.ParamTypeString,
+			Pattern: "^v[0-9]+$",
+		}},
+	}
+	for _, tc := range []struct {
+		name    string
+		params  []v1beta1.Param
+		wantErr bool
+	}{{
+		name: "allowed values",
+		params: []v1beta1.Param{{
+			Name:  "env",
+			Value: *v1beta1.NewArrayOrString("dev"),
+		}, {
+			Name:  "version",
+			Value: *v1beta1.NewArrayOrString("v3"),
+		}, {
+			Name:  "extra",
+			Value: *v1beta1.NewArrayOrString("anything"),
+		}},
+	}, {
+		name: "value not in enum",
+		params: []v1beta1.Param{{
+			Name:  "env",
+			Value: *v1beta1.NewArrayOrString("prdo"),
+		}},
+		wantErr: true,
+	}, {
+		name: "value not matching pattern",
+		params: []v1beta1.Param{{
+			Name:  "version",
+			Value: *v1beta1.NewArrayOrString("latest"),
+		}},
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1beta1.PipelineRun{Spec: v1beta1.PipelineRunSpec{Params: tc.params}}
+			err := ValidateParamValues(p, pr)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateParamValues() error = %v, wantErr %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateTaskParamValues(t *testing.T) {
+	taskSpec := &v1beta1.TaskSpec{
+		Params: []v1beta1.ParamSpec{{
+			Name: "env",
+			Type: v1beta1.ParamTypeString,
+			Enum: []string{"dev", "prod"},
+		}},
+	}
+	for _, tc := range []struct {
+		name    string
+		rprt    *ResolvedPipelineRunTask
+		wantErr bool
+	}{{
+		name: "value produced from a result allowed",
+		rprt: &ResolvedPipelineRunTask{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:   "deploy",
+				Params: []v1beta1.Param{{Name: "env", Value: *v1beta1.NewArrayOrString("prod")}},
+			},
+			ResolvedTaskResources: &resources.ResolvedTaskResources{TaskSpec: taskSpec},
+		},
+	}, {
+		name: "value produced from a result not allowed",
+		rprt: &ResolvedPipelineRunTask{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:   "deploy",
+				Params: []v1beta1.Param{{Name: "env", Value: *v1beta1.NewArrayOrString("prdo")}},
+			},
+			ResolvedTaskResources: &resources.ResolvedTaskResources{TaskSpec: taskSpec},
+		},
+		wantErr: true,
+	}, {
+		name: "matrix element not allowed",
+		rprt: &ResolvedPipelineRunTask{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:   "deploy",
+				Matrix: []v1beta1.Param{{Name: "env", Value: *v1beta1.NewArrayOrString("dev", "qa")}},
+			},
+			ResolvedTaskResources: &resources.ResolvedTaskResources{TaskSpec: taskSpec},
+		},
+		wantErr: true,
+	}, {
+		name: "custom task",
+		rprt: &ResolvedPipelineRunTask{
+			CustomTask: true,
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:   "deploy",
+				Params: []v1beta1.Param{{Name: "env", Value: *v1beta1.NewArrayOrString("prdo")}},
+			},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateTaskParamValues(tc.rprt)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateTaskParamValues() error = %v, wantErr %t", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	pipelineruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipelinerun"
+	taskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/taskrun"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// NewController instantiates a new controller.Impl from knative.dev/pkg/controller
+func NewController(namespace string) func(context.Context, configmap.Watcher) *controller.Impl {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		logger := logging.FromContext(ctx)
+		pipelineRunInformer := pipelineruninformer.Get(ctx)
+		taskRunInformer := taskruninformer.Get(ctx)
+		metrics, err := NewRecorder()
+		if err != nil {
+			logger.Errorf("Failed to create pruner metrics recorder %v", err)
+		}
+
+		c := &Reconciler{
+			KubeClientSet:     kubeclient.Get(ctx),
+			PipelineClientSet: pipelineclient.Get(ctx),
+			pipelineRunLister: pipelineRunInformer.Lister(),
+			taskRunLister:     taskRunInformer.Lister(),
+			metrics:           metrics,
+		}
+		impl := controller.NewImpl(c, logger, pipeline.PrunerControllerName)
+		c.enqueueAfter = impl.EnqueueKeyAfter
+
+		enqueueNamespace := func(obj interface{}) {
+			object, err := meta.Accessor(obj)
+			if err != nil {
+				return
+			}
+			if namespace == "" || object.GetNamespace() == namespace {
+				impl.EnqueueKey(types.NamespacedName{Name: object.GetNamespace()})
+			}
+		}
+
+		// Every namespace with runs is pruned again when the retention policy changes.
+		configStore := config.NewStore(logger.Named("config-store"), func(name string, _ interface{}) {
+			if name != config.GetPrunerConfigName() {
+				return
+			}
+			prs, _ := pipelineRunInformer.Lister().List(labels.Everything())
+			for _, pr := range prs {
+				enqueueNamespace(pr)
+			}
+			trs, _ := taskRunInformer.Lister().List(labels.Everything())
+			for _, tr := range trs {
+				enqueueNamespace(tr)
+			}
+		})
+		configStore.WatchConfigs(cmw)
+		c.configStore = configStore
+
+		logger.Info("Setting up event handlers")
+		for _, informer := range []cache.SharedIndexInformer{pipelineRunInformer.Informer(), taskRunInformer.Informer()} {
+			informer.AddEventHandler(cache.FilteringResourceEventHandler{
+				FilterFunc: isDone,
+				Handler: cache.ResourceEventHandlerFuncs{
+					AddFunc:    enqueueNamespace,
+					UpdateFunc: controller.PassNew(enqueueNamespace),
+				},
+			})
+		}
+
+		return impl
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner
+
+import (
+	"context"
+	"errors"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"knative.dev/pkg/metrics"
+)
+
+var (
+	prunedPRsCount = stats.Float64("pruned_pipelineruns_count",
+		"number of completed pipelineruns deleted by the pruner",
+		stats.UnitDimensionless)
+
+	prunedTRsCount = stats.Float64("pruned_taskruns_count",
+		"number of completed taskruns deleted by the pruner",
+		stats.UnitDimensionless)
+)
+
+// Recorder holds keys for the metrics of the pruner
+type Recorder struct {
+	initialized bool
+
+	namespace tag.Key
+}
+
+// NewRecorder creates a new metrics recorder instance
+// to log the metrics of the pruner
+func NewRecorder() (*Recorder, error) {
+	r := &Recorder{
+		initialized: true,
+	}
+
+	namespace, err := tag.NewKey("namespace")
+	if err != nil {
+		return nil, err
+	}
+	r.namespace = namespace
+
+	err = view.Register(
+		&view.View{
+			Description: prunedPRsCount.Description(),
+			Measure:     prunedPRsCount,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{r.namespace},
+		},
+		&view.View{
+			Description: prunedTRsCount.Description(),
+			Measure:     prunedTRsCount,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{r.namespace},
+		},
+	)
+
+	if err != nil {
+		r.initialized = false
+		return r, err
+	}
+
+	return r, nil
+}
+
+// PrunedPipelineRun counts a PipelineRun deleted by the pruner
+// returns an error if its failed to log the metrics
+func (r *Recorder) PrunedPipelineRun(namespace string) error {
+	return r.pruned(namespace, prunedPRsCount)
+}
+
+// PrunedTaskRun counts a TaskRun deleted by the pruner
+// returns an error if its failed to log the metrics
+func (r *Recorder) PrunedTaskRun(namespace string) error {
+	return r.pruned(namespace, prunedTRsCount)
+}
+
+func (r *Recorder) pruned(namespace string, m *stats.Float64Measure) error {
+	if r == nil || !r.initialized {
+		return errors.New("ignoring the metrics recording, failed to initialize the metrics recorder")
+	}
+
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(r.namespace, namespace),
+	)
+	if err != nil {
+		return err
+	}
+
+	metrics.Record(ctx, m.M(1))
+	return nil
+}
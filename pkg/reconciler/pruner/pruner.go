@@ -0,0 +1,284 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/logarchive"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+)
+
+// Reconciler deletes the completed PipelineRuns and TaskRuns of a namespace which are
+// no longer retained by the retention policy of that namespace. It is keyed by the name
+// of the namespace.
+type Reconciler struct {
+	KubeClientSet     kubernetes.Interface
+	PipelineClientSet clientset.Interface
+
+	pipelineRunLister listers.PipelineRunLister
+	taskRunLister     listers.TaskRunLister
+	configStore       pkgreconciler.ConfigStore
+	metrics           *Recorder
+	enqueueAfter      func(types.NamespacedName, time.Duration)
+}
+
+// Check that our Reconciler implements controller.Reconciler
+var _ controller.Reconciler = (*Reconciler)(nil)
+
+// completedRun is a completed PipelineRun or TaskRun considered for pruning
+type completedRun struct {
+	name           string
+	completionTime time.Time
+}
+
+// Reconcile prunes the completed runs of the namespace named by key, and schedules the
+// namespace to be reconciled again when the next retained run expires.
+func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
+	logger := logging.FromContext(ctx)
+	if c.configStore != nil {
+		ctx = c.configStore.ToContext(ctx)
+	}
+	cfgs := config.FromContextOrDefaults(ctx)
+	cfg := cfgs.Pruner
+
+	ns, err := c.KubeClientSet.CoreV1().Namespaces().Get(key, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		// The runs of the namespace are deleted with it.
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get namespace %s: %w", key, err)
+	}
+	policy, err := cfg.ForNamespace(ns)
+	if err != nil {
+		logger.Warnf("Ignoring the invalid retention policy overrides of %v", err)
+		policy = cfg
+	}
+	if !policy.Enabled() {
+		return nil
+	}
+
+	now := time.Now()
+	var merr *multierror.Error
+	var requeue time.Duration
+
+	prs, err := c.pipelineRunLister.PipelineRuns(key).List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list pipelineruns of namespace %s: %w", key, err)
+	}
+	trs, err := c.taskRunLister.TaskRuns(key).List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list taskruns of namespace %s: %w", key, err)
+	}
+
+	// The runs whose logs are being archived, or whose record is being exported, are
+	// retained until they are: they count towards keep, but aren't pruned yet.
+	unfinishedPRs, unfinishedTRs := sets.NewString(), sets.NewString()
+	for _, tr := range trs {
+		if !taskRunFinished(cfgs.LogArchive, policy, tr) {
+			unfinishedTRs.Insert(tr.Name)
+			// The TaskRuns of a PipelineRun are deleted along with it.
+			if owner := tr.Labels[pipeline.GroupName+pipeline.PipelineRunLabelKey]; owner != "" {
+				unfinishedPRs.Insert(owner)
+			}
+		}
+	}
+	byName := map[string]*v1beta1.PipelineRun{}
+	for _, pr := range prs {
+		byName[pr.Name] = pr
+		if policy.WaitForResultsExport && !isExported(pr) {
+			unfinishedPRs.Insert(pr.Name)
+		}
+	}
+	// The child PipelineRuns of a PipelineRun are deleted along with it too.
+	for _, name := range unfinishedPRs.List() {
+		for pr := byName[name]; pr != nil; {
+			owner := metav1.GetControllerOf(pr)
+			if owner == nil || owner.Kind != pipeline.PipelineRunControllerName {
+				break
+			}
+			unfinishedPRs.Insert(owner.Name)
+			pr = byName[owner.Name]
+		}
+	}
+
+	prGroups := map[string][]completedRun{}
+	for _, pr := range prs {
+		// PipelineRuns controlled by a PipelineRun, or by any other controller, are
+		// deleted along with their owner.
+		if metav1.GetControllerOf(pr) != nil {
+			continue
+		}
+		if !pr.IsDone() || pr.Status.CompletionTime == nil || !pr.DeletionTimestamp.IsZero() {
+			continue
+		}
+		group := ""
+		if pr.Spec.PipelineRef != nil {
+			group = pr.Spec.PipelineRef.Name
+		}
+		prGroups[group] = append(prGroups[group], completedRun{name: pr.Name, completionTime: pr.Status.CompletionTime.Time})
+	}
+	for _, runs := range prGroups {
+		expired, next := expiredRuns(runs, policy, now)
+		requeue = earliest(requeue, next)
+		for _, name := range expired {
+			if unfinishedPRs.Has(name) {
+				continue
+			}
+			if err := c.deletePipelineRun(key, name); err != nil {
+				merr = multierror.Append(merr, err)
+				continue
+			}
+			logger.Infof("Pruned PipelineRun %s/%s", key, name)
+			if err := c.metrics.PrunedPipelineRun(key); err != nil {
+				logger.Warnf("Failed to log the metrics : %v", err)
+			}
+		}
+	}
+
+	trGroups := map[string][]completedRun{}
+	for _, tr := range trs {
+		// TaskRuns controlled by a PipelineRun, or by any other controller, are
+		// deleted along with their owner.
+		if metav1.GetControllerOf(tr) != nil {
+			continue
+		}
+		if !tr.IsDone() || tr.Status.CompletionTime == nil || !tr.DeletionTimestamp.IsZero() {
+			continue
+		}
+		group := ""
+		if tr.Spec.TaskRef != nil {
+			group = fmt.Sprintf("%s/%s", tr.Spec.TaskRef.Kind, tr.Spec.TaskRef.Name)
+		}
+		trGroups[group] = append(trGroups[group], completedRun{name: tr.Name, completionTime: tr.Status.CompletionTime.Time})
+	}
+	for _, runs := range trGroups {
+		expired, next := expiredRuns(runs, policy, now)
+		requeue = earliest(requeue, next)
+		for _, name := range expired {
+			if unfinishedTRs.Has(name) {
+				continue
+			}
+			if err := c.deleteTaskRun(key, name); err != nil {
+				merr = multierror.Append(merr, err)
+				continue
+			}
+			logger.Infof("Pruned TaskRun %s/%s", key, name)
+			if err := c.metrics.PrunedTaskRun(key); err != nil {
+				logger.Warnf("Failed to log the metrics : %v", err)
+			}
+		}
+	}
+
+	if requeue > 0 && c.enqueueAfter != nil {
+		c.enqueueAfter(types.NamespacedName{Name: key}, requeue)
+	}
+	return merr.ErrorOrNil()
+}
+
+// expiredRuns returns the names of the runs, all of the same Pipeline or Task, which
+// are not retained by the policy, and how long until the next retained run expires.
+func expiredRuns(runs []completedRun, policy *config.Pruner, now time.Time) ([]string, time.Duration) {
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].completionTime.After(runs[j].completionTime)
+	})
+	var expired []string
+	var next time.Duration
+	for i, r := range runs {
+		age := now.Sub(r.completionTime)
+		switch {
+		case policy.Keep > 0 && i >= policy.Keep:
+			expired = append(expired, r.name)
+		case policy.MaxAge > 0 && age >= policy.MaxAge:
+			expired = append(expired, r.name)
+		case policy.MaxAge > 0:
+			next = earliest(next, policy.MaxAge-age)
+		}
+	}
+	return expired, next
+}
+
+// taskRunFinished returns whether the logs of a completed TaskRun are archived, and its
+// record exported, when the log archive and the wait for the results-exporter are enabled.
+// The logs of the TaskRuns which never ran a pod aren't archived.
+func taskRunFinished(logArchive *config.LogArchive, policy *config.Pruner, tr *v1beta1.TaskRun) bool {
+	if logArchive.Enabled() && tr.Status.PodName != "" && !logarchive.IsArchived(tr) {
+		return false
+	}
+	return !policy.WaitForResultsExport || isExported(tr)
+}
+
+// isExported returns whether the results-exporter exported the record of a run
+func isExported(run metav1.Object) bool {
+	return run.GetAnnotations()[pipeline.GroupName+pipeline.ResultsExportedAnnotationKey] == "true"
+}
+
+// earliest returns the shortest of two delays, where zero means no delay is set
+func earliest(a, b time.Duration) time.Duration {
+	if a == 0 || (b > 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+// The pods of the runs, and the TaskRuns of a PipelineRun, are controlled by the
+// run and deleted by the garbage collector.
+var propagationPolicy = metav1.DeletePropagationBackground
+
+func (c *Reconciler) deletePipelineRun(namespace, name string) error {
+	err := c.PipelineClientSet.TektonV1beta1().PipelineRuns(namespace).Delete(name, &metav1.DeleteOptions{PropagationPolicy: &propagationPolicy})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete pipelinerun %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+func (c *Reconciler) deleteTaskRun(namespace, name string) error {
+	err := c.PipelineClientSet.TektonV1beta1().TaskRuns(namespace).Delete(name, &metav1.DeleteOptions{PropagationPolicy: &propagationPolicy})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete taskrun %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// isDone returns true for the PipelineRuns and TaskRuns which are completed
+func isDone(obj interface{}) bool {
+	switch run := obj.(type) {
+	case *v1beta1.PipelineRun:
+		return run.IsDone()
+	case *v1beta1.TaskRun:
+		return run.IsDone()
+	}
+	return false
+}
@@ -0,0 +1,442 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/pkg/system"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ktesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/metrics/metricstest" // Required to setup metrics env for testing
+	_ "knative.dev/pkg/metrics/testing"
+)
+
+const namespace = "foo"
+
+var now = time.Now()
+
+func ago(d time.Duration) *metav1.Time {
+	t := metav1.NewTime(now.Add(-d))
+	return &t
+}
+
+func completedPipelineRun(name, pipeline string, completed time.Duration) *v1beta1.PipelineRun {
+	return &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       v1beta1.PipelineRunSpec{PipelineRef: &v1beta1.PipelineRef{Name: pipeline}},
+		Status: v1beta1.PipelineRunStatus{
+			Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			}}},
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{CompletionTime: ago(completed)},
+		},
+	}
+}
+
+func completedTaskRun(name, task string, completed time.Duration) *v1beta1.TaskRun {
+	return &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       v1beta1.TaskRunSpec{TaskRef: &v1beta1.TaskRef{Name: task, Kind: v1beta1.NamespacedTaskKind}},
+		Status: v1beta1.TaskRunStatus{
+			Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+			}}},
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{CompletionTime: ago(completed)},
+		},
+	}
+}
+
+func runningPipelineRun(name, pipeline string) *v1beta1.PipelineRun {
+	pr := completedPipelineRun(name, pipeline, 0)
+	pr.Status = v1beta1.PipelineRunStatus{}
+	return pr
+}
+
+func ownedTaskRun(name, task string, completed time.Duration) *v1beta1.TaskRun {
+	tr := completedTaskRun(name, task, completed)
+	tr.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(
+		completedPipelineRun("pipelinerun", "pipeline", completed), v1beta1.SchemeGroupVersion.WithKind("PipelineRun"))}
+	return tr
+}
+
+func ownedPipelineRun(name, pipeline string, completed time.Duration) *v1beta1.PipelineRun {
+	pr := completedPipelineRun(name, pipeline, completed)
+	pr.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(
+		completedPipelineRun("pipelinerun", "parent-pipeline", completed), v1beta1.SchemeGroupVersion.WithKind("PipelineRun"))}
+	return pr
+}
+
+// exported marks a run as exported by the results-exporter
+func exported(run metav1.Object) {
+	run.SetAnnotations(map[string]string{"tekton.dev/results-exported": "true"})
+}
+
+// taskRunWithPod returns a completed TaskRun which ran a pod, and whose logs are archived
+// if archived is true
+func taskRunWithPod(name, pipelineRun string, completed time.Duration, archived bool) *v1beta1.TaskRun {
+	tr := completedTaskRun(name, "task", completed)
+	tr.Status.PodName = name + "-pod"
+	if pipelineRun != "" {
+		tr = ownedTaskRun(name, "task", completed)
+		tr.Status.PodName = name + "-pod"
+		tr.Labels = map[string]string{"tekton.dev/pipelineRun": pipelineRun}
+	}
+	if archived {
+		tr.Annotations = map[string]string{"tekton.dev/logs-archived": "true"}
+	}
+	return tr
+}
+
+func prunerConfig(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetPrunerConfigName(), Namespace: system.GetNamespace()},
+		Data:       data,
+	}
+}
+
+// getPrunerController returns an instance of the pruner controller that has been seeded with
+// d, where d represents the state of the system (existing resources) needed for the test.
+func getPrunerController(t *testing.T, d test.Data) (test.Assets, func()) {
+	t.Helper()
+	ctx, _ := ttesting.SetupFakeContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	for _, name := range []string{
+		config.GetDefaultsConfigName(),
+		config.GetFeatureFlagsConfigName(),
+		config.GetArtifactBucketConfigName(),
+		config.GetArtifactPVCConfigName(),
+		config.GetProvenanceConfigName(),
+		config.GetTrustedResourcesConfigName(),
+		config.GetPrunerConfigName(),
+		config.GetLogArchiveConfigName(),
+	} {
+		exists := false
+		for _, cm := range d.ConfigMaps {
+			exists = exists || cm.Name == name
+		}
+		if !exists {
+			d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: system.GetNamespace()},
+			})
+		}
+	}
+	c, informers := test.SeedTestData(t, ctx, d)
+	configMapWatcher := configmap.NewInformedWatcher(c.Kube, system.GetNamespace())
+
+	ctl := NewController("")(ctx, configMapWatcher)
+	if err := configMapWatcher.Start(ctx.Done()); err != nil {
+		t.Fatalf("error starting configmap watcher: %v", err)
+	}
+
+	return test.Assets{
+		Controller: ctl,
+		Clients:    c,
+		Informers:  informers,
+	}, cancel
+}
+
+// deletedRuns returns the names of the PipelineRuns and TaskRuns deleted through the client
+func deletedRuns(actions []ktesting.Action) (prs []string, trs []string) {
+	for _, action := range actions {
+		del, ok := action.(ktesting.DeleteAction)
+		if !ok {
+			continue
+		}
+		switch del.GetResource().Resource {
+		case "pipelineruns":
+			prs = append(prs, del.GetName())
+		case "taskruns":
+			trs = append(trs, del.GetName())
+		}
+	}
+	sort.Strings(prs)
+	sort.Strings(trs)
+	return prs, trs
+}
+
+func TestReconcile(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		config      map[string]string
+		logArchive  map[string]string
+		annotations map[string]string
+		prs         []*v1beta1.PipelineRun
+		trs         []*v1beta1.TaskRun
+		wantPRs     []string
+		wantTRs     []string
+	}{{
+		name: "pruning disabled",
+		prs: []*v1beta1.PipelineRun{
+			completedPipelineRun("pr-1", "pipeline", 1000*time.Hour),
+		},
+		trs: []*v1beta1.TaskRun{
+			completedTaskRun("tr-1", "task", 1000*time.Hour),
+		},
+	}, {
+		name:   "keep the last runs of each pipeline",
+		config: map[string]string{config.PrunerKeepKey: "2"},
+		prs: []*v1beta1.PipelineRun{
+			completedPipelineRun("pr-1", "pipeline", 3*time.Hour),
+			completedPipelineRun("pr-2", "pipeline", 2*time.Hour),
+			completedPipelineRun("pr-3", "pipeline", time.Hour),
+			completedPipelineRun("pr-4", "pipeline", 4*time.Hour),
+			runningPipelineRun("pr-5", "pipeline"),
+			completedPipelineRun("other-pr-1", "other-pipeline", 5*time.Hour),
+			completedPipelineRun("other-pr-2", "other-pipeline", 6*time.Hour),
+		},
+		wantPRs: []string{"pr-1", "pr-4"},
+	}, {
+		name:   "keep the child pipelineruns of a retained pipelinerun",
+		config: map[string]string{config.PrunerKeepKey: "1"},
+		prs: []*v1beta1.PipelineRun{
+			completedPipelineRun("pr-1", "pipeline", 2*time.Hour),
+			completedPipelineRun("pr-2", "pipeline", time.Hour),
+			ownedPipelineRun("owned-pr-1", "child-pipeline", 3*time.Hour),
+			ownedPipelineRun("owned-pr-2", "child-pipeline", 4*time.Hour),
+		},
+		wantPRs: []string{"pr-1"},
+	}, {
+		name:   "keep the last runs of each task",
+		config: map[string]string{config.PrunerKeepKey: "1"},
+		trs: []*v1beta1.TaskRun{
+			completedTaskRun("tr-1", "task", 2*time.Hour),
+			completedTaskRun("tr-2", "task", time.Hour),
+			completedTaskRun("other-tr-1", "other-task", 5*time.Hour),
+			ownedTaskRun("owned-tr-1", "task", 3*time.Hour),
+			ownedTaskRun("owned-tr-2", "task", 4*time.Hour),
+		},
+		wantTRs: []string{"tr-1"},
+	}, {
+		name:   "max age",
+		config: map[string]string{config.PrunerMaxAgeKey: "24h"},
+		prs: []*v1beta1.PipelineRun{
+			completedPipelineRun("pr-1", "pipeline", 25*time.Hour),
+			completedPipelineRun("pr-2", "pipeline", 23*time.Hour),
+		},
+		trs: []*v1beta1.TaskRun{
+			completedTaskRun("tr-1", "task", 48*time.Hour),
+			completedTaskRun("tr-2", "task", time.Hour),
+		},
+		wantPRs: []string{"pr-1"},
+		wantTRs: []string{"tr-1"},
+	}, {
+		name:   "keep and max age",
+		config: map[string]string{config.PrunerKeepKey: "2", config.PrunerMaxAgeKey: "24h"},
+		prs: []*v1beta1.PipelineRun{
+			completedPipelineRun("pr-1", "pipeline", 25*time.Hour),
+			completedPipelineRun("pr-2", "pipeline", 3*time.Hour),
+			completedPipelineRun("pr-3", "pipeline", 2*time.Hour),
+			completedPipelineRun("pr-4", "pipeline", time.Hour),
+		},
+		wantPRs: []string{"pr-1", "pr-2"},
+	}, {
+		name:        "namespace overrides",
+		config:      map[string]string{config.PrunerKeepKey: "5"},
+		annotations: map[string]string{config.PrunerKeepAnnotation: "1"},
+		prs: []*v1beta1.PipelineRun{
+			completedPipelineRun("pr-1", "pipeline", 2*time.Hour),
+			completedPipelineRun("pr-2", "pipeline", time.Hour),
+		},
+		wantPRs: []string{"pr-1"},
+	}, {
+		name:        "namespace opts out",
+		config:      map[string]string{config.PrunerKeepKey: "1"},
+		annotations: map[string]string{config.PrunerKeepAnnotation: "0"},
+		prs: []*v1beta1.PipelineRun{
+			completedPipelineRun("pr-1", "pipeline", 2*time.Hour),
+			completedPipelineRun("pr-2", "pipeline", time.Hour),
+		},
+	}, {
+		name:        "invalid namespace overrides are ignored",
+		config:      map[string]string{config.PrunerKeepKey: "1"},
+		annotations: map[string]string{config.PrunerKeepAnnotation: "all"},
+		prs: []*v1beta1.PipelineRun{
+			completedPipelineRun("pr-1", "pipeline", 2*time.Hour),
+			completedPipelineRun("pr-2", "pipeline", time.Hour),
+		},
+		wantPRs: []string{"pr-1"},
+	}, {
+		name:       "wait for the logs to be archived",
+		config:     map[string]string{config.PrunerKeepKey: "1"},
+		logArchive: map[string]string{config.LogArchiveSinkKey: config.LogArchiveSinkPVC, config.LogArchivePVCClaimNameKey: "logs"},
+		prs: []*v1beta1.PipelineRun{
+			completedPipelineRun("pr-1", "pipeline", 3*time.Hour),
+			completedPipelineRun("pr-2", "pipeline", 2*time.Hour),
+			completedPipelineRun("pr-3", "pipeline", time.Hour),
+		},
+		trs: []*v1beta1.TaskRun{
+			taskRunWithPod("pr-1-task", "pr-1", 3*time.Hour, true),
+			taskRunWithPod("pr-2-task", "pr-2", 2*time.Hour, false),
+			taskRunWithPod("tr-1", "", 4*time.Hour, true),
+			taskRunWithPod("tr-2", "", 3*time.Hour, false),
+			completedTaskRun("tr-3", "task", 2*time.Hour),
+			taskRunWithPod("tr-4", "", time.Hour, true),
+		},
+		wantPRs: []string{"pr-1"},
+		wantTRs: []string{"tr-1", "tr-3"},
+	}, {
+		name:   "wait for the results to be exported",
+		config: map[string]string{config.PrunerKeepKey: "1", config.PrunerWaitForResultsExportKey: "true"},
+		prs: func() []*v1beta1.PipelineRun {
+			prs := []*v1beta1.PipelineRun{
+				completedPipelineRun("pr-1", "pipeline", 3*time.Hour),
+				completedPipelineRun("pr-2", "pipeline", 2*time.Hour),
+				completedPipelineRun("pr-3", "pipeline", time.Hour),
+			}
+			exported(prs[0])
+			return prs
+		}(),
+		trs: func() []*v1beta1.TaskRun {
+			trs := []*v1beta1.TaskRun{
+				completedTaskRun("tr-1", "task", 3*time.Hour),
+				completedTaskRun("tr-2", "task", 2*time.Hour),
+				completedTaskRun("tr-3", "task", time.Hour),
+			}
+			exported(trs[1])
+			return trs
+		}(),
+		wantPRs: []string{"pr-1"},
+		wantTRs: []string{"tr-2"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := test.Data{
+				Namespaces: []*corev1.Namespace{{
+					ObjectMeta: metav1.ObjectMeta{Name: namespace, Annotations: tc.annotations},
+				}},
+				PipelineRuns: tc.prs,
+				TaskRuns:     tc.trs,
+				ConfigMaps: []*corev1.ConfigMap{prunerConfig(tc.config), {
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetLogArchiveConfigName(), Namespace: system.GetNamespace()},
+					Data:       tc.logArchive,
+				}},
+			}
+			testAssets, cancel := getPrunerController(t, d)
+			defer cancel()
+
+			if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), namespace); err != nil {
+				t.Fatalf("Reconcile() = %v", err)
+			}
+
+			gotPRs, gotTRs := deletedRuns(testAssets.Clients.Pipeline.Actions())
+			if d := cmp.Diff(tc.wantPRs, gotPRs); d != "" {
+				t.Errorf("Deleted PipelineRuns %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantTRs, gotTRs); d != "" {
+				t.Errorf("Deleted TaskRuns %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestReconcileRequeuesAtNextExpiry(t *testing.T) {
+	d := test.Data{
+		Namespaces: []*corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: namespace}}},
+		PipelineRuns: []*v1beta1.PipelineRun{
+			completedPipelineRun("pr-1", "pipeline", 20*time.Hour),
+		},
+		TaskRuns: []*v1beta1.TaskRun{
+			completedTaskRun("tr-1", "task", 22*time.Hour),
+		},
+		ConfigMaps: []*corev1.ConfigMap{prunerConfig(map[string]string{config.PrunerMaxAgeKey: "24h"})},
+	}
+	testAssets, cancel := getPrunerController(t, d)
+	defer cancel()
+
+	var gotKey types.NamespacedName
+	var gotDelay time.Duration
+	r := testAssets.Controller.Reconciler.(*Reconciler)
+	r.enqueueAfter = func(key types.NamespacedName, delay time.Duration) {
+		gotKey, gotDelay = key, delay
+	}
+	if err := r.Reconcile(context.Background(), namespace); err != nil {
+		t.Fatalf("Reconcile() = %v", err)
+	}
+
+	if gotKey.Name != namespace {
+		t.Errorf("Expected namespace %s to be requeued but got %v", namespace, gotKey)
+	}
+	// The TaskRun expires in 2 hours, minus the time elapsed since the test started.
+	if gotDelay <= 2*time.Hour-time.Minute || gotDelay > 2*time.Hour {
+		t.Errorf("Expected the namespace to be requeued in about 2h but got %v", gotDelay)
+	}
+}
+
+func TestReconcileRecordsMetrics(t *testing.T) {
+	metricstest.Unregister("pruned_pipelineruns_count", "pruned_taskruns_count")
+	d := test.Data{
+		Namespaces: []*corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: namespace}}},
+		PipelineRuns: []*v1beta1.PipelineRun{
+			completedPipelineRun("pr-1", "pipeline", 2*time.Hour),
+			completedPipelineRun("pr-2", "pipeline", time.Hour),
+		},
+		TaskRuns: []*v1beta1.TaskRun{
+			completedTaskRun("tr-1", "task", 3*time.Hour),
+			completedTaskRun("tr-2", "task", 2*time.Hour),
+			completedTaskRun("tr-3", "task", time.Hour),
+		},
+		ConfigMaps: []*corev1.ConfigMap{prunerConfig(map[string]string{config.PrunerKeepKey: "1"})},
+	}
+	testAssets, cancel := getPrunerController(t, d)
+	defer cancel()
+
+	if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), namespace); err != nil {
+		t.Fatalf("Reconcile() = %v", err)
+	}
+
+	metricstest.CheckCountData(t, "pruned_pipelineruns_count", map[string]string{"namespace": namespace}, 1)
+	metricstest.CheckCountData(t, "pruned_taskruns_count", map[string]string{"namespace": namespace}, 2)
+}
+
+func TestReconcileNamespaceNotFound(t *testing.T) {
+	d := test.Data{
+		ConfigMaps: []*corev1.ConfigMap{prunerConfig(map[string]string{config.PrunerKeepKey: "1"})},
+	}
+	testAssets, cancel := getPrunerController(t, d)
+	defer cancel()
+
+	if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), namespace); err != nil {
+		t.Errorf("Reconcile() = %v", err)
+	}
+}
+
+func TestUninitializedMetrics(t *testing.T) {
+	metrics := Recorder{}
+
+	if err := metrics.PrunedPipelineRun(namespace); err == nil {
+		t.Error("PrunedPipelineRun recording expected to return error but got nil")
+	}
+	if err := metrics.PrunedTaskRun(namespace); err == nil {
+		t.Error("PrunedTaskRun recording expected to return error but got nil")
+	}
+}
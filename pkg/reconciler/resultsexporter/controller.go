@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resultsexporter
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	pipelineruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipelinerun"
+	taskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/taskrun"
+	"github.com/tektoncd/pipeline/pkg/results"
+	"go.uber.org/zap"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// NewPipelineRunController instantiates a new controller.Impl from knative.dev/pkg/controller,
+// exporting the records of the completed PipelineRuns to store.
+func NewPipelineRunController(store results.Store) func(context.Context, configmap.Watcher) *controller.Impl {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		logger := logging.FromContext(ctx)
+		pipelineRunInformer := pipelineruninformer.Get(ctx)
+
+		c := &PipelineRunReconciler{
+			PipelineClientSet: pipelineclient.Get(ctx),
+			pipelineRunLister: pipelineRunInformer.Lister(),
+			store:             store,
+		}
+		impl := controller.NewImpl(c, logger, pipeline.PipelineRunResultsExporterControllerName)
+
+		logger.Info("Setting up event handlers")
+		pipelineRunInformer.Informer().AddEventHandler(eventHandler(ctx, impl, store, logger))
+		return impl
+	}
+}
+
+// NewTaskRunController instantiates a new controller.Impl from knative.dev/pkg/controller,
+// exporting the records of the completed TaskRuns to store.
+func NewTaskRunController(store results.Store) func(context.Context, configmap.Watcher) *controller.Impl {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		logger := logging.FromContext(ctx)
+		taskRunInformer := taskruninformer.Get(ctx)
+
+		c := &TaskRunReconciler{
+			PipelineClientSet: pipelineclient.Get(ctx),
+			taskRunLister:     taskRunInformer.Lister(),
+			store:             store,
+		}
+		impl := controller.NewImpl(c, logger, pipeline.TaskRunResultsExporterControllerName)
+
+		logger.Info("Setting up event handlers")
+		taskRunInformer.Informer().AddEventHandler(eventHandler(ctx, impl, store, logger))
+		return impl
+	}
+}
+
+// eventHandler enqueues the completed runs, and exports the completed runs as they are
+// deleted.
+func eventHandler(ctx context.Context, impl *controller.Impl, store results.Store, logger *zap.SugaredLogger) cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if isDone(obj) {
+				impl.Enqueue(obj)
+			}
+		},
+		UpdateFunc: controller.PassNew(func(obj interface{}) {
+			if isDone(obj) {
+				impl.Enqueue(obj)
+			}
+		}),
+		DeleteFunc: func(obj interface{}) {
+			r := recordOfDeleted(obj)
+			if r == nil {
+				return
+			}
+			if err := store.Put(ctx, r); err != nil {
+				logger.Errorf("Failed to export the record of deleted %s %s/%s: %v", r.Kind, r.Namespace, r.Name, err)
+			}
+		},
+	}
+}
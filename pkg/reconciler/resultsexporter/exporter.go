@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resultsexporter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/results"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// PipelineRunReconciler exports the records of completed PipelineRuns to the store.
+type PipelineRunReconciler struct {
+	PipelineClientSet clientset.Interface
+
+	pipelineRunLister listers.PipelineRunLister
+	store             results.Store
+}
+
+// TaskRunReconciler exports the records of completed TaskRuns to the store.
+type TaskRunReconciler struct {
+	PipelineClientSet clientset.Interface
+
+	taskRunLister listers.TaskRunLister
+	store         results.Store
+}
+
+// exportedAnnotation marks the runs whose record is exported, so that the pruner can wait
+// for it before deleting them.
+const exportedAnnotation = pipeline.GroupName + pipeline.ResultsExportedAnnotationKey
+
+var exportedPatch = []byte(fmt.Sprintf(`{"metadata":{"annotations":{%q:"true"}}}`, exportedAnnotation))
+
+func isExported(run metav1.Object) bool {
+	return run.GetAnnotations()[exportedAnnotation] == "true"
+}
+
+// Check that our Reconcilers implement controller.Reconciler
+var (
+	_ controller.Reconciler = (*PipelineRunReconciler)(nil)
+	_ controller.Reconciler = (*TaskRunReconciler)(nil)
+)
+
+// Reconcile exports the record of the PipelineRun named by key once it is done.
+func (c *PipelineRunReconciler) Reconcile(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("invalid resource key: %s", key)
+		return nil
+	}
+	pr, err := c.pipelineRunLister.PipelineRuns(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		// The record of a deleted PipelineRun was exported when it completed, or
+		// when it was deleted.
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get pipelinerun %s: %w", key, err)
+	}
+	if !pr.IsDone() {
+		return nil
+	}
+	if err := c.store.Put(ctx, results.FromPipelineRun(pr)); err != nil {
+		return err
+	}
+	if isExported(pr) {
+		return nil
+	}
+	if _, err := c.PipelineClientSet.TektonV1beta1().PipelineRuns(namespace).Patch(name, types.MergePatchType, exportedPatch); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to mark pipelinerun %s as exported: %w", key, err)
+	}
+	return nil
+}
+
+// Reconcile exports the record of the TaskRun named by key once it is done.
+func (c *TaskRunReconciler) Reconcile(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("invalid resource key: %s", key)
+		return nil
+	}
+	tr, err := c.taskRunLister.TaskRuns(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		// The record of a deleted TaskRun was exported when it completed, or
+		// when it was deleted.
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get taskrun %s: %w", key, err)
+	}
+	if !tr.IsDone() {
+		return nil
+	}
+	if err := c.store.Put(ctx, results.FromTaskRun(tr)); err != nil {
+		return err
+	}
+	if isExported(tr) {
+		return nil
+	}
+	if _, err := c.PipelineClientSet.TektonV1beta1().TaskRuns(namespace).Patch(name, types.MergePatchType, exportedPatch); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to mark taskrun %s as exported: %w", key, err)
+	}
+	return nil
+}
+
+// recordOfDeleted returns the record of a completed run deleted from the cluster, or nil
+// when obj is not a completed run. Runs deleted right after they complete, for instance
+// by the pruner, may be gone before their key is reconciled.
+func recordOfDeleted(obj interface{}) *results.Record {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	switch run := obj.(type) {
+	case *v1beta1.PipelineRun:
+		if run.IsDone() {
+			return results.FromPipelineRun(run)
+		}
+	case *v1beta1.TaskRun:
+		if run.IsDone() {
+			return results.FromTaskRun(run)
+		}
+	}
+	return nil
+}
+
+func isDone(obj interface{}) bool {
+	switch run := obj.(type) {
+	case *v1beta1.PipelineRun:
+		return run.IsDone()
+	case *v1beta1.TaskRun:
+		return run.IsDone()
+	}
+	return false
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resultsexporter
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	fakepipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client/fake"
+	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/pkg/results"
+	"github.com/tektoncd/pipeline/pkg/system"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ktesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// fakeStore keeps the records in memory, keyed by UID
+type fakeStore struct {
+	mu      sync.Mutex
+	records map[string]*results.Record
+}
+
+func (s *fakeStore) Put(_ context.Context, r *results.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.records == nil {
+		s.records = map[string]*results.Record{}
+	}
+	s.records[r.UID] = r
+	return nil
+}
+
+func (s *fakeStore) List(context.Context, results.Filter) ([]*results.Record, error) {
+	return nil, nil
+}
+
+func (s *fakeStore) Summarize(context.Context, results.Filter) (*results.Summary, error) {
+	return &results.Summary{}, nil
+}
+
+func (s *fakeStore) Close() error { return nil }
+
+// names returns the sorted names of the stored records
+func (s *fakeStore) names() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var names []string
+	for _, r := range s.records {
+		names = append(names, r.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func succeeded(status corev1.ConditionStatus) duckv1beta1.Status {
+	return duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: status}}}
+}
+
+func pipelineRun(name string, status corev1.ConditionStatus) *v1beta1.PipelineRun {
+	return &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "foo", UID: types.UID(name + "-uid")},
+		Spec:       v1beta1.PipelineRunSpec{PipelineRef: &v1beta1.PipelineRef{Name: "build"}},
+		Status:     v1beta1.PipelineRunStatus{Status: succeeded(status)},
+	}
+}
+
+func taskRun(name string, status corev1.ConditionStatus) *v1beta1.TaskRun {
+	return &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "foo", UID: types.UID(name + "-uid")},
+		Spec:       v1beta1.TaskRunSpec{TaskRef: &v1beta1.TaskRef{Name: "compile"}},
+		Status:     v1beta1.TaskRunStatus{Status: succeeded(status)},
+	}
+}
+
+func getControllers(t *testing.T, d test.Data, store results.Store) (context.Context, *controller.Impl, *controller.Impl, func()) {
+	t.Helper()
+	ctx, _ := ttesting.SetupFakeContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	c, _ := test.SeedTestData(t, ctx, d)
+	configMapWatcher := configmap.NewInformedWatcher(c.Kube, system.GetNamespace())
+	prImpl := NewPipelineRunController(store)(ctx, configMapWatcher)
+	trImpl := NewTaskRunController(store)(ctx, configMapWatcher)
+	return ctx, prImpl, trImpl, cancel
+}
+
+func TestReconcile(t *testing.T) {
+	store := &fakeStore{}
+	prExported := pipelineRun("pr-exported", corev1.ConditionTrue)
+	prExported.Annotations = map[string]string{"tekton.dev/results-exported": "true"}
+	d := test.Data{
+		PipelineRuns: []*v1beta1.PipelineRun{
+			pipelineRun("pr-succeeded", corev1.ConditionTrue),
+			pipelineRun("pr-failed", corev1.ConditionFalse),
+			pipelineRun("pr-running", corev1.ConditionUnknown),
+			prExported,
+		},
+		TaskRuns: []*v1beta1.TaskRun{
+			taskRun("tr-succeeded", corev1.ConditionTrue),
+			taskRun("tr-running", corev1.ConditionUnknown),
+		},
+	}
+	ctx, prImpl, trImpl, cancel := getControllers(t, d, store)
+	defer cancel()
+
+	for _, pr := range d.PipelineRuns {
+		if err := prImpl.Reconciler.Reconcile(ctx, pr.Namespace+"/"+pr.Name); err != nil {
+			t.Fatalf("Reconcile(%s) = %v", pr.Name, err)
+		}
+	}
+	for _, tr := range d.TaskRuns {
+		if err := trImpl.Reconciler.Reconcile(ctx, tr.Namespace+"/"+tr.Name); err != nil {
+			t.Fatalf("Reconcile(%s) = %v", tr.Name, err)
+		}
+	}
+	// Runs which no longer exist are ignored.
+	for _, impl := range []*controller.Impl{prImpl, trImpl} {
+		if err := impl.Reconciler.Reconcile(ctx, "foo/deleted"); err != nil {
+			t.Fatalf("Reconcile(foo/deleted) = %v", err)
+		}
+	}
+
+	want := []string{"pr-exported", "pr-failed", "pr-succeeded", "tr-succeeded"}
+	if d := cmp.Diff(want, store.names()); d != "" {
+		t.Errorf("Unexpected exported records %s", diff.PrintWantGot(d))
+	}
+	// The runs are marked as exported, unless they are already.
+	var marked []string
+	for _, action := range fakepipelineclient.Get(ctx).Actions() {
+		if patch, ok := action.(ktesting.PatchAction); ok {
+			if string(patch.GetPatch()) != `{"metadata":{"annotations":{"tekton.dev/results-exported":"true"}}}` {
+				t.Errorf("Unexpected patch of %s: %s", patch.GetName(), patch.GetPatch())
+			}
+			marked = append(marked, patch.GetName())
+		}
+	}
+	sort.Strings(marked)
+	if d := cmp.Diff([]string{"pr-failed", "pr-succeeded", "tr-succeeded"}, marked); d != "" {
+		t.Errorf("Unexpected runs marked as exported %s", diff.PrintWantGot(d))
+	}
+	if r := store.records["pr-failed-uid"]; r.Status != results.StatusFailed || r.Pipeline != "build" {
+		t.Errorf("Unexpected record of pr-failed: %+v", r)
+	}
+}
+
+func TestExportDeletedRuns(t *testing.T) {
+	store := &fakeStore{}
+	ctx, _ := ttesting.SetupFakeContext(t)
+	handler := eventHandler(ctx, nil, store, logging.FromContext(ctx))
+
+	handler.OnDelete(pipelineRun("pr-succeeded", corev1.ConditionTrue))
+	handler.OnDelete(pipelineRun("pr-running", corev1.ConditionUnknown))
+	handler.OnDelete(cache.DeletedFinalStateUnknown{Key: "foo/tr-failed", Obj: taskRun("tr-failed", corev1.ConditionFalse)})
+
+	want := []string{"pr-succeeded", "tr-failed"}
+	if d := cmp.Diff(want, store.names()); d != "" {
+		t.Errorf("Unexpected exported records %s", diff.PrintWantGot(d))
+	}
+}
@@ -28,6 +28,7 @@ import (
 	taskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/taskrun"
 	taskrunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1beta1/taskrun"
 	resourceinformer "github.com/tektoncd/pipeline/pkg/client/resource/injection/informers/resource/v1alpha1/pipelineresource"
+	"github.com/tektoncd/pipeline/pkg/logarchive"
 	"github.com/tektoncd/pipeline/pkg/pod"
 	cloudeventclient "github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
@@ -64,18 +65,21 @@ func NewController(namespace string, images pipeline.Images) func(context.Contex
 		}
 
 		c := &Reconciler{
-			KubeClientSet:     kubeclientset,
-			PipelineClientSet: pipelineclientset,
-			Images:            images,
-			taskRunLister:     taskRunInformer.Lister(),
-			taskLister:        taskInformer.Lister(),
-			clusterTaskLister: clusterTaskInformer.Lister(),
-			resourceLister:    resourceInformer.Lister(),
-			timeoutHandler:    timeoutHandler,
-			cloudEventClient:  cloudeventclient.Get(ctx),
-			metrics:           metrics,
-			entrypointCache:   entrypointCache,
-			pvcHandler:        volumeclaim.NewPVCHandler(kubeclientset, logger),
+			KubeClientSet:        kubeclientset,
+			PipelineClientSet:    pipelineclientset,
+			Images:               images,
+			taskRunLister:        taskRunInformer.Lister(),
+			taskLister:           taskInformer.Lister(),
+			clusterTaskLister:    clusterTaskInformer.Lister(),
+			resourceLister:       resourceInformer.Lister(),
+			timeoutHandler:       timeoutHandler,
+			cloudEventClient:     cloudeventclient.Get(ctx),
+			cloudEventDispatcher: cloudeventclient.GetDispatcher(ctx),
+			logStreamer:          logarchive.Get(ctx),
+			logArchiver:          newLogArchiver(),
+			metrics:              metrics,
+			entrypointCache:      entrypointCache,
+			pvcHandler:           volumeclaim.NewPVCHandler(kubeclientset, logger),
 		}
 		impl := taskrunreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
 			configStore := config.NewStore(logger.Named("config-store"))
@@ -88,6 +92,18 @@ func NewController(namespace string, images pipeline.Images) func(context.Contex
 		})
 
 		timeoutHandler.SetCallbackFunc(impl.EnqueueKey)
+		if c.cloudEventDispatcher != nil {
+			// The TaskRun is reconciled once its cloud events are done, to record their delivery
+			c.cloudEventDispatcher.SetCallbackFunc(pipeline.TaskRunControllerName, impl.EnqueueKey)
+			taskRunInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+				DeleteFunc: c.cloudEventDispatcher.Forget,
+			})
+		}
+		// The TaskRun is reconciled once its logs are archived, to record their location
+		c.logArchiver.SetCallbackFunc(impl.EnqueueKey)
+		taskRunInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			DeleteFunc: c.logArchiver.Forget,
+		})
 		timeoutHandler.CheckTimeouts(ctx, namespace, kubeclientset, pipelineclientset)
 
 		logger.Info("Setting up event handlers")
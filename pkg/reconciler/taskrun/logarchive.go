@@ -0,0 +1,207 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/logarchive"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/logging"
+)
+
+// logArchiver archives the logs of TaskRuns in the background, so that streaming them to
+// the sink doesn't hold up the workers of the controller. The TaskRuns are enqueued once
+// their logs are archived, for the next reconcile to record their location.
+type logArchiver struct {
+	mu sync.Mutex
+	// archives are the archives in flight or done, keyed by the UID of their TaskRun
+	archives map[types.UID]*logArchive
+	// enqueue is called with the key of a TaskRun once its logs are archived
+	enqueue func(types.NamespacedName)
+	// wg tracks the archives in flight
+	wg sync.WaitGroup
+}
+
+// logArchive is an archive of the logs of the steps of a TaskRun
+type logArchive struct {
+	done bool
+	// locations are the locations of the archived logs, keyed by step name
+	locations map[string]string
+	err       error
+}
+
+func newLogArchiver() *logArchiver {
+	return &logArchiver{archives: map[types.UID]*logArchive{}}
+}
+
+// SetCallbackFunc sets the function called with the key of a TaskRun once its logs are archived.
+func (a *logArchiver) SetCallbackFunc(f func(types.NamespacedName)) {
+	a.enqueue = f
+}
+
+// Forget drops the archive of a deleted TaskRun. It is meant to be the DeleteFunc of the
+// event handler of the TaskRun informer.
+func (a *logArchiver) Forget(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.archives, m.GetUID())
+}
+
+// Wait waits for the archives in flight.
+func (a *logArchiver) Wait() {
+	a.wg.Wait()
+}
+
+// start records an archive in flight for the TaskRun with the given UID, unless there is
+// one already, in which case it returns false.
+func (a *logArchiver) start(uid types.UID) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.archives[uid]; ok {
+		return false
+	}
+	a.archives[uid] = &logArchive{}
+	a.wg.Add(1)
+	return true
+}
+
+// finish records the result of the archive of the TaskRun with the given UID.
+func (a *logArchiver) finish(uid types.UID, locations map[string]string, err error) {
+	a.mu.Lock()
+	if archive, ok := a.archives[uid]; ok {
+		archive.done, archive.locations, archive.err = true, locations, err
+	}
+	a.mu.Unlock()
+	a.wg.Done()
+}
+
+// take returns and drops the archive of the TaskRun with the given UID once it is done.
+func (a *logArchiver) take(uid types.UID) *logArchive {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	archive, ok := a.archives[uid]
+	if !ok || !archive.done {
+		return nil
+	}
+	delete(a.archives, uid)
+	return archive
+}
+
+// archiveLogs records the location of the logs of the steps of a TaskRun in its step states,
+// and marks the TaskRun with the logs-archived annotation, once its logs are archived. Until
+// then it starts archiving them in the background, when enabled. If the archive failed, the
+// error is returned and the logs are archived again on the next reconcile.
+func (c *Reconciler) archiveLogs(ctx context.Context, tr *v1beta1.TaskRun) error {
+	if archive := c.logArchiver.take(tr.UID); archive != nil {
+		for i, step := range tr.Status.Steps {
+			if location, ok := archive.locations[step.Name]; ok {
+				tr.Status.Steps[i].LogLocation = location
+			}
+		}
+		if archive.err != nil {
+			return archive.err
+		}
+		logarchive.MarkArchived(tr)
+		return nil
+	}
+	c.startArchivingLogs(ctx, tr, nil)
+	return nil
+}
+
+// startArchivingLogs starts archiving the logs of the steps of the pod of a TaskRun to the sink
+// of the log archive config in the background, when enabled and unless they are archived or being
+// archived already. Steps whose logs are already archived, or which never started, are skipped.
+// then, if not nil, is called once the logs are archived. startArchivingLogs returns whether it
+// started archiving the logs: if it didn't, then isn't called.
+func (c *Reconciler) startArchivingLogs(ctx context.Context, tr *v1beta1.TaskRun, then func()) bool {
+	cfg := config.FromContextOrDefaults(ctx).LogArchive
+	if !cfg.Enabled() || tr.Status.PodName == "" || c.logStreamer == nil || logarchive.IsArchived(tr) {
+		return false
+	}
+	if !c.logArchiver.start(tr.UID) {
+		return false
+	}
+
+	// The archive outlives the reconcile, so it only keeps the logger of its context.
+	actx := logging.WithLogger(context.Background(), logging.FromContext(ctx))
+	tr = tr.DeepCopy()
+	go func() {
+		locations, err := c.archiveStepLogs(actx, cfg, tr)
+		if then != nil {
+			then()
+		}
+		c.logArchiver.finish(tr.UID, locations, err)
+		if c.logArchiver.enqueue != nil {
+			c.logArchiver.enqueue(tr.GetNamespacedName())
+		}
+	}()
+	return true
+}
+
+// archiveStepLogs archives the logs of the steps of the pod of a TaskRun, and returns their
+// location keyed by step name.
+func (c *Reconciler) archiveStepLogs(ctx context.Context, cfg *config.LogArchive, tr *v1beta1.TaskRun) (map[string]string, error) {
+	logger := logging.FromContext(ctx)
+	locations := map[string]string{}
+	var sink logarchive.Sink
+	for _, step := range tr.Status.Steps {
+		if step.LogLocation != "" || (step.Running == nil && step.Terminated == nil) {
+			continue
+		}
+		logs, err := c.logStreamer.Stream(tr.Namespace, tr.Status.PodName, step.ContainerName)
+		switch {
+		case k8serrors.IsNotFound(err):
+			// The pod is gone, and its logs with it.
+			logger.Infof("The logs of TaskRun %s can't be archived: pod %s not found", tr.Name, tr.Status.PodName)
+			return locations, nil
+		case k8serrors.IsBadRequest(err):
+			// The container never ran, so it has no logs.
+			continue
+		case err != nil:
+			return locations, fmt.Errorf("failed to get the logs of step %q: %w", step.Name, err)
+		}
+
+		if sink == nil {
+			if sink, err = logarchive.NewSink(ctx, cfg, c.KubeClientSet); err != nil {
+				logs.Close()
+				return locations, err
+			}
+		}
+		location, err := sink.Put(ctx, logarchive.Key(tr, step.Name), logs)
+		logs.Close()
+		if err != nil {
+			return locations, fmt.Errorf("failed to archive the logs of step %q: %w", step.Name, err)
+		}
+		locations[step.Name] = location
+	}
+	return locations, nil
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	tb "github.com/tektoncd/pipeline/internal/builder/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/logarchive"
+	"github.com/tektoncd/pipeline/pkg/system"
+	test "github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"knative.dev/pkg/apis"
+)
+
+func TestReconcileArchivesLogs(t *testing.T) {
+	for _, tc := range []struct {
+		desc       string
+		specOps    []tb.TaskRunSpecOp
+		conditions apis.Condition
+		running    bool
+	}{{
+		desc:       "completed",
+		conditions: apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue},
+	}, {
+		desc:       "cancelled",
+		specOps:    []tb.TaskRunSpecOp{tb.TaskRunCancelled},
+		conditions: apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown},
+		running:    true,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			mountPath, err := ioutil.TempDir("", "logs")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(mountPath)
+
+			taskRun := tb.TaskRun("test-taskrun-logs", tb.TaskRunNamespace("foo"),
+				tb.TaskRunSpec(append([]tb.TaskRunSpecOp{tb.TaskRunTaskRef(simpleTask.Name)}, tc.specOps...)...),
+				tb.TaskRunStatus(tb.StatusCondition(tc.conditions), tb.PodName("test-taskrun-logs-pod")))
+			lastState := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}}
+			if tc.running {
+				lastState = corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+			}
+			taskRun.Status.Steps = []v1beta1.StepState{{
+				Name:           "build",
+				ContainerName:  "step-build",
+				ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+			}, {
+				Name:           "push",
+				ContainerName:  "step-push",
+				ContainerState: lastState,
+			}, {
+				Name:           "notify",
+				ContainerName:  "step-notify",
+				ContainerState: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{}},
+			}}
+			d := test.Data{
+				TaskRuns: []*v1beta1.TaskRun{taskRun},
+				Tasks:    []*v1beta1.Task{simpleTask},
+				Pods: []*corev1.Pod{{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-taskrun-logs-pod", Namespace: "foo"},
+				}},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetLogArchiveConfigName(), Namespace: system.GetNamespace()},
+					Data: map[string]string{
+						"sink":           "pvc",
+						"pvc.claim.name": "tekton-logs",
+						"pvc.mount.path": mountPath,
+					},
+				}},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			c := testAssets.Controller
+			clients := testAssets.Clients
+
+			// The logs are archived in the background, and their location is recorded by
+			// a later reconcile.
+			var newTr *v1beta1.TaskRun
+			for deadline := time.Now().Add(wait.ForeverTestTimeout); ; time.Sleep(10 * time.Millisecond) {
+				if err := c.Reconciler.Reconcile(context.Background(), getRunName(taskRun)); err != nil {
+					t.Fatalf("Unexpected error when reconciling TaskRun: %v", err)
+				}
+				newTr, err = clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(taskRun.Name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+				}
+				if logarchive.IsArchived(newTr) {
+					break
+				}
+				if time.Now().After(deadline) {
+					t.Fatalf("Expected the logs of TaskRun %s to be archived", taskRun.Name)
+				}
+				testAssets.Informers.TaskRun.Informer().GetIndexer().Update(newTr)
+			}
+
+			var gotLocations []string
+			for _, step := range newTr.Status.Steps {
+				gotLocations = append(gotLocations, step.LogLocation)
+			}
+			wantLocations := []string{
+				"pvc://tekton-logs/foo/test-taskrun-logs/build.log",
+				"pvc://tekton-logs/foo/test-taskrun-logs/push.log",
+				"",
+			}
+			if d := cmp.Diff(wantLocations, gotLocations); d != "" {
+				t.Errorf("Unexpected log locations %s", diff.PrintWantGot(d))
+			}
+			for _, step := range []string{"build", "push"} {
+				got, err := ioutil.ReadFile(filepath.Join(mountPath, "foo", "test-taskrun-logs", step+".log"))
+				if err != nil {
+					t.Fatalf("Expected the logs of step %s to be archived: %v", step, err)
+				}
+				if want := logarchive.FakeLogs("foo", "test-taskrun-logs-pod", "step-"+step); string(got) != want {
+					t.Errorf("Expected the logs of step %s to be %q, got %q", step, want, got)
+				}
+			}
+			if tc.running {
+				// The pod of the cancelled TaskRun is deleted once its logs are archived.
+				if _, err := clients.Kube.CoreV1().Pods("foo").Get("test-taskrun-logs-pod", metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+					t.Errorf("Expected the pod of the cancelled TaskRun to be deleted, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileArchivesLogs_Disabled(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun-logs", tb.TaskRunNamespace("foo"),
+		tb.TaskRunSpec(tb.TaskRunTaskRef(simpleTask.Name)),
+		tb.TaskRunStatus(
+			tb.StatusCondition(apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}),
+			tb.PodName("test-taskrun-logs-pod")))
+	taskRun.Status.Steps = []v1beta1.StepState{{
+		Name:           "build",
+		ContainerName:  "step-build",
+		ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+	}}
+	d := test.Data{
+		TaskRuns: []*v1beta1.TaskRun{taskRun},
+		Tasks:    []*v1beta1.Task{simpleTask},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), getRunName(taskRun)); err != nil {
+		t.Fatalf("Unexpected error when reconciling completed TaskRun: %v", err)
+	}
+	newTr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected completed TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	if got := newTr.Status.Steps[0].LogLocation; got != "" {
+		t.Errorf("Expected the logs not to be archived, got location %q", got)
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/provenance"
+	"github.com/tektoncd/pipeline/pkg/system"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// signProvenance signs the provenance of the artifacts built by a successful TaskRun, when
+// enabled in the provenance config, and stores it in the annotations of the TaskRun or pushes
+// it next to the images it built. The annotations are updated by the caller. Failures which
+// retrying won't fix, such as a missing signing secret, are recorded in the annotations of the
+// TaskRun instead of being returned, so that the TaskRun isn't requeued for them.
+func (c *Reconciler) signProvenance(ctx context.Context, tr *v1beta1.TaskRun) error {
+	cfg := config.FromContextOrDefaults(ctx).Provenance
+	if !cfg.Enabled() || !tr.IsSuccessful() || provenance.IsSigned(tr) || provenance.HasFailed(tr) {
+		return nil
+	}
+	err := c.signAndStoreProvenance(ctx, tr, cfg)
+	if controller.IsPermanentError(err) {
+		logging.FromContext(ctx).Errorf("Giving up on the provenance of TaskRun %q: %v", tr.Name, err)
+		provenance.MarkFailed(tr, err)
+		return nil
+	}
+	return err
+}
+
+func (c *Reconciler) signAndStoreProvenance(ctx context.Context, tr *v1beta1.TaskRun, cfg *config.Provenance) error {
+	secret, err := c.KubeClientSet.CoreV1().Secrets(system.GetNamespace()).Get(cfg.SigningSecretName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return controller.NewPermanentError(fmt.Errorf("the provenance signing secret %q doesn't exist", cfg.SigningSecretName))
+	} else if err != nil {
+		return fmt.Errorf("error getting the provenance signing secret %q: %w", cfg.SigningSecretName, err)
+	}
+	signer, err := provenance.LoadSigner(secret)
+	if err != nil {
+		return controller.NewPermanentError(err)
+	}
+	statement := provenance.NewStatement(tr, cfg.BuilderID)
+	env, err := provenance.Sign(statement, signer)
+	if err != nil {
+		return controller.NewPermanentError(err)
+	}
+
+	// Without images to push it next to, the provenance is kept in the annotations.
+	if cfg.Storage != config.ProvenanceStorageOCI || len(statement.Subject) == 0 {
+		if err := provenance.StoreInAnnotations(tr, env); err != nil {
+			return controller.NewPermanentError(err)
+		}
+		return nil
+	}
+	kc, err := k8schain.New(c.KubeClientSet, k8schain.Options{
+		Namespace:          tr.Namespace,
+		ServiceAccountName: tr.Spec.ServiceAccountName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get keychain: %w", err)
+	}
+	refs, err := provenance.PushToOCI(env, statement.Subject, remote.WithAuthFromKeychain(kc), remote.WithContext(ctx))
+	if err != nil {
+		if isPermanentRegistryError(err) {
+			return controller.NewPermanentError(err)
+		}
+		return err
+	}
+	provenance.MarkPushed(tr, refs)
+	return nil
+}
+
+// isPermanentRegistryError returns true if the registry rejected the request with an error
+// which retrying won't fix, e.g. because the credentials don't allow pushing.
+func isPermanentRegistryError(err error) bool {
+	var terr *transport.Error
+	if !errors.As(err, &terr) {
+		return false
+	}
+	switch terr.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return false
+	}
+	return terr.StatusCode >= 400 && terr.StatusCode < 500
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	tb "github.com/tektoncd/pipeline/internal/builder/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/provenance"
+	"github.com/tektoncd/pipeline/pkg/system"
+	test "github.com/tektoncd/pipeline/test"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestReconcileOnCompletedTaskRun_Provenance(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Couldn't generate key: %v", err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Couldn't encode key: %v", err)
+	}
+	signingSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "signing-secret", Namespace: system.GetNamespace()},
+		Data: map[string][]byte{
+			provenance.SigningSecretKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}),
+		},
+	}
+
+	for _, tc := range []struct {
+		desc       string
+		status     corev1.ConditionStatus
+		noSecret   bool
+		wantSigned bool
+		wantFailed bool
+	}{{
+		desc:       "succeeded",
+		status:     corev1.ConditionTrue,
+		wantSigned: true,
+	}, {
+		desc:       "failed",
+		status:     corev1.ConditionFalse,
+		wantSigned: false,
+	}, {
+		// A missing secret isn't retried forever, the failure is recorded instead
+		desc:       "missing signing secret",
+		status:     corev1.ConditionTrue,
+		noSecret:   true,
+		wantSigned: false,
+		wantFailed: true,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			taskRun := tb.TaskRun("test-taskrun-provenance", tb.TaskRunNamespace("foo"), tb.TaskRunSpec(
+				tb.TaskRunTaskRef(simpleTask.Name),
+			), tb.TaskRunStatus(
+				tb.StatusCondition(apis.Condition{Type: apis.ConditionSucceeded, Status: tc.status}),
+				tb.TaskRunResult("IMAGE_URL", "gcr.io/foo/bar"),
+				tb.TaskRunResult("IMAGE_DIGEST", "sha256:1234"),
+			))
+			d := test.Data{
+				TaskRuns: []*v1beta1.TaskRun{taskRun},
+				Tasks:    []*v1beta1.Task{simpleTask},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetProvenanceConfigName(), Namespace: system.GetNamespace()},
+					Data: map[string]string{
+						"signing-secret-name": signingSecret.Name,
+					},
+				}},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			c := testAssets.Controller
+			clients := testAssets.Clients
+			if !tc.noSecret {
+				if _, err := clients.Kube.CoreV1().Secrets(signingSecret.Namespace).Create(signingSecret); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := c.Reconciler.Reconcile(context.Background(), getRunName(taskRun)); err != nil {
+				t.Fatalf("Unexpected error when reconciling completed TaskRun : %v", err)
+			}
+			newTr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Expected completed TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+			}
+			if got := provenance.IsSigned(newTr); got != tc.wantSigned {
+				t.Fatalf("Expected signed to be %t, got %t", tc.wantSigned, got)
+			}
+			if got := provenance.HasFailed(newTr); got != tc.wantFailed {
+				t.Fatalf("Expected failed to be %t, got %t: %v", tc.wantFailed, got, newTr.Annotations)
+			}
+			if !tc.wantSigned {
+				return
+			}
+			env := &provenance.Envelope{
+				PayloadType: provenance.PayloadType,
+				Payload:     newTr.Annotations[provenance.PayloadAnnotation],
+				Signatures: []provenance.Signature{{
+					KeyID: newTr.Annotations[provenance.KeyIDAnnotation],
+					Sig:   newTr.Annotations[provenance.SignatureAnnotation],
+				}},
+			}
+			statement, err := provenance.Verify(env, key.Public())
+			if err != nil {
+				t.Fatalf("Couldn't verify the provenance: %v", err)
+			}
+			if len(statement.Subject) != 1 || statement.Subject[0].Name != "gcr.io/foo/bar" {
+				t.Errorf("Expected gcr.io/foo/bar to be the subject of the provenance, got %v", statement.Subject)
+			}
+		})
+	}
+}
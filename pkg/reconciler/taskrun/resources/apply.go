@@ -19,12 +19,14 @@ package resources
 import (
 	"fmt"
 	"path/filepath"
+	"strconv"
 
 	"github.com/tektoncd/pipeline/pkg/workspace"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/substitution"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // ApplyParameters applies the params from a TaskRun.Input.Parameters to a TaskSpec
@@ -39,11 +41,17 @@ func ApplyParameters(spec *v1beta1.TaskSpec, tr *v1beta1.TaskRun, defaults ...v1
 	// Set all the default stringReplacements
 	for _, p := range defaults {
 		if p.Default != nil {
-			if p.Default.Type == v1beta1.ParamTypeString {
+			switch p.Default.Type {
+			case v1beta1.ParamTypeString:
 				stringReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Default.StringVal
 				// FIXME(vdemeester) Remove that with deprecating v1beta1
 				stringReplacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Default.StringVal
-			} else {
+			case v1beta1.ParamTypeObject:
+				// The keys of an object are replaced one by one, e.g. $(params.foo.key)
+				for k, v := range p.Default.ObjectVal {
+					stringReplacements[fmt.Sprintf("params.%s.%s", p.Name, k)] = v
+				}
+			default:
 				arrayReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Default.ArrayVal
 				// FIXME(vdemeester) Remove that with deprecating v1beta1
 				arrayReplacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Default.ArrayVal
@@ -52,11 +60,17 @@ func ApplyParameters(spec *v1beta1.TaskSpec, tr *v1beta1.TaskRun, defaults ...v1
 	}
 	// Set and overwrite params with the ones from the TaskRun
 	for _, p := range tr.Spec.Params {
-		if p.Value.Type == v1beta1.ParamTypeString {
+		switch p.Value.Type {
+		case v1beta1.ParamTypeString:
 			stringReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Value.StringVal
 			// FIXME(vdemeester) Remove that with deprecating v1beta1
 			stringReplacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Value.StringVal
-		} else {
+		case v1beta1.ParamTypeObject:
+			// Keys missing from the TaskRun keep the value of the default
+			for k, v := range p.Value.ObjectVal {
+				stringReplacements[fmt.Sprintf("params.%s.%s", p.Name, k)] = v
+			}
+		default:
 			arrayReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Value.ArrayVal
 			// FIXME(vdemeester) Remove that with deprecating v1beta1
 			arrayReplacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Value.ArrayVal
@@ -110,11 +124,21 @@ func ApplyContexts(spec *v1beta1.TaskSpec, rtr *ResolvedTaskResources, tr *v1bet
 
 // ApplyWorkspaces applies the substitution from paths that the workspaces in w are mounted to, the
 // volumes that wb are realized with in the task spec ts and the PersistentVolumeClaim names for the
-// workspaces.
+// workspaces, as well as whether optional workspaces are bound.
 func ApplyWorkspaces(spec *v1beta1.TaskSpec, w []v1beta1.WorkspaceDeclaration, wb []v1beta1.WorkspaceBinding) *v1beta1.TaskSpec {
 	stringReplacements := map[string]string{}
 
+	bindNames := sets.NewString()
+	for _, binding := range wb {
+		bindNames.Insert(binding.Name)
+	}
 	for _, ww := range w {
+		stringReplacements[fmt.Sprintf("workspaces.%s.bound", ww.Name)] = strconv.FormatBool(bindNames.Has(ww.Name))
+		if ww.Optional && !bindNames.Has(ww.Name) {
+			// Unbound optional workspaces are not mounted
+			stringReplacements[fmt.Sprintf("workspaces.%s.path", ww.Name)] = ""
+			continue
+		}
 		stringReplacements[fmt.Sprintf("workspaces.%s.path", ww.Name)] = ww.GetMountPath()
 	}
 	v := workspace.GetVolumes(wb)
@@ -142,6 +166,19 @@ func ApplyTaskResults(spec *v1beta1.TaskSpec) *v1beta1.TaskSpec {
 	return ApplyReplacements(spec, stringReplacements, map[string][]string{})
 }
 
+// ApplyExecutionMode sets the execution mode of the TaskRun on the steps of the TaskSpec. It must be
+// applied before the steps of the PipelineResources are added, so that they keep network access.
+func ApplyExecutionMode(spec *v1beta1.TaskSpec, tr *v1beta1.TaskRun) *v1beta1.TaskSpec {
+	spec = spec.DeepCopy()
+	if tr.Spec.ExecutionMode == "" {
+		return spec
+	}
+	for i := range spec.Steps {
+		spec.Steps[i].ExecutionMode = tr.Spec.ExecutionMode
+	}
+	return spec
+}
+
 // ApplyCredentialsPath applies a substitution of the key $(credentials.path) with the path that credentials
 // from annotated secrets are written to.
 func ApplyCredentialsPath(spec *v1beta1.TaskSpec, path string) *v1beta1.TaskSpec {
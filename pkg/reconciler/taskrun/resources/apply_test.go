@@ -572,6 +572,41 @@ func TestApplyParameters(t *testing.T) {
 	}
 }
 
+func TestApplyObjectParameters(t *testing.T) {
+	ts := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Name:  "clone",
+				Image: "alpine/git",
+				Args:  []string{"clone", "$(params.repo.url)"},
+			},
+			Script: "git checkout $(params.repo.commit)",
+		}},
+	}
+	tr := &v1beta1.TaskRun{
+		Spec: v1beta1.TaskRunSpec{
+			Params: []v1beta1.Param{{
+				Name:  "repo",
+				Value: *v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline"}),
+			}},
+		},
+	}
+	dp := []v1beta1.ParamSpec{{
+		Name:       "repo",
+		Type:       v1beta1.ParamTypeObject,
+		Properties: map[string]v1beta1.PropertySpec{"url": {}, "commit": {}},
+		Default:    v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/triggers", "commit": "main"}),
+	}}
+	want := applyMutation(ts, func(spec *v1beta1.TaskSpec) {
+		spec.Steps[0].Args[1] = "https://github.com/tektoncd/pipeline"
+		spec.Steps[0].Script = "git checkout main"
+	})
+	got := resources.ApplyParameters(ts, tr, dp...)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ApplyParameters() got diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestApplyResources(t *testing.T) {
 	tests := []struct {
 		name string
@@ -754,6 +789,41 @@ func TestApplyWorkspaces(t *testing.T) {
 	}
 }
 
+func TestApplyWorkspaces_OptionalWorkspaces(t *testing.T) {
+	names.TestingSeed()
+	ts := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Name:  "step",
+				Image: "bash",
+				Args:  []string{"$(workspaces.cache.path)", "$(workspaces.creds.path)"},
+			},
+			Script: `if [ "$(workspaces.cache.bound)" == "true" ] ; then echo cached ; fi
+if [ "$(workspaces.creds.bound)" == "false" ] ; then echo anonymous ; fi`,
+		}},
+	}
+	want := applyMutation(ts, func(spec *v1beta1.TaskSpec) {
+		spec.Steps[0].Args = []string{"/workspace/cache", ""}
+		spec.Steps[0].Script = `if [ "true" == "true" ] ; then echo cached ; fi
+if [ "false" == "false" ] ; then echo anonymous ; fi`
+	})
+	w := []v1beta1.WorkspaceDeclaration{{
+		Name:     "cache",
+		Optional: true,
+	}, {
+		Name:     "creds",
+		Optional: true,
+	}}
+	wb := []v1beta1.WorkspaceBinding{{
+		Name:     "cache",
+		EmptyDir: &corev1.EmptyDirVolumeSource{},
+	}}
+	got := resources.ApplyWorkspaces(ts, w, wb)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("TestApplyWorkspaces_OptionalWorkspaces() got diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestContext(t *testing.T) {
 	for _, tc := range []struct {
 		description string
@@ -1045,3 +1115,42 @@ func TestApplyCredentialsPath(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyExecutionMode(t *testing.T) {
+	spec := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Container: corev1.Container{Name: "build"},
+		}, {
+			Container:     corev1.Container{Name: "test"},
+			ExecutionMode: v1beta1.ExecutionModeHermetic,
+		}},
+	}
+	for _, tc := range []struct {
+		description string
+		mode        v1beta1.ExecutionMode
+		want        *v1beta1.TaskSpec
+	}{{
+		description: "default execution mode",
+		want:        spec,
+	}, {
+		description: "hermetic TaskRun",
+		mode:        v1beta1.ExecutionModeHermetic,
+		want: &v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Container:     corev1.Container{Name: "build"},
+				ExecutionMode: v1beta1.ExecutionModeHermetic,
+			}, {
+				Container:     corev1.Container{Name: "test"},
+				ExecutionMode: v1beta1.ExecutionModeHermetic,
+			}},
+		},
+	}} {
+		t.Run(tc.description, func(t *testing.T) {
+			tr := &v1beta1.TaskRun{Spec: v1beta1.TaskRunSpec{ExecutionMode: tc.mode}}
+			got := resources.ApplyExecutionMode(spec, tr)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -17,13 +17,82 @@ limitations under the License.
 package resources
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"github.com/tektoncd/pipeline/pkg/remote/oci"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 )
 
+// GetTaskFunc is a factory function that will use the given TaskRef as context to return a valid GetTask function. It
+// also requires a kubeclient, tektonclient, namespace, and service account in case it needs to find that task in
+// cluster or authorize against an external repository. It will figure out whether it needs to look in the cluster or in
+// a remote image to fetch the reference.
+func GetTaskFunc(ctx context.Context, k8s kubernetes.Interface, tekton clientset.Interface, tr *v1beta1.TaskRef, namespace, saName string) (GetTask, error) {
+	cfg := config.FromContextOrDefaults(ctx)
+	kind := v1beta1.NamespacedTaskKind
+	if tr != nil && tr.Kind != "" {
+		kind = tr.Kind
+	}
+
+	if cfg.FeatureFlags.EnableTektonOCIBundles && tr != nil && tr.Bundle != "" {
+		// Return an inline function that implements GetTask by calling Resolver.Get with the specified task type and
+		// casting it to a TaskInterface.
+		return func(name string) (v1beta1.TaskInterface, error) {
+			// If there is a bundle url at all, construct an OCI resolver to fetch the task, using the
+			// imagePullSecrets of the service account as credentials.
+			kc, err := k8schain.New(k8s, k8schain.Options{
+				Namespace:          namespace,
+				ServiceAccountName: saName,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get keychain: %w", err)
+			}
+			resolver := oci.NewResolver(tr.Bundle, kc)
+			obj, err := resolver.Get(strings.ToLower(string(kind)), name)
+			if err != nil {
+				return nil, err
+			}
+			return taskFromObject(ctx, obj)
+		}, nil
+	}
+
+	local := &LocalTaskRefResolver{
+		Namespace:    namespace,
+		Kind:         kind,
+		Tektonclient: tekton,
+	}
+	return local.GetTask, nil
+}
+
+// taskFromObject casts an object read out of a bundle into a TaskInterface, converting it to v1beta1 if needed.
+func taskFromObject(ctx context.Context, obj interface{}) (v1beta1.TaskInterface, error) {
+	switch o := obj.(type) {
+	case v1beta1.TaskInterface:
+		return o, nil
+	case *v1alpha1.Task:
+		t := &v1beta1.Task{}
+		if err := o.ConvertTo(ctx, t); err != nil {
+			return nil, fmt.Errorf("failed to convert v1alpha1 Task %s into v1beta1: %w", o.Name, err)
+		}
+		return t, nil
+	case *v1alpha1.ClusterTask:
+		t := &v1beta1.ClusterTask{}
+		if err := o.ConvertTo(ctx, t); err != nil {
+			return nil, fmt.Errorf("failed to convert v1alpha1 ClusterTask %s into v1beta1: %w", o.Name, err)
+		}
+		return t, nil
+	}
+	return nil, fmt.Errorf("failed to convert %T into a Task", obj)
+}
+
 // LocalTaskRefResolver uses the current cluster to resolve a task reference.
 type LocalTaskRefResolver struct {
 	Namespace    string
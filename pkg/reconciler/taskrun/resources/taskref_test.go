@@ -17,15 +17,25 @@
 package resources_test
 
 import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/registry"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
 
 	tb "github.com/tektoncd/pipeline/internal/builder/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	"github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
 )
 
@@ -96,3 +106,116 @@ func TestTaskRef(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTaskFunc(t *testing.T) {
+	// Set up a fake registry to push an image to.
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := config.ToContext(context.Background(), &config.Config{
+		FeatureFlags: &config.FeatureFlags{EnableTektonOCIBundles: true},
+	})
+	kubeclient := fakek8s.NewSimpleClientset(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"},
+	})
+
+	testcases := []struct {
+		name         string
+		localTasks   []runtime.Object
+		remoteTasks  []runtime.Object
+		ref          *v1beta1.TaskRef
+		expected     runtime.Object
+		expectedKind v1beta1.TaskKind
+	}{{
+		name: "remote-task",
+		localTasks: []runtime.Object{
+			tb.Task("simple", tb.TaskNamespace("default"), tb.TaskType(), tb.TaskSpec(tb.Step("something"))),
+		},
+		remoteTasks: []runtime.Object{
+			tb.Task("simple", tb.TaskType()),
+			tb.Task("dummy", tb.TaskType()),
+		},
+		ref: &v1beta1.TaskRef{
+			Name:   "simple",
+			Bundle: u.Host + "/remote-task",
+		},
+		expected: tb.Task("simple", tb.TaskType()),
+	}, {
+		name: "remote-cluster-task",
+		localTasks: []runtime.Object{
+			tb.ClusterTask("simple", tb.ClusterTaskType(), tb.ClusterTaskSpec(tb.Step("something"))),
+		},
+		remoteTasks: []runtime.Object{
+			tb.ClusterTask("simple", tb.ClusterTaskType()),
+			tb.Task("simple", tb.TaskType()),
+		},
+		ref: &v1beta1.TaskRef{
+			Name:   "simple",
+			Kind:   v1beta1.ClusterTaskKind,
+			Bundle: u.Host + "/remote-cluster-task",
+		},
+		expected: tb.ClusterTask("simple", tb.ClusterTaskType()),
+	}, {
+		name: "local-task",
+		localTasks: []runtime.Object{
+			tb.Task("simple", tb.TaskNamespace("default"), tb.TaskType()),
+		},
+		remoteTasks: []runtime.Object{
+			tb.Task("simple", tb.TaskType(), tb.TaskSpec(tb.Step("something"))),
+		},
+		ref: &v1beta1.TaskRef{
+			Name: "simple",
+		},
+		expected: tb.Task("simple", tb.TaskNamespace("default"), tb.TaskType()),
+	}}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tektonclient := fake.NewSimpleClientset(tc.localTasks...)
+
+			_, err := test.CreateImage(fmt.Sprintf("%s/%s", u.Host, tc.name), tc.remoteTasks...)
+			if err != nil {
+				t.Fatalf("failed to upload test image: %s", err.Error())
+			}
+
+			fn, err := resources.GetTaskFunc(ctx, kubeclient, tektonclient, tc.ref, "default", "default")
+			if err != nil {
+				t.Fatalf("failed to get task fn: %s", err.Error())
+			}
+
+			task, err := fn(tc.ref.Name)
+			if err != nil {
+				t.Fatalf("failed to call taskfn: %s", err.Error())
+			}
+
+			if d := cmp.Diff(task, tc.expected); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestGetTaskFuncBundleWithoutFeatureFlag(t *testing.T) {
+	tektonclient := fake.NewSimpleClientset(tb.Task("simple", tb.TaskNamespace("default")))
+	ref := &v1beta1.TaskRef{
+		Name:   "simple",
+		Bundle: "registry.example.com/bundle:latest",
+	}
+
+	fn, err := resources.GetTaskFunc(context.Background(), fakek8s.NewSimpleClientset(), tektonclient, ref, "default", "default")
+	if err != nil {
+		t.Fatalf("failed to get task fn: %s", err.Error())
+	}
+	// Without the feature flag the bundle is ignored and the Task is looked up in the cluster.
+	task, err := fn(ref.Name)
+	if err != nil {
+		t.Fatalf("failed to call taskfn: %s", err.Error())
+	}
+	if d := cmp.Diff(task, tb.Task("simple", tb.TaskNamespace("default"))); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
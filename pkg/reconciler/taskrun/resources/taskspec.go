@@ -22,6 +22,7 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/contexts"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -45,6 +46,9 @@ func GetTaskData(ctx context.Context, taskRun *v1beta1.TaskRun, getTask GetTask)
 		if err != nil {
 			return nil, nil, fmt.Errorf("error when listing tasks for taskRun %s: %w", taskRun.Name, err)
 		}
+		if err := trustedresources.VerifyTask(ctx, t); err != nil {
+			return nil, nil, err
+		}
 		taskMeta = t.TaskMetadata()
 		taskSpec = t.TaskSpec()
 		taskSpec.SetDefaults(contexts.WithUpgradeViaDefaulting(ctx))
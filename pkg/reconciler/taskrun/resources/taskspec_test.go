@@ -18,10 +18,17 @@ package resources
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"testing"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -122,3 +129,54 @@ func TestGetTaskSpec_Error(t *testing.T) {
 		t.Fatalf("Expected error when unable to find referenced Task but got none")
 	}
 }
+
+func TestGetTaskSpec_Verification(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Couldn't generate key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatalf("Couldn't encode the public key: %v", err)
+	}
+	ctx := config.ToContext(context.Background(), &config.Config{
+		TrustedResources: &config.TrustedResources{
+			VerificationMode: config.VerificationModeEnforce,
+			PublicKeys:       string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+		},
+	})
+
+	task := &v1beta1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "orchestrate",
+		},
+		Spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{Container: corev1.Container{
+				Name: "step1",
+			}}},
+		},
+	}
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mytaskrun",
+		},
+		Spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "orchestrate",
+			},
+		},
+	}
+	gt := func(n string) (v1beta1.TaskInterface, error) { return task, nil }
+	if _, _, err := GetTaskData(ctx, tr, gt); !errors.Is(err, trustedresources.ErrResourceVerificationFailed) {
+		t.Fatalf("Expected a verification error for an unsigned Task but got %v", err)
+	}
+
+	sig, err := trustedresources.SignTask(context.Background(), task, key)
+	if err != nil {
+		t.Fatalf("Unexpected error signing the Task: %v", err)
+	}
+	task.Annotations = map[string]string{trustedresources.SignatureAnnotation: sig}
+	if _, _, err := GetTaskData(ctx, tr, gt); err != nil {
+		t.Fatalf("Did not expect error getting the signed Task but got: %s", err)
+	}
+}
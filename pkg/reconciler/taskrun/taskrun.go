@@ -36,12 +36,15 @@ import (
 	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
 	resourcelisters "github.com/tektoncd/pipeline/pkg/client/resource/listers/resource/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/contexts"
+	"github.com/tektoncd/pipeline/pkg/logarchive"
 	podconvert "github.com/tektoncd/pipeline/pkg/pod"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
+	"github.com/tektoncd/pipeline/pkg/sidecarlogresults"
 	"github.com/tektoncd/pipeline/pkg/timeout"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	"github.com/tektoncd/pipeline/pkg/workspace"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -61,16 +64,19 @@ type Reconciler struct {
 	Images            pipeline.Images
 
 	// listers index properties about resources
-	taskRunLister     listers.TaskRunLister
-	taskLister        listers.TaskLister
-	clusterTaskLister listers.ClusterTaskLister
-	resourceLister    resourcelisters.PipelineResourceLister
-	cloudEventClient  cloudevent.CEClient
-	tracker           tracker.Interface
-	entrypointCache   podconvert.EntrypointCache
-	timeoutHandler    *timeout.Handler
-	metrics           *Recorder
-	pvcHandler        volumeclaim.PvcHandler
+	taskRunLister        listers.TaskRunLister
+	taskLister           listers.TaskLister
+	clusterTaskLister    listers.ClusterTaskLister
+	resourceLister       resourcelisters.PipelineResourceLister
+	cloudEventClient     cloudevent.CEClient
+	cloudEventDispatcher *cloudevent.Dispatcher
+	logStreamer          logarchive.Streamer
+	logArchiver          *logArchiver
+	tracker              tracker.Interface
+	entrypointCache      podconvert.EntrypointCache
+	timeoutHandler       *timeout.Handler
+	metrics              *Recorder
+	pvcHandler           volumeclaim.PvcHandler
 }
 
 // Check that our Reconciler implements taskrunreconciler.Interface
@@ -82,6 +88,7 @@ var _ taskrunreconciler.Interface = (*Reconciler)(nil)
 func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkgreconciler.Event {
 	logger := logging.FromContext(ctx)
 	ctx = cloudevent.ToContext(ctx, c.cloudEventClient)
+	ctx = cloudevent.WithDispatcher(ctx, c.cloudEventDispatcher)
 
 	// Read the initial condition
 	before := tr.Status.GetCondition(apis.ConditionSucceeded)
@@ -110,10 +117,22 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 		var merr *multierror.Error
 		// Try to send cloud events first
 		cloudEventErr := cloudevent.SendCloudEvents(tr, c.cloudEventClient, logger)
+		// Record the delivery of the cloud events sent to the sinks of the TaskRun
+		tr.Status.CloudEventSinks = c.cloudEventDispatcher.Deliveries(tr, tr.Status.CloudEventSinks)
+		// Archive the logs of the steps in the background, if enabled, while the pod is still around
+		archiveErr := c.archiveLogs(ctx, tr)
+		if archiveErr != nil {
+			logger.Errorf("Error archiving the logs of TaskRun %q: %v", tr.Name, archiveErr)
+		}
+		// Sign the provenance of the TaskRun, if enabled, before writing back its annotations
+		provenanceErr := c.signProvenance(ctx, tr)
+		if provenanceErr != nil {
+			logger.Errorf("Error signing the provenance of TaskRun %q: %v", tr.Name, provenanceErr)
+		}
 		// Regardless of `err`, we must write back any status update that may have
 		// been generated by `sendCloudEvents`
 		_, updateErr := c.updateLabelsAndAnnotations(tr)
-		merr = multierror.Append(cloudEventErr, updateErr)
+		merr = multierror.Append(cloudEventErr, archiveErr, provenanceErr, updateErr)
 		if cloudEventErr != nil {
 			// Let's keep timeouts and sidecars running as long as we're trying to
 			// send cloud events. So we stop here an return errors encountered this far.
@@ -199,6 +218,7 @@ func (c *Reconciler) finishReconcileUpdateEmitEvents(ctx context.Context, tr *v1
 
 	// Send k8s events and cloud events (when configured)
 	events.Emit(ctx, beforeCondition, afterCondition, tr)
+	tr.Status.CloudEventSinks = c.cloudEventDispatcher.Deliveries(tr, tr.Status.CloudEventSinks)
 
 	_, err := c.updateLabelsAndAnnotations(tr)
 	if err != nil {
@@ -210,19 +230,6 @@ func (c *Reconciler) finishReconcileUpdateEmitEvents(ctx context.Context, tr *v1
 	return multierror.Append(previousError, err).ErrorOrNil()
 }
 
-func (c *Reconciler) getTaskResolver(tr *v1beta1.TaskRun) (*resources.LocalTaskRefResolver, v1beta1.TaskKind) {
-	resolver := &resources.LocalTaskRefResolver{
-		Namespace:    tr.Namespace,
-		Tektonclient: c.PipelineClientSet,
-	}
-	kind := v1beta1.NamespacedTaskKind
-	if tr.Spec.TaskRef != nil && tr.Spec.TaskRef.Kind == v1beta1.ClusterTaskKind {
-		kind = v1beta1.ClusterTaskKind
-	}
-	resolver.Kind = kind
-	return resolver, kind
-}
-
 // `prepare` fetches resources the taskrun depends on, runs validation and conversion
 // It may report errors back to Reconcile, it updates the taskrun status in case of
 // error but it does not sync updates back to etcd. It does not emit events.
@@ -239,14 +246,24 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1beta1.TaskRun) (*v1beta1
 	// and may not have had all of the assumed default specified.
 	tr.SetDefaults(contexts.WithUpgradeViaDefaulting(ctx))
 
-	resolver, kind := c.getTaskResolver(tr)
-	taskMeta, taskSpec, err := resources.GetTaskData(ctx, tr, resolver.GetTask)
+	getTaskfunc, err := resources.GetTaskFunc(ctx, c.KubeClientSet, c.PipelineClientSet, tr.Spec.TaskRef, tr.Namespace, tr.Spec.ServiceAccountName)
 	if err != nil {
-		logger.Errorf("Failed to determine Task spec to use for taskrun %s: %v", tr.Name, err)
+		logger.Errorf("Failed to fetch task reference for taskrun %s: %v", tr.Name, err)
 		tr.Status.MarkResourceFailed(podconvert.ReasonFailedResolution, err)
 		return nil, nil, controller.NewPermanentError(err)
 	}
 
+	taskMeta, taskSpec, err := resources.GetTaskData(ctx, tr, getTaskfunc)
+	if err != nil {
+		logger.Errorf("Failed to determine Task spec to use for taskrun %s: %v", tr.Name, err)
+		if errors.Is(err, trustedresources.ErrResourceVerificationFailed) {
+			tr.Status.MarkResourceFailed(podconvert.ReasonResourceVerificationFailed, err)
+		} else {
+			tr.Status.MarkResourceFailed(podconvert.ReasonFailedResolution, err)
+		}
+		return nil, nil, controller.NewPermanentError(err)
+	}
+
 	// Store the fetched TaskSpec on the TaskRun for auditing
 	if err := storeTaskSpec(ctx, tr, taskSpec); err != nil {
 		logger.Errorf("Failed to store TaskSpec on TaskRun.Statusfor taskrun %s: %v", tr.Name, err)
@@ -280,6 +297,10 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1beta1.TaskRun) (*v1beta1
 		inputs = tr.Spec.Resources.Inputs
 		outputs = tr.Spec.Resources.Outputs
 	}
+	kind := v1beta1.NamespacedTaskKind
+	if tr.Spec.TaskRef != nil && tr.Spec.TaskRef.Kind == v1beta1.ClusterTaskKind {
+		kind = v1beta1.ClusterTaskKind
+	}
 	rtr, err := resources.ResolveTaskResources(taskSpec, taskMeta.Name, kind, inputs, outputs, c.resourceLister.PipelineResources(tr.Namespace).Get)
 	if err != nil {
 		logger.Errorf("Failed to resolve references for taskrun %s: %v", tr.Name, err)
@@ -293,6 +314,12 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1beta1.TaskRun) (*v1beta1
 		return nil, nil, controller.NewPermanentError(err)
 	}
 
+	if err := v1beta1.ValidateParamValues(rtr.TaskSpec.Params, tr.Spec.Params).ViaField("params"); err != nil {
+		logger.Errorf("TaskRun %q params are invalid: %v", tr.Name, err)
+		tr.Status.MarkResourceFailed(podconvert.ReasonInvalidParamValue, err)
+		return nil, nil, controller.NewPermanentError(err)
+	}
+
 	if err := c.updateTaskRunWithDefaultWorkspaces(ctx, tr, taskSpec); err != nil {
 		logger.Errorf("Failed to update taskrun %s with default workspace: %v", tr.Name, err)
 		tr.Status.MarkResourceFailed(podconvert.ReasonFailedResolution, err)
@@ -407,10 +434,46 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun,
 		return err
 	}
 
+	if tr.IsSuccessful() && hasResultsSidecar(pod) {
+		if err := c.updateTaskRunResultsFromSidecarLogs(ctx, tr, pod); err != nil {
+			return err
+		}
+	}
+
 	logger.Infof("Successfully reconciled taskrun %s/%s with status: %#v", tr.Name, tr.Namespace, tr.Status.GetCondition(apis.ConditionSucceeded))
 	return nil
 }
 
+// updateTaskRunResultsFromSidecarLogs reads the Task results from the logs of the results sidecar
+// of the Pod. The TaskRun fails if any result is larger than the max-result-size limit.
+func (c *Reconciler) updateTaskRunResultsFromSidecarLogs(ctx context.Context, tr *v1beta1.TaskRun, pod *corev1.Pod) error {
+	logger := logging.FromContext(ctx)
+	maxResultSize := config.FromContextOrDefaults(ctx).FeatureFlags.MaxResultSize
+	results, err := sidecarlogresults.GetResultsFromSidecarLogs(c.KubeClientSet, pod.Namespace, pod.Name, podconvert.ResultsSidecarContainerName, maxResultSize)
+	if errors.Is(err, sidecarlogresults.ErrSizeExceeded) {
+		logger.Errorf("TaskRun %q results are invalid: %v", tr.Name, err)
+		tr.Status.MarkResourceFailed(v1beta1.TaskRunReasonResultLargerThanAllowedLimit, err)
+		return nil
+	} else if err != nil {
+		// Keep the TaskRun running, so that reading the results is retried
+		podconvert.MarkStatusRunning(&tr.Status, v1beta1.TaskRunReasonRunning.String(), "Waiting for the Task results to be read from the results sidecar")
+		tr.Status.CompletionTime = nil
+		return err
+	}
+	tr.Status.TaskRunResults = append(tr.Status.TaskRunResults, results...)
+	return nil
+}
+
+// hasResultsSidecar returns true if the Pod has a sidecar writing the Task results to its logs
+func hasResultsSidecar(pod *corev1.Pod) bool {
+	for _, c := range pod.Spec.Containers {
+		if c.Name == podconvert.ResultsSidecarContainerName {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Reconciler) updateTaskRunWithDefaultWorkspaces(ctx context.Context, tr *v1beta1.TaskRun, taskSpec *v1beta1.TaskSpec) error {
 	configMap := config.FromContextOrDefaults(ctx)
 	defaults := configMap.Defaults
@@ -421,6 +484,10 @@ func (c *Reconciler) updateTaskRunWithDefaultWorkspaces(ctx context.Context, tr
 		}
 		workspaceBindings := map[string]v1beta1.WorkspaceBinding{}
 		for _, tsWorkspace := range taskSpec.Workspaces {
+			if tsWorkspace.Optional {
+				// Optional workspaces are left unbound rather than bound to the default
+				continue
+			}
 			workspaceBindings[tsWorkspace.Name] = v1beta1.WorkspaceBinding{
 				Name:                  tsWorkspace.Name,
 				SubPath:               defaultWS.SubPath,
@@ -514,10 +581,19 @@ func (c *Reconciler) failTaskRun(ctx context.Context, tr *v1beta1.TaskRun, reaso
 	// tr.Status.PodName will be empty if the pod was never successfully created. This condition
 	// can be reached, for example, by the pod never being schedulable due to limits imposed by
 	// a namespace's ResourceQuota.
-	err := c.KubeClientSet.CoreV1().Pods(tr.Namespace).Delete(tr.Status.PodName, &metav1.DeleteOptions{})
-	if err != nil && !k8serrors.IsNotFound(err) {
-		logger.Infof("Failed to terminate pod: %v", err)
-		return err
+	deletePod := func() error {
+		err := c.KubeClientSet.CoreV1().Pods(tr.Namespace).Delete(tr.Status.PodName, &metav1.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			logger.Infof("Failed to terminate pod: %v", err)
+			return err
+		}
+		return nil
+	}
+	// The logs of the steps are lost with the pod, so it is deleted once they are archived.
+	if !c.startArchivingLogs(ctx, tr, func() { deletePod() }) {
+		if err := deletePod(); err != nil {
+			return err
+		}
 	}
 
 	// Update step states for TaskRun on TaskRun object since pod has been deleted for cancel or timeout
@@ -552,7 +628,8 @@ func (c *Reconciler) failTaskRun(ctx context.Context, tr *v1beta1.TaskRun, reaso
 // TODO(dibyom): Refactor resource setup/substitution logic to its own function in the resources package
 func (c *Reconciler) createPod(ctx context.Context, tr *v1beta1.TaskRun, rtr *resources.ResolvedTaskResources) (*corev1.Pod, error) {
 	logger := logging.FromContext(ctx)
-	ts := rtr.TaskSpec.DeepCopy()
+	// The steps of the PipelineResources added below keep network access in hermetic TaskRuns
+	ts := resources.ApplyExecutionMode(rtr.TaskSpec, tr)
 	inputResources, err := resourceImplBinding(rtr.Inputs, c.Images)
 	if err != nil {
 		logger.Errorf("Failed to initialize input resources: %v", err)
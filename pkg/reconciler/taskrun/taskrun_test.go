@@ -20,6 +20,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http/httptest"
+	"net/url"
 	"regexp"
 	"strings"
 	"testing"
@@ -27,6 +29,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-containerregistry/pkg/registry"
 	tb "github.com/tektoncd/pipeline/internal/builder/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
@@ -234,7 +237,7 @@ func getRunName(tr *v1beta1.TaskRun) string {
 }
 
 func ensureConfigurationConfigMapsExist(d *test.Data) {
-	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists bool
+	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists, provenanceExists, trustedResourcesExists, prunerExists, logArchiveExists bool
 	for _, cm := range d.ConfigMaps {
 		if cm.Name == config.GetDefaultsConfigName() {
 			defaultsExists = true
@@ -248,6 +251,18 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 		if cm.Name == config.GetArtifactPVCConfigName() {
 			artifactPVCExists = true
 		}
+		if cm.Name == config.GetProvenanceConfigName() {
+			provenanceExists = true
+		}
+		if cm.Name == config.GetTrustedResourcesConfigName() {
+			trustedResourcesExists = true
+		}
+		if cm.Name == config.GetPrunerConfigName() {
+			prunerExists = true
+		}
+		if cm.Name == config.GetLogArchiveConfigName() {
+			logArchiveExists = true
+		}
 	}
 	if !defaultsExists {
 		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
@@ -273,6 +288,30 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 			Data:       map[string]string{},
 		})
 	}
+	if !provenanceExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetProvenanceConfigName(), Namespace: system.GetNamespace()},
+			Data:       map[string]string{},
+		})
+	}
+	if !trustedResourcesExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetTrustedResourcesConfigName(), Namespace: system.GetNamespace()},
+			Data:       map[string]string{},
+		})
+	}
+	if !prunerExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetPrunerConfigName(), Namespace: system.GetNamespace()},
+			Data:       map[string]string{},
+		})
+	}
+	if !logArchiveExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetLogArchiveConfigName(), Namespace: system.GetNamespace()},
+			Data:       map[string]string{},
+		})
+	}
 }
 
 // getTaskRunController returns an instance of the TaskRun controller/reconciler that has been seeded with
@@ -1738,6 +1777,85 @@ func TestReconcileInvalidTaskRuns(t *testing.T) {
 
 }
 
+func TestReconcileTaskRunWithBundle(t *testing.T) {
+	// Set up a fake registry to push an image to.
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bundleTask := tb.Task("test-task", tb.TaskType(), tb.TaskSpec(simpleStep))
+	ref, err := test.CreateImage(u.Host+"/tasks/test-task", bundleTask)
+	if err != nil {
+		t.Fatalf("failed to upload test image: %v", err)
+	}
+
+	withBundle := tb.TaskRun("taskrun-with-bundle", tb.TaskRunNamespace("foo"), tb.TaskRunSpec(
+		tb.TaskRunTaskRef("test-task", tb.TaskRefBundle(ref)),
+	))
+	withMissingTask := tb.TaskRun("taskrun-with-missing-bundle-task", tb.TaskRunNamespace("foo"), tb.TaskRunSpec(
+		tb.TaskRunTaskRef("not-in-bundle", tb.TaskRefBundle(ref)),
+	))
+
+	d := test.Data{
+		TaskRuns: []*v1beta1.TaskRun{withBundle, withMissingTask},
+		ServiceAccounts: []*corev1.ServiceAccount{{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+		}},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.GetNamespace()},
+			Data: map[string]string{
+				"enable-tekton-oci-bundles": "true",
+			},
+		}},
+	}
+
+	for _, tc := range []struct {
+		name       string
+		taskRun    *v1beta1.TaskRun
+		wantReason string
+	}{{
+		name:       "task found in bundle",
+		taskRun:    withBundle,
+		wantReason: v1beta1.TaskRunReasonRunning.String(),
+	}, {
+		name:       "task missing from bundle",
+		taskRun:    withMissingTask,
+		wantReason: podconvert.ReasonFailedResolution,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			c := testAssets.Controller
+			clients := testAssets.Clients
+
+			if err := c.Reconciler.Reconcile(context.Background(), getRunName(tc.taskRun)); err != nil && tc.wantReason != podconvert.ReasonFailedResolution {
+				t.Fatalf("Unexpected error reconciling TaskRun: %v", err)
+			}
+
+			newTr, err := clients.Pipeline.TektonV1beta1().TaskRuns(tc.taskRun.Namespace).Get(tc.taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", tc.taskRun.Name, err)
+			}
+			condition := newTr.Status.GetCondition(apis.ConditionSucceeded)
+			if condition == nil || condition.Reason != tc.wantReason {
+				t.Errorf("Expected TaskRun to have reason %q, but had %v", tc.wantReason, condition)
+			}
+			if tc.wantReason == podconvert.ReasonFailedResolution {
+				return
+			}
+			if d := cmp.Diff(&bundleTask.Spec, newTr.Status.TaskSpec); d != "" {
+				t.Errorf("TaskSpec stored on the TaskRun doesn't match the bundle %s", diff.PrintWantGot(d))
+			}
+			if newTr.Labels[taskNameLabelKey] != "test-task" {
+				t.Errorf("Expected label %s to be test-task but got %q", taskNameLabelKey, newTr.Labels[taskNameLabelKey])
+			}
+		})
+	}
+}
+
 func TestReconcilePodFetchError(t *testing.T) {
 	taskRun := tb.TaskRun("test-taskrun-run-success",
 		tb.TaskRunNamespace("foo"),
@@ -1880,6 +1998,44 @@ func TestReconcilePodUpdateStatus(t *testing.T) {
 	}
 }
 
+func TestReconcileTaskRunResourceVerificationFailed(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun-unsigned-task", tb.TaskRunNamespace("foo"), tb.TaskRunSpec(
+		tb.TaskRunTaskRef(simpleTask.Name),
+	))
+	d := test.Data{
+		TaskRuns: []*v1beta1.TaskRun{taskRun},
+		Tasks:    []*v1beta1.Task{simpleTask},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetTrustedResourcesConfigName(), Namespace: system.GetNamespace()},
+			Data: map[string]string{
+				"verification-mode": "enforce",
+				"public-keys": `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEWe7GDGWlsQZvXhAdip/DYYR6P/NA
+XTYCDs35fNb0j9xAVG64fm7xlHd/ICxdGhxA3cvRVlEW5/eFJTAWtwCdrw==
+-----END PUBLIC KEY-----
+`,
+			},
+		}},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	reconcileErr := c.Reconciler.Reconcile(context.Background(), getRunName(taskRun))
+	if !controller.IsPermanentError(reconcileErr) {
+		t.Fatalf("Expected to see a permanent error when reconciling a TaskRun of an unsigned Task, got %v instead", reconcileErr)
+	}
+	newTr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	condition := newTr.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != podconvert.ReasonResourceVerificationFailed {
+		t.Errorf("Expected TaskRun to fail with reason %q, but had %v", podconvert.ReasonResourceVerificationFailed, condition)
+	}
+}
+
 func TestReconcileOnCompletedTaskRun(t *testing.T) {
 	taskSt := &apis.Condition{
 		Type:    apis.ConditionSucceeded,
@@ -2540,6 +2696,34 @@ func TestReconcileValidDefaultWorkspace(t *testing.T) {
 	}
 }
 
+func TestUpdateTaskRunWithDefaultWorkspaces_OptionalWorkspace(t *testing.T) {
+	taskSpec := &v1beta1.TaskSpec{
+		Workspaces: []v1beta1.WorkspaceDeclaration{{
+			Name: "source",
+		}, {
+			Name:     "cache",
+			Optional: true,
+		}},
+	}
+	tr := tb.TaskRun("test-taskrun-default-workspace", tb.TaskRunNamespace("foo"))
+	ctx := config.ToContext(context.Background(), &config.Config{
+		Defaults: &config.Defaults{
+			DefaultTaskRunWorkspaceBinding: "emptyDir: {}",
+		},
+	})
+	c := &Reconciler{}
+	if err := c.updateTaskRunWithDefaultWorkspaces(ctx, tr, taskSpec); err != nil {
+		t.Fatalf("Unexpected error updating TaskRun with default workspaces: %v", err)
+	}
+	want := []v1beta1.WorkspaceBinding{{
+		Name:     "source",
+		EmptyDir: &corev1.EmptyDirVolumeSource{},
+	}}
+	if d := cmp.Diff(want, tr.Spec.Workspaces); d != "" {
+		t.Errorf("Expected the optional workspace to be left unbound %s", diff.PrintWantGot(d))
+	}
+}
+
 // TestReconcileInvalidDefaultWorkspace tests a reconcile of a TaskRun that does
 // not include a Workspace that the Task is expecting, and gets an error updating
 // the TaskRun with an invalid default workspace.
@@ -105,6 +105,18 @@ func validateParams(paramSpecs []v1beta1.ParamSpec, params []v1beta1.Param) erro
 		return fmt.Errorf("param types don't match the user-specified type: %s", wrongTypeParamNames)
 	}
 
+	// Make sure the object params provide all the keys which don't have a default value.
+	for _, param := range params {
+		for i := range paramSpecs {
+			if paramSpecs[i].Name != param.Name || paramSpecs[i].Type != v1beta1.ParamTypeObject {
+				continue
+			}
+			if missingKeys := paramSpecs[i].MissingObjectKeys(param.Value); len(missingKeys) != 0 {
+				return fmt.Errorf("missing keys for object param %q which have no default values: %s", param.Name, missingKeys)
+			}
+		}
+	}
+
 	return nil
 }
 
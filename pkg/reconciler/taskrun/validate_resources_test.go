@@ -111,6 +111,21 @@ func TestValidateResolvedTaskResources_InvalidParams(t *testing.T) {
 			Name:  "extra",
 			Value: *v1beta1.NewArrayOrString("i am an extra param"),
 		}},
+	}, {
+		name: "missing-object-keys",
+		rtr: &resources.ResolvedTaskResources{
+			TaskSpec: &v1beta1.TaskSpec{
+				Params: []v1beta1.ParamSpec{{
+					Name:       "repo",
+					Type:       v1beta1.ParamTypeObject,
+					Properties: map[string]v1beta1.PropertySpec{"url": {Type: v1beta1.ParamTypeString}, "commit": {Type: v1beta1.ParamTypeString}},
+				}},
+			},
+		},
+		params: []v1beta1.Param{{
+			Name:  "repo",
+			Value: *v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline"}),
+		}},
 	}}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
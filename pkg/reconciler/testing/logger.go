@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/tektoncd/pipeline/pkg/logarchive"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"go.uber.org/zap"
 	"knative.dev/pkg/controller"
@@ -17,6 +18,7 @@ func SetupFakeContext(t *testing.T) (context.Context, []controller.Informer) {
 		SendSuccessfully: true,
 	}
 	ctx = cloudevent.WithClient(ctx, &cloudEventClientBehaviour)
+	ctx = logarchive.ToContext(ctx, logarchive.FakeStreamer{})
 	return WithLogger(ctx, t), informer
 }
 
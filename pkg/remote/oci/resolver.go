@@ -19,16 +19,35 @@ package oci
 import (
 	"fmt"
 	"io/ioutil"
+	"strings"
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	imgname "github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	ociremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
 	"github.com/tektoncd/pipeline/pkg/remote"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+const (
+	// KindAnnotation is the layer annotation holding the lowercased kind of the Tekton object.
+	KindAnnotation = "cdf.tekton.image.kind"
+	// APIVersionAnnotation is the layer annotation holding the API version of the Tekton object.
+	APIVersionAnnotation = "cdf.tekton.image.apiVersion"
+	// TitleAnnotation is the layer annotation holding the name of the Tekton object.
+	TitleAnnotation = "org.opencontainers.image.title"
+
+	cacheSize = 1024
+)
+
+// objectCache holds the objects already read out of bundles, keyed by repository,
+// image digest, kind and name. Since an image digest identifies immutable content,
+// entries never need to be invalidated. The cache is shared by all the callers,
+// which must still be allowed to pull the image before being served its objects.
+var objectCache, _ = lru.New(cacheSize)
+
 var _ remote.Resolver = (*Resolver)(nil)
 
 // Resolver implements the Resolver interface using OCI images.
@@ -37,6 +56,11 @@ type Resolver struct {
 	keychain       authn.Keychain
 }
 
+// NewResolver is a convenience function to return a new OCI resolver instance as a remote.Resolver.
+func NewResolver(ref string, keychain authn.Keychain) remote.Resolver {
+	return &Resolver{imageReference: ref, keychain: keychain}
+}
+
 func (o *Resolver) List() ([]remote.ResolvedObject, error) {
 	img, err := o.retrieveImage()
 	if err != nil {
@@ -50,9 +74,9 @@ func (o *Resolver) List() ([]remote.ResolvedObject, error) {
 	contents := make([]remote.ResolvedObject, 0, len(manifest.Layers))
 	for _, l := range manifest.Layers {
 		contents = append(contents, remote.ResolvedObject{
-			Kind:       l.Annotations["cdf.tekton.image.kind"],
-			APIVersion: l.Annotations["cdf.tekton.image.apiVersion"],
-			Name:       l.Annotations["org.opencontainers.image.title"],
+			Kind:       l.Annotations[KindAnnotation],
+			APIVersion: l.Annotations[APIVersionAnnotation],
+			Name:       l.Annotations[TitleAnnotation],
 		})
 	}
 
@@ -60,7 +84,23 @@ func (o *Resolver) List() ([]remote.ResolvedObject, error) {
 }
 
 func (o *Resolver) Get(kind, name string) (runtime.Object, error) {
-	img, err := o.retrieveImage()
+	imgRef, err := imgname.ParseReference(o.imageReference)
+	if err != nil {
+		return nil, fmt.Errorf("%s is an unparseable image reference: %w", o.imageReference, err)
+	}
+
+	// If the bundle is specified by digest, we may have already read the object out of it.
+	if digest, ok := imgRef.(imgname.Digest); ok {
+		if obj, ok := objectCache.Get(cacheKey(digest, kind, name)); ok {
+			// Only serve the object to callers allowed to pull the image
+			if _, err := ociremote.Get(imgRef, ociremote.WithAuthFromKeychain(o.keychain)); err != nil {
+				return nil, err
+			}
+			return obj.(runtime.Object).DeepCopyObject(), nil
+		}
+	}
+
+	img, err := ociremote.Image(imgRef, ociremote.WithAuthFromKeychain(o.keychain))
 	if err != nil {
 		return nil, err
 	}
@@ -76,16 +116,36 @@ func (o *Resolver) Get(kind, name string) (runtime.Object, error) {
 	}
 
 	for idx, l := range manifest.Layers {
-		lKind := l.Annotations["cdf.tekton.image.kind"]
-		lName := l.Annotations["org.opencontainers.image.title"]
+		lKind := l.Annotations[KindAnnotation]
+		lName := l.Annotations[TitleAnnotation]
+
+		if lKind == "" {
+			return nil, fmt.Errorf("The layer %d of %s does not contain a %s annotation", idx, o.imageReference, KindAnnotation)
+		}
+		if lName == "" {
+			return nil, fmt.Errorf("The layer %d of %s does not contain a %s annotation", idx, o.imageReference, TitleAnnotation)
+		}
 
 		if kind == lKind && name == lName {
-			return readLayer(layers[idx])
+			obj, err := readLayer(layers[idx])
+			if err != nil {
+				return nil, err
+			}
+			if digest, err := img.Digest(); err == nil {
+				objectCache.Add(cacheKey(imgRef.Context().Digest(digest.String()), kind, name), obj.DeepCopyObject())
+			}
+			return obj, nil
 		}
 	}
 	return nil, fmt.Errorf("Could not find object in image with kind: %s and name: %s", kind, name)
 }
 
+// cacheKey returns the key used to store an object read out of the image with the given
+// digest, which identifies both its repository and its content: repo@sha256:...
+func cacheKey(digest imgname.Digest, kind, name string) string {
+	return strings.Join([]string{digest.Context().Name() + "@" + digest.DigestStr(), kind, name}, "/")
+}
+
 // retrieveImage will fetch the image's contents and manifest.
 func (o *Resolver) retrieveImage() (v1.Image, error) {
 	imgRef, err := imgname.ParseReference(o.imageReference)
@@ -18,6 +18,7 @@ package oci
 
 import (
 	"fmt"
+	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"reflect"
@@ -26,7 +27,12 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-containerregistry/pkg/authn"
+	imgname "github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ociremote "github.com/google/go-containerregistry/pkg/v1/remote"
 	tb "github.com/tektoncd/pipeline/internal/builder/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/remote"
 	"github.com/tektoncd/pipeline/test"
@@ -114,6 +120,104 @@ func TestOCIResolver(t *testing.T) {
 	}
 }
 
+func TestOCIResolverMissingAnnotations(t *testing.T) {
+	// Set up a fake registry to push an image to.
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		wantErr     string
+	}{{
+		name:        "missing-kind",
+		annotations: map[string]string{TitleAnnotation: "simple-task"},
+		wantErr:     fmt.Sprintf("does not contain a %s annotation", KindAnnotation),
+	}, {
+		name:        "missing-title",
+		annotations: map[string]string{KindAnnotation: "task"},
+		wantErr:     fmt.Sprintf("does not contain a %s annotation", TitleAnnotation),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			layer, err := random.Layer(64, "application/vnd.oci.image.layer.v1.tar")
+			if err != nil {
+				t.Fatal(err)
+			}
+			img, err := mutate.Append(empty.Image, mutate.Addendum{Layer: layer, Annotations: tc.annotations})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ref, err := imgname.ParseReference(fmt.Sprintf("%s/testociresolve/%s", u.Host, tc.name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := ociremote.Write(ref, img); err != nil {
+				t.Fatalf("could not push image: %#v", err)
+			}
+
+			resolver := NewResolver(ref.String(), authn.DefaultKeychain)
+			if _, err := resolver.Get("task", "simple-task"); err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("expected error containing %q but got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+// privateKeychain resolves the credentials of the private registry set up by TestOCIResolverCacheAccess.
+type privateKeychain struct{}
+
+func (privateKeychain) Resolve(authn.Resource) (authn.Authenticator, error) {
+	return &authn.Basic{Username: "user", Password: "secret"}, nil
+}
+
+func TestOCIResolverCacheAccess(t *testing.T) {
+	// Set up a fake registry which requires credentials once the image is pushed.
+	var private bool
+	reg := registry.New()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); private && (!ok || user != "user" || password != "secret") {
+			w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		reg.ServeHTTP(w, r)
+	}))
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	task := tb.Task("private-task", tb.TaskType())
+	ref, err := test.CreateImage(fmt.Sprintf("%s/testociresolve/private", u.Host), task)
+	if err != nil {
+		t.Fatalf("could not push image: %#v", err)
+	}
+	private = true
+
+	// The object is read with the credentials of the registry, and cached
+	if _, err := NewResolver(ref, privateKeychain{}).Get("task", "private-task"); err != nil {
+		t.Fatalf("could not retrieve object from image: %#v", err)
+	}
+	// The cached object isn't served without the credentials of the registry
+	if _, err := NewResolver(ref, authn.DefaultKeychain).Get("task", "private-task"); err == nil {
+		t.Error("expected an error retrieving a cached object without the credentials of the registry")
+	}
+	// The cached object isn't served for another repository with the same digest
+	digest, err := imgname.NewDigest(ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherRef := fmt.Sprintf("%s/testociresolve/other@%s", u.Host, digest.DigestStr())
+	if _, err := NewResolver(otherRef, privateKeychain{}).Get("task", "private-task"); err == nil {
+		t.Error("expected an error retrieving a cached object from another repository")
+	}
+}
+
 func getObjectName(obj runtime.Object) string {
 	return reflect.Indirect(reflect.ValueOf(obj)).FieldByName("ObjectMeta").FieldByName("Name").String()
 }
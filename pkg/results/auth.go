@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+var (
+	// errUnauthenticated is returned when the client of the API can't be authenticated
+	errUnauthenticated = errors.New("unauthenticated")
+	// errForbidden is returned when the client of the API may not read the requested records
+	errForbidden = errors.New("forbidden")
+)
+
+// Authorizer authorizes the clients of the API to read records.
+type Authorizer interface {
+	// Authorize returns errUnauthenticated or errForbidden, or another error if the
+	// request can't be authorized, unless its client may list the runs of the kind in the
+	// namespace. An empty kind means both kinds, and an empty namespace all namespaces.
+	Authorize(r *http.Request, kind, namespace string) error
+}
+
+// kubeAuthorizer authorizes the requests carrying the bearer token of a Kubernetes user
+// or ServiceAccount which may list the runs through the Kubernetes API.
+type kubeAuthorizer struct {
+	client kubernetes.Interface
+}
+
+// NewKubeAuthorizer returns an Authorizer reviewing the bearer token of the requests with a
+// TokenReview, and their access to the records of a namespace with SubjectAccessReviews: a
+// client may read the records of the runs it may list.
+func NewKubeAuthorizer(client kubernetes.Interface) Authorizer {
+	return &kubeAuthorizer{client: client}
+}
+
+func (a *kubeAuthorizer) Authorize(r *http.Request, kind, namespace string) error {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" || token == r.Header.Get("Authorization") {
+		return errUnauthenticated
+	}
+	review, err := a.client.AuthenticationV1().TokenReviews().Create(&authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to review token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return errUnauthenticated
+	}
+
+	resources := []string{"pipelineruns", "taskruns"}
+	switch kind {
+	case PipelineRunKind:
+		resources = resources[:1]
+	case TaskRunKind:
+		resources = resources[1:]
+	}
+	user := review.Status.User
+	extra := map[string]authorizationv1.ExtraValue{}
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	for _, resource := range resources {
+		sar, err := a.client.AuthorizationV1().SubjectAccessReviews().Create(&authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: namespace,
+					Verb:      "list",
+					Group:     pipeline.GroupName,
+					Resource:  resource,
+				},
+				User:   user.Username,
+				Groups: user.Groups,
+				UID:    user.UID,
+				Extra:  extra,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to review access to %s: %w", resource, err)
+		}
+		if !sar.Status.Allowed {
+			return errForbidden
+		}
+	}
+	return nil
+}
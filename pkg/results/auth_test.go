@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap/zaptest"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+)
+
+// newFakeAuthorizer returns a kubeAuthorizer authenticating the token "alice-token" as the user
+// alice, who may only list the runs of the namespace "alice".
+func newFakeAuthorizer() Authorizer {
+	client := fakekubeclientset.NewSimpleClientset()
+	client.PrependReactor("create", "tokenreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+		review := action.(ktesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if review.Spec.Token == "alice-token" {
+			review.Status.Authenticated = true
+			review.Status.User = authenticationv1.UserInfo{Username: "alice"}
+		}
+		return true, review, nil
+	})
+	client.PrependReactor("create", "subjectaccessreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+		sar := action.(ktesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		attrs := sar.Spec.ResourceAttributes
+		sar.Status.Allowed = sar.Spec.User == "alice" && attrs.Namespace == "alice" && attrs.Verb == "list" && attrs.Group == "tekton.dev"
+		return true, sar, nil
+	})
+	return NewKubeAuthorizer(client)
+}
+
+func TestHandler_Authorization(t *testing.T) {
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+	srv := httptest.NewServer(NewHandler(store, newFakeAuthorizer(), zaptest.NewLogger(t).Sugar()))
+	defer srv.Close()
+
+	for _, tc := range []struct {
+		name  string
+		token string
+		query string
+		want  int
+	}{{
+		name:  "no token",
+		query: "?namespace=alice",
+		want:  http.StatusUnauthorized,
+	}, {
+		name:  "invalid token",
+		token: "mallory-token",
+		query: "?namespace=alice",
+		want:  http.StatusUnauthorized,
+	}, {
+		name:  "allowed namespace",
+		token: "alice-token",
+		query: "?namespace=alice",
+		want:  http.StatusOK,
+	}, {
+		name:  "allowed namespace and kind",
+		token: "alice-token",
+		query: "?namespace=alice&kind=TaskRun",
+		want:  http.StatusOK,
+	}, {
+		name:  "other namespace",
+		token: "alice-token",
+		query: "?namespace=bob",
+		want:  http.StatusForbidden,
+	}, {
+		name:  "all namespaces",
+		token: "alice-token",
+		want:  http.StatusForbidden,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, srv.URL+RecordsPath+tc.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tc.want {
+				t.Errorf("Expected status %d, got %d", tc.want, resp.StatusCode)
+			}
+		})
+	}
+}
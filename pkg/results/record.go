@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+const (
+	// PipelineRunKind is the kind of the records of PipelineRuns
+	PipelineRunKind = "PipelineRun"
+	// TaskRunKind is the kind of the records of TaskRuns
+	TaskRunKind = "TaskRun"
+
+	// StatusSucceeded is the status of the records of successful runs
+	StatusSucceeded = "Succeeded"
+	// StatusFailed is the status of the records of failed, cancelled or timed out runs
+	StatusFailed = "Failed"
+	// StatusUnknown is the status of the records of runs without a Succeeded condition
+	StatusUnknown = "Unknown"
+)
+
+// Record is the normalized outcome of a completed PipelineRun or TaskRun, which is
+// retained after the run itself is deleted from the cluster.
+type Record struct {
+	// Kind is either PipelineRun or TaskRun
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	UID       string `json:"uid"`
+
+	// Pipeline is the name of the Pipeline run by a PipelineRun, or of the Pipeline
+	// which a TaskRun is part of.
+	Pipeline string `json:"pipeline,omitempty"`
+	// PipelineRun is the name of the PipelineRun which a TaskRun is part of
+	PipelineRun string `json:"pipelineRun,omitempty"`
+	// PipelineTask is the name of the PipelineTask which a TaskRun runs
+	PipelineTask string `json:"pipelineTask,omitempty"`
+	// Task is the name of the Task or ClusterTask run by a TaskRun
+	Task string `json:"task,omitempty"`
+
+	// Status is one of Succeeded, Failed or Unknown
+	Status string `json:"status"`
+	// Reason is the reason of the Succeeded condition of the run
+	Reason string `json:"reason,omitempty"`
+
+	StartTime      *time.Time `json:"startTime,omitempty"`
+	CompletionTime *time.Time `json:"completionTime,omitempty"`
+	// DurationSeconds is the time between the start and the completion of the run
+	DurationSeconds float64 `json:"durationSeconds,omitempty"`
+
+	Params     []v1beta1.Param        `json:"params,omitempty"`
+	Results    []Result               `json:"results,omitempty"`
+	Conditions duckv1beta1.Conditions `json:"conditions,omitempty"`
+	Steps      []Step                 `json:"steps,omitempty"`
+}
+
+// Result is a result emitted by a PipelineRun or TaskRun
+type Result struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Step is the final state of a step of a TaskRun
+type Step struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+	// ExitCode is the exit code of the step container, when it terminated
+	ExitCode       *int32     `json:"exitCode,omitempty"`
+	StartTime      *time.Time `json:"startTime,omitempty"`
+	CompletionTime *time.Time `json:"completionTime,omitempty"`
+	LogLocation    string     `json:"logLocation,omitempty"`
+}
+
+// FromPipelineRun returns the record of a PipelineRun
+func FromPipelineRun(pr *v1beta1.PipelineRun) *Record {
+	r := &Record{
+		Kind:       PipelineRunKind,
+		Namespace:  pr.Namespace,
+		Name:       pr.Name,
+		UID:        string(pr.UID),
+		Pipeline:   pr.Labels[pipeline.GroupName+pipeline.PipelineLabelKey],
+		Params:     pr.Spec.Params,
+		Conditions: pr.Status.Conditions,
+	}
+	if r.Pipeline == "" && pr.Spec.PipelineRef != nil {
+		r.Pipeline = pr.Spec.PipelineRef.Name
+	}
+	r.Status, r.Reason = status(pr.Status.GetCondition(apis.ConditionSucceeded))
+	r.setTimes(pr.Status.StartTime, pr.Status.CompletionTime)
+	for _, result := range pr.Status.PipelineResults {
+		r.Results = append(r.Results, Result{Name: result.Name, Value: result.Value})
+	}
+	return r
+}
+
+// FromTaskRun returns the record of a TaskRun
+func FromTaskRun(tr *v1beta1.TaskRun) *Record {
+	r := &Record{
+		Kind:         TaskRunKind,
+		Namespace:    tr.Namespace,
+		Name:         tr.Name,
+		UID:          string(tr.UID),
+		Pipeline:     tr.Labels[pipeline.GroupName+pipeline.PipelineLabelKey],
+		PipelineRun:  tr.Labels[pipeline.GroupName+pipeline.PipelineRunLabelKey],
+		PipelineTask: tr.Labels[pipeline.GroupName+pipeline.PipelineTaskLabelKey],
+		Task:         tr.Labels[pipeline.GroupName+pipeline.TaskLabelKey],
+		Params:       tr.Spec.Params,
+		Conditions:   tr.Status.Conditions,
+	}
+	if r.Task == "" {
+		r.Task = tr.Labels[pipeline.GroupName+pipeline.ClusterTaskLabelKey]
+	}
+	if r.Task == "" && tr.Spec.TaskRef != nil {
+		r.Task = tr.Spec.TaskRef.Name
+	}
+	r.Status, r.Reason = status(tr.Status.GetCondition(apis.ConditionSucceeded))
+	r.setTimes(tr.Status.StartTime, tr.Status.CompletionTime)
+	for _, result := range tr.Status.TaskRunResults {
+		r.Results = append(r.Results, Result{Name: result.Name, Value: result.Value})
+	}
+	for _, s := range tr.Status.Steps {
+		r.Steps = append(r.Steps, fromStepState(s))
+	}
+	return r
+}
+
+func (r *Record) setTimes(start, completion *metav1.Time) {
+	r.StartTime = timeOf(start)
+	r.CompletionTime = timeOf(completion)
+	if r.StartTime != nil && r.CompletionTime != nil {
+		r.DurationSeconds = r.CompletionTime.Sub(*r.StartTime).Seconds()
+	}
+}
+
+func fromStepState(s v1beta1.StepState) Step {
+	step := Step{Name: s.Name, Status: StatusUnknown, LogLocation: s.LogLocation}
+	if t := s.Terminated; t != nil {
+		exitCode := t.ExitCode
+		step.ExitCode = &exitCode
+		step.Reason = t.Reason
+		step.StartTime = timeOf(&t.StartedAt)
+		step.CompletionTime = timeOf(&t.FinishedAt)
+		step.Status = StatusFailed
+		if t.ExitCode == 0 {
+			step.Status = StatusSucceeded
+		}
+	} else if s.Running != nil {
+		step.StartTime = timeOf(&s.Running.StartedAt)
+	} else if s.Waiting != nil {
+		step.Reason = s.Waiting.Reason
+	}
+	return step
+}
+
+// status returns the status and the reason of the record of a run with the
+// Succeeded condition c.
+func status(c *apis.Condition) (string, string) {
+	if c == nil {
+		return StatusUnknown, ""
+	}
+	switch c.Status {
+	case corev1.ConditionTrue:
+		return StatusSucceeded, c.Reason
+	case corev1.ConditionFalse:
+		return StatusFailed, c.Reason
+	default:
+		return StatusUnknown, c.Reason
+	}
+}
+
+func timeOf(t *metav1.Time) *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	u := t.Time.UTC()
+	return &u
+}
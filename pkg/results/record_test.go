@@ -0,0 +1,183 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+var (
+	started   = time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	completed = started.Add(90 * time.Second)
+)
+
+func TestFromPipelineRun(t *testing.T) {
+	conditions := duckv1beta1.Conditions{{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionFalse,
+		Reason: "Failed",
+	}}
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "build-1", Namespace: "foo", UID: "1234"},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "build"},
+			Params:      []v1beta1.Param{{Name: "revision", Value: *v1beta1.NewArrayOrString("main")}},
+		},
+		Status: v1beta1.PipelineRunStatus{
+			Status: duckv1beta1.Status{Conditions: conditions},
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				StartTime:       &metav1.Time{Time: started},
+				CompletionTime:  &metav1.Time{Time: completed},
+				PipelineResults: []v1beta1.PipelineRunResult{{Name: "digest", Value: "sha256:abc"}},
+			},
+		},
+	}
+	want := &Record{
+		Kind:            PipelineRunKind,
+		Namespace:       "foo",
+		Name:            "build-1",
+		UID:             "1234",
+		Pipeline:        "build",
+		Status:          StatusFailed,
+		Reason:          "Failed",
+		StartTime:       &started,
+		CompletionTime:  &completed,
+		DurationSeconds: 90,
+		Params:          []v1beta1.Param{{Name: "revision", Value: *v1beta1.NewArrayOrString("main")}},
+		Results:         []Result{{Name: "digest", Value: "sha256:abc"}},
+		Conditions:      conditions,
+	}
+	if d := cmp.Diff(want, FromPipelineRun(pr)); d != "" {
+		t.Errorf("FromPipelineRun() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestFromTaskRun(t *testing.T) {
+	exitCode, failedExitCode := int32(0), int32(1)
+	conditions := duckv1beta1.Conditions{{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionTrue,
+		Reason: "Succeeded",
+	}}
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "build-1-compile", Namespace: "foo", UID: "5678", Labels: map[string]string{
+			"tekton.dev/pipeline":     "build",
+			"tekton.dev/pipelineRun":  "build-1",
+			"tekton.dev/pipelineTask": "compile",
+			"tekton.dev/clusterTask":  "golang-build",
+		}},
+		Spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "golang-build", Kind: v1beta1.ClusterTaskKind},
+		},
+		Status: v1beta1.TaskRunStatus{
+			Status: duckv1beta1.Status{Conditions: conditions},
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				StartTime:      &metav1.Time{Time: started},
+				CompletionTime: &metav1.Time{Time: completed},
+				TaskRunResults: []v1beta1.TaskRunResult{{Name: "binary", Value: "/workspace/bin/app"}},
+				Steps: []v1beta1.StepState{{
+					Name: "compile",
+					ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						ExitCode:   0,
+						Reason:     "Completed",
+						StartedAt:  metav1.Time{Time: started},
+						FinishedAt: metav1.Time{Time: completed},
+					}},
+					LogLocation: "pvc://tekton-logs/foo/build-1-compile/5678/compile.log",
+				}, {
+					Name: "lint",
+					ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						ExitCode:   1,
+						Reason:     "Error",
+						StartedAt:  metav1.Time{Time: started},
+						FinishedAt: metav1.Time{Time: completed},
+					}},
+				}, {
+					Name:           "upload",
+					ContainerState: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"}},
+				}},
+			},
+		},
+	}
+	want := &Record{
+		Kind:            TaskRunKind,
+		Namespace:       "foo",
+		Name:            "build-1-compile",
+		UID:             "5678",
+		Pipeline:        "build",
+		PipelineRun:     "build-1",
+		PipelineTask:    "compile",
+		Task:            "golang-build",
+		Status:          StatusSucceeded,
+		Reason:          "Succeeded",
+		StartTime:       &started,
+		CompletionTime:  &completed,
+		DurationSeconds: 90,
+		Results:         []Result{{Name: "binary", Value: "/workspace/bin/app"}},
+		Conditions:      conditions,
+		Steps: []Step{{
+			Name:           "compile",
+			Status:         StatusSucceeded,
+			Reason:         "Completed",
+			ExitCode:       &exitCode,
+			StartTime:      &started,
+			CompletionTime: &completed,
+			LogLocation:    "pvc://tekton-logs/foo/build-1-compile/5678/compile.log",
+		}, {
+			Name:           "lint",
+			Status:         StatusFailed,
+			Reason:         "Error",
+			ExitCode:       &failedExitCode,
+			StartTime:      &started,
+			CompletionTime: &completed,
+		}, {
+			Name:   "upload",
+			Status: StatusUnknown,
+			Reason: "PodInitializing",
+		}},
+	}
+	if d := cmp.Diff(want, FromTaskRun(tr)); d != "" {
+		t.Errorf("FromTaskRun() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestFromTaskRun_NotStarted(t *testing.T) {
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "foo", UID: "5678"},
+		Spec:       v1beta1.TaskRunSpec{TaskRef: &v1beta1.TaskRef{Name: "golang-build"}},
+	}
+	want := &Record{
+		Kind:      TaskRunKind,
+		Namespace: "foo",
+		Name:      "build",
+		UID:       "5678",
+		Task:      "golang-build",
+		Status:    StatusUnknown,
+	}
+	if d := cmp.Diff(want, FromTaskRun(tr)); d != "" {
+		t.Errorf("FromTaskRun() %s", diff.PrintWantGot(d))
+	}
+}
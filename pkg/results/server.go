@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// RecordsPath is the path of the API listing the records
+	RecordsPath = "/v1/records"
+	// SummaryPath is the path of the API summarizing the records
+	SummaryPath = "/v1/summary"
+
+	// defaultLimit is the number of records listed when no limit is requested
+	defaultLimit = 100
+)
+
+// RecordList is the response of the API listing the records
+type RecordList struct {
+	Records []*Record `json:"records"`
+}
+
+// NewHandler returns the read API of the store. Both of its paths accept the query
+// parameters kind, namespace, name, pipeline, task and status to select the records,
+// and since and until to bound their completion time. since and until are either
+// RFC 3339 times, or durations relative to now such as 720h. The records path also
+// accepts a limit. The authorizer authorizes each request to read the records of its
+// kind and namespace.
+func NewHandler(store Store, authorizer Authorizer, logger *zap.SugaredLogger) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(RecordsPath, func(w http.ResponseWriter, r *http.Request) {
+		f, ok := parseFilter(w, r, authorizer, logger)
+		if !ok {
+			return
+		}
+		records, err := store.List(r.Context(), f)
+		if err != nil {
+			logger.Errorf("Failed to list records: %v", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, logger, RecordList{Records: records})
+	})
+	mux.HandleFunc(SummaryPath, func(w http.ResponseWriter, r *http.Request) {
+		f, ok := parseFilter(w, r, authorizer, logger)
+		if !ok {
+			return
+		}
+		summary, err := store.Summarize(r.Context(), f)
+		if err != nil {
+			logger.Errorf("Failed to summarize records: %v", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, logger, summary)
+	})
+	return mux
+}
+
+// parseFilter returns the filter of a request to the API, and writes an error response
+// when the request is invalid or not authorized.
+func parseFilter(w http.ResponseWriter, r *http.Request, authorizer Authorizer, logger *zap.SugaredLogger) (Filter, bool) {
+	if r.Method != http.MethodGet {
+		http.Error(w, fmt.Sprintf("method %s is not allowed", r.Method), http.StatusMethodNotAllowed)
+		return Filter{}, false
+	}
+	f, err := filterFromQuery(r.URL.Query(), time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return Filter{}, false
+	}
+	if err := authorizer.Authorize(r, f.Kind, f.Namespace); err != nil {
+		switch {
+		case errors.Is(err, errUnauthenticated):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		case errors.Is(err, errForbidden):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		default:
+			logger.Errorf("Failed to authorize request: %v", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return Filter{}, false
+	}
+	return f, true
+}
+
+func filterFromQuery(q url.Values, now time.Time) (Filter, error) {
+	f := Filter{
+		Kind:      q.Get("kind"),
+		Namespace: q.Get("namespace"),
+		Name:      q.Get("name"),
+		Pipeline:  q.Get("pipeline"),
+		Task:      q.Get("task"),
+		Status:    q.Get("status"),
+		Limit:     defaultLimit,
+	}
+	var err error
+	if f.Since, err = parseTime(q.Get("since"), now); err != nil {
+		return Filter{}, fmt.Errorf("invalid since: %w", err)
+	}
+	if f.Until, err = parseTime(q.Get("until"), now); err != nil {
+		return Filter{}, fmt.Errorf("invalid until: %w", err)
+	}
+	if limit := q.Get("limit"); limit != "" {
+		if f.Limit, err = strconv.Atoi(limit); err != nil || f.Limit <= 0 {
+			return Filter{}, fmt.Errorf("invalid limit %q: must be a positive integer", limit)
+		}
+	}
+	return f, nil
+}
+
+// parseTime parses an RFC 3339 time, or a duration before now.
+func parseTime(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither an RFC 3339 time nor a duration", s)
+	}
+	return t, nil
+}
+
+func writeJSON(w http.ResponseWriter, logger *zap.SugaredLogger, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Errorf("Failed to write response: %v", err)
+	}
+}
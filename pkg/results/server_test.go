@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestFilterFromQuery(t *testing.T) {
+	now := time.Date(2020, 10, 31, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		query   string
+		want    Filter
+		wantErr bool
+	}{{
+		query: "",
+		want:  Filter{Limit: defaultLimit},
+	}, {
+		query: "kind=PipelineRun&namespace=foo&pipeline=build&since=720h&limit=10",
+		want:  Filter{Kind: PipelineRunKind, Namespace: "foo", Pipeline: "build", Since: now.Add(-720 * time.Hour), Limit: 10},
+	}, {
+		query: "task=compile&status=Failed&since=2020-10-01T00:00:00Z&until=2020-10-15T00:00:00Z",
+		want: Filter{Task: "compile", Status: StatusFailed, Limit: defaultLimit,
+			Since: time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC), Until: time.Date(2020, 10, 15, 0, 0, 0, 0, time.UTC)},
+	}, {
+		query:   "since=last-month",
+		wantErr: true,
+	}, {
+		query:   "until=yesterday",
+		wantErr: true,
+	}, {
+		query:   "limit=0",
+		wantErr: true,
+	}} {
+		t.Run(tc.query, func(t *testing.T) {
+			q, err := url.ParseQuery(tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := filterFromQuery(q, now)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got filter %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("filterFromQuery() = %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("filterFromQuery() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+// allowAll authorizes all the requests
+type allowAll struct{}
+
+func (allowAll) Authorize(*http.Request, string, string) error { return nil }
+
+func TestHandler(t *testing.T) {
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+	now := time.Now().UTC().Truncate(time.Second)
+	for _, r := range []*Record{
+		record(PipelineRunKind, "build-1", "build", StatusSucceeded, now.Add(-40*24*time.Hour), 10*time.Minute),
+		record(PipelineRunKind, "build-2", "build", StatusSucceeded, now.Add(-2*time.Hour), 4*time.Minute),
+		record(PipelineRunKind, "build-3", "build", StatusFailed, now.Add(-time.Hour), 2*time.Minute),
+		record(PipelineRunKind, "deploy-1", "deploy", StatusSucceeded, now, time.Minute),
+	} {
+		if err := store.Put(context.Background(), r); err != nil {
+			t.Fatalf("Put() = %v", err)
+		}
+	}
+	srv := httptest.NewServer(NewHandler(store, allowAll{}, zaptest.NewLogger(t).Sugar()))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + RecordsPath + "?pipeline=build&since=720h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	var list RecordList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatalf("Failed to decode records: %v", err)
+	}
+	if d := cmp.Diff([]string{"build-3", "build-2"}, names(list.Records)); d != "" {
+		t.Errorf("Unexpected records %s", diff.PrintWantGot(d))
+	}
+
+	resp, err = http.Get(srv.URL + SummaryPath + "?pipeline=build&since=720h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var summary Summary
+	if err := json.NewDecoder(resp.Body).Decode(&summary); err != nil {
+		t.Fatalf("Failed to decode summary: %v", err)
+	}
+	want := Summary{Count: 2, Succeeded: 1, Failed: 1, MinDurationSeconds: 120, MaxDurationSeconds: 240,
+		AverageDurationSeconds: 180, TotalDurationSeconds: 360}
+	if d := cmp.Diff(want, summary); d != "" {
+		t.Errorf("Unexpected summary %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestHandler_Errors(t *testing.T) {
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+	srv := httptest.NewServer(NewHandler(store, allowAll{}, zaptest.NewLogger(t).Sugar()))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + SummaryPath + "?since=yesterday")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an invalid query, got %d", http.StatusBadRequest, resp.StatusCode)
+	}
+
+	resp, err = http.Post(srv.URL+RecordsPath, "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status %d for a POST, got %d", http.StatusMethodNotAllowed, resp.StatusCode)
+	}
+}
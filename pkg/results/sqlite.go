@@ -0,0 +1,181 @@
+// +build cgo
+
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	// Registers the sqlite3 driver, which requires cgo: sqlite_nocgo.go stubs this store
+	// out without it.
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// The indexed columns are copied out of the record, which is stored as JSON in data.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS records (
+	uid TEXT PRIMARY KEY,
+	kind TEXT NOT NULL,
+	namespace TEXT NOT NULL,
+	name TEXT NOT NULL,
+	pipeline TEXT NOT NULL,
+	task TEXT NOT NULL,
+	status TEXT NOT NULL,
+	completion_time INTEGER NOT NULL,
+	duration_seconds REAL,
+	data TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS records_by_pipeline ON records (namespace, pipeline, completion_time);
+CREATE INDEX IF NOT EXISTS records_by_task ON records (namespace, task, completion_time);
+CREATE INDEX IF NOT EXISTS records_by_completion_time ON records (completion_time);
+`
+
+type sqliteStore struct {
+	db *sql.DB
+}
+
+// Check that our sqliteStore implements Store
+var _ Store = (*sqliteStore)(nil)
+
+// NewSQLiteStore opens, and creates if needed, the SQLite database at path.
+func NewSQLiteStore(path string) (Store, error) {
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?_busy_timeout=5000&_journal_mode=WAL", path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open results database %s: %w", path, err)
+	}
+	// SQLite serializes the writes anyway, and a single connection avoids busy errors.
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create the schema of results database %s: %w", path, err)
+	}
+	return &sqliteStore{db: db}, nil
+}
+
+func (s *sqliteStore) Put(ctx context.Context, r *Record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to marshal record of %s %s/%s: %w", r.Kind, r.Namespace, r.Name, err)
+	}
+	var completionTime int64
+	if r.CompletionTime != nil {
+		completionTime = r.CompletionTime.Unix()
+	}
+	var duration sql.NullFloat64
+	if r.StartTime != nil && r.CompletionTime != nil {
+		duration = sql.NullFloat64{Float64: r.DurationSeconds, Valid: true}
+	}
+	_, err = s.db.ExecContext(ctx, `INSERT OR REPLACE INTO records
+		(uid, kind, namespace, name, pipeline, task, status, completion_time, duration_seconds, data)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		r.UID, r.Kind, r.Namespace, r.Name, r.Pipeline, r.Task, r.Status, completionTime, duration, string(data))
+	if err != nil {
+		return fmt.Errorf("failed to store record of %s %s/%s: %w", r.Kind, r.Namespace, r.Name, err)
+	}
+	return nil
+}
+
+func (s *sqliteStore) List(ctx context.Context, f Filter) ([]*Record, error) {
+	where, args := f.where()
+	query := "SELECT data FROM records" + where + " ORDER BY completion_time DESC, name"
+	if f.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, f.Limit)
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list records: %w", err)
+	}
+	defer rows.Close()
+
+	records := []*Record{}
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to read record: %w", err)
+		}
+		r := &Record{}
+		if err := json.Unmarshal([]byte(data), r); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal record: %w", err)
+		}
+		records = append(records, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list records: %w", err)
+	}
+	return records, nil
+}
+
+func (s *sqliteStore) Summarize(ctx context.Context, f Filter) (*Summary, error) {
+	where, args := f.where()
+	query := `SELECT COUNT(*),
+		COALESCE(SUM(status = ?), 0), COALESCE(SUM(status = ?), 0),
+		COALESCE(MIN(duration_seconds), 0), COALESCE(MAX(duration_seconds), 0),
+		COALESCE(AVG(duration_seconds), 0), COALESCE(SUM(duration_seconds), 0)
+		FROM records` + where
+	args = append([]interface{}{StatusSucceeded, StatusFailed}, args...)
+
+	summary := &Summary{}
+	err := s.db.QueryRowContext(ctx, query, args...).Scan(&summary.Count, &summary.Succeeded, &summary.Failed,
+		&summary.MinDurationSeconds, &summary.MaxDurationSeconds, &summary.AverageDurationSeconds, &summary.TotalDurationSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize records: %w", err)
+	}
+	return summary, nil
+}
+
+func (s *sqliteStore) Close() error {
+	return s.db.Close()
+}
+
+// where returns the WHERE clause selecting the records of the filter, and its arguments
+func (f Filter) where() (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	for _, c := range []struct {
+		column, value string
+	}{
+		{"kind", f.Kind},
+		{"namespace", f.Namespace},
+		{"name", f.Name},
+		{"pipeline", f.Pipeline},
+		{"task", f.Task},
+		{"status", f.Status},
+	} {
+		if c.value != "" {
+			conditions = append(conditions, c.column+" = ?")
+			args = append(args, c.value)
+		}
+	}
+	if !f.Since.IsZero() {
+		conditions = append(conditions, "completion_time >= ?")
+		args = append(args, f.Since.Unix())
+	}
+	if !f.Until.IsZero() {
+		conditions = append(conditions, "completion_time < ?")
+		args = append(args, f.Until.Unix())
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+// newTestStore returns a store backed by a temporary database, and a function removing it
+func newTestStore(t *testing.T) (Store, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "results")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := NewSQLiteStore(filepath.Join(dir, "results.db"))
+	if err != nil {
+		os.RemoveAll(dir)
+		skipWithoutSQLite(t, err)
+		t.Fatalf("NewSQLiteStore() = %v", err)
+	}
+	return store, func() {
+		store.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+// record returns the record of a run which took duration and completed at completion
+func record(kind, name, pipeline, status string, completion time.Time, duration time.Duration) *Record {
+	start := completion.Add(-duration)
+	return &Record{
+		Kind:            kind,
+		Namespace:       "foo",
+		Name:            name,
+		UID:             name + "-uid",
+		Pipeline:        pipeline,
+		Status:          status,
+		StartTime:       &start,
+		CompletionTime:  &completion,
+		DurationSeconds: duration.Seconds(),
+	}
+}
+
+// skipWithoutSQLite skips the test when SQLite isn't available, since the test binary
+// is built without cgo.
+func skipWithoutSQLite(t *testing.T, err error) {
+	t.Helper()
+	if errors.Is(err, ErrNoSQLite) {
+		t.Skip(err)
+	}
+}
+
+func names(records []*Record) []string {
+	var names []string
+	for _, r := range records {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+func TestSQLiteStore(t *testing.T) {
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	now := time.Date(2020, 10, 31, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	records := []*Record{
+		record(PipelineRunKind, "build-1", "build", StatusSucceeded, now.Add(-40*day), 10*time.Minute),
+		record(PipelineRunKind, "build-2", "build", StatusSucceeded, now.Add(-20*day), 4*time.Minute),
+		record(PipelineRunKind, "build-3", "build", StatusFailed, now.Add(-10*day), 2*time.Minute),
+		record(PipelineRunKind, "build-4", "build", StatusSucceeded, now.Add(-1*day), 6*time.Minute),
+		record(PipelineRunKind, "deploy-1", "deploy", StatusSucceeded, now.Add(-2*day), time.Minute),
+		record(TaskRunKind, "build-4-compile", "build", StatusSucceeded, now.Add(-1*day), 5*time.Minute),
+	}
+	// A cancelled run which never started has no duration.
+	notStarted := record(PipelineRunKind, "build-5", "build", StatusFailed, now, 0)
+	notStarted.StartTime = nil
+	records = append(records, notStarted)
+	for _, r := range records {
+		if err := store.Put(ctx, r); err != nil {
+			t.Fatalf("Put(%s) = %v", r.Name, err)
+		}
+	}
+
+	for _, tc := range []struct {
+		desc    string
+		filter  Filter
+		want    []string
+		summary Summary
+	}{{
+		desc:   "all",
+		filter: Filter{},
+		want:   []string{"build-5", "build-4", "build-4-compile", "deploy-1", "build-3", "build-2", "build-1"},
+		summary: Summary{Count: 7, Succeeded: 5, Failed: 2, MinDurationSeconds: 60, MaxDurationSeconds: 600,
+			AverageDurationSeconds: 280, TotalDurationSeconds: 1680},
+	}, {
+		desc:   "pipeline over the last month",
+		filter: Filter{Kind: PipelineRunKind, Namespace: "foo", Pipeline: "build", Since: now.Add(-30 * day)},
+		want:   []string{"build-5", "build-4", "build-3", "build-2"},
+		summary: Summary{Count: 4, Succeeded: 2, Failed: 2, MinDurationSeconds: 120, MaxDurationSeconds: 360,
+			AverageDurationSeconds: 240, TotalDurationSeconds: 720},
+	}, {
+		desc:    "until",
+		filter:  Filter{Kind: PipelineRunKind, Pipeline: "build", Until: now.Add(-10 * day)},
+		want:    []string{"build-2", "build-1"},
+		summary: Summary{Count: 2, Succeeded: 2, MinDurationSeconds: 240, MaxDurationSeconds: 600, AverageDurationSeconds: 420, TotalDurationSeconds: 840},
+	}, {
+		desc:    "status and limit",
+		filter:  Filter{Status: StatusSucceeded, Kind: PipelineRunKind, Limit: 2},
+		want:    []string{"build-4", "deploy-1"},
+		summary: Summary{Count: 4, Succeeded: 4, MinDurationSeconds: 60, MaxDurationSeconds: 600, AverageDurationSeconds: 315, TotalDurationSeconds: 1260},
+	}, {
+		desc:    "name",
+		filter:  Filter{Name: "deploy-1"},
+		want:    []string{"deploy-1"},
+		summary: Summary{Count: 1, Succeeded: 1, MinDurationSeconds: 60, MaxDurationSeconds: 60, AverageDurationSeconds: 60, TotalDurationSeconds: 60},
+	}, {
+		desc:   "no match",
+		filter: Filter{Namespace: "bar"},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := store.List(ctx, tc.filter)
+			if err != nil {
+				t.Fatalf("List() = %v", err)
+			}
+			if d := cmp.Diff(tc.want, names(got)); d != "" {
+				t.Errorf("List() %s", diff.PrintWantGot(d))
+			}
+			summary, err := store.Summarize(ctx, tc.filter)
+			if err != nil {
+				t.Fatalf("Summarize() = %v", err)
+			}
+			if d := cmp.Diff(tc.summary, *summary); d != "" {
+				t.Errorf("Summarize() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestSQLiteStore_Put(t *testing.T) {
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	completion := time.Date(2020, 10, 31, 0, 0, 0, 0, time.UTC)
+	r := record(TaskRunKind, "compile", "", StatusSucceeded, completion, time.Minute)
+	r.Results = []Result{{Name: "digest", Value: "sha256:abc"}}
+	r.Steps = []Step{{Name: "compile", Status: StatusSucceeded}}
+	if err := store.Put(ctx, r); err != nil {
+		t.Fatalf("Put() = %v", err)
+	}
+	// Storing the record of a run again replaces it.
+	r.Steps[0].LogLocation = "pvc://tekton-logs/foo/compile/compile-uid/compile.log"
+	if err := store.Put(ctx, r); err != nil {
+		t.Fatalf("Put() = %v", err)
+	}
+
+	got, err := store.List(ctx, Filter{})
+	if err != nil {
+		t.Fatalf("List() = %v", err)
+	}
+	if d := cmp.Diff([]*Record{r}, got); d != "" {
+		t.Errorf("List() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestSQLiteStore_Reopen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "results")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "results.db")
+
+	store, err := NewSQLiteStore(path)
+	if err != nil {
+		skipWithoutSQLite(t, err)
+		t.Fatalf("NewSQLiteStore() = %v", err)
+	}
+	if err := store.Put(context.Background(), record(PipelineRunKind, "build-1", "build", StatusSucceeded, time.Now(), time.Minute)); err != nil {
+		t.Fatalf("Put() = %v", err)
+	}
+	store.Close()
+
+	store, err = NewSQLiteStore(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() = %v", err)
+	}
+	defer store.Close()
+	got, err := store.List(context.Background(), Filter{})
+	if err != nil {
+		t.Fatalf("List() = %v", err)
+	}
+	if d := cmp.Diff([]string{"build-1"}, names(got)); d != "" {
+		t.Errorf("Expected the records to be retained %s", diff.PrintWantGot(d))
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNoSQLite is returned by NewSQLiteStore when the binary is built without cgo, which
+// SQLite requires.
+var ErrNoSQLite = errors.New("the SQLite store requires a binary built with cgo")
+
+// Store retains the records of completed runs and answers queries about them.
+type Store interface {
+	// Put creates the record, or replaces the record of the same UID
+	Put(ctx context.Context, r *Record) error
+	// List returns the records matching the filter, most recently completed first
+	List(ctx context.Context, f Filter) ([]*Record, error)
+	// Summarize returns statistics about the records matching the filter
+	Summarize(ctx context.Context, f Filter) (*Summary, error)
+	// Close releases the resources of the store
+	Close() error
+}
+
+// Filter selects records. Fields which are not set match every record.
+type Filter struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Pipeline  string
+	Task      string
+	Status    string
+	// Since and Until bound the completion time of the records
+	Since time.Time
+	Until time.Time
+	// Limit is the maximum number of records returned by List
+	Limit int
+}
+
+// Summary holds statistics about a set of records.
+type Summary struct {
+	Count     int `json:"count"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	// The durations only account for the records with both a start and a completion time
+	MinDurationSeconds     float64 `json:"minDurationSeconds"`
+	MaxDurationSeconds     float64 `json:"maxDurationSeconds"`
+	AverageDurationSeconds float64 `json:"averageDurationSeconds"`
+	TotalDurationSeconds   float64 `json:"totalDurationSeconds"`
+}
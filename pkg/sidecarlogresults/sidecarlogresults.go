@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecarlogresults
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ErrSizeExceeded indicates that a result read from the logs of the results sidecar is larger
+// than the maximum size allowed for a result.
+var ErrSizeExceeded = errors.New("result size exceeds the max-result-size limit")
+
+// LookForResults reads the files named after the results in resultNames from resultsDir and
+// writes them to w, one JSON encoded result per line. Results which the Steps didn't write are
+// skipped.
+func LookForResults(w io.Writer, resultsDir string, resultNames []string) error {
+	encoder := json.NewEncoder(w)
+	for _, name := range resultNames {
+		if name == "" {
+			continue
+		}
+		value, err := ioutil.ReadFile(filepath.Join(resultsDir, name))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("error reading result %q: %w", name, err)
+		}
+		if err := encoder.Encode(v1beta1.PipelineResourceResult{
+			Key:        name,
+			Value:      string(value),
+			ResultType: v1beta1.TaskRunResultType,
+		}); err != nil {
+			return fmt.Errorf("error writing result %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// GetResultsFromSidecarLogs reads the Task results from the logs of the results sidecar
+// container of a Pod. An error wrapping ErrSizeExceeded is returned if any result is larger
+// than maxResultSize.
+func GetResultsFromSidecarLogs(kubeclient kubernetes.Interface, namespace, podName, container string, maxResultSize int) ([]v1beta1.TaskRunResult, error) {
+	logs, err := kubeclient.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{Container: container}).Stream()
+	if err != nil {
+		return nil, fmt.Errorf("error getting the logs of container %q of Pod %q: %w", container, podName, err)
+	}
+	defer logs.Close()
+	return ParseResults(logs, maxResultSize)
+}
+
+// ParseResults parses the results written by LookForResults. An error wrapping ErrSizeExceeded is
+// returned if any result is larger than maxResultSize.
+func ParseResults(r io.Reader, maxResultSize int) ([]v1beta1.TaskRunResult, error) {
+	var results []v1beta1.TaskRunResult
+	decoder := json.NewDecoder(r)
+	for {
+		var result v1beta1.PipelineResourceResult
+		if err := decoder.Decode(&result); err == io.EOF {
+			return results, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("error parsing the results sidecar logs: %w", err)
+		}
+		if result.ResultType != v1beta1.TaskRunResultType {
+			continue
+		}
+		if len(result.Value) > maxResultSize {
+			return nil, fmt.Errorf("result %q is %d bytes long, above the limit of %d bytes: %w", result.Key, len(result.Value), maxResultSize, ErrSizeExceeded)
+		}
+		results = append(results, v1beta1.TaskRunResult{
+			Name:  result.Key,
+			Value: result.Value,
+		})
+	}
+}
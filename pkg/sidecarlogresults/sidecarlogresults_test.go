@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecarlogresults
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestLookForResultsAndParseResults(t *testing.T) {
+	dir, err := ioutil.TempDir("", "results")
+	if err != nil {
+		t.Fatalf("Couldn't create the results directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	large := strings.Repeat("a", 8192)
+	for name, value := range map[string]string{
+		"digest": "sha256:1234",
+		"sbom":   large,
+		"json":   "{\"a\": [1, 2]}\nsecond line",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(value), 0644); err != nil {
+			t.Fatalf("Couldn't write result %q: %v", name, err)
+		}
+	}
+
+	var logs bytes.Buffer
+	if err := LookForResults(&logs, dir, []string{"digest", "sbom", "json", "missing", ""}); err != nil {
+		t.Fatalf("Unexpected error looking for results: %v", err)
+	}
+	got, err := ParseResults(&logs, 10000)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing results: %v", err)
+	}
+	want := []v1beta1.TaskRunResult{{
+		Name:  "digest",
+		Value: "sha256:1234",
+	}, {
+		Name:  "sbom",
+		Value: large,
+	}, {
+		Name:  "json",
+		Value: "{\"a\": [1, 2]}\nsecond line",
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected results %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestParseResults(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		logs string
+		want []v1beta1.TaskRunResult
+	}{{
+		desc: "no results",
+		logs: "",
+		want: nil,
+	}, {
+		desc: "results other than task results are ignored",
+		logs: `{"key":"foo","value":"bar","type":"TaskRunResult"}
+{"key":"StartedAt","value":"2020-10-14T10:00:00.000Z","type":"InternalTektonResult"}
+`,
+		want: []v1beta1.TaskRunResult{{
+			Name:  "foo",
+			Value: "bar",
+		}},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := ParseResults(strings.NewReader(tc.logs), 4096)
+			if err != nil {
+				t.Fatalf("Unexpected error parsing results: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Unexpected results %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestParseResults_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		desc         string
+		logs         string
+		sizeExceeded bool
+	}{{
+		desc: "invalid logs",
+		logs: "not a result",
+	}, {
+		desc:         "result above the max size",
+		logs:         `{"key":"foo","value":"` + strings.Repeat("a", 11) + `","type":"TaskRunResult"}`,
+		sizeExceeded: true,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := ParseResults(strings.NewReader(tc.logs), 10)
+			if err == nil {
+				t.Fatal("Expected an error parsing results but got none")
+			}
+			if errors.Is(err, ErrSizeExceeded) != tc.sizeExceeded {
+				t.Errorf("Expected the error to be ErrSizeExceeded: %t, got %v", tc.sizeExceeded, err)
+			}
+		})
+	}
+}
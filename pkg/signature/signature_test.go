@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Couldn't generate ECDSA key: %v", err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Couldn't generate RSA key: %v", err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Couldn't generate Ed25519 key: %v", err)
+	}
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatalf("Couldn't encode ECDSA key: %v", err)
+	}
+	edDER, err := x509.MarshalPKCS8PrivateKey(edKey)
+	if err != nil {
+		t.Fatalf("Couldn't encode Ed25519 key: %v", err)
+	}
+
+	for _, tc := range []struct {
+		desc string
+		pem  *pem.Block
+		pub  crypto.PublicKey
+	}{{
+		desc: "ECDSA",
+		pem:  &pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER},
+		pub:  ecKey.Public(),
+	}, {
+		desc: "RSA",
+		pem:  &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)},
+		pub:  rsaKey.Public(),
+	}, {
+		desc: "Ed25519",
+		pem:  &pem.Block{Type: "PRIVATE KEY", Bytes: edDER},
+		pub:  edKey.Public(),
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			signer, err := ParsePrivateKey(pem.EncodeToMemory(tc.pem))
+			if err != nil {
+				t.Fatalf("Unexpected error parsing the private key: %v", err)
+			}
+			pubDER, err := x509.MarshalPKIXPublicKey(tc.pub)
+			if err != nil {
+				t.Fatalf("Couldn't encode the public key: %v", err)
+			}
+			pub, err := ParsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}))
+			if err != nil {
+				t.Fatalf("Unexpected error parsing the public key: %v", err)
+			}
+
+			sig, err := Sign(signer, []byte("message"))
+			if err != nil {
+				t.Fatalf("Unexpected error signing: %v", err)
+			}
+			if err := Verify(pub, []byte("message"), sig); err != nil {
+				t.Errorf("Unexpected error verifying the signature: %v", err)
+			}
+			if err := Verify(pub, []byte("tampered message"), sig); err == nil {
+				t.Error("Expected an error verifying the signature of another message but got none")
+			}
+		})
+	}
+}
+
+func TestKeyID(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Couldn't generate ECDSA key: %v", err)
+	}
+	id, err := KeyID(key.Public())
+	if err != nil {
+		t.Fatalf("Unexpected error getting the key ID: %v", err)
+	}
+	if len(id) != 64 {
+		t.Errorf("Expected a hex encoded SHA-256 key ID, got %q", id)
+	}
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Couldn't generate ECDSA key: %v", err)
+	}
+	if otherID, _ := KeyID(other.Public()); otherID == id {
+		t.Errorf("Expected different keys to have different IDs, got %q", id)
+	}
+}
+
+func TestParsePublicKeys(t *testing.T) {
+	var data []byte
+	for i := 0; i < 2; i++ {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatalf("Couldn't generate ECDSA key: %v", err)
+		}
+		der, err := x509.MarshalPKIXPublicKey(key.Public())
+		if err != nil {
+			t.Fatalf("Couldn't encode the public key: %v", err)
+		}
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})...)
+	}
+	keys, err := ParsePublicKeys(data)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing the public keys: %v", err)
+	}
+	if len(keys) != 2 {
+		t.Errorf("Expected 2 public keys, got %d", len(keys))
+	}
+	if _, err := ParsePublicKeys([]byte("not a key")); err == nil {
+		t.Error("Expected an error parsing invalid public keys but got none")
+	}
+	invalid := append(data, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("garbage")})...)
+	if _, err := ParsePublicKeys(invalid); err == nil {
+		t.Error("Expected an error parsing a garbage public key but got none")
+	}
+}
+
+func TestParseKeyErrors(t *testing.T) {
+	if _, err := ParsePrivateKey([]byte("not a key")); err == nil {
+		t.Error("Expected an error parsing an invalid private key but got none")
+	}
+	if _, err := ParsePublicKey([]byte("not a key")); err == nil {
+		t.Error("Expected an error parsing an invalid public key but got none")
+	}
+	invalid := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("garbage")})
+	if _, err := ParsePublicKey(invalid); err == nil {
+		t.Error("Expected an error parsing a garbage public key but got none")
+	}
+}
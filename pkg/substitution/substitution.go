@@ -130,6 +130,35 @@ func ValidateVariableIsolatedP(value, prefix string, vars sets.String) *apis.Fie
 	return nil
 }
 
+// ValidateObjectKeysP verifies that the variables referencing one of the objects in objectKeys
+// reference one of its declared keys, e.g. "$(params.foo.key)".
+func ValidateObjectKeysP(value, prefix string, objectKeys map[string]sets.String) *apis.FieldError {
+	if vs, present := extractEntireVariablesFromString(value, prefix); present {
+		for _, v := range vs {
+			parts := strings.SplitN(strings.TrimSuffix(v, "[*]"), ".", 2)
+			keys, ok := objectKeys[parts[0]]
+			if !ok {
+				continue
+			}
+			if len(parts) == 1 {
+				return &apis.FieldError{
+					Message: fmt.Sprintf("object variable must reference one of its keys in %q", value),
+					// Empty path is required to make the `ViaField`, … work
+					Paths: []string{""},
+				}
+			}
+			if !keys.Has(parts[1]) {
+				return &apis.FieldError{
+					Message: fmt.Sprintf("non-existent key %q of object %q in %q", parts[1], parts[0], value),
+					// Empty path is required to make the `ViaField`, … work
+					Paths: []string{""},
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // Extract a the first full string expressions found (e.g "$(input.params.foo)"). Return
 // "" and false if nothing is found.
 func extractExpressionFromString(s, prefix string) (string, bool) {
@@ -160,6 +189,22 @@ func extractVariablesFromString(s, prefix string) ([]string, bool) {
 	return vars, true
 }
 
+// extractEntireVariablesFromString returns the entire names of the variables found in s,
+// e.g. "foo.bar" for "$(params.foo.bar)", where extractVariablesFromString returns "foo".
+func extractEntireVariablesFromString(s, prefix string) ([]string, bool) {
+	pattern := fmt.Sprintf(braceMatchingRegex, prefix, parameterSubstitution)
+	re := regexp.MustCompile(pattern)
+	matches := re.FindAllStringSubmatch(s, -1)
+	if len(matches) == 0 {
+		return []string{}, false
+	}
+	vars := make([]string, len(matches))
+	for i, match := range matches {
+		vars[i] = matchGroups(match, re)["var"]
+	}
+	return vars, true
+}
+
 func matchGroups(matches []string, pattern *regexp.Regexp) map[string]string {
 	groups := make(map[string]string)
 	for i, name := range pattern.SubexpNames()[1:] {
@@ -116,6 +116,42 @@ func TestValidateVariables(t *testing.T) {
 	}
 }
 
+func TestValidateObjectKeysP(t *testing.T) {
+	objectKeys := map[string]sets.String{"repo": sets.NewString("url", "commit")}
+	for _, tc := range []struct {
+		name          string
+		input         string
+		expectedError *apis.FieldError
+	}{{
+		name:  "declared key",
+		input: "git clone $(params.repo.url) && git checkout $(params.repo.commit)",
+	}, {
+		name:  "variables which are not objects are ignored",
+		input: "--flag=$(params.foo) $(params.bar[*])",
+	}, {
+		name:  "undeclared key",
+		input: "git clone $(params.repo.branch)",
+		expectedError: &apis.FieldError{
+			Message: `non-existent key "branch" of object "repo" in "git clone $(params.repo.branch)"`,
+			Paths:   []string{""},
+		},
+	}, {
+		name:  "entire object",
+		input: "$(params.repo[*])",
+		expectedError: &apis.FieldError{
+			Message: `object variable must reference one of its keys in "$(params.repo[*])"`,
+			Paths:   []string{""},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := substitution.ValidateObjectKeysP(tc.input, "params", objectKeys)
+			if d := cmp.Diff(tc.expectedError, got, cmp.AllowUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("ValidateObjectKeysP() error did not match expected error %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestApplyReplacements(t *testing.T) {
 	type args struct {
 		input        string
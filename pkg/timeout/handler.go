@@ -182,7 +182,11 @@ func (t *Handler) checkPipelineRunTimeouts(ctx context.Context, namespace string
 			continue
 		}
 		if pipelineRun.HasStarted() {
-			go t.Wait(pipelineRun.GetNamespacedName(), *pipelineRun.Status.StartTime, *pipelineRun.Spec.Timeout)
+			go t.WaitPipelineRun(pipelineRun.GetNamespacedName(), *pipelineRun.Status.StartTime,
+				metav1.Duration{Duration: pipelineRun.PipelineTimeout(ctx)}, pipelineRun.TasksTimeout())
+		}
+		if pipelineRun.Status.FinallyStartTime != nil {
+			t.WaitFinally(pipelineRun.GetNamespacedName(), *pipelineRun.Status.FinallyStartTime, pipelineRun.FinallyTimeout())
 		}
 	}
 }
@@ -245,6 +249,38 @@ func (t *Handler) Wait(n types.NamespacedName, startTime metav1.Time, timeout me
 	t.setTimer(n, timeout.Duration-runtime, t.callbackFunc)
 }
 
+// WaitPipelineRun is Wait for a PipelineRun whose tasks may have a timeout of their own:
+// the callback function is called as well when tasksTimeout has elapsed since startTime,
+// so that no new task gets scheduled after it.
+func (t *Handler) WaitPipelineRun(n types.NamespacedName, startTime metav1.Time, timeout metav1.Duration, tasksTimeout *metav1.Duration) {
+	if t.callbackFunc == nil {
+		t.logger.Errorf("somehow the timeout handler was not initialized with a callback function")
+		return
+	}
+	if tasksTimeout != nil && tasksTimeout.Duration > 0 {
+		// The done channel is created before Wait may release n, so that the timer of
+		// the tasks can't create it again once n is released.
+		done := t.getOrCreateDoneChan(n)
+		go t.waitOn(n, done, tasksTimeout.Duration-time.Since(startTime.Time), t.callbackFunc)
+	}
+	t.Wait(n, startTime, timeout)
+}
+
+// WaitFinally calls the callback function of a PipelineRun whose finally tasks may have a
+// timeout of their own, when finallyTimeout has elapsed since finallyStartTime, unless the
+// PipelineRun is released before. It does not block.
+func (t *Handler) WaitFinally(n types.NamespacedName, finallyStartTime metav1.Time, finallyTimeout *metav1.Duration) {
+	if finallyTimeout == nil || finallyTimeout.Duration <= 0 {
+		return
+	}
+	if t.callbackFunc == nil {
+		t.logger.Errorf("somehow the timeout handler was not initialized with a callback function")
+		return
+	}
+	done := t.getOrCreateDoneChan(n)
+	go t.waitOn(n, done, finallyTimeout.Duration-time.Since(finallyStartTime.Time), t.callbackFunc)
+}
+
 // SetTimer creates a blocking function for n to wait for
 // 1. Stop signal, 2. TaskRun to complete or 3. a given Duration to elapse.
 //
@@ -261,7 +297,12 @@ func (t *Handler) SetTimer(n types.NamespacedName, d time.Duration) {
 }
 
 func (t *Handler) setTimer(n types.NamespacedName, timeout time.Duration, callback func(types.NamespacedName)) {
-	done := t.getOrCreateDoneChan(n)
+	t.waitOn(n, t.getOrCreateDoneChan(n), timeout, callback)
+}
+
+// waitOn waits for the stop signal, for done to be closed or for timeout to elapse, in
+// which case it calls callback.
+func (t *Handler) waitOn(n types.NamespacedName, done chan bool, timeout time.Duration, callback func(types.NamespacedName)) {
 	started := time.Now()
 	select {
 	case <-t.stopCh:
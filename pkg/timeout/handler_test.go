@@ -430,6 +430,78 @@ func TestSetTaskRunTimer(t *testing.T) {
 	}
 }
 
+func TestWaitPipelineRun_TasksTimeout(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	observer, _ := observer.New(zap.InfoLevel)
+	testHandler := NewHandler(stopCh, zap.New(observer).Sugar())
+	calls := make(chan time.Time, 2)
+	testHandler.SetCallbackFunc(func(_ types.NamespacedName) {
+		calls <- time.Now()
+	})
+
+	n := types.NamespacedName{Namespace: testNs, Name: "test-pipeline-run-with-timeouts"}
+	started := time.Now()
+	go testHandler.WaitPipelineRun(n, metav1.NewTime(started),
+		metav1.Duration{Duration: 200 * time.Millisecond}, &metav1.Duration{Duration: 50 * time.Millisecond})
+
+	// The callback is called once the tasks timeout elapses, and once the pipeline timeout elapses
+	for _, want := range []time.Duration{50 * time.Millisecond, 200 * time.Millisecond} {
+		select {
+		case called := <-calls:
+			if elapsed := called.Sub(started); elapsed < want {
+				t.Errorf("Expected the callback to be called after %s, got %s", want, elapsed)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Expected the callback to be called after %s", want)
+		}
+	}
+}
+
+func TestWaitPipelineRun_ReleasedBeforeTasksTimer(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	observer, _ := observer.New(zap.InfoLevel)
+	testHandler := NewHandler(stopCh, zap.New(observer).Sugar())
+	testHandler.SetCallbackFunc(func(_ types.NamespacedName) {})
+
+	// The pipeline timeout elapses right away and n is released, the timer of the tasks
+	// must not keep waiting on n.
+	n := types.NamespacedName{Namespace: testNs, Name: "test-pipeline-run-released"}
+	testHandler.WaitPipelineRun(n, metav1.Now(), metav1.Duration{}, &metav1.Duration{Duration: time.Hour})
+	time.Sleep(50 * time.Millisecond)
+	testHandler.doneMut.Lock()
+	defer testHandler.doneMut.Unlock()
+	if _, ok := testHandler.done[n.String()]; ok {
+		t.Errorf("Expected %s to be released", n)
+	}
+}
+
+func TestWaitFinally(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	observer, _ := observer.New(zap.InfoLevel)
+	testHandler := NewHandler(stopCh, zap.New(observer).Sugar())
+	calls := make(chan time.Time, 1)
+	testHandler.SetCallbackFunc(func(_ types.NamespacedName) {
+		calls <- time.Now()
+	})
+
+	n := types.NamespacedName{Namespace: testNs, Name: "test-pipeline-run-with-finally-timeout"}
+	finallyStarted := time.Now().Add(-50 * time.Millisecond)
+	testHandler.WaitFinally(n, metav1.NewTime(finallyStarted), &metav1.Duration{Duration: 100 * time.Millisecond})
+
+	// The callback is called once the finally timeout elapses since the finally tasks started
+	select {
+	case called := <-calls:
+		if elapsed := called.Sub(finallyStarted); elapsed < 100*time.Millisecond {
+			t.Errorf("Expected the callback to be called after 100ms, got %s", elapsed)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the callback to be called after 100ms")
+	}
+}
+
 // TestBackoffDuration asserts that the backoffDuration func returns Durations
 // within the timeout handler's bounds.
 func TestBackoffDuration(t *testing.T) {
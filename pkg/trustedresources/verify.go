@@ -0,0 +1,175 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package trustedresources verifies the signatures of the Tasks and Pipelines
+// referenced by TaskRuns and PipelineRuns.
+package trustedresources
+
+import (
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/signature"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// SignatureAnnotation holds the base64 encoded signature of a Task or Pipeline
+	SignatureAnnotation = pipeline.GroupName + "/signature"
+
+	// lastAppliedConfigAnnotation is set by kubectl apply, after the resource was signed
+	lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// ErrResourceVerificationFailed is returned, wrapped, when a Task or Pipeline fails verification
+var ErrResourceVerificationFailed = errors.New("resource verification failed")
+
+// signedResource is what the signature of a Task or Pipeline covers. Its namespace isn't
+// part of it, so that a signed resource can be installed in any namespace.
+type signedResource struct {
+	Name        string            `json:"name"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Spec        interface{}       `json:"spec"`
+}
+
+// SignTask returns the signature of the Task, to be set in its SignatureAnnotation.
+func SignTask(ctx context.Context, t v1beta1.TaskInterface, signer crypto.Signer) (string, error) {
+	return sign(t.TaskMetadata(), taskPayload(ctx, t.TaskSpec()), signer)
+}
+
+// SignPipeline returns the signature of the Pipeline, to be set in its SignatureAnnotation.
+func SignPipeline(ctx context.Context, p v1beta1.PipelineInterface, signer crypto.Signer) (string, error) {
+	return sign(p.PipelineMetadata(), pipelinePayload(ctx, p.PipelineSpec()), signer)
+}
+
+// VerifyTask verifies the signature of the Task against the public keys of the trusted resources
+// config. In warn mode, a verification failure is only logged.
+func VerifyTask(ctx context.Context, t v1beta1.TaskInterface) error {
+	cfg := config.FromContextOrDefaults(ctx).TrustedResources
+	if !cfg.Enabled() {
+		return nil
+	}
+	return verify(ctx, cfg, "Task", t.TaskMetadata(), taskPayload(ctx, t.TaskSpec()))
+}
+
+// VerifyPipeline verifies the signature of the Pipeline against the public keys of the trusted
+// resources config. In warn mode, a verification failure is only logged.
+func VerifyPipeline(ctx context.Context, p v1beta1.PipelineInterface) error {
+	cfg := config.FromContextOrDefaults(ctx).TrustedResources
+	if !cfg.Enabled() {
+		return nil
+	}
+	return verify(ctx, cfg, "Pipeline", p.PipelineMetadata(), pipelinePayload(ctx, p.PipelineSpec()))
+}
+
+// taskPayload defaults a copy of the spec, so that a Task signed before being applied verifies
+// once defaulted by the webhook.
+func taskPayload(ctx context.Context, spec v1beta1.TaskSpec) interface{} {
+	defaulted := spec.DeepCopy()
+	defaulted.SetDefaults(ctx)
+	return defaulted
+}
+
+// pipelinePayload defaults a copy of the spec, so that a Pipeline signed before being applied
+// verifies once defaulted by the webhook.
+func pipelinePayload(ctx context.Context, spec v1beta1.PipelineSpec) interface{} {
+	defaulted := spec.DeepCopy()
+	defaulted.SetDefaults(ctx)
+	return defaulted
+}
+
+func verify(ctx context.Context, cfg *config.TrustedResources, kind string, meta metav1.ObjectMeta, spec interface{}) error {
+	err := verifySignature(cfg, meta, spec)
+	if err == nil {
+		return nil
+	}
+	if cfg.VerificationMode == config.VerificationModeWarn {
+		logging.FromContext(ctx).Warnf("%s %q failed verification: %v", kind, meta.Name, err)
+		return nil
+	}
+	return fmt.Errorf("%w: %s %q: %v", ErrResourceVerificationFailed, kind, meta.Name, err)
+}
+
+func verifySignature(cfg *config.TrustedResources, meta metav1.ObjectMeta, spec interface{}) error {
+	encoded, ok := meta.Annotations[SignatureAnnotation]
+	if !ok {
+		return fmt.Errorf("no %q annotation", SignatureAnnotation)
+	}
+	sig, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("error decoding the signature: %w", err)
+	}
+	keys, err := signature.ParsePublicKeys([]byte(cfg.PublicKeys))
+	if err != nil {
+		return err
+	}
+	msg, err := payload(meta, spec)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := signature.Verify(key, msg, sig); err == nil {
+			return nil
+		}
+	}
+	return errors.New("the signature doesn't match any of the trusted public keys")
+}
+
+func sign(meta metav1.ObjectMeta, spec interface{}, signer crypto.Signer) (string, error) {
+	msg, err := payload(meta, spec)
+	if err != nil {
+		return "", err
+	}
+	sig, err := signature.Sign(signer, msg)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// payload returns the JSON encoding of what the signature of a resource covers: its name,
+// labels, annotations and spec.
+func payload(meta metav1.ObjectMeta, spec interface{}) ([]byte, error) {
+	var annotations map[string]string
+	for k, v := range meta.Annotations {
+		if k == SignatureAnnotation || k == lastAppliedConfigAnnotation {
+			continue
+		}
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[k] = v
+	}
+	b, err := json.Marshal(signedResource{
+		Name:        meta.Name,
+		Labels:      meta.Labels,
+		Annotations: annotations,
+		Spec:        spec,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error encoding the resource: %w", err)
+	}
+	return b, nil
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trustedresources
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func generateKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Couldn't generate key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatalf("Couldn't encode the public key: %v", err)
+	}
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func withConfig(mode, publicKeys string) context.Context {
+	return config.ToContext(context.Background(), &config.Config{
+		TrustedResources: &config.TrustedResources{
+			VerificationMode: mode,
+			PublicKeys:       publicKeys,
+		},
+	})
+}
+
+func task() *v1beta1.Task {
+	return &v1beta1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "build",
+			Namespace: "foo",
+			Labels:    map[string]string{"app": "build"},
+		},
+		Spec: v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{Name: "revision"}},
+			Steps: []v1beta1.Step{{Container: corev1.Container{
+				Name:  "build",
+				Image: "golang",
+			}}},
+		},
+	}
+}
+
+func signTask(t *testing.T, key *ecdsa.PrivateKey, task *v1beta1.Task) {
+	t.Helper()
+	sig, err := SignTask(context.Background(), task, key)
+	if err != nil {
+		t.Fatalf("Unexpected error signing the Task: %v", err)
+	}
+	if task.Annotations == nil {
+		task.Annotations = map[string]string{}
+	}
+	task.Annotations[SignatureAnnotation] = sig
+}
+
+func TestVerifyTask(t *testing.T) {
+	key, publicKey := generateKey(t)
+	otherKey, otherPublicKey := generateKey(t)
+
+	signed := task()
+	signTask(t, key, signed)
+
+	// Applying and defaulting the Task doesn't invalidate its signature
+	applied := signed.DeepCopy()
+	applied.Namespace = "bar"
+	applied.Annotations[lastAppliedConfigAnnotation] = "{}"
+	applied.Spec.SetDefaults(context.Background())
+
+	tampered := signed.DeepCopy()
+	tampered.Spec.Steps[0].Image = "evil"
+
+	relabeled := signed.DeepCopy()
+	relabeled.Labels["app"] = "other"
+
+	signedByOther := task()
+	signTask(t, otherKey, signedByOther)
+
+	invalidSignature := task()
+	invalidSignature.Annotations = map[string]string{SignatureAnnotation: "not base64!"}
+
+	for _, tc := range []struct {
+		desc       string
+		task       *v1beta1.Task
+		mode       string
+		publicKeys string
+		wantErr    bool
+	}{{
+		desc:       "signed",
+		task:       signed,
+		mode:       config.VerificationModeEnforce,
+		publicKeys: publicKey,
+	}, {
+		desc:       "applied",
+		task:       applied,
+		mode:       config.VerificationModeEnforce,
+		publicKeys: publicKey,
+	}, {
+		desc:       "signed by one of the keys",
+		task:       signedByOther,
+		mode:       config.VerificationModeEnforce,
+		publicKeys: publicKey + otherPublicKey,
+	}, {
+		desc:       "unsigned",
+		task:       task(),
+		mode:       config.VerificationModeEnforce,
+		publicKeys: publicKey,
+		wantErr:    true,
+	}, {
+		desc:       "tampered spec",
+		task:       tampered,
+		mode:       config.VerificationModeEnforce,
+		publicKeys: publicKey,
+		wantErr:    true,
+	}, {
+		desc:       "tampered labels",
+		task:       relabeled,
+		mode:       config.VerificationModeEnforce,
+		publicKeys: publicKey,
+		wantErr:    true,
+	}, {
+		desc:       "untrusted key",
+		task:       signedByOther,
+		mode:       config.VerificationModeEnforce,
+		publicKeys: publicKey,
+		wantErr:    true,
+	}, {
+		desc:       "invalid signature",
+		task:       invalidSignature,
+		mode:       config.VerificationModeEnforce,
+		publicKeys: publicKey,
+		wantErr:    true,
+	}, {
+		desc:       "warn",
+		task:       tampered,
+		mode:       config.VerificationModeWarn,
+		publicKeys: publicKey,
+	}, {
+		desc: "skip",
+		task: tampered,
+		mode: config.VerificationModeSkip,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := VerifyTask(withConfig(tc.mode, tc.publicKeys), tc.task)
+			if tc.wantErr {
+				if !errors.Is(err, ErrResourceVerificationFailed) {
+					t.Errorf("Expected a verification error, got %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("Unexpected error verifying the Task: %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyTask_NoConfig(t *testing.T) {
+	if err := VerifyTask(context.Background(), task()); err != nil {
+		t.Errorf("Expected Tasks not to be verified by default, got %v", err)
+	}
+}
+
+func TestVerifyPipeline(t *testing.T) {
+	key, publicKey := generateKey(t)
+	p := &v1beta1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipeline", Namespace: "foo"},
+		Spec: v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{
+				Name:    "build",
+				TaskRef: &v1beta1.TaskRef{Name: "build"},
+			}},
+		},
+	}
+	sig, err := SignPipeline(context.Background(), p, key)
+	if err != nil {
+		t.Fatalf("Unexpected error signing the Pipeline: %v", err)
+	}
+	p.Annotations = map[string]string{SignatureAnnotation: sig}
+
+	ctx := withConfig(config.VerificationModeEnforce, publicKey)
+	if err := VerifyPipeline(ctx, p); err != nil {
+		t.Errorf("Unexpected error verifying the Pipeline: %v", err)
+	}
+	p.Spec.Tasks[0].TaskRef.Name = "other"
+	if err := VerifyPipeline(ctx, p); !errors.Is(err, ErrResourceVerificationFailed) {
+		t.Errorf("Expected a verification error for a tampered Pipeline, got %v", err)
+	}
+}
@@ -509,6 +509,40 @@ func TestApply(t *testing.T) {
 				ReadOnly:  true,
 			}},
 		},
+	}, {
+		name: "unbound optional workspace is not mounted",
+		ts: v1beta1.TaskSpec{
+			Workspaces: []v1beta1.WorkspaceDeclaration{{
+				Name: "source",
+			}, {
+				Name:     "cache",
+				Optional: true,
+			}},
+		},
+		workspaces: []v1beta1.WorkspaceBinding{{
+			Name:     "source",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		}},
+		expectedTaskSpec: v1beta1.TaskSpec{
+			StepTemplate: &corev1.Container{
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "ws-mnq6l",
+					MountPath: "/workspace/source",
+				}},
+			},
+			Volumes: []corev1.Volume{{
+				Name: "ws-mnq6l",
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			}},
+			Workspaces: []v1beta1.WorkspaceDeclaration{{
+				Name: "source",
+			}, {
+				Name:     "cache",
+				Optional: true,
+			}},
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			ts, err := workspace.Apply(tc.ts, tc.workspaces)
@@ -36,15 +36,23 @@ func ValidateBindings(w []v1beta1.WorkspaceDeclaration, wb []v1beta1.WorkspaceBi
 	}
 
 	declNames := make([]string, len(w))
+	requiredNames := []string{}
 	for i := range w {
 		declNames[i] = w[i].Name
+		// Optional workspaces may be left unbound
+		if !w[i].Optional {
+			requiredNames = append(requiredNames, w[i].Name)
+		}
 	}
 	bindNames := make([]string, len(wb))
 	for i := range wb {
 		bindNames[i] = wb[i].Name
 	}
-	if err := list.IsSame(declNames, bindNames); err != nil {
-		return fmt.Errorf("bound workspaces did not match declared workspaces: %v", err)
+	if missing := list.DiffLeft(requiredNames, bindNames); len(missing) > 0 {
+		return fmt.Errorf("bound workspaces did not match declared workspaces: didn't provide required values: %s", missing)
+	}
+	if extra := list.DiffLeft(bindNames, declNames); len(extra) > 0 {
+		return fmt.Errorf("bound workspaces did not match declared workspaces: provided extra values: %s", extra)
 	}
 	return nil
 }
@@ -58,6 +58,28 @@ func TestValidateBindingsValid(t *testing.T) {
 			Name:     "beth",
 			EmptyDir: &corev1.EmptyDirVolumeSource{},
 		}},
+	}, {
+		name: "Optional workspace left unbound",
+		declarations: []v1alpha1.WorkspaceDeclaration{{
+			Name: "beth",
+		}, {
+			Name:     "randall",
+			Optional: true,
+		}},
+		bindings: []v1alpha1.WorkspaceBinding{{
+			Name:     "beth",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		}},
+	}, {
+		name: "Optional workspace bound",
+		declarations: []v1alpha1.WorkspaceDeclaration{{
+			Name:     "beth",
+			Optional: true,
+		}},
+		bindings: []v1alpha1.WorkspaceBinding{{
+			Name:     "beth",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		}},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			if err := ValidateBindings(tc.declarations, tc.bindings); err != nil {
@@ -96,6 +118,16 @@ func TestValidateBindingsInvalid(t *testing.T) {
 			Name:     "beth",
 			EmptyDir: &corev1.EmptyDirVolumeSource{},
 		}},
+	}, {
+		name: "Provided a binding for an undeclared workspace next to an optional one",
+		declarations: []v1alpha1.WorkspaceDeclaration{{
+			Name:     "beth",
+			Optional: true,
+		}},
+		bindings: []v1alpha1.WorkspaceBinding{{
+			Name:     "kate",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		}},
 	}, {
 		name: "Provided both pvc and emptydir",
 		declarations: []v1alpha1.WorkspaceDeclaration{{
@@ -30,6 +30,7 @@ import (
 	informersv1beta1 "github.com/tektoncd/pipeline/pkg/client/informers/externalversions/pipeline/v1beta1"
 	fakepipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client/fake"
 	fakeconditioninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/condition/fake"
+	fakeruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/run/fake"
 	fakeclustertaskinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/clustertask/fake"
 	fakepipelineinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipeline/fake"
 	fakepipelineruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipelinerun/fake"
@@ -67,6 +68,7 @@ type Data struct {
 	ClusterTasks      []*v1beta1.ClusterTask
 	PipelineResources []*v1alpha1.PipelineResource
 	Conditions        []*v1alpha1.Condition
+	Runs              []*v1alpha1.Run
 	Pods              []*corev1.Pod
 	Namespaces        []*corev1.Namespace
 	ConfigMaps        []*corev1.ConfigMap
@@ -90,6 +92,7 @@ type Informers struct {
 	ClusterTask      informersv1beta1.ClusterTaskInformer
 	PipelineResource resourceinformersv1alpha1.PipelineResourceInformer
 	Condition        informersv1alpha1.ConditionInformer
+	Run              informersv1alpha1.RunInformer
 	Pod              coreinformers.PodInformer
 	ConfigMap        coreinformers.ConfigMapInformer
 	ServiceAccount   coreinformers.ServiceAccountInformer
@@ -168,6 +171,7 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 		ClusterTask:      fakeclustertaskinformer.Get(ctx),
 		PipelineResource: fakeresourceinformer.Get(ctx),
 		Condition:        fakeconditioninformer.Get(ctx),
+		Run:              fakeruninformer.Get(ctx),
 		Pod:              fakepodinformer.Get(ctx),
 		ConfigMap:        fakeconfigmapinformer.Get(ctx),
 		ServiceAccount:   fakeserviceaccountinformer.Get(ctx),
@@ -225,6 +229,13 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 			t.Fatal(err)
 		}
 	}
+	c.Pipeline.PrependReactor("*", "runs", AddToInformer(t, i.Run.Informer().GetIndexer()))
+	for _, run := range d.Runs {
+		run := run.DeepCopy() // Avoid assumptions that the informer's copy is modified.
+		if _, err := c.Pipeline.TektonV1alpha1().Runs(run.Namespace).Create(run); err != nil {
+			t.Fatal(err)
+		}
+	}
 	c.Kube.PrependReactor("*", "pods", AddToInformer(t, i.Pod.Informer().GetIndexer()))
 	for _, p := range d.Pods {
 		p := p.DeepCopy() // Avoid assumptions that the informer's copy is modified.